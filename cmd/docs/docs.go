@@ -53,19 +53,19 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
@@ -73,11 +73,21 @@ const docTemplate = `{
         },
         "/api/auth/logout": {
             "post": {
-                "description": "Logout user",
+                "description": "Logout user, revoking the session of the refresh token (body or refreshToken cookie)",
                 "tags": [
                     "Auth"
                 ],
                 "summary": "Logout user",
+                "parameters": [
+                    {
+                        "description": "Refresh token of the session",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_auth.RefreshRequestDTO"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK"
@@ -85,19 +95,19 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
@@ -105,7 +115,7 @@ const docTemplate = `{
         },
         "/api/auth/refresh": {
             "post": {
-                "description": "Get new access token using refresh token",
+                "description": "Get new access token using the refresh token (body or refreshToken cookie)",
                 "consumes": [
                     "application/json"
                 ],
@@ -121,7 +131,6 @@ const docTemplate = `{
                         "description": "Refresh token request",
                         "name": "body",
                         "in": "body",
-                        "required": true,
                         "schema": {
                             "$ref": "#/definitions/internal_auth.RefreshRequestDTO"
                         }
@@ -137,7 +146,13 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
@@ -145,6 +160,11 @@ const docTemplate = `{
         },
         "/api/auth/register": {
             "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
                 "description": "Register new user based on parameters",
                 "consumes": [
                     "application/json"
@@ -174,19 +194,25 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
@@ -213,6 +239,26 @@ const docTemplate = `{
                     "Categories"
                 ],
                 "summary": "Fetch all Categories",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "rows per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields of id, categoryName; prefix - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -226,19 +272,19 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
@@ -288,19 +334,19 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
@@ -346,19 +392,19 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
@@ -411,19 +457,19 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
@@ -467,19 +513,19 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
@@ -503,6 +549,32 @@ const docTemplate = `{
                     "Customers"
                 ],
                 "summary": "Fetch all customers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "rows per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields of id, name; prefix - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only the customer with this phone number",
+                        "name": "phone",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -516,19 +588,19 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
@@ -568,19 +640,19 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
@@ -623,19 +695,19 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
@@ -685,19 +757,19 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
@@ -738,32 +810,32 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             }
         },
-        "/api/inventories": {
+        "/api/customers/{id}/balance": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Fetch all inventory records",
+                "description": "Fetch what a customer owes: sales less returns and payments, with the sales still open",
                 "consumes": [
                     "application/json"
                 ],
@@ -771,117 +843,135 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Inventories"
+                    "Customers"
+                ],
+                "summary": "Fetch the outstanding balance of a customer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "customer Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Fetch all inventory records",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Inventory"
-                            }
+                            "$ref": "#/definitions/internal_domain_receivable.CustomerBalanceDTO"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             }
         },
-        "/api/inventories/decrease": {
-            "post": {
+        "/api/customers/{id}/payments": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Create decrease inventory record based on parameters",
+                "description": "Fetch the payments of a customer with the sales each one was applied to",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Inventories"
+                    "Customers"
                 ],
-                "summary": "Create decrease inventory record based on parameters",
+                "summary": "Fetch the payments of a customer",
                 "parameters": [
                     {
-                        "description": "Inventory Data",
-                        "name": "inventory",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_domain_inventory.IncreaseInventoryDTO"
-                        }
+                        "type": "string",
+                        "description": "customer Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Inventory"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Payment"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
-            }
-        },
-        "/api/inventories/increase": {
+            },
             "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Create increase inventory record based on parameters",
+                "description": "Record a payment and apply it to the given sales, or to the oldest open sales when none are given",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Inventories"
+                    "Customers"
                 ],
-                "summary": "Create increase inventory record based on parameters",
+                "summary": "Record a payment received from a customer",
                 "parameters": [
                     {
-                        "description": "Inventory Data",
-                        "name": "inventory",
+                        "type": "string",
+                        "description": "customer Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment Data",
+                        "name": "payment",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_domain_inventory.IncreaseInventoryDTO"
+                            "$ref": "#/definitions/internal_domain_receivable.PaymentRequestDTO"
                         }
                     }
                 ],
@@ -889,38 +979,38 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Inventory"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Payment"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             }
         },
-        "/api/product": {
-            "post": {
+        "/api/customers/{id}/statement": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Create a new product with name, category, prices, and status",
+                "description": "Fetch sales, returns and payments of a customer in booking order with a running balance",
                 "consumes": [
                     "application/json"
                 ],
@@ -928,56 +1018,54 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Customers"
                 ],
-                "summary": "Create new product",
+                "summary": "Fetch the account statement of a customer",
                 "parameters": [
                     {
-                        "description": "Product input data",
-                        "name": "product",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_domain_product.CreateProductRequstDTO"
-                        }
+                        "type": "string",
+                        "description": "customer Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Product"
+                            "$ref": "#/definitions/internal_domain_receivable.CustomerStatementDTO"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             }
         },
-        "/api/productprices": {
+        "/api/goodsreceipts": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Fetch all product prices",
+                "description": "Fetch all goods receipts with their lines",
                 "consumes": [
                     "application/json"
                 ],
@@ -985,35 +1073,91 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ProductPrice"
+                    "GoodsReceipts"
+                ],
+                "summary": "Fetch all goods receipts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "rows per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields of id, receiptDate, createdAt; prefix - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only the receipts of this purchase order",
+                        "name": "purchaseOrderId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only the receipts billed by this purchase invoice",
+                        "name": "purchaseId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the receipts from this supplier",
+                        "name": "supplierId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the receipts into this location",
+                        "name": "locationId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "first receipt date, yyyy-mm-dd",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "last receipt date, yyyy-mm-dd",
+                        "name": "to",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Fetch all product prices",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ProductPrice"
+                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.GoodsReceipt"
                             }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
@@ -1024,7 +1168,7 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "Create a new product price with productId, unitId, and unitPrice",
+                "description": "Book goods that arrived for a sent purchase order into the stock of its location; a line may receive part of what is still to come",
                 "consumes": [
                     "application/json"
                 ],
@@ -1032,56 +1176,68 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ProductPrice"
+                    "GoodsReceipts"
                 ],
-                "summary": "Create new product price",
+                "summary": "Receive goods of a purchase order",
                 "parameters": [
                     {
-                        "description": "Product Price Input Data",
-                        "name": "productPrice",
+                        "description": "Goods receipt",
+                        "name": "receipt",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ProductPrice"
+                            "$ref": "#/definitions/internal_domain_goodsreceipt.GoodsReceiptRequestDTO"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ProductPrice"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.GoodsReceipt"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             }
         },
-        "/api/productprices/{id}": {
+        "/api/goodsreceipts/{id}": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Fetch individual product price by Id",
+                "description": "Fetch individual goods receipt by Id",
                 "consumes": [
                     "application/json"
                 ],
@@ -1089,13 +1245,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ProductPrice"
+                    "GoodsReceipts"
                 ],
-                "summary": "Fetch individual product price by Id",
+                "summary": "Fetch individual goods receipt by Id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "product price Id",
+                        "description": "goods receipt Id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1105,36 +1261,38 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ProductPrice"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.GoodsReceipt"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/inventories": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Update individual product price",
+                "description": "Fetch all inventory records",
                 "consumes": [
                     "application/json"
                 ],
@@ -1142,158 +1300,206 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ProductPrice"
+                    "Inventories"
                 ],
-                "summary": "Update individual product price",
+                "summary": "Fetch all inventory records",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "rows per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "product price Id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "comma separated fields of createdAt, productId; prefix - for descending",
+                        "name": "sort",
+                        "in": "query"
                     },
                     {
-                        "description": "Product Price Data",
-                        "name": "productPrice",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_domain_productprice.UpdateProductPriceRequestDTO"
-                        }
+                        "type": "string",
+                        "description": "only the movements of this product",
+                        "name": "productId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "DEBIT, CREDIT, ADJUSTMENT or TRANSFER",
+                        "name": "tranType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the movements at this location",
+                        "name": "locationId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "first day, yyyy-mm-dd",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "last day, yyyy-mm-dd",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ProductPrice"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Inventory"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             }
         },
-        "/api/products": {
-            "get": {
+        "/api/inventories/decrease": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Fetch all products",
+                "description": "Create decrease inventory record based on parameters",
                 "consumes": [
                     "application/json"
                 ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "Products"
+                    "Inventories"
                 ],
-                "summary": "Fetch all products",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                "summary": "Create decrease inventory record based on parameters",
+                "parameters": [
+                    {
+                        "description": "Inventory Data",
+                        "name": "inventory",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Product"
-                            }
+                            "$ref": "#/definitions/internal_domain_inventory.IncreaseInventoryDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Inventory"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             }
         },
-        "/api/products/prices/": {
-            "get": {
+        "/api/inventories/increase": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get all product prices",
+                "description": "Create increase inventory record based on parameters",
                 "consumes": [
                     "application/json"
                 ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "Products"
+                    "Inventories"
+                ],
+                "summary": "Create increase inventory record based on parameters",
+                "parameters": [
+                    {
+                        "description": "Inventory Data",
+                        "name": "inventory",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_domain_inventory.IncreaseInventoryDTO"
+                        }
+                    }
                 ],
-                "summary": "Get all product prices",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Product"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Inventory"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             }
         },
-        "/api/products/prices/{id}": {
+        "/api/locations": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Fetch individual product price by Id",
+                "description": "Fetch all locations",
                 "consumes": [
                     "application/json"
                 ],
@@ -1301,54 +1507,66 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Locations"
                 ],
-                "summary": "Fetch individual product price by Id",
+                "summary": "Fetch all locations",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "rows per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "product Id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "comma separated fields of id, code, name; prefix - for descending",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Product"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Location"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
-            }
-        },
-        "/api/products/stocks": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get all product stocks",
+                "description": "Create a place stock is kept. A new default location takes over from the old one.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1356,45 +1574,68 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Locations"
+                ],
+                "summary": "Create new location",
+                "parameters": [
+                    {
+                        "description": "Location Data",
+                        "name": "location",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_domain_location.CreateLocationRequestDTO"
+                        }
+                    }
                 ],
-                "summary": "Get all product stocks",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Product"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Location"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             }
         },
-        "/api/products/stocks/{id}": {
+        "/api/locations/{id}": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get all product stocks By Id",
+                "description": "Fetch individual location by Id",
                 "consumes": [
                     "application/json"
                 ],
@@ -1402,13 +1643,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Locations"
                 ],
-                "summary": "Get all product stocks By Id",
+                "summary": "Fetch individual location by Id",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "product Id",
+                        "type": "integer",
+                        "description": "location Id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1418,38 +1659,42 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Product"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Location"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
-            }
-        },
-        "/api/products/{id}": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Fetch individual product by Id",
+                "description": "Update the code, name or default flag of a location",
                 "consumes": [
                     "application/json"
                 ],
@@ -1457,52 +1702,79 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Locations"
                 ],
-                "summary": "Fetch individual product by Id",
+                "summary": "Update individual location",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "product Id",
+                        "type": "integer",
+                        "description": "location Id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Location Data",
+                        "name": "location",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_domain_location.UpdateLocationRequestDTO"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Product"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Location"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             },
-            "put": {
+            "delete": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Update individual product",
+                "description": "Delete a location that is not the default one and holds no stock",
                 "consumes": [
                     "application/json"
                 ],
@@ -1510,61 +1782,73 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Locations"
                 ],
-                "summary": "Update individual product",
+                "summary": "Delete individual location",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "product Id",
+                        "type": "integer",
+                        "description": "location Id",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Product Data",
-                        "name": "product",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_domain_product.UpdateProductRequstDTO"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Product"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/locations/{id}/stocks": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Delete individual product",
+                "description": "Fetch the stock of every product at the location",
                 "consumes": [
                     "application/json"
                 ],
@@ -1572,54 +1856,87 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Locations"
                 ],
-                "summary": "Delete individual product",
+                "summary": "Fetch the stock at a location",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "product Id",
+                        "type": "integer",
+                        "description": "location Id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "rows per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields of productId, productName, baseQty, derivedQty; prefix - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only the stock of this product",
+                        "name": "productId",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Product"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_domain_location.LocationStockResponseDTO"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             }
         },
-        "/api/productstocks": {
+        "/api/payables/aging": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Get all product stocks",
+                "description": "Fetch what is owed to each supplier split into current, 30, 60 and 90+ days",
                 "consumes": [
                     "application/json"
                 ],
@@ -1627,43 +1944,56 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ProductStocks"
+                    "Payables"
+                ],
+                "summary": "Fetch the payables aging of all suppliers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "aging date, yyyy-mm-dd (default today)",
+                        "name": "asOf",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all product stocks",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ProductStock"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_domain_payable.SupplierAgingDTO"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/product": {
             "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Create a new product stock with productId, baseQty, derivedQty, and reorderLvl",
+                "description": "Create a new product with name, category, prices, and status",
                 "consumes": [
                     "application/json"
                 ],
@@ -1671,17 +2001,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ProductStocks"
+                    "Products"
                 ],
-                "summary": "Create new product stock",
+                "summary": "Create new product",
                 "parameters": [
                     {
-                        "description": "Product Stock Input Data",
-                        "name": "productStock",
+                        "description": "Product input data",
+                        "name": "product",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ProductStock"
+                            "$ref": "#/definitions/internal_domain_product.CreateProductRequstDTO"
                         }
                     }
                 ],
@@ -1689,38 +2019,38 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ProductStock"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Product"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             }
         },
-        "/api/productstocks/{id}": {
+        "/api/productprices": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Fetch individual productstock by Id",
+                "description": "Fetch all product prices",
                 "consumes": [
                     "application/json"
                 ],
@@ -1728,52 +2058,84 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ProductStocks"
+                    "ProductPrice"
                 ],
-                "summary": "Fetch individual productstock by Id",
+                "summary": "Fetch all product prices",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "rows per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "product Id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "comma separated fields of id, productId, productName, price; prefix - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only the prices of this product",
+                        "name": "productId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the prices of this unit",
+                        "name": "unitId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "BUY or SELL",
+                        "name": "priceType",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ProductStock"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ProductPrice"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Update individual products",
+                "description": "Create a new product price with productId, unitId, and unitPrice",
                 "consumes": [
                     "application/json"
                 ],
@@ -1781,24 +2143,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ProductStocks"
+                    "ProductPrice"
                 ],
-                "summary": "Update individual productstock",
+                "summary": "Create new product price",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "product Id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "ProductStock Data",
-                        "name": "product",
+                        "description": "Product Price Input Data",
+                        "name": "productPrice",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_domain_productstock.UpdateProductStockDTO"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ProductPrice"
                         }
                     }
                 ],
@@ -1806,38 +2161,38 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ProductStock"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ProductPrice"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             }
         },
-        "/api/purchases": {
+        "/api/productprices/{id}": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Fetch all purchases",
+                "description": "Fetch individual product price by Id",
                 "consumes": [
                     "application/json"
                 ],
@@ -1845,61 +2200,77 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Purchases"
+                    "ProductPrice"
+                ],
+                "summary": "Fetch individual product price by Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "product price Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Fetch all purchases",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Purchase"
-                            }
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ProductPrice"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Create new purchase based on parameters",
+                "description": "Update individual product price",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Purchases"
+                    "ProductPrice"
                 ],
-                "summary": "Create new purchase based on parameters",
+                "summary": "Update individual product price",
                 "parameters": [
                     {
-                        "description": "Product Data",
-                        "name": "purchase",
+                        "type": "string",
+                        "description": "product price Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Product Price Data",
+                        "name": "productPrice",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_domain_purchase.PurchaseInvoiceRequestDTO"
+                            "$ref": "#/definitions/internal_domain_productprice.UpdateProductPriceRequestDTO"
                         }
                     }
                 ],
@@ -1907,38 +2278,38 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Purchase"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ProductPrice"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             }
         },
-        "/api/purchases/{id}": {
+        "/api/products": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Fetch individual purchase by Id",
+                "description": "Fetch all products",
                 "consumes": [
                     "application/json"
                 ],
@@ -1946,54 +2317,92 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Purchases"
+                    "Products"
                 ],
-                "summary": "Fetch individual purchase by Id",
+                "summary": "Fetch all products",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "rows per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "purchase Id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "comma separated fields of id, productName, brandName, buyPrice, sellPrice, createdAt; prefix - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the products of this category",
+                        "name": "categoryId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only active or only inactive products",
+                        "name": "isActive",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only the products of this brand",
+                        "name": "brandName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the products with this base unit",
+                        "name": "uomId",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Purchase"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Product"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             }
         },
-        "/api/sales": {
+        "/api/products/by-barcode/{code}": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Fetch all sales",
+                "description": "Returns the product, the unit and the current sell price of the barcode",
                 "consumes": [
                     "application/json"
                 ],
@@ -2001,100 +2410,132 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Sales"
+                    "Barcodes"
+                ],
+                "summary": "Look up a scanned barcode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "barcode",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Fetch all sales",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Sale"
-                            }
+                            "$ref": "#/definitions/internal_domain_barcode.BarcodeLookupDTO"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/products/prices/": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Create new sale based on parameters",
+                "description": "Get all product prices",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Sales"
+                    "Products"
                 ],
-                "summary": "Create new sale based on parameters",
+                "summary": "Get all product prices",
                 "parameters": [
                     {
-                        "description": "Product Data",
-                        "name": "sale",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_domain_sale.SaleInvoiceRequestDTO"
-                        }
+                        "type": "integer",
+                        "description": "page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "rows per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields of serial, productId, productName, unitPrice; prefix - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only the prices of this product",
+                        "name": "productId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "BUY or SELL",
+                        "name": "priceType",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Sale"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Product"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             }
         },
-        "/api/sales/{id}": {
+        "/api/products/prices/{id}": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Fetch individual sale by Id",
+                "description": "Fetch individual product price by Id",
                 "consumes": [
                     "application/json"
                 ],
@@ -2102,13 +2543,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Sales"
+                    "Products"
                 ],
-                "summary": "Fetch individual sale by Id",
+                "summary": "Fetch individual product price by Id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "sale Id",
+                        "description": "product Id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2118,38 +2559,38 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Sale"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Product"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             }
         },
-        "/api/suppliers": {
+        "/api/products/search": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Fetch all supplier",
+                "description": "Type-ahead lookup of products by id, name, brand or category name, Myanmar script included. Best matches come first.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2157,100 +2598,127 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Suppliers"
+                    "Products"
+                ],
+                "summary": "Search products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "search term",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "rows per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the products of this category",
+                        "name": "categoryId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only active or only inactive products",
+                        "name": "isActive",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Fetch all supplier",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Supplier"
+                                "$ref": "#/definitions/internal_domain_product.ProductSearchResultDTO"
                             }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/products/stocks": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Create new supplier based on parameters",
+                "description": "Get all product stocks",
                 "consumes": [
                     "application/json"
                 ],
-                "tags": [
-                    "Suppliers"
+                "produces": [
+                    "application/json"
                 ],
-                "summary": "Create new supplier based on parameters",
-                "parameters": [
-                    {
-                        "description": "Supplier Data",
-                        "name": "supplier",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_domain_supplier.CreateSupplierRequestDTO"
-                        }
-                    }
+                "tags": [
+                    "Products"
                 ],
+                "summary": "Get all product stocks",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Supplier"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Product"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             }
         },
-        "/api/suppliers/{id}": {
+        "/api/products/stocks/{id}": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Fetch individual supplier by Id",
+                "description": "Get all product stocks By Id",
                 "consumes": [
                     "application/json"
                 ],
@@ -2258,13 +2726,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Suppliers"
+                    "Products"
                 ],
-                "summary": "Fetch individual supplier by Id",
+                "summary": "Get all product stocks By Id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "supplier Id",
+                        "description": "product Id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2274,36 +2742,38 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Supplier"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Product"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/products/{id}": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Update individual supplier",
+                "description": "Fetch individual product by Id",
                 "consumes": [
                     "application/json"
                 ],
@@ -2311,61 +2781,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Suppliers"
+                    "Products"
                 ],
-                "summary": "Update individual supplier",
+                "summary": "Fetch individual product by Id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Supplier Id",
+                        "description": "product Id",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Supplier Data",
-                        "name": "product",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_domain_supplier.UpdateSupplierRequstDTO"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Supplier"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Product"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             },
-            "delete": {
+            "put": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Delete individual supplier",
+                "description": "Update individual product",
                 "consumes": [
                     "application/json"
                 ],
@@ -2373,54 +2834,61 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Suppliers"
+                    "Products"
                 ],
-                "summary": "Delete individual supplier",
+                "summary": "Update individual product",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "supplier Id",
+                        "description": "product Id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Product Data",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_domain_product.UpdateProductRequstDTO"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Supplier"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Product"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
-            }
-        },
-        "/api/transactions": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Fetch all transactions",
+                "description": "Delete individual product",
                 "consumes": [
                     "application/json"
                 ],
@@ -2428,48 +2896,54 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Products"
+                ],
+                "summary": "Delete individual product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "product Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Fetch all transactions",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ItemTransaction"
-                            }
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Product"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             }
         },
-        "/api/transactions/adjustment": {
-            "post": {
+        "/api/products/{id}/barcodes": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Create",
+                "description": "Fetch the barcodes of a product, of all its units",
                 "consumes": [
                     "application/json"
                 ],
@@ -2477,63 +2951,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Barcodes"
                 ],
-                "summary": "Create adjustment transaction",
+                "summary": "Fetch the barcodes of a product",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer token",
-                        "name": "Authorization",
-                        "in": "header",
+                        "description": "product Id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Product input data",
-                        "name": "transaction",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_domain_itemtransactions.ResquestAdjustInventoryDTO"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ItemTransaction"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_domain_barcode.BarcodeResponseDTO"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
-            }
-        },
-        "/api/transactions/by-product-type/{productId}/{tranType}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Fetch individual transaction by productId and tranType",
+                "description": "Add an EAN, UPC or INTERNAL barcode to the base or derived unit of a product. Codes are unique.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2541,64 +3007,81 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Barcodes"
                 ],
-                "summary": "Fetch individual transaction by productId and tranType",
+                "summary": "Add a barcode to a product",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Product ID",
-                        "name": "productId",
+                        "description": "product Id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Transaction Type",
-                        "name": "tranType",
-                        "in": "path",
-                        "required": true
+                        "description": "Barcode Input Data",
+                        "name": "barcode",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_domain_barcode.CreateBarcodeRequestDTO"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ItemTransaction"
-                            }
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ProductBarcode"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             }
         },
-        "/api/transactions/by-product/{productId}": {
-            "get": {
+        "/api/products/{id}/barcodes/{code}": {
+            "delete": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Fetch individual transaction by productId",
+                "description": "Remove a barcode from a product; the code can be given out again",
                 "consumes": [
                     "application/json"
                 ],
@@ -2606,9 +3089,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Barcodes"
                 ],
-                "summary": "Fetch individual transaction by productId",
+                "summary": "Remove a barcode from a product",
                 "parameters": [
                     {
                         "type": "string",
@@ -2616,47 +3099,58 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "barcode",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ItemTransaction"
-                            }
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             }
         },
-        "/api/transactions/by-type/{tranType}": {
+        "/api/productstocks": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Fetch individual transaction by protransactionType",
+                "description": "Get the stock of every product over all locations, with the stock at each location",
                 "consumes": [
                     "application/json"
                 ],
@@ -2664,16 +3158,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "ProductStocks"
                 ],
-                "summary": "Fetch individual transaction by transactionType",
+                "summary": "Get all product stocks",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "rows per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "transactionType",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "comma separated fields of productId, productName, baseQty, derivedQty; prefix - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only the stock of this product",
+                        "name": "productId",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2682,39 +3193,37 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ItemTransaction"
+                                "$ref": "#/definitions/internal_domain_productstock.ResponseProductStockDTO"
                             }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
-            }
-        },
-        "/api/unitconversions": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Fetch all unit conversions",
+                "description": "Create the stock of a product at a location, the default location when locationId is empty, with baseQty, derivedQty and reorderLvl",
                 "consumes": [
                     "application/json"
                 ],
@@ -2722,100 +3231,143 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "UnitConversions"
+                    "ProductStocks"
+                ],
+                "summary": "Create new product stock",
+                "parameters": [
+                    {
+                        "description": "Product Stock Input Data",
+                        "name": "productStock",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ProductStock"
+                        }
+                    }
                 ],
-                "summary": "Fetch all unit conversions",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.UnitConversion"
-                            }
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ProductStock"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/productstocks/low": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Create new unit conversion based on parameters",
+                "description": "Fetch the stock of every product and location that is at or below its reorder level, loose derived units counting as 1/factor of a base unit",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "UnitConversions"
+                    "ProductStocks"
                 ],
-                "summary": "Create new unit conversion based on parameters",
+                "summary": "Fetch the stock at or below its reorder level",
                 "parameters": [
                     {
-                        "description": "Product Data",
-                        "name": "product",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_domain_unitconversion.CreateUnitConversionDTO"
-                        }
+                        "type": "integer",
+                        "description": "page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "rows per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields of productId, productName, locationId, shortage; prefix - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only the stock of this product",
+                        "name": "productId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the stock at this location",
+                        "name": "locationId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the products of this category",
+                        "name": "categoryId",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.UnitConversion"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_domain_productstock.LowStockDTO"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             }
         },
-        "/api/unitconversions/{id}": {
+        "/api/productstocks/{id}": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Fetch individual unit conversion by Id",
+                "description": "Fetch the stock of a product over all locations, with the stock at each location",
                 "consumes": [
                     "application/json"
                 ],
@@ -2823,13 +3375,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "UnitConversions"
+                    "ProductStocks"
                 ],
-                "summary": "Fetch individual unit conversion by Id",
+                "summary": "Fetch individual productstock by Id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "unit conversion Id",
+                        "description": "product Id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2839,25 +3391,25 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.UnitConversion"
+                            "$ref": "#/definitions/internal_domain_productstock.ResponseProductStockDTO"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
@@ -2868,7 +3420,7 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "Update individual unit conversion",
+                "description": "Set the stock of a product at a location, the default location when locationId is empty; the differences are booked as adjustments",
                 "consumes": [
                     "application/json"
                 ],
@@ -2876,24 +3428,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "UnitConversions"
+                    "ProductStocks"
                 ],
-                "summary": "Update individual unit conversion",
+                "summary": "Update individual productstock",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "unit conversion Id",
+                        "description": "product Id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Product Data",
+                        "description": "ProductStock Data",
                         "name": "product",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_domain_unitconversion.UpdateUnitConversionRequestDTO"
+                            "$ref": "#/definitions/internal_domain_productstock.UpdateProductStockDTO"
                         }
                     }
                 ],
@@ -2901,91 +3453,135 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.UnitConversion"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ProductStock"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/profits": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Delete individual unit conversion",
+                "description": "Compare what the sale lines brought in, net of discounts and returns and without tax, with what their goods cost at the time of the sale, per sale, product, category, day, week or month. format=csv downloads the report as CSV.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
-                    "UnitConversions"
+                    "Profits"
                 ],
-                "summary": "Delete individual unit conversion",
+                "summary": "Gross profit and margin of the sales",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "unit conversion Id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "sale (default), product, category, day, week or month",
+                        "name": "groupBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "first sale date, yyyy-mm-dd",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "last sale date, yyyy-mm-dd",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the sales from this location",
+                        "name": "locationId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the sales to this customer",
+                        "name": "customerId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the lines of products in this category",
+                        "name": "categoryId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only the lines of this product",
+                        "name": "productId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default) or csv",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.UnitConversion"
+                            "$ref": "#/definitions/internal_domain_profit.ProfitReportDTO"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             }
         },
-        "/api/unitofmeasurements": {
+        "/api/purchaseorders": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Fetch all unit of measurement",
+                "description": "Fetch all purchase orders with their supplier and lines",
                 "consumes": [
                     "application/json"
                 ],
@@ -2993,35 +3589,85 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "UnitOfMeasurements"
+                    "PurchaseOrders"
                 ],
-                "summary": "Fetch all unit of measurement",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                "summary": "Fetch all purchase orders",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "rows per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields of id, orderDate, total, createdAt; prefix - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only the orders in this status: DRAFT, SENT, PARTIALLY_RECEIVED, RECEIVED or CLOSED",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the orders of this supplier",
+                        "name": "supplierId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the orders to be received at this location",
+                        "name": "locationId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "first order date, yyyy-mm-dd",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "last order date, yyyy-mm-dd",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.UnitOfMeasure"
+                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.PurchaseOrder"
                             }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
@@ -3032,61 +3678,70 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "Create new unit of measurement based on parameters",
+                "description": "Create a draft purchase order; each line must be an active product in its base or derived unit (empty is the base unit); line totals are quantity times price",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "UnitOfMeasurements"
+                    "PurchaseOrders"
                 ],
-                "summary": "Create new unit of measurement based on parameters",
+                "summary": "Create a draft purchase order",
                 "parameters": [
                     {
-                        "description": "Product Data",
-                        "name": "product",
+                        "description": "Purchase order",
+                        "name": "order",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.UnitOfMeasure"
+                            "$ref": "#/definitions/internal_domain_purchaseorder.PurchaseOrderRequestDTO"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.UnitOfMeasure"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.PurchaseOrder"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             }
         },
-        "/api/unitofmeasurements/{id}": {
+        "/api/purchaseorders/{id}": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Fetch individual unit of measurement by Id",
+                "description": "Fetch individual purchase order by Id",
                 "consumes": [
                     "application/json"
                 ],
@@ -3094,13 +3749,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "UnitOfMeasurements"
+                    "PurchaseOrders"
                 ],
-                "summary": "Fetch individual unit of measurement by Id",
+                "summary": "Fetch individual purchase order by Id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "unit of measurement Id",
+                        "description": "purchase order Id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -3110,36 +3765,99 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.UnitOfMeasure"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.PurchaseOrder"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/purchaseorders/{id}/close": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Close a draft, sent or partially received purchase order; nothing more can be received for it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PurchaseOrders"
+                ],
+                "summary": "Close a purchase order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "purchase order Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.PurchaseOrder"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/purchaseorders/{id}/send": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Update individual unit of measurement",
+                "description": "Mark a draft purchase order as sent to its supplier; only a sent order can be received",
                 "consumes": [
                     "application/json"
                 ],
@@ -3147,24 +3865,172 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "UnitOfMeasurements"
+                    "PurchaseOrders"
                 ],
-                "summary": "Update individual unit of measurement",
+                "summary": "Send a draft purchase order",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "unit of measurement Id",
+                        "description": "purchase order Id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.PurchaseOrder"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/purchases": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Fetch all purchases",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchases"
+                ],
+                "summary": "Fetch all purchases",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "rows per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields of id, purchaseDate, grandTotal, createdAt; prefix - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the purchases of this supplier",
+                        "name": "supplierId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the purchases received at this location",
+                        "name": "locationId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only the purchases matched against their goods receipts as MATCHED or MISMATCHED",
+                        "name": "matchStatus",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "first purchase date, yyyy-mm-dd",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "last purchase date, yyyy-mm-dd",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Purchase"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Book a supplier invoice and match it against the goods receipts it bills; it moves no stock. Lines whose quantity or price differs from the receipts are flagged and the purchase is MISMATCHED",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchases"
+                ],
+                "summary": "Create new purchase based on parameters",
+                "parameters": [
                     {
                         "description": "Product Data",
-                        "name": "product",
+                        "name": "purchase",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.UnitOfMeasure"
+                            "$ref": "#/definitions/internal_domain_purchase.PurchaseInvoiceRequestDTO"
                         }
                     }
                 ],
@@ -3172,36 +4038,50 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.UnitOfMeasure"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Purchase"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/purchases/{id}": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Delete individual unit of measurement",
+                "description": "Fetch individual purchase by Id",
                 "consumes": [
                     "application/json"
                 ],
@@ -3209,13 +4089,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "UnitOfMeasurements"
+                    "Purchases"
                 ],
-                "summary": "Delete individual unit of measurement",
+                "summary": "Fetch individual purchase by Id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "unit of measurement Id",
+                        "description": "purchase Id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -3225,159 +4105,4985 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.UnitOfMeasure"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Purchase"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError400"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError401"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HttpError500"
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.UpdateCategoryRequestDTO": {
-            "type": "object",
-            "properties": {
-                "categoryName": {
-                    "type": "string"
-                }
-            }
         },
-        "github_com_sankangkin_di-rest-api_internal_models.Category": {
-            "type": "object",
-            "required": [
-                "categoryName"
-            ],
-            "properties": {
+        "/api/purchases/{id}/returns": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Send goods of a purchase back to its supplier, take them out of stock at the locations they were received at and reduce the supplier's payable balance. Only goods its goods receipts brought in can go back",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchases"
+                ],
+                "summary": "Return purchased goods to the supplier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "purchase Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Return Data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_domain_purchase.PurchaseReturnRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.PurchaseReturn"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reorders/purchaseorders": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Work out the suggestion with the same parameters and save what it proposes to buy from the supplier as a draft purchase order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reorders"
+                ],
+                "summary": "Turn a reorder suggestion into a draft purchase order",
+                "parameters": [
+                    {
+                        "description": "Suggestion parameters",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_domain_reorder.CreateOrderRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.PurchaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reorders/suggestions": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Propose reorder quantities for a location from the average daily sales of the last days: enough for coverDays more of them on top of the reorder level. The products are grouped by the supplier they were last bought from, supplierId 0 holding those never bought.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reorders"
+                ],
+                "summary": "Suggest what to reorder",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "the location to restock, the default location when empty",
+                        "name": "locationId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "days of sales to average, 30 by default",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "days of sales the order should cover, 30 by default",
+                        "name": "coverDays",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated product ids to limit the suggestion to",
+                        "name": "productIds",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_domain_reorder.SuggestionReportDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/sales": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Fetch all sales",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sales"
+                ],
+                "summary": "Fetch all sales",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "rows per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields of id, saleDate, grandTotal, createdAt; prefix - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the sales of this customer",
+                        "name": "customerId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the sales from this location",
+                        "name": "locationId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ACTIVE, PARTIALLY_RETURNED, RETURNED or VOIDED",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "CASH, CREDIT or PARTIAL",
+                        "name": "paymentType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "first sale date, yyyy-mm-dd",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "last sale date, yyyy-mm-dd",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Sale"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Create new sale based on parameters",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sales"
+                ],
+                "summary": "Create new sale based on parameters",
+                "parameters": [
+                    {
+                        "description": "Product Data",
+                        "name": "sale",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_domain_sale.SaleInvoiceRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Sale"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/sales/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Fetch individual sale by Id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sales"
+                ],
+                "summary": "Fetch individual sale by Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "sale Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Sale"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/sales/{id}/returns": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Book a customer return against the lines of a sale and put the returned quantities back into stock",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sales"
+                ],
+                "summary": "Return items of a sale",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "sale Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Return Data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_domain_sale.SaleReturnRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.SaleReturn"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/sales/{id}/void": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Void a sale, put every unreturned quantity back into stock and keep the invoice marked as VOIDED",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sales"
+                ],
+                "summary": "Void a sale",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "sale Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Void Data",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_domain_sale.SaleVoidRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Sale"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/stocktakes": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Fetch all stock takes, without their lines",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "StockTakes"
+                ],
+                "summary": "Fetch all stock takes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "rows per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields of id, createdAt; prefix - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OPEN or POSTED",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the stock takes of this location",
+                        "name": "locationId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.StockTake"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Start a physical count of a location. The expected stock of every product to count is snapshotted; only one count can be open per location.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "StockTakes"
+                ],
+                "summary": "Open a stock take",
+                "parameters": [
+                    {
+                        "description": "Stock take",
+                        "name": "stockTake",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_domain_stocktake.OpenStockTakeRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.StockTake"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/stocktakes/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Fetch a stock take with its lines and the counts of every counter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "StockTakes"
+                ],
+                "summary": "Fetch individual stock take by Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "stock take Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.StockTake"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/stocktakes/{id}/counts": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Record what the signed-in user counted. Counting a product again replaces the user's earlier count of it; the counts of all users are added up.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "StockTakes"
+                ],
+                "summary": "Enter counted quantities",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "stock take Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Counts",
+                        "name": "counts",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_domain_stocktake.EnterCountsRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.StockTake"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/stocktakes/{id}/post": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Book every variance of the counted products as an ADJUSTMENT and lock the stock take. Products nobody counted keep their stock.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "StockTakes"
+                ],
+                "summary": "Post a stock take",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "stock take Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.StockTake"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/stocktakes/{id}/variances": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "List the counted products whose stock differs from the snapshot, valued at the buy price",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "StockTakes"
+                ],
+                "summary": "Review the variances of a stock take",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "stock take Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_domain_stocktake.VarianceReportDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/suppliers": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Fetch all supplier",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Fetch all supplier",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "rows per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields of id, name; prefix - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only the supplier with this phone number",
+                        "name": "phone",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Supplier"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Create new supplier based on parameters",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Create new supplier based on parameters",
+                "parameters": [
+                    {
+                        "description": "Supplier Data",
+                        "name": "supplier",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_domain_supplier.CreateSupplierRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Supplier"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/suppliers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Fetch individual supplier by Id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Fetch individual supplier by Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "supplier Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Supplier"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Update individual supplier",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Update individual supplier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Supplier Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Supplier Data",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_domain_supplier.UpdateSupplierRequstDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Supplier"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Delete individual supplier",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Delete individual supplier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "supplier Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Supplier"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/suppliers/{id}/aging": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Fetch the open purchases of a supplier split into current, 30, 60 and 90+ days",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Fetch the payables aging of a supplier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "supplier Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "aging date, yyyy-mm-dd (default today)",
+                        "name": "asOf",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_domain_payable.SupplierAgingDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/suppliers/{id}/balance": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Fetch what is owed to a supplier: purchases less goods returned and payments made",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Fetch the payable balance of a supplier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "supplier Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_domain_supplier.SupplierBalanceDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/suppliers/{id}/payments": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Fetch the payments made to a supplier with the purchases each one was applied to",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Fetch the payments made to a supplier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "supplier Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.SupplierPayment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Record a payment and apply it to the given purchases, or to the oldest open purchases when none are given",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Record a payment made to a supplier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "supplier Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment Data",
+                        "name": "payment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_domain_payable.SupplierPaymentRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.SupplierPayment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/suppliers/{id}/statement": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Fetch purchases, returns and payments of a supplier in booking order with a running balance",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Fetch the account statement of a supplier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "supplier Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_domain_payable.SupplierStatementDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/transactions": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Fetch all transactions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Fetch all transactions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "nextCursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "rows per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "id for oldest first, -id for newest first",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only the movements of this product",
+                        "name": "productId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "DEBIT, CREDIT, ADJUSTMENT or TRANSFER",
+                        "name": "tranType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the movements at this location",
+                        "name": "locationId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only the movements of this reference",
+                        "name": "referenceNo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "first day, yyyy-mm-dd",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "last day, yyyy-mm-dd",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ItemTransaction"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/transactions/adjustment": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Create",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Create adjustment transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Product input data",
+                        "name": "transaction",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_domain_itemtransactions.ResquestAdjustInventoryDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ItemTransaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/transactions/by-product-type/{productId}/{tranType}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Fetch individual transaction by productId and tranType",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Fetch individual transaction by productId and tranType",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transaction Type",
+                        "name": "tranType",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "rows per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "-id for newest first (default), id for oldest first",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the movements at this location",
+                        "name": "locationId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "first day, yyyy-mm-dd",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "last day, yyyy-mm-dd",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ItemTransaction"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/transactions/by-product/{productId}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Fetch individual transaction by productId",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Fetch individual transaction by productId",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "product Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "rows per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "-id for newest first (default), id for oldest first",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the movements at this location",
+                        "name": "locationId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "first day, yyyy-mm-dd",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "last day, yyyy-mm-dd",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ItemTransaction"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/transactions/by-type/{tranType}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Fetch individual transaction by protransactionType",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Fetch individual transaction by transactionType",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "transactionType",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "rows per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "-id for newest first (default), id for oldest first",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the movements at this location",
+                        "name": "locationId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "first day, yyyy-mm-dd",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "last day, yyyy-mm-dd",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ItemTransaction"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/transfers": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Fetch all stock transfers with their lines",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Fetch all stock transfers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "rows per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields of id, transferDate, createdAt; prefix - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the transfers out of this location",
+                        "name": "fromLocationId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the transfers into this location",
+                        "name": "toLocationId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "first transfer date, yyyy-mm-dd",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "last transfer date, yyyy-mm-dd",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.StockTransfer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Take every line out of the from location and put it into the to location in one go, booking a TRANSFER ledger row at each side",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Move stock between locations",
+                "parameters": [
+                    {
+                        "description": "Transfer Data",
+                        "name": "transfer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_domain_stocktransfer.TransferRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.StockTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/transfers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Fetch individual stock transfer by Id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Fetch individual stock transfer by Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "transfer Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.StockTransfer"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/unitconversions": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Fetch all unit conversions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UnitConversions"
+                ],
+                "summary": "Fetch all unit conversions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "rows per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields of id, productId; prefix - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only the conversions of this product",
+                        "name": "productId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.UnitConversion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Create new unit conversion based on parameters",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UnitConversions"
+                ],
+                "summary": "Create new unit conversion based on parameters",
+                "parameters": [
+                    {
+                        "description": "Product Data",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_domain_unitconversion.CreateUnitConversionDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.UnitConversion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/unitconversions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Fetch individual unit conversion by Id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UnitConversions"
+                ],
+                "summary": "Fetch individual unit conversion by Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "unit conversion Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.UnitConversion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Update individual unit conversion",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UnitConversions"
+                ],
+                "summary": "Update individual unit conversion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "unit conversion Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Product Data",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_domain_unitconversion.UpdateUnitConversionRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.UnitConversion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Delete individual unit conversion",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UnitConversions"
+                ],
+                "summary": "Delete individual unit conversion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "unit conversion Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.UnitConversion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/unitofmeasurements": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Fetch all unit of measurement",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UnitOfMeasurements"
+                ],
+                "summary": "Fetch all unit of measurement",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.UnitOfMeasure"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Create new unit of measurement based on parameters",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UnitOfMeasurements"
+                ],
+                "summary": "Create new unit of measurement based on parameters",
+                "parameters": [
+                    {
+                        "description": "Product Data",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.UnitOfMeasure"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.UnitOfMeasure"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/unitofmeasurements/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Fetch individual unit of measurement by Id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UnitOfMeasurements"
+                ],
+                "summary": "Fetch individual unit of measurement by Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "unit of measurement Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "rows per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields of id, unitName; prefix - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.UnitOfMeasure"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Update individual unit of measurement",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UnitOfMeasurements"
+                ],
+                "summary": "Update individual unit of measurement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "unit of measurement Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Product Data",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.UnitOfMeasure"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.UnitOfMeasure"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Delete individual unit of measurement",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UnitOfMeasurements"
+                ],
+                "summary": "Delete individual unit of measurement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "unit of measurement Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.UnitOfMeasure"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/valuation": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Replay the ledger to the end of the asOf day, local midnight in SHOP_TIME_ZONE, and value every product's stock at quantity times its moving-average cost then, per product and per category",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Valuation"
+                ],
+                "summary": "Value the stock as of a date",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "valuation date, yyyy-mm-dd in the shop's time zone (default today)",
+                        "name": "asOf",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the stock at this location, every location when empty",
+                        "name": "locationId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the products of this category",
+                        "name": "categoryId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_domain_valuation.ValuationDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Answers 200 as long as the process serves requests",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Answers 200 when the database is reachable and its schema is at the version of the embedded migrations, 503 otherwise or while shutting down",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.UpdateCategoryRequestDTO": {
+            "type": "object",
+            "properties": {
+                "categoryName": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_apperr.Code": {
+            "type": "string",
+            "enum": [
+                "NOT_FOUND",
+                "CONFLICT",
+                "VALIDATION_FAILED",
+                "INSUFFICIENT_STOCK",
+                "UNAUTHORIZED",
+                "FORBIDDEN",
+                "INTERNAL"
+            ],
+            "x-enum-varnames": [
+                "CodeNotFound",
+                "CodeConflict",
+                "CodeValidation",
+                "CodeInsufficientStock",
+                "CodeUnauthorized",
+                "CodeForbidden",
+                "CodeInternal"
+            ]
+        },
+        "github_com_sankangkin_di-rest-api_internal_apperr.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "Sale.CustomerId"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "CustomerId is a required field"
+                },
+                "param": {
+                    "type": "string"
+                },
+                "tag": {
+                    "type": "string",
+                    "example": "required"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_apperr.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.Code"
+                        }
+                    ],
+                    "example": "NOT_FOUND"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_apperr.FieldError"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "record not found"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "FAIL"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.BarcodeKind": {
+            "type": "string",
+            "enum": [
+                "EAN",
+                "UPC",
+                "INTERNAL"
+            ],
+            "x-enum-comments": {
+                "BarcodeEAN": "EAN-8 or EAN-13, with check digit",
+                "BarcodeUPC": "UPC-A, with check digit"
+            },
+            "x-enum-varnames": [
+                "BarcodeEAN",
+                "BarcodeUPC",
+                "BarcodeInternal"
+            ]
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.Category": {
+            "type": "object",
+            "required": [
+                "categoryName"
+            ],
+            "properties": {
                 "categoryName": {
                     "type": "string",
-                    "minLength": 3
+                    "minLength": 3
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.Customer": {
+            "type": "object",
+            "required": [
+                "address",
+                "name",
+                "phone"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "minLength": 3
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 3
+                },
+                "phone": {
+                    "type": "string",
+                    "minLength": 3
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.GoodsReceipt": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.GoodsReceiptLine"
+                    }
+                },
+                "locationId": {
+                    "type": "integer"
+                },
+                "purchaseId": {
+                    "description": "the invoice that bills it, nil until invoiced",
+                    "type": "string"
+                },
+                "purchaseOrderId": {
+                    "type": "string"
+                },
+                "receiptDate": {
+                    "type": "string"
+                },
+                "remark": {
+                    "type": "string"
+                },
+                "supplierId": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.GoodsReceiptLine": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "goodsReceiptId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "description": "the order price",
+                    "type": "integer"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "purchaseOrderLineId": {
+                    "type": "integer"
+                },
+                "qty": {
+                    "type": "integer"
+                },
+                "unitName": {
+                    "description": "the unit the stock was booked in",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.Inventory": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdTime": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "inQty": {
+                    "type": "integer"
+                },
+                "locationId": {
+                    "type": "integer"
+                },
+                "outQty": {
+                    "type": "integer"
+                },
+                "product": {
+                    "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Product"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "remark": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "updatedTime": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.ItemTransaction": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdTime": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "description": "TODO to enhance with UUID",
+                    "type": "integer"
+                },
+                "inQty": {
+                    "type": "integer"
+                },
+                "locationId": {
+                    "type": "integer"
+                },
+                "outQty": {
+                    "type": "integer"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "referenceNo": {
+                    "type": "string"
+                },
+                "remark": {
+                    "type": "string"
+                },
+                "tranType": {
+                    "type": "string"
+                },
+                "unitCost": {
+                    "description": "the product's average cost of one base unit after this movement",
+                    "type": "integer"
+                },
+                "uom": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.Location": {
+            "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isDefault": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 3
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.Payment": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "customerId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "method": {
+                    "description": "CASH, BANK, MOBILE, CHEQUE",
+                    "type": "string"
+                },
+                "paymentAllocations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.PaymentAllocation"
+                    }
+                },
+                "paymentDate": {
+                    "type": "string"
+                },
+                "referenceNo": {
+                    "type": "string"
+                },
+                "remark": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.PaymentAllocation": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "paymentId": {
+                    "type": "integer"
+                },
+                "saleId": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.PaymentType": {
+            "type": "string",
+            "enum": [
+                "CASH",
+                "CREDIT",
+                "PARTIAL"
+            ],
+            "x-enum-comments": {
+                "PaymentCash": "paid in full at the counter",
+                "PaymentCredit": "bought on account",
+                "PaymentPartial": "part paid at the counter, rest on account"
+            },
+            "x-enum-varnames": [
+                "PaymentCash",
+                "PaymentCredit",
+                "PaymentPartial"
+            ]
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.Product": {
+            "type": "object",
+            "required": [
+                "buyPrice",
+                "deriveUnitPrice",
+                "deriveUomId",
+                "productName",
+                "sellPricelvl1",
+                "uomId"
+            ],
+            "properties": {
+                "avgCost": {
+                    "description": "moving-average cost of one base unit, kept by the stock engine",
+                    "type": "integer"
+                },
+                "brandName": {
+                    "type": "string"
+                },
+                "buyPrice": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "categoryId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "deriveUnitPrice": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "deriveUom": {
+                    "type": "string"
+                },
+                "deriveUomId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "productName": {
+                    "type": "string",
+                    "minLength": 3
+                },
+                "sellPricelvl1": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "uom": {
+                    "type": "string"
+                },
+                "uomId": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.ProductBarcode": {
+            "type": "object",
+            "required": [
+                "code",
+                "productId",
+                "unitId"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.BarcodeKind"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "unitId": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.ProductPrice": {
+            "type": "object",
+            "required": [
+                "price",
+                "priceType",
+                "productId",
+                "unitId"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "priceType": {
+                    "description": "\"BUY\" or \"SELL\"",
+                    "type": "string",
+                    "minLength": 1
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "unitId": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.ProductStock": {
+            "type": "object",
+            "required": [
+                "baseQty",
+                "baseUnitId",
+                "deriveUnitId",
+                "derivedQty",
+                "reorderlvl"
+            ],
+            "properties": {
+                "baseQty": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "baseUnitId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "deriveUnitId": {
+                    "type": "integer"
+                },
+                "derivedQty": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "locationId": {
+                    "type": "integer"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "reorderlvl": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.Purchase": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "discount": {
+                    "type": "integer"
+                },
+                "goodsReceipts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.GoodsReceipt"
+                    }
+                },
+                "grandTotal": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "locationId": {
+                    "description": "where returned goods are taken from",
+                    "type": "integer"
+                },
+                "matchNote": {
+                    "description": "received goods the invoice does not bill",
+                    "type": "string"
+                },
+                "matchStatus": {
+                    "description": "MATCHED or MISMATCHED against its goods receipts",
+                    "type": "string"
+                },
+                "purchaseDate": {
+                    "type": "string"
+                },
+                "purchaseDetails": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.PurchaseDetail"
+                    }
+                },
+                "purchaseReturns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.PurchaseReturn"
+                    }
+                },
+                "remark": {
+                    "type": "string"
+                },
+                "returnedTotal": {
+                    "type": "integer"
+                },
+                "supplier": {
+                    "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Supplier"
+                },
+                "supplierId": {
+                    "type": "integer"
+                },
+                "tax": {
+                    "type": "integer"
+                },
+                "taxRate": {
+                    "description": "basis points, 750 = 7.5%",
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.PurchaseDetail": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "discount": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "priceMismatch": {
+                    "type": "boolean"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "productName": {
+                    "type": "string"
+                },
+                "purchaseId": {
+                    "type": "string"
+                },
+                "qty": {
+                    "type": "integer"
+                },
+                "qtyMismatch": {
+                    "type": "boolean"
+                },
+                "receivedPrice": {
+                    "description": "the order price",
+                    "type": "integer"
+                },
+                "receivedQty": {
+                    "description": "what the matched goods receipts say about the product in this unit",
+                    "type": "integer"
+                },
+                "returnedQty": {
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "Qty x Price - Discount",
+                    "type": "integer"
+                },
+                "unitName": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.PurchaseOrder": {
+            "type": "object",
+            "required": [
+                "lines",
+                "supplierId"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "goodsReceipts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.GoodsReceipt"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.PurchaseOrderLine"
+                    }
+                },
+                "locationId": {
+                    "description": "where the goods are to be received",
+                    "type": "integer"
+                },
+                "orderDate": {
+                    "type": "string"
+                },
+                "remark": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "supplier": {
+                    "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Supplier"
+                },
+                "supplierId": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.PurchaseOrderLine": {
+            "type": "object",
+            "required": [
+                "productId",
+                "qty"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "productName": {
+                    "type": "string"
+                },
+                "purchaseOrderId": {
+                    "type": "string"
+                },
+                "qty": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "receivedQty": {
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "Qty x Price",
+                    "type": "integer"
+                },
+                "unitName": {
+                    "description": "empty is the base unit",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.PurchaseReturn": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "purchaseId": {
+                    "type": "string"
+                },
+                "purchaseReturnDetails": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.PurchaseReturnDetail"
+                    }
+                },
+                "remark": {
+                    "type": "string"
+                },
+                "returnDate": {
+                    "type": "string"
+                },
+                "supplierId": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.PurchaseReturnDetail": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "purchaseDetailId": {
+                    "type": "integer"
+                },
+                "purchaseReturnId": {
+                    "type": "integer"
+                },
+                "qty": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "unitName": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.Role": {
+            "type": "string",
+            "enum": [
+                "admin",
+                "user"
+            ],
+            "x-enum-varnames": [
+                "ADMIN",
+                "USER"
+            ]
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.Sale": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "customer": {
+                    "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Customer"
+                },
+                "customerId": {
+                    "type": "integer"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "discount": {
+                    "type": "integer"
+                },
+                "grandTotal": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "locationId": {
+                    "description": "where the goods are taken from",
+                    "type": "integer"
+                },
+                "paidAmount": {
+                    "description": "copy of the sum of the payment allocations to this sale",
+                    "type": "integer"
+                },
+                "paymentType": {
+                    "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.PaymentType"
+                },
+                "remark": {
+                    "type": "string"
+                },
+                "returnedTotal": {
+                    "type": "integer"
+                },
+                "saleDate": {
+                    "type": "string"
+                },
+                "saleDetails": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.SaleDetail"
+                    }
+                },
+                "saleReturns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.SaleReturn"
+                    }
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.SaleStatus"
+                },
+                "tax": {
+                    "type": "integer"
+                },
+                "taxRate": {
+                    "description": "basis points, 750 = 7.5%",
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.SaleDetail": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "description": "scanned code the line was made from, if any",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "derivedQty": {
+                    "type": "integer"
+                },
+                "discount": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "productName": {
+                    "type": "string"
+                },
+                "qty": {
+                    "type": "integer"
+                },
+                "returnedQty": {
+                    "description": "in the line's own unit (Qty for base, DerivedQty for derived)",
+                    "type": "integer"
+                },
+                "saleId": {
+                    "type": "string"
+                },
+                "total": {
+                    "description": "line qty x Price - Discount",
+                    "type": "integer"
+                },
+                "uom": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.SaleReturn": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isVoid": {
+                    "type": "boolean"
+                },
+                "remark": {
+                    "type": "string"
+                },
+                "returnDate": {
+                    "type": "string"
+                },
+                "saleId": {
+                    "type": "string"
+                },
+                "saleReturnDetails": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.SaleReturnDetail"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.SaleReturnDetail": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "qty": {
+                    "type": "integer"
+                },
+                "saleDetailId": {
+                    "type": "integer"
+                },
+                "saleReturnId": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "uom": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.SaleStatus": {
+            "type": "string",
+            "enum": [
+                "ACTIVE",
+                "PARTIALLY_RETURNED",
+                "RETURNED",
+                "VOIDED"
+            ],
+            "x-enum-varnames": [
+                "SaleActive",
+                "SalePartiallyReturned",
+                "SaleReturned",
+                "SaleVoided"
+            ]
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.StockTake": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.StockTakeLine"
+                    }
+                },
+                "locationId": {
+                    "type": "integer"
+                },
+                "postedAt": {
+                    "type": "string"
+                },
+                "remark": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.StockTakeCount": {
+            "type": "object",
+            "properties": {
+                "baseQty": {
+                    "type": "integer"
+                },
+                "countedBy": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "derivedQty": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "stockTakeLineId": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.StockTakeLine": {
+            "type": "object",
+            "properties": {
+                "buyPrice": {
+                    "type": "integer"
+                },
+                "counted": {
+                    "description": "lines nobody counted are left alone when posting",
+                    "type": "boolean"
+                },
+                "countedBaseQty": {
+                    "type": "integer"
+                },
+                "countedDerivedQty": {
+                    "type": "integer"
+                },
+                "counts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.StockTakeCount"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "expectedBaseQty": {
+                    "type": "integer"
+                },
+                "expectedDerivedQty": {
+                    "type": "integer"
+                },
+                "factor": {
+                    "description": "derived units per base unit, 1 without a derived unit",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "stockTakeId": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.StockTransfer": {
+            "type": "object",
+            "required": [
+                "fromLocationId",
+                "stockTransferDetails",
+                "toLocationId"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "fromLocationId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "remark": {
+                    "type": "string"
+                },
+                "stockTransferDetails": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.StockTransferDetail"
+                    }
+                },
+                "toLocationId": {
+                    "type": "integer"
+                },
+                "transferDate": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.StockTransferDetail": {
+            "type": "object",
+            "required": [
+                "productId",
+                "qty"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "qty": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "stockTransferId": {
+                    "type": "string"
+                },
+                "uom": {
+                    "description": "empty is the base unit",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.Supplier": {
+            "type": "object",
+            "required": [
+                "address",
+                "name",
+                "phone"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "minLength": 3
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 3
+                },
+                "phone": {
+                    "type": "string",
+                    "minLength": 3
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.SupplierPayment": {
+            "type": "object",
+            "properties": {
+                "allocations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.SupplierPaymentAllocation"
+                    }
+                },
+                "amount": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "method": {
+                    "description": "CASH, BANK, MOBILE, CHEQUE",
+                    "type": "string"
+                },
+                "paymentDate": {
+                    "type": "string"
+                },
+                "referenceNo": {
+                    "type": "string"
+                },
+                "remark": {
+                    "type": "string"
+                },
+                "supplierId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.SupplierPaymentAllocation": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "purchaseId": {
+                    "type": "string"
+                },
+                "supplierPaymentId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.UnitConversion": {
+            "type": "object",
+            "required": [
+                "baseUnitId",
+                "deriveUnitId",
+                "factor",
+                "productId"
+            ],
+            "properties": {
+                "baseUnit": {
+                    "type": "string"
+                },
+                "baseUnitId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "deriveUnit": {
+                    "type": "string"
+                },
+                "deriveUnitId": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "factor": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.UnitOfMeasure": {
+            "type": "object",
+            "required": [
+                "unitName"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "unitName": {
+                    "type": "string",
+                    "minLength": 3
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sankangkin_di-rest-api_internal_models.User": {
+            "type": "object",
+            "required": [
+                "email",
+                "isAdmin",
+                "password",
+                "role",
+                "userName"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isAdmin": {
+                    "type": "boolean"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 3
+                },
+                "role": {
+                    "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Role"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userName": {
+                    "type": "string",
+                    "minLength": 3
+                }
+            }
+        },
+        "gorm.DeletedAt": {
+            "type": "object",
+            "properties": {
+                "time": {
+                    "type": "string"
+                },
+                "valid": {
+                    "description": "Valid is true if Time is not NULL",
+                    "type": "boolean"
+                }
+            }
+        },
+        "internal_auth.RefreshRequestDTO": {
+            "type": "object",
+            "properties": {
+                "refreshToken": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_auth.RefreshResponseDTO": {
+            "type": "object",
+            "properties": {
+                "accessToken": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "refreshToken": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "userName": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_auth.SignInRequestDTO": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_auth.SignInResponseDTO": {
+            "type": "object",
+            "properties": {
+                "accessToken": {
+                    "type": "string"
+                },
+                "refreshToken": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "userName": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_auth.SignUpDTO": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "role",
+                "userName"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "userName": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_auth.SignUpResponseDTO": {
+            "type": "object",
+            "properties": {
+                "AccessToken": {
+                    "type": "string"
+                },
+                "RefreshToken": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.User"
+                }
+            }
+        },
+        "internal_domain_barcode.BarcodeLookupDTO": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "kind": {
+                    "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.BarcodeKind"
+                },
+                "price": {
+                    "description": "Price is the SELL entry of the price list for the unit, null when the\nlist has none and the price comes from the product itself.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.ProductPrice"
+                        }
+                    ]
+                },
+                "product": {
+                    "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Product"
+                },
+                "unit": {
+                    "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.UnitOfMeasure"
+                },
+                "unitPrice": {
+                    "description": "UnitPrice is what a sale line of this unit is priced at, null when\nthe product has no sell price for the unit at all.",
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_domain_barcode.BarcodeResponseDTO": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.BarcodeKind"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "unitId": {
+                    "type": "integer"
+                },
+                "unitName": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_domain_barcode.CreateBarcodeRequestDTO": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "8851234567892"
+                },
+                "kind": {
+                    "description": "EAN, UPC or INTERNAL (default)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.BarcodeKind"
+                        }
+                    ],
+                    "example": "EAN"
+                },
+                "unitId": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "internal_domain_category.CreateCategoryRequestDTO": {
+            "type": "object",
+            "properties": {
+                "categoryName": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_domain_customer.CreateCustomerRequestDTO": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_domain_customer.UpdateCustomerRequstDTO": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_domain_goodsreceipt.GoodsReceiptItemRequestDTO": {
+            "type": "object",
+            "properties": {
+                "purchaseOrderLineId": {
+                    "type": "integer"
+                },
+                "qty": {
+                    "description": "in the unit of the order line, at most what is still to come",
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_domain_goodsreceipt.GoodsReceiptRequestDTO": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_domain_goodsreceipt.GoodsReceiptItemRequestDTO"
+                    }
+                },
+                "purchaseOrderId": {
+                    "type": "string"
+                },
+                "receiptDate": {
+                    "type": "string"
+                },
+                "remark": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_domain_inventory.IncreaseInventoryDTO": {
+            "type": "object",
+            "properties": {
+                "inQty": {
+                    "type": "integer"
+                },
+                "locationId": {
+                    "description": "the default location when empty",
+                    "type": "integer"
+                },
+                "outQty": {
+                    "type": "integer"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "remark": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_domain_itemtransactions.ResquestAdjustInventoryDTO": {
+            "type": "object",
+            "properties": {
+                "baseQty": {
+                    "type": "integer"
                 },
                 "createdAt": {
+                    "description": "Timestamp of the transaction",
                     "type": "string"
                 },
-                "deletedAt": {
-                    "$ref": "#/definitions/gorm.DeletedAt"
+                "derivedQty": {
+                    "type": "integer"
                 },
-                "id": {
+                "inQty": {
+                    "description": "Quantity to be added",
                     "type": "integer"
                 },
-                "updatedAt": {
+                "locationId": {
+                    "description": "the default location when empty",
+                    "type": "integer"
+                },
+                "outQty": {
+                    "description": "Quantity to be removed",
+                    "type": "integer"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "referenceNo": {
+                    "description": "Reference number for the transaction",
+                    "type": "string"
+                },
+                "remark": {
+                    "type": "string"
+                },
+                "tranType": {
+                    "description": "DEBIT or CREDIT",
+                    "type": "string"
+                },
+                "uom": {
+                    "description": "Unit of Measure (e.g., EACH, KG)",
+                    "type": "string"
+                }
+            }
+        },
+        "internal_domain_location.CreateLocationRequestDTO": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "YARD"
+                },
+                "isDefault": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Back yard"
+                }
+            }
+        },
+        "internal_domain_location.LocationStockResponseDTO": {
+            "type": "object",
+            "properties": {
+                "baseQty": {
+                    "type": "integer"
+                },
+                "baseUnit": {
+                    "type": "string"
+                },
+                "deriveUnit": {
+                    "type": "string"
+                },
+                "derivedQty": {
+                    "type": "integer"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "productName": {
+                    "type": "string"
+                },
+                "reorderlvl": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_domain_location.UpdateLocationRequestDTO": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "YARD"
+                },
+                "isDefault": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Back yard"
+                }
+            }
+        },
+        "internal_domain_payable.OpenPurchaseDTO": {
+            "type": "object",
+            "properties": {
+                "ageDays": {
+                    "type": "integer"
+                },
+                "grandTotal": {
+                    "type": "integer"
+                },
+                "outstanding": {
+                    "type": "integer"
+                },
+                "paidAmount": {
+                    "type": "integer"
+                },
+                "purchaseDate": {
                     "type": "string"
+                },
+                "purchaseId": {
+                    "type": "string"
+                },
+                "returnedTotal": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_sankangkin_di-rest-api_internal_models.Customer": {
+        "internal_domain_payable.StatementEntryDTO": {
             "type": "object",
-            "required": [
-                "address",
-                "name",
-                "phone"
-            ],
             "properties": {
-                "address": {
-                    "type": "string",
-                    "minLength": 3
+                "balance": {
+                    "type": "integer"
                 },
-                "createdAt": {
-                    "type": "string"
+                "credit": {
+                    "type": "integer"
                 },
-                "deletedAt": {
-                    "$ref": "#/definitions/gorm.DeletedAt"
+                "date": {
+                    "type": "string"
                 },
-                "id": {
+                "debit": {
                     "type": "integer"
                 },
-                "name": {
-                    "type": "string",
-                    "minLength": 3
-                },
-                "phone": {
-                    "type": "string",
-                    "minLength": 3
+                "referenceNo": {
+                    "type": "string"
                 },
-                "updatedAt": {
+                "type": {
+                    "description": "PURCHASE, RETURN or PAYMENT",
                     "type": "string"
                 }
             }
         },
-        "github_com_sankangkin_di-rest-api_internal_models.Inventory": {
+        "internal_domain_payable.SupplierAgingDTO": {
             "type": "object",
             "properties": {
-                "createdAt": {
-                    "type": "string"
-                },
-                "createdTime": {
-                    "type": "string"
-                },
-                "deletedAt": {
-                    "$ref": "#/definitions/gorm.DeletedAt"
-                },
-                "id": {
+                "current": {
                     "type": "integer"
                 },
-                "inQty": {
+                "days30": {
                     "type": "integer"
                 },
-                "outQty": {
+                "days60": {
                     "type": "integer"
                 },
-                "product": {
-                    "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Product"
+                "days90Plus": {
+                    "type": "integer"
                 },
-                "productId": {
+                "name": {
                     "type": "string"
                 },
-                "remark": {
-                    "type": "string"
+                "purchases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_domain_payable.OpenPurchaseDTO"
+                    }
                 },
-                "updatedAt": {
-                    "type": "string"
+                "supplierId": {
+                    "type": "integer"
                 },
-                "updatedTime": {
-                    "type": "string"
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_sankangkin_di-rest-api_internal_models.ItemTransaction": {
+        "internal_domain_payable.SupplierPaymentAllocationRequestDTO": {
             "type": "object",
             "properties": {
-                "createdAt": {
-                    "type": "string"
+                "amount": {
+                    "type": "integer"
                 },
-                "createdTime": {
+                "purchaseId": {
                     "type": "string"
+                }
+            }
+        },
+        "internal_domain_payable.SupplierPaymentRequestDTO": {
+            "type": "object",
+            "properties": {
+                "allocations": {
+                    "description": "empty: oldest open purchases first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_domain_payable.SupplierPaymentAllocationRequestDTO"
+                    }
                 },
-                "deletedAt": {
-                    "$ref": "#/definitions/gorm.DeletedAt"
-                },
-                "id": {
-                    "description": "TODO to enhance with UUID",
-                    "type": "integer"
-                },
-                "inQty": {
+                "amount": {
                     "type": "integer"
                 },
-                "outQty": {
-                    "type": "integer"
+                "method": {
+                    "description": "CASH (default), BANK, MOBILE, CHEQUE",
+                    "type": "string"
                 },
-                "productId": {
+                "paymentDate": {
                     "type": "string"
                 },
                 "referenceNo": {
@@ -3385,26 +9091,40 @@ const docTemplate = `{
                 },
                 "remark": {
                     "type": "string"
+                }
+            }
+        },
+        "internal_domain_payable.SupplierStatementDTO": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "integer"
                 },
-                "tranType": {
-                    "type": "string"
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_domain_payable.StatementEntryDTO"
+                    }
                 },
-                "uom": {
+                "name": {
                     "type": "string"
                 },
-                "updatedAt": {
-                    "type": "string"
+                "supplierId": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_sankangkin_di-rest-api_internal_models.Product": {
+        "internal_domain_product.CreateProductRequstDTO": {
             "type": "object",
             "required": [
                 "buyPrice",
+                "categoryId",
                 "deriveUnitPrice",
                 "deriveUomId",
                 "productName",
-                "sellPricelvl1",
+                "qtyOhHand",
+                "reorderlvl",
+                "sellPriceLevel1",
                 "uomId"
             ],
             "properties": {
@@ -3418,12 +9138,6 @@ const docTemplate = `{
                 "categoryId": {
                     "type": "integer"
                 },
-                "createdAt": {
-                    "type": "string"
-                },
-                "deletedAt": {
-                    "$ref": "#/definitions/gorm.DeletedAt"
-                },
                 "deriveUnitPrice": {
                     "type": "integer",
                     "minimum": 1
@@ -3444,7 +9158,14 @@ const docTemplate = `{
                     "type": "string",
                     "minLength": 3
                 },
-                "sellPricelvl1": {
+                "qtyOhHand": {
+                    "type": "integer"
+                },
+                "reorderlvl": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "sellPriceLevel1": {
                     "type": "integer",
                     "minimum": 1
                 },
@@ -3453,248 +9174,155 @@ const docTemplate = `{
                 },
                 "uomId": {
                     "type": "integer"
-                },
-                "updatedAt": {
-                    "type": "string"
                 }
             }
         },
-        "github_com_sankangkin_di-rest-api_internal_models.ProductPrice": {
+        "internal_domain_product.ProductSearchResultDTO": {
             "type": "object",
-            "required": [
-                "price",
-                "priceType",
-                "productId",
-                "unitId"
-            ],
             "properties": {
-                "createdAt": {
+                "baseUnit": {
                     "type": "string"
                 },
-                "deletedAt": {
-                    "$ref": "#/definitions/gorm.DeletedAt"
+                "brandName": {
+                    "type": "string"
                 },
-                "id": {
+                "categoryId": {
                     "type": "integer"
                 },
-                "price": {
-                    "type": "integer",
-                    "minimum": 1
-                },
-                "priceType": {
-                    "description": "\"BUY\" or \"SELL\"",
-                    "type": "string",
-                    "minLength": 1
+                "categoryName": {
+                    "type": "string"
                 },
-                "productId": {
+                "id": {
                     "type": "string"
                 },
-                "unitId": {
-                    "type": "integer"
+                "isActive": {
+                    "type": "boolean"
                 },
-                "updatedAt": {
+                "productName": {
                     "type": "string"
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "sellPricelvl1": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_sankangkin_di-rest-api_internal_models.ProductStock": {
+        "internal_domain_product.UpdateProductRequstDTO": {
             "type": "object",
             "required": [
-                "baseQty",
-                "baseUnitId",
-                "deriveUnitId",
-                "derivedQty",
-                "reorderlvl"
+                "buyPrice",
+                "categoryId",
+                "deriveUnitPrice",
+                "productName",
+                "sellPricelvl1",
+                "uomId"
             ],
             "properties": {
-                "baseQty": {
-                    "type": "integer",
-                    "minimum": 1
-                },
-                "baseUnitId": {
-                    "type": "integer"
-                },
-                "createdAt": {
+                "brandName": {
+                    "description": "ReorderLvl      uint   ` + "`" + `json:\"reorderlvl\" gorm:\"default:1\" validate:\"required,min=1\"` + "`" + `\nQtyOnHand       int    ` + "`" + `json:\"qtyOhHand\" validate:\"required\"` + "`" + `",
                     "type": "string"
                 },
-                "deletedAt": {
-                    "$ref": "#/definitions/gorm.DeletedAt"
-                },
-                "deriveUnitId": {
-                    "type": "integer"
-                },
-                "derivedQty": {
+                "buyPrice": {
+                    "description": "Uom             string ` + "`" + `json:\"uom\" validate:\"required,min=2\"` + "`" + `",
                     "type": "integer",
                     "minimum": 1
                 },
-                "id": {
+                "categoryId": {
                     "type": "integer"
                 },
-                "productId": {
-                    "type": "string"
-                },
-                "reorderlvl": {
+                "deriveUnitPrice": {
                     "type": "integer",
                     "minimum": 1
                 },
-                "updatedAt": {
-                    "type": "string"
-                }
-            }
-        },
-        "github_com_sankangkin_di-rest-api_internal_models.Purchase": {
-            "type": "object",
-            "properties": {
-                "createdAt": {
-                    "type": "string"
-                },
-                "deletedAt": {
-                    "$ref": "#/definitions/gorm.DeletedAt"
-                },
-                "discount": {
-                    "type": "integer"
-                },
-                "grandTotal": {
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "purchaseDate": {
-                    "type": "string"
-                },
-                "purchaseDetails": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.PurchaseDetail"
-                    }
-                },
-                "remark": {
-                    "type": "string"
+                "isActive": {
+                    "type": "boolean"
                 },
-                "supplier": {
-                    "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Supplier"
+                "productName": {
+                    "type": "string",
+                    "minLength": 3
                 },
-                "supplierId": {
-                    "type": "integer"
+                "sellPricelvl1": {
+                    "type": "integer",
+                    "minimum": 1
                 },
-                "total": {
+                "uomId": {
                     "type": "integer"
-                },
-                "updatedAt": {
-                    "type": "string"
                 }
             }
         },
-        "github_com_sankangkin_di-rest-api_internal_models.PurchaseDetail": {
+        "internal_domain_productprice.UpdateProductPriceRequestDTO": {
             "type": "object",
             "properties": {
-                "createdAt": {
-                    "type": "string"
-                },
-                "deletedAt": {
-                    "$ref": "#/definitions/gorm.DeletedAt"
-                },
                 "id": {
                     "type": "integer"
                 },
                 "price": {
                     "type": "integer"
                 },
-                "productId": {
+                "priceType": {
                     "type": "string"
                 },
-                "productName": {
+                "productId": {
                     "type": "string"
                 },
-                "purchaseId": {
+                "productName": {
                     "type": "string"
                 },
-                "qty": {
-                    "type": "integer"
-                },
-                "total": {
+                "unitId": {
                     "type": "integer"
                 },
                 "unitName": {
                     "type": "string"
-                },
-                "updatedAt": {
-                    "type": "string"
                 }
             }
         },
-        "github_com_sankangkin_di-rest-api_internal_models.Role": {
-            "type": "string",
-            "enum": [
-                "admin",
-                "user"
-            ],
-            "x-enum-varnames": [
-                "ADMIN",
-                "USER"
-            ]
-        },
-        "github_com_sankangkin_di-rest-api_internal_models.Sale": {
+        "internal_domain_productstock.LocationStockDTO": {
             "type": "object",
             "properties": {
-                "createdAt": {
-                    "type": "string"
-                },
-                "customer": {
-                    "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Customer"
-                },
-                "customerId": {
-                    "type": "integer"
-                },
-                "deletedAt": {
-                    "$ref": "#/definitions/gorm.DeletedAt"
-                },
-                "discount": {
+                "baseQty": {
                     "type": "integer"
                 },
-                "grandTotal": {
+                "derivedQty": {
                     "type": "integer"
                 },
-                "id": {
+                "locationCode": {
                     "type": "string"
                 },
-                "remark": {
-                    "type": "string"
+                "locationId": {
+                    "type": "integer"
                 },
-                "saleDate": {
+                "locationName": {
                     "type": "string"
                 },
-                "saleDetails": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.SaleDetail"
-                    }
-                },
-                "total": {
+                "reorderlvl": {
                     "type": "integer"
-                },
-                "updatedAt": {
-                    "type": "string"
                 }
             }
         },
-        "github_com_sankangkin_di-rest-api_internal_models.SaleDetail": {
+        "internal_domain_productstock.LowStockDTO": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "baseQty": {
+                    "type": "integer"
+                },
+                "baseUnit": {
                     "type": "string"
                 },
-                "deletedAt": {
-                    "$ref": "#/definitions/gorm.DeletedAt"
+                "deriveUnit": {
+                    "type": "string"
                 },
                 "derivedQty": {
                     "type": "integer"
                 },
-                "id": {
+                "factor": {
                     "type": "integer"
                 },
-                "price": {
+                "locationCode": {
+                    "type": "string"
+                },
+                "locationId": {
                     "type": "integer"
                 },
                 "productId": {
@@ -3703,622 +9331,726 @@ const docTemplate = `{
                 "productName": {
                     "type": "string"
                 },
-                "qty": {
+                "reorderlvl": {
                     "type": "integer"
                 },
-                "saleId": {
-                    "type": "string"
-                },
-                "total": {
+                "shortage": {
                     "type": "integer"
-                },
-                "uom": {
-                    "type": "string"
-                },
-                "updatedAt": {
-                    "type": "string"
                 }
             }
         },
-        "github_com_sankangkin_di-rest-api_internal_models.Supplier": {
+        "internal_domain_productstock.ResponseProductStockDTO": {
             "type": "object",
-            "required": [
-                "address",
-                "name",
-                "phone"
-            ],
             "properties": {
-                "address": {
-                    "type": "string",
-                    "minLength": 3
+                "baseQty": {
+                    "type": "integer"
                 },
-                "createdAt": {
+                "baseUnit": {
                     "type": "string"
                 },
-                "deletedAt": {
-                    "$ref": "#/definitions/gorm.DeletedAt"
+                "deriveUnit": {
+                    "type": "string"
                 },
-                "id": {
+                "derivedQty": {
                     "type": "integer"
                 },
-                "name": {
-                    "type": "string",
-                    "minLength": 3
+                "factor": {
+                    "type": "integer"
                 },
-                "phone": {
-                    "type": "string",
-                    "minLength": 3
+                "locations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_domain_productstock.LocationStockDTO"
+                    }
                 },
-                "updatedAt": {
+                "productId": {
+                    "type": "string"
+                },
+                "productName": {
                     "type": "string"
+                },
+                "reorderlvl": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_sankangkin_di-rest-api_internal_models.UnitConversion": {
+        "internal_domain_productstock.UpdateProductStockDTO": {
             "type": "object",
-            "required": [
-                "baseUnitId",
-                "deriveUnitId",
-                "factor",
-                "productId"
-            ],
             "properties": {
-                "baseUnit": {
-                    "type": "string"
-                },
-                "baseUnitId": {
+                "baseQty": {
                     "type": "integer"
                 },
-                "createdAt": {
-                    "type": "string"
-                },
-                "deletedAt": {
-                    "$ref": "#/definitions/gorm.DeletedAt"
-                },
-                "deriveUnit": {
-                    "type": "string"
-                },
-                "deriveUnitId": {
+                "derivedQty": {
                     "type": "integer"
                 },
-                "description": {
-                    "type": "string"
-                },
-                "factor": {
-                    "type": "integer",
-                    "minimum": 1
-                },
                 "id": {
                     "type": "integer"
                 },
+                "locationId": {
+                    "description": "the default location when empty",
+                    "type": "integer"
+                },
                 "productId": {
                     "type": "string"
                 },
-                "updatedAt": {
-                    "type": "string"
+                "reorderlvl": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_sankangkin_di-rest-api_internal_models.UnitOfMeasure": {
+        "internal_domain_profit.ProfitReportDTO": {
             "type": "object",
-            "required": [
-                "unitName"
-            ],
             "properties": {
-                "createdAt": {
+                "from": {
                     "type": "string"
                 },
-                "deletedAt": {
-                    "$ref": "#/definitions/gorm.DeletedAt"
-                },
-                "id": {
-                    "type": "integer"
+                "groupBy": {
+                    "type": "string"
                 },
-                "unitName": {
-                    "type": "string",
-                    "minLength": 3
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_domain_profit.ProfitRowDTO"
+                    }
                 },
-                "updatedAt": {
+                "to": {
                     "type": "string"
+                },
+                "total": {
+                    "$ref": "#/definitions/internal_domain_profit.ProfitRowDTO"
                 }
             }
         },
-        "github_com_sankangkin_di-rest-api_internal_models.User": {
+        "internal_domain_profit.ProfitRowDTO": {
             "type": "object",
-            "required": [
-                "email",
-                "isAdmin",
-                "password",
-                "role",
-                "userName"
-            ],
             "properties": {
-                "createdAt": {
-                    "type": "string"
-                },
-                "deletedAt": {
-                    "$ref": "#/definitions/gorm.DeletedAt"
+                "cost": {
+                    "type": "integer"
                 },
-                "email": {
+                "key": {
+                    "description": "sale id, product id, category id or first day of the period",
                     "type": "string"
                 },
-                "id": {
+                "lines": {
                     "type": "integer"
                 },
-                "isAdmin": {
-                    "type": "boolean"
-                },
-                "password": {
-                    "type": "string",
-                    "minLength": 3
-                },
-                "role": {
-                    "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.Role"
+                "margin": {
+                    "description": "profit in percent of revenue",
+                    "type": "number"
                 },
-                "updatedAt": {
+                "name": {
+                    "description": "sale date, product or category name",
                     "type": "string"
                 },
-                "userName": {
-                    "type": "string",
-                    "minLength": 3
+                "profit": {
+                    "type": "integer"
+                },
+                "revenue": {
+                    "type": "integer"
                 }
             }
         },
-        "gorm.DeletedAt": {
+        "internal_domain_purchase.PurchaseInvoiceRequestDTO": {
             "type": "object",
             "properties": {
-                "time": {
+                "discount": {
+                    "type": "integer"
+                },
+                "goodsReceiptIds": {
+                    "description": "the receipts of the goods this invoice bills",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "grandTotal": {
+                    "type": "integer"
+                },
+                "locationId": {
+                    "description": "the default location when empty",
+                    "type": "integer"
+                },
+                "purchaseDate": {
                     "type": "string"
                 },
-                "valid": {
-                    "description": "Valid is true if Time is not NULL",
-                    "type": "boolean"
-                }
-            }
-        },
-        "httputil.HttpError400": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 400
+                "purchaseDetails": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.PurchaseDetail"
+                    }
+                },
+                "remark": {
+                    "type": "string"
+                },
+                "supplierId": {
+                    "type": "integer"
                 },
-                "message": {
-                    "type": "string",
-                    "example": "status bad request"
-                }
-            }
-        },
-        "httputil.HttpError401": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 401
+                "tax": {
+                    "type": "integer"
                 },
-                "message": {
-                    "type": "string",
-                    "example": "Unauthorized"
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "httputil.HttpError500": {
+        "internal_domain_purchase.PurchaseReturnItemRequestDTO": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 500
+                "purchaseDetailId": {
+                    "type": "integer"
                 },
-                "message": {
-                    "type": "string",
-                    "example": "internal server error"
+                "qty": {
+                    "type": "integer"
                 }
             }
         },
-        "internal_auth.RefreshRequestDTO": {
+        "internal_domain_purchase.PurchaseReturnRequestDTO": {
             "type": "object",
             "properties": {
-                "refreshToken": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_domain_purchase.PurchaseReturnItemRequestDTO"
+                    }
+                },
+                "remark": {
+                    "type": "string"
+                },
+                "returnDate": {
                     "type": "string"
                 }
             }
         },
-        "internal_auth.RefreshResponseDTO": {
+        "internal_domain_purchaseorder.PurchaseOrderLineRequestDTO": {
             "type": "object",
             "properties": {
-                "accessToken": {
-                    "type": "string"
+                "price": {
+                    "type": "integer"
                 },
-                "email": {
+                "productId": {
                     "type": "string"
                 },
-                "refreshToken": {
+                "productName": {
                     "type": "string"
                 },
-                "role": {
-                    "type": "string"
+                "qty": {
+                    "type": "integer"
                 },
-                "userName": {
+                "unitName": {
                     "type": "string"
                 }
             }
         },
-        "internal_auth.SignInRequestDTO": {
+        "internal_domain_purchaseorder.PurchaseOrderRequestDTO": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_domain_purchaseorder.PurchaseOrderLineRequestDTO"
+                    }
+                },
+                "locationId": {
+                    "description": "the default location when empty",
+                    "type": "integer"
+                },
+                "orderDate": {
                     "type": "string"
                 },
-                "password": {
+                "remark": {
                     "type": "string"
+                },
+                "supplierId": {
+                    "type": "integer"
                 }
             }
         },
-        "internal_auth.SignInResponseDTO": {
+        "internal_domain_receivable.CustomerBalanceDTO": {
             "type": "object",
             "properties": {
-                "accessToken": {
-                    "type": "string"
+                "balance": {
+                    "description": "negative: the customer is in credit",
+                    "type": "integer"
                 },
-                "refreshToken": {
-                    "type": "string"
+                "customerId": {
+                    "type": "integer"
                 },
-                "role": {
+                "name": {
                     "type": "string"
                 },
-                "userName": {
-                    "type": "string"
+                "openSales": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_domain_receivable.OpenSaleDTO"
+                    }
+                },
+                "paid": {
+                    "type": "integer"
+                },
+                "returned": {
+                    "type": "integer"
+                },
+                "sales": {
+                    "type": "integer"
+                },
+                "unallocated": {
+                    "description": "paid but not applied to any sale yet",
+                    "type": "integer"
                 }
             }
         },
-        "internal_auth.SignUpDTO": {
+        "internal_domain_receivable.CustomerStatementDTO": {
             "type": "object",
-            "required": [
-                "email",
-                "password",
-                "role",
-                "userName"
-            ],
             "properties": {
-                "email": {
-                    "type": "string"
+                "balance": {
+                    "type": "integer"
                 },
-                "password": {
-                    "type": "string"
+                "customerId": {
+                    "type": "integer"
                 },
-                "role": {
-                    "type": "string"
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_domain_receivable.StatementEntryDTO"
+                    }
                 },
-                "userName": {
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "internal_auth.SignUpResponseDTO": {
+        "internal_domain_receivable.OpenSaleDTO": {
             "type": "object",
             "properties": {
-                "AccessToken": {
-                    "type": "string"
+                "grandTotal": {
+                    "type": "integer"
                 },
-                "RefreshToken": {
+                "outstanding": {
+                    "type": "integer"
+                },
+                "paidAmount": {
+                    "type": "integer"
+                },
+                "returnedTotal": {
+                    "type": "integer"
+                },
+                "saleDate": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.User"
+                "saleId": {
+                    "type": "string"
                 }
             }
         },
-        "internal_domain_category.CreateCategoryRequestDTO": {
+        "internal_domain_receivable.PaymentAllocationRequestDTO": {
             "type": "object",
             "properties": {
-                "categoryName": {
+                "amount": {
+                    "type": "integer"
+                },
+                "saleId": {
                     "type": "string"
                 }
             }
         },
-        "internal_domain_customer.CreateCustomerRequestDTO": {
+        "internal_domain_receivable.PaymentRequestDTO": {
             "type": "object",
             "properties": {
-                "address": {
+                "allocations": {
+                    "description": "empty: oldest open sales first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_domain_receivable.PaymentAllocationRequestDTO"
+                    }
+                },
+                "amount": {
+                    "type": "integer"
+                },
+                "method": {
+                    "description": "CASH (default), BANK, MOBILE, CHEQUE",
                     "type": "string"
                 },
-                "name": {
+                "paymentDate": {
                     "type": "string"
                 },
-                "phone": {
+                "referenceNo": {
+                    "type": "string"
+                },
+                "remark": {
                     "type": "string"
                 }
             }
         },
-        "internal_domain_customer.UpdateCustomerRequstDTO": {
+        "internal_domain_receivable.StatementEntryDTO": {
             "type": "object",
             "properties": {
-                "address": {
+                "balance": {
+                    "type": "integer"
+                },
+                "credit": {
+                    "type": "integer"
+                },
+                "date": {
                     "type": "string"
                 },
-                "name": {
+                "debit": {
+                    "type": "integer"
+                },
+                "referenceNo": {
                     "type": "string"
                 },
-                "phone": {
+                "type": {
+                    "description": "SALE, RETURN or PAYMENT",
                     "type": "string"
                 }
             }
         },
-        "internal_domain_inventory.IncreaseInventoryDTO": {
+        "internal_domain_reorder.CreateOrderRequestDTO": {
             "type": "object",
             "properties": {
-                "inQty": {
+                "coverDays": {
+                    "description": "30 when empty",
                     "type": "integer"
                 },
-                "outQty": {
+                "days": {
+                    "description": "30 when empty",
                     "type": "integer"
                 },
-                "productId": {
+                "locationId": {
+                    "description": "the default location when empty",
+                    "type": "integer"
+                },
+                "orderDate": {
                     "type": "string"
                 },
+                "productIds": {
+                    "description": "every suggested product of the supplier when empty",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "remark": {
                     "type": "string"
+                },
+                "supplierId": {
+                    "type": "integer"
                 }
             }
         },
-        "internal_domain_itemtransactions.ResquestAdjustInventoryDTO": {
+        "internal_domain_reorder.SuggestionLineDTO": {
             "type": "object",
             "properties": {
                 "baseQty": {
                     "type": "integer"
                 },
-                "createdAt": {
-                    "description": "Timestamp of the transaction",
+                "baseUnit": {
                     "type": "string"
                 },
-                "derivedQty": {
-                    "type": "integer"
+                "dailySales": {
+                    "type": "number"
                 },
-                "inQty": {
-                    "description": "Quantity to be added",
+                "derivedQty": {
                     "type": "integer"
                 },
-                "outQty": {
-                    "description": "Quantity to be removed",
+                "price": {
                     "type": "integer"
                 },
                 "productId": {
                     "type": "string"
                 },
-                "referenceNo": {
-                    "description": "Reference number for the transaction",
+                "productName": {
                     "type": "string"
                 },
-                "remark": {
-                    "type": "string"
+                "reorderlvl": {
+                    "type": "integer"
                 },
-                "tranType": {
-                    "description": "DEBIT or CREDIT",
-                    "type": "string"
+                "suggestedQty": {
+                    "type": "integer"
                 },
-                "uom": {
-                    "description": "Unit of Measure (e.g., EACH, KG)",
-                    "type": "string"
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "internal_domain_product.CreateProductRequstDTO": {
+        "internal_domain_reorder.SuggestionReportDTO": {
             "type": "object",
-            "required": [
-                "buyPrice",
-                "categoryId",
-                "deriveUnitPrice",
-                "deriveUomId",
-                "productName",
-                "qtyOhHand",
-                "reorderlvl",
-                "sellPriceLevel1",
-                "uomId"
-            ],
             "properties": {
-                "brandName": {
-                    "type": "string"
-                },
-                "buyPrice": {
-                    "type": "integer",
-                    "minimum": 1
-                },
-                "categoryId": {
+                "coverDays": {
                     "type": "integer"
                 },
-                "deriveUnitPrice": {
-                    "type": "integer",
-                    "minimum": 1
-                },
-                "deriveUom": {
-                    "type": "string"
-                },
-                "deriveUomId": {
+                "days": {
                     "type": "integer"
                 },
-                "id": {
-                    "type": "string"
-                },
-                "isActive": {
-                    "type": "boolean"
-                },
-                "productName": {
-                    "type": "string",
-                    "minLength": 3
-                },
-                "qtyOhHand": {
+                "locationId": {
                     "type": "integer"
                 },
-                "reorderlvl": {
-                    "type": "integer",
-                    "minimum": 1
+                "suppliers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_domain_reorder.SupplierSuggestionDTO"
+                    }
+                }
+            }
+        },
+        "internal_domain_reorder.SupplierSuggestionDTO": {
+            "type": "object",
+            "properties": {
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_domain_reorder.SuggestionLineDTO"
+                    }
                 },
-                "sellPriceLevel1": {
-                    "type": "integer",
-                    "minimum": 1
+                "supplierId": {
+                    "type": "integer"
                 },
-                "uom": {
+                "supplierName": {
                     "type": "string"
                 },
-                "uomId": {
+                "total": {
                     "type": "integer"
                 }
             }
         },
-        "internal_domain_product.UpdateProductRequstDTO": {
+        "internal_domain_sale.SaleInvoiceRequestDTO": {
             "type": "object",
-            "required": [
-                "buyPrice",
-                "categoryId",
-                "deriveUnitPrice",
-                "productName",
-                "sellPricelvl1",
-                "uomId"
-            ],
             "properties": {
-                "brandName": {
-                    "description": "ReorderLvl      uint   ` + "`" + `json:\"reorderlvl\" gorm:\"default:1\" validate:\"required,min=1\"` + "`" + `\nQtyOnHand       int    ` + "`" + `json:\"qtyOhHand\" validate:\"required\"` + "`" + `",
-                    "type": "string"
+                "customerId": {
+                    "type": "integer"
                 },
-                "buyPrice": {
-                    "description": "Uom             string ` + "`" + `json:\"uom\" validate:\"required,min=2\"` + "`" + `",
-                    "type": "integer",
-                    "minimum": 1
+                "discount": {
+                    "type": "integer"
                 },
-                "categoryId": {
+                "grandTotal": {
                     "type": "integer"
                 },
-                "deriveUnitPrice": {
-                    "type": "integer",
-                    "minimum": 1
+                "locationId": {
+                    "description": "the default location when empty",
+                    "type": "integer"
                 },
-                "isActive": {
-                    "type": "boolean"
+                "paidAmount": {
+                    "description": "only for PARTIAL",
+                    "type": "integer"
                 },
-                "productName": {
-                    "type": "string",
-                    "minLength": 3
+                "paymentType": {
+                    "description": "CASH (default), CREDIT or PARTIAL",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.PaymentType"
+                        }
+                    ]
                 },
-                "sellPricelvl1": {
-                    "type": "integer",
-                    "minimum": 1
+                "remark": {
+                    "type": "string"
                 },
-                "uomId": {
+                "saleDate": {
+                    "type": "string"
+                },
+                "saleDetails": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.SaleDetail"
+                    }
+                },
+                "tax": {
+                    "type": "integer"
+                },
+                "total": {
                     "type": "integer"
                 }
             }
         },
-        "internal_domain_productprice.UpdateProductPriceRequestDTO": {
+        "internal_domain_sale.SaleReturnItemRequestDTO": {
             "type": "object",
             "properties": {
-                "id": {
+                "qty": {
                     "type": "integer"
                 },
-                "price": {
+                "saleDetailId": {
                     "type": "integer"
+                }
+            }
+        },
+        "internal_domain_sale.SaleReturnRequestDTO": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_domain_sale.SaleReturnItemRequestDTO"
+                    }
                 },
-                "priceType": {
+                "remark": {
                     "type": "string"
                 },
-                "productId": {
+                "returnDate": {
                     "type": "string"
-                },
-                "productName": {
+                }
+            }
+        },
+        "internal_domain_sale.SaleVoidRequestDTO": {
+            "type": "object",
+            "properties": {
+                "remark": {
                     "type": "string"
+                }
+            }
+        },
+        "internal_domain_stocktake.CountItemDTO": {
+            "type": "object",
+            "properties": {
+                "baseQty": {
+                    "type": "integer"
                 },
-                "unitId": {
+                "derivedQty": {
                     "type": "integer"
                 },
-                "unitName": {
+                "productId": {
                     "type": "string"
                 }
             }
         },
-        "internal_domain_productstock.UpdateProductStockDTO": {
+        "internal_domain_stocktake.EnterCountsRequestDTO": {
+            "type": "object",
+            "properties": {
+                "counts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_domain_stocktake.CountItemDTO"
+                    }
+                }
+            }
+        },
+        "internal_domain_stocktake.OpenStockTakeRequestDTO": {
+            "type": "object",
+            "properties": {
+                "locationId": {
+                    "description": "the default location when empty",
+                    "type": "integer"
+                },
+                "productIds": {
+                    "description": "every product stocked at the location when empty",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "remark": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_domain_stocktake.VarianceDTO": {
             "type": "object",
             "properties": {
                 "baseQty": {
                     "type": "integer"
                 },
+                "buyPrice": {
+                    "type": "integer"
+                },
+                "countedBaseQty": {
+                    "type": "integer"
+                },
+                "countedDerivedQty": {
+                    "type": "integer"
+                },
                 "derivedQty": {
                     "type": "integer"
                 },
-                "id": {
+                "expectedBaseQty": {
+                    "type": "integer"
+                },
+                "expectedDerivedQty": {
+                    "type": "integer"
+                },
+                "lineId": {
                     "type": "integer"
                 },
                 "productId": {
                     "type": "string"
                 },
-                "reorderlvl": {
+                "value": {
+                    "description": "at BuyPrice, negative is a loss",
                     "type": "integer"
                 }
             }
         },
-        "internal_domain_purchase.PurchaseInvoiceRequestDTO": {
+        "internal_domain_stocktake.VarianceReportDTO": {
             "type": "object",
             "properties": {
-                "discount": {
+                "counted": {
                     "type": "integer"
                 },
-                "grandTotal": {
+                "gain": {
                     "type": "integer"
                 },
-                "id": {
+                "lines": {
+                    "type": "integer"
+                },
+                "locationId": {
+                    "type": "integer"
+                },
+                "loss": {
+                    "type": "integer"
+                },
+                "net": {
+                    "type": "integer"
+                },
+                "status": {
                     "type": "string"
                 },
-                "purchaseDate": {
+                "stockTakeId": {
                     "type": "string"
                 },
-                "purchaseDetails": {
+                "uncounted": {
+                    "description": "left alone when posting",
+                    "type": "integer"
+                },
+                "variances": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.PurchaseDetail"
+                        "$ref": "#/definitions/internal_domain_stocktake.VarianceDTO"
                     }
-                },
-                "remark": {
+                }
+            }
+        },
+        "internal_domain_stocktransfer.TransferItemRequestDTO": {
+            "type": "object",
+            "properties": {
+                "productId": {
                     "type": "string"
                 },
-                "supplierId": {
+                "qty": {
                     "type": "integer"
                 },
-                "total": {
-                    "type": "integer"
+                "uom": {
+                    "description": "the base unit when empty",
+                    "type": "string"
                 }
             }
         },
-        "internal_domain_sale.SaleInvoiceRequestDTO": {
+        "internal_domain_stocktransfer.TransferRequestDTO": {
             "type": "object",
             "properties": {
-                "customerId": {
-                    "type": "integer"
-                },
-                "discount": {
+                "fromLocationId": {
                     "type": "integer"
                 },
-                "grandTotal": {
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "remark": {
-                    "type": "string"
-                },
-                "saleDate": {
-                    "type": "string"
-                },
-                "saleDetails": {
+                "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_sankangkin_di-rest-api_internal_models.SaleDetail"
+                        "$ref": "#/definitions/internal_domain_stocktransfer.TransferItemRequestDTO"
                     }
                 },
-                "total": {
+                "remark": {
+                    "type": "string"
+                },
+                "toLocationId": {
                     "type": "integer"
+                },
+                "transferDate": {
+                    "type": "string"
                 }
             }
         },
@@ -4336,6 +10068,29 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_domain_supplier.SupplierBalanceDTO": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "paid": {
+                    "type": "integer"
+                },
+                "purchased": {
+                    "type": "integer"
+                },
+                "returned": {
+                    "type": "integer"
+                },
+                "supplierId": {
+                    "type": "integer"
+                }
+            }
+        },
         "internal_domain_supplier.UpdateSupplierRequstDTO": {
             "type": "object",
             "properties": {
@@ -4392,6 +10147,83 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "internal_domain_valuation.CategoryValuationDTO": {
+            "type": "object",
+            "properties": {
+                "categoryId": {
+                    "type": "integer"
+                },
+                "categoryName": {
+                    "type": "string"
+                },
+                "products": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_domain_valuation.ProductValuationDTO": {
+            "type": "object",
+            "properties": {
+                "baseQty": {
+                    "type": "integer"
+                },
+                "baseUnit": {
+                    "type": "string"
+                },
+                "categoryId": {
+                    "type": "integer"
+                },
+                "categoryName": {
+                    "type": "string"
+                },
+                "derivedQty": {
+                    "type": "integer"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "productName": {
+                    "type": "string"
+                },
+                "unitCost": {
+                    "description": "of one base unit",
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_domain_valuation.ValuationDTO": {
+            "type": "object",
+            "properties": {
+                "asOf": {
+                    "type": "string"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_domain_valuation.CategoryValuationDTO"
+                    }
+                },
+                "locationId": {
+                    "description": "zero is every location",
+                    "type": "integer"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_domain_valuation.ProductValuationDTO"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -1,12 +1,18 @@
 package main
 
 import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
 	_ "github.com/sankangkin/di-rest-api/cmd/docs"
 
-	fiber "github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/sankangkin/di-rest-api/internal/router"
-	fiberSwagger "github.com/swaggo/fiber-swagger"
+	"github.com/sankangkin/di-rest-api/internal/app"
+	"github.com/sankangkin/di-rest-api/internal/config"
+	"github.com/sankangkin/di-rest-api/internal/database"
+	"github.com/sankangkin/di-rest-api/internal/database/migrate"
 )
 
 // @title					REST-API with(golang fiber, google wire dependency injection)
@@ -28,13 +34,37 @@ func main() {
 
 	// log.SetFlags(log.LstdFlags | log.Lshortfile)
 
-	app := fiber.New()
-	app.Use(cors.New())
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	api, err := app.InitApp()
+	if err != nil {
+		log.Fatalf("Failed to initialize app: %v", err)
+	}
 
-	// app.Get("/swagger/*", swagger.HandlerDefault) // default
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	if err := api.Run(ctx); err != nil {
+		log.Fatal(err)
+	}
+	log.Println("api stopped")
 
-	app.Get("/swagger/*", fiberSwagger.WrapHandler)
-	router.Initialize(app)
-	app.Listen(":5555")
+}
 
+// runMigrate handles `migrate [flags] up [n] | down [n] | status`, the flags
+// being the same as the api's.
+func runMigrate(args []string) error {
+	cfg, args, err := config.LoadCommand(args)
+	if err != nil {
+		return err
+	}
+	db, err := database.Open(cfg)
+	if err != nil {
+		return err
+	}
+	return migrate.Command(db, args, os.Stdout)
 }
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/auth"
+	"github.com/sankangkin/di-rest-api/internal/config"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/router"
+	fiberSwagger "github.com/swaggo/fiber-swagger"
+	"gorm.io/gorm"
+)
+
+// App is the whole api: the fiber app with every route, and what it was
+// built from.
+type App struct {
+	Fiber  *fiber.App
+	Config *config.Config
+	DB     *gorm.DB
+
+	// draining is set once shutdown starts, so /readyz takes the instance
+	// out of the load balancer while in-flight requests finish.
+	draining atomic.Bool
+}
+
+func NewApp(cfg *config.Config, db *gorm.DB, sessions auth.AuthServiceInterface, handlers *router.Handlers) *App {
+	log.Println(util.Magenta + "App constructor is called" + util.Reset)
+
+	// handlers and middleware return errors, apperr.Handler answers them
+	app := fiber.New(fiber.Config{ErrorHandler: apperr.Handler})
+	a := &App{Fiber: app, Config: cfg, DB: db}
+	app.Use(cors.New(cors.Config{
+		AllowOrigins: strings.Join(cfg.CORS.AllowOrigins, ","),
+	}))
+
+	// probes for the orchestrator, outside /api and without auth
+	app.Get("/healthz", a.Healthz)
+	app.Get("/readyz", a.Readyz)
+
+	// app.Get("/swagger/*", swagger.HandlerDefault) // default
+
+	app.Get("/swagger/*", fiberSwagger.WrapHandler)
+	router.Initialize(app, cfg, sessions, handlers)
+
+	return a
+}
+
+// Listen serves the api on the configured port.
+func (a *App) Listen() error {
+	return a.Fiber.Listen(fmt.Sprintf(":%d", a.Config.HTTP.Port))
+}
+
+// Run serves the api until ctx is done, then stops taking connections, gives
+// in-flight requests up to HTTP_SHUTDOWN_TIMEOUT to finish and closes the
+// database pool.
+func (a *App) Run(ctx context.Context) error {
+	served := make(chan error, 1)
+	go func() { served <- a.Listen() }()
+
+	select {
+	case err := <-served:
+		return errors.Join(err, a.Close())
+	case <-ctx.Done():
+	}
+
+	log.Printf("shutting down, waiting up to %s for in-flight requests", a.Config.HTTP.ShutdownTimeout)
+	a.draining.Store(true)
+	err := a.Fiber.ShutdownWithTimeout(a.Config.HTTP.ShutdownTimeout)
+	if err != nil {
+		err = fmt.Errorf("shutdown: %w", err)
+	}
+	return errors.Join(err, <-served, a.Close())
+}
+
+// Close closes the database pool.
+func (a *App) Close() error {
+	if a.DB == nil {
+		return nil
+	}
+	sqlDB, err := a.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
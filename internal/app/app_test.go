@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func freePort(t *testing.T) int {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+func testApp(t *testing.T) *App {
+	cfg := &config.Config{HTTP: config.HTTPConfig{Port: freePort(t), ShutdownTimeout: 5 * time.Second}}
+	a := &App{Fiber: fiber.New(fiber.Config{DisableStartupMessage: true}), Config: cfg}
+	a.Fiber.Get("/healthz", a.Healthz)
+	a.Fiber.Get("/readyz", a.Readyz)
+	return a
+}
+
+func TestHealthz(t *testing.T) {
+	a := testApp(t)
+
+	resp, err := a.Fiber.Test(httptest.NewRequest(fiber.MethodGet, "/healthz", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestReadyzWithoutDatabase(t *testing.T) {
+	a := testApp(t)
+
+	resp, err := a.Fiber.Test(httptest.NewRequest(fiber.MethodGet, "/readyz", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusServiceUnavailable, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), `"database":"no database"`)
+}
+
+func TestRunDrainsInFlightRequests(t *testing.T) {
+	a := testApp(t)
+	started := make(chan struct{})
+	a.Fiber.Get("/slow", func(c *fiber.Ctx) error {
+		close(started)
+		time.Sleep(300 * time.Millisecond)
+		return c.SendString("done")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error, 1)
+	go func() { stopped <- a.Run(ctx) }()
+
+	url := fmt.Sprintf("http://127.0.0.1:%d", a.Config.HTTP.Port)
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(url + "/healthz")
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return true
+	}, 2*time.Second, 20*time.Millisecond)
+
+	type result struct {
+		body string
+		err  error
+	}
+	slow := make(chan result, 1)
+	go func() {
+		resp, err := http.Get(url + "/slow")
+		if err != nil {
+			slow <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		slow <- result{body: string(body), err: err}
+	}()
+
+	<-started
+	cancel()
+
+	r := <-slow
+	require.NoError(t, r.err)
+	assert.Equal(t, "done", r.body)
+	assert.NoError(t, <-stopped)
+	assert.True(t, a.draining.Load())
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/database/migrate"
+)
+
+// readyTimeout bounds the database checks of /readyz, so a hanging database
+// fails the probe instead of stalling it.
+const readyTimeout = 2 * time.Second
+
+// Healthz godoc
+//
+//	@Summary		Liveness probe
+//	@Description	Answers 200 as long as the process serves requests
+//	@Tags			Health
+//	@Produce		json
+//	@Success		200	{object}	map[string]string
+//	@Router			/healthz	[get]
+func (a *App) Healthz(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "UP"})
+}
+
+// Readyz godoc
+//
+//	@Summary		Readiness probe
+//	@Description	Answers 200 when the database is reachable and its schema is at the version of the embedded migrations, 503 otherwise or while shutting down
+//	@Tags			Health
+//	@Produce		json
+//	@Success		200	{object}	map[string]interface{}
+//	@Failure		503	{object}	map[string]interface{}
+//	@Router			/readyz	[get]
+func (a *App) Readyz(c *fiber.Ctx) error {
+	checks := fiber.Map{}
+	ready := true
+
+	if a.draining.Load() {
+		checks["shutdown"] = "in progress"
+		ready = false
+	}
+	if err := a.pingDB(c.UserContext()); err != nil {
+		checks["database"] = err.Error()
+		ready = false
+	} else {
+		checks["database"] = "UP"
+		if err := a.checkSchema(); err != nil {
+			checks["migrations"] = err.Error()
+			ready = false
+		} else {
+			checks["migrations"] = "UP"
+		}
+	}
+
+	if !ready {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"status": "DOWN", "checks": checks,
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "UP", "checks": checks,
+	})
+}
+
+func (a *App) pingDB(ctx context.Context) error {
+	if a.DB == nil {
+		return fmt.Errorf("no database")
+	}
+	sqlDB, err := a.DB.DB()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, readyTimeout)
+	defer cancel()
+	return sqlDB.PingContext(ctx)
+}
+
+// checkSchema fails when migrations are pending, e.g. a new release started
+// before `migrate up` ran.
+func (a *App) checkSchema() error {
+	ctx, cancel := context.WithTimeout(context.Background(), readyTimeout)
+	defer cancel()
+
+	m, err := migrate.New(a.DB.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	pending, err := m.Pending()
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%d migration(s) pending, next is %04d_%s", len(pending), pending[0].Version, pending[0].Name)
+	}
+	return nil
+}
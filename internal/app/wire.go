@@ -0,0 +1,233 @@
+//go:build wireinject
+// +build wireinject
+
+package app
+
+import (
+	"github.com/google/wire"
+	"github.com/sankangkin/di-rest-api/internal/auth"
+	"github.com/sankangkin/di-rest-api/internal/config"
+	"github.com/sankangkin/di-rest-api/internal/database"
+	"github.com/sankangkin/di-rest-api/internal/domain/barcode"
+	"github.com/sankangkin/di-rest-api/internal/domain/category"
+	"github.com/sankangkin/di-rest-api/internal/domain/customer"
+	"github.com/sankangkin/di-rest-api/internal/domain/docnumber"
+	"github.com/sankangkin/di-rest-api/internal/domain/goodsreceipt"
+	"github.com/sankangkin/di-rest-api/internal/domain/inventory"
+	"github.com/sankangkin/di-rest-api/internal/domain/itemtransactions"
+	"github.com/sankangkin/di-rest-api/internal/domain/location"
+	"github.com/sankangkin/di-rest-api/internal/domain/payable"
+	"github.com/sankangkin/di-rest-api/internal/domain/pricing"
+	"github.com/sankangkin/di-rest-api/internal/domain/product"
+	"github.com/sankangkin/di-rest-api/internal/domain/productprice"
+	"github.com/sankangkin/di-rest-api/internal/domain/productstock"
+	"github.com/sankangkin/di-rest-api/internal/domain/profit"
+	"github.com/sankangkin/di-rest-api/internal/domain/purchase"
+	"github.com/sankangkin/di-rest-api/internal/domain/purchaseorder"
+	"github.com/sankangkin/di-rest-api/internal/domain/receivable"
+	"github.com/sankangkin/di-rest-api/internal/domain/reorder"
+	"github.com/sankangkin/di-rest-api/internal/domain/sale"
+	"github.com/sankangkin/di-rest-api/internal/domain/stockmovement"
+	"github.com/sankangkin/di-rest-api/internal/domain/stocktake"
+	"github.com/sankangkin/di-rest-api/internal/domain/stocktransfer"
+	"github.com/sankangkin/di-rest-api/internal/domain/supplier"
+	"github.com/sankangkin/di-rest-api/internal/domain/unitconversion"
+	"github.com/sankangkin/di-rest-api/internal/domain/unitofmeasurement"
+	"github.com/sankangkin/di-rest-api/internal/domain/valuation"
+	"github.com/sankangkin/di-rest-api/internal/router"
+)
+
+// InfraWireSet is what every domain builds on.
+var InfraWireSet = wire.NewSet(
+	config.NewConfig,
+	database.NewDB,
+)
+
+// SharedWireSet holds the services used by more than one domain.
+var SharedWireSet = wire.NewSet(
+	stockmovement.NewStockMovementService,
+	docnumber.NewDocNumberService,
+	pricing.NewPricingRepository,
+	pricing.NewPricingService,
+)
+
+var AuthWireSet = wire.NewSet(
+	auth.NewAuthRepository,
+	auth.NewAuthService,
+	auth.NewAuthHandler,
+)
+
+var CategoryWireSet = wire.NewSet(
+	category.NewCategoryRepository,
+	category.NewCategoryService,
+	category.NewCategoryHandler,
+)
+
+var ProductWireSet = wire.NewSet(
+	product.NewProductRepository,
+	product.NewProductService,
+	product.NewProductHandler,
+)
+
+var BarcodeWireSet = wire.NewSet(
+	barcode.NewBarcodeRepository,
+	barcode.NewBarcodeService,
+	barcode.NewBarcodeHandler,
+)
+
+var UnitConversionWireSet = wire.NewSet(
+	unitconversion.NewUnitConversionRepository,
+	unitconversion.NewUnitConversionService,
+	unitconversion.NewUnitConversionHandler,
+)
+
+var UnitOfMeasurementWireSet = wire.NewSet(
+	unitofmeasurement.NewUnitOfMeasurementRepository,
+	unitofmeasurement.NewUnitOfMeasurementService,
+	unitofmeasurement.NewUnitOfMeasurementHandler,
+)
+
+var LocationWireSet = wire.NewSet(
+	location.NewLocationRepository,
+	location.NewLocationService,
+	location.NewLocationHandler,
+)
+
+var ProductStockWireSet = wire.NewSet(
+	productstock.NewProductStockRepository,
+	productstock.NewProductStockHandler,
+)
+
+var TransferWireSet = wire.NewSet(
+	stocktransfer.NewStockTransferRepository,
+	stocktransfer.NewStockTransferService,
+	stocktransfer.NewStockTransferHandler,
+)
+
+var StockTakeWireSet = wire.NewSet(
+	stocktake.NewStockTakeRepository,
+	stocktake.NewStockTakeService,
+	stocktake.NewStockTakeHandler,
+)
+
+var ValuationWireSet = wire.NewSet(
+	valuation.NewValuationRepository,
+	valuation.NewValuationService,
+	valuation.NewValuationHandler,
+)
+
+var ProductPriceWireSet = wire.NewSet(
+	productprice.NewProductPriceRepository,
+	productprice.NewProductPriceService,
+	productprice.NewProductPriceHandler,
+)
+
+var TransactionWireSet = wire.NewSet(
+	itemtransactions.NewTransactionRepository,
+	itemtransactions.NewTransactionService,
+	itemtransactions.NewTransactionHandler,
+)
+
+var CustomerWireSet = wire.NewSet(
+	customer.NewCustomerRepository,
+	customer.NewCustomerService,
+	customer.NewCustomerHandler,
+)
+
+var ReceivableWireSet = wire.NewSet(
+	receivable.NewReceivableRepository,
+	receivable.NewReceivableService,
+	receivable.NewReceivableHandler,
+)
+
+var SupplierWireSet = wire.NewSet(
+	supplier.NewSupplierRepository,
+	supplier.NewSupplierService,
+	supplier.NewSupplierHandler,
+)
+
+var PayableWireSet = wire.NewSet(
+	payable.NewPayableRepository,
+	payable.NewPayableService,
+	payable.NewPayableHandler,
+)
+
+var InventoryWireSet = wire.NewSet(
+	inventory.NewInventoryRepository,
+	inventory.NewInventoryService,
+	inventory.NewInventoryHandler,
+)
+
+var SaleWireSet = wire.NewSet(
+	sale.NewSaleRepository,
+	sale.NewSaleService,
+	sale.NewSaleHandler,
+)
+
+var ProfitWireSet = wire.NewSet(
+	profit.NewProfitRepository,
+	profit.NewProfitService,
+	profit.NewProfitHandler,
+)
+
+var PurchaseWireSet = wire.NewSet(
+	purchase.NewSaleRepository,
+	purchase.NewSaleService,
+	purchase.NewSaleHandler,
+)
+
+var PurchaseOrderWireSet = wire.NewSet(
+	purchaseorder.NewPurchaseOrderRepository,
+	purchaseorder.NewPurchaseOrderService,
+	purchaseorder.NewPurchaseOrderHandler,
+)
+
+var GoodsReceiptWireSet = wire.NewSet(
+	goodsreceipt.NewGoodsReceiptRepository,
+	goodsreceipt.NewGoodsReceiptService,
+	goodsreceipt.NewGoodsReceiptHandler,
+)
+
+var ReorderWireSet = wire.NewSet(
+	reorder.NewReorderRepository,
+	reorder.NewReorderService,
+	reorder.NewReorderHandler,
+)
+
+var AppWireSet = wire.NewSet(
+	InfraWireSet,
+	SharedWireSet,
+	AuthWireSet,
+	CategoryWireSet,
+	ProductWireSet,
+	BarcodeWireSet,
+	UnitConversionWireSet,
+	UnitOfMeasurementWireSet,
+	LocationWireSet,
+	ProductStockWireSet,
+	TransferWireSet,
+	StockTakeWireSet,
+	ValuationWireSet,
+	ProductPriceWireSet,
+	TransactionWireSet,
+	CustomerWireSet,
+	ReceivableWireSet,
+	SupplierWireSet,
+	PayableWireSet,
+	InventoryWireSet,
+	SaleWireSet,
+	ProfitWireSet,
+	PurchaseWireSet,
+	PurchaseOrderWireSet,
+	GoodsReceiptWireSet,
+	ReorderWireSet,
+	wire.Struct(new(router.Handlers), "*"),
+	NewApp,
+)
+
+// InitApp builds the whole api in one graph: config, database,
+// repositories, services, handlers and the fiber app.
+func InitApp() (*App, error) {
+	wire.Build(AppWireSet)
+	return &App{}, nil
+}
@@ -0,0 +1,121 @@
+// Package apperr holds the errors the domain packages return to say what went
+// wrong, and the fiber ErrorHandler that turns them into HTTP answers.
+package apperr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Code names the kind of an Error; it is sent to clients as is.
+type Code string
+
+const (
+	CodeNotFound          Code = "NOT_FOUND"
+	CodeConflict          Code = "CONFLICT"
+	CodeValidation        Code = "VALIDATION_FAILED"
+	CodeInsufficientStock Code = "INSUFFICIENT_STOCK"
+	CodeUnauthorized      Code = "UNAUTHORIZED"
+	CodeForbidden         Code = "FORBIDDEN"
+	CodeInternal          Code = "INTERNAL"
+)
+
+// Error is an error a client can act on. Message is shown to the client,
+// Err (the cause, if any) only goes to the log.
+type Error struct {
+	Code    Code
+	Message string
+	Fields  []FieldError
+	Err     error
+
+	status int // overrides the status of Code
+}
+
+// FieldError is one failed rule of a validated request body.
+type FieldError struct {
+	Field   string `json:"field" example:"Sale.CustomerId"`
+	Tag     string `json:"tag" example:"required"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message" example:"CustomerId is a required field"`
+}
+
+// Sentinels to test the kind of an error with errors.Is, e.g.
+// errors.Is(err, apperr.ErrNotFound).
+var (
+	ErrNotFound          = &Error{Code: CodeNotFound}
+	ErrConflict          = &Error{Code: CodeConflict}
+	ErrValidation        = &Error{Code: CodeValidation}
+	ErrInsufficientStock = &Error{Code: CodeInsufficientStock}
+	ErrUnauthorized      = &Error{Code: CodeUnauthorized}
+	ErrForbidden         = &Error{Code: CodeForbidden}
+)
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// Is matches the sentinels above: any Error of the same code.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Message == "" && t.Code == e.Code
+}
+
+// Wrap keeps err as the cause of e.
+func (e *Error) Wrap(err error) *Error {
+	e.Err = err
+	return e
+}
+
+func newError(code Code, format string, args []interface{}) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// NotFound is a record that does not exist.
+func NotFound(format string, args ...interface{}) *Error {
+	return newError(CodeNotFound, format, args)
+}
+
+// Conflict is a request that clashes with the current state of a record,
+// e.g. voiding a sale twice.
+func Conflict(format string, args ...interface{}) *Error {
+	return newError(CodeConflict, format, args)
+}
+
+// Validation is a request that breaks a business rule or is malformed.
+func Validation(format string, args ...interface{}) *Error {
+	return newError(CodeValidation, format, args)
+}
+
+// InvalidFields is a request body that failed struct validation.
+func InvalidFields(fields []FieldError) *Error {
+	return &Error{Code: CodeValidation, Message: "request validation failed", Fields: fields}
+}
+
+// InsufficientStock is a movement that would take more than is on hand.
+func InsufficientStock(format string, args ...interface{}) *Error {
+	return newError(CodeInsufficientStock, format, args)
+}
+
+// Unauthorized is a caller that could not be authenticated.
+func Unauthorized(format string, args ...interface{}) *Error {
+	return newError(CodeUnauthorized, format, args)
+}
+
+// Forbidden is an authenticated caller without the role for the route.
+func Forbidden(format string, args ...interface{}) *Error {
+	return newError(CodeForbidden, format, args)
+}
+
+// As returns err as an *Error when it is one, or wraps one.
+func As(err error) (*Error, bool) {
+	var e *Error
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
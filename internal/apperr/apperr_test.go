@@ -0,0 +1,87 @@
+package apperr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestFrom(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    Code
+		wantStatus  int
+		wantMessage string
+	}{
+		{"not found", NotFound("sale %s not found", "S1"), CodeNotFound, 404, "sale S1 not found"},
+		{"conflict", Conflict("sale is already voided"), CodeConflict, 409, "sale is already voided"},
+		{"validation", Validation("invalid JSON format"), CodeValidation, 400, "invalid JSON format"},
+		{"insufficient stock", InsufficientStock("not enough stock"), CodeInsufficientStock, 409, "not enough stock"},
+		{"unauthorized", Unauthorized("invalid credentials"), CodeUnauthorized, 401, "invalid credentials"},
+		{"forbidden", Forbidden("admins only"), CodeForbidden, 403, "admins only"},
+		{"wrapped with detail", fmt.Errorf("%w: tax 5 does not match 7", Validation("invalid invoice")), CodeValidation, 400, "invalid invoice: tax 5 does not match 7"},
+		{"gorm not found", fmt.Errorf("get sale: %w", gorm.ErrRecordNotFound), CodeNotFound, 404, "record not found"},
+		{"gorm duplicate", gorm.ErrDuplicatedKey, CodeConflict, 409, "record already exists"},
+		{"fiber not found", fiber.ErrNotFound, CodeNotFound, 404, "Not Found"},
+		{"fiber method not allowed", fiber.ErrMethodNotAllowed, CodeValidation, 405, "Method Not Allowed"},
+		{"anything else", errors.New("pq: connection refused"), CodeInternal, 500, "Internal Server Error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := From(tt.err)
+			assert.Equal(t, tt.wantCode, e.Code)
+			assert.Equal(t, tt.wantMessage, e.Message)
+			assert.Equal(t, tt.wantStatus, Status(tt.err))
+		})
+	}
+}
+
+func TestIs(t *testing.T) {
+	err := fmt.Errorf("create sale: %w", InsufficientStock("not enough stock of %s", "P1"))
+	assert.ErrorIs(t, err, ErrInsufficientStock)
+	assert.NotErrorIs(t, err, ErrNotFound)
+
+	cause := errors.New("strconv.ParseUint: invalid syntax")
+	assert.ErrorIs(t, Validation("invalid ID parameter").Wrap(cause), cause)
+}
+
+func TestHandler(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: Handler})
+	app.Post("/sales", func(c *fiber.Ctx) error {
+		return InvalidFields([]FieldError{{Field: "Sale.CustomerId", Tag: "required", Message: "CustomerId is a required field"}})
+	})
+	app.Get("/boom", func(c *fiber.Ctx) error {
+		return errors.New("dial tcp 10.0.0.5:5432: connection refused")
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/sales", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	var body Response
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, Response{
+		Status:  "FAIL",
+		Code:    CodeValidation,
+		Message: "request validation failed",
+		Errors:  []FieldError{{Field: "Sale.CustomerId", Tag: "required", Message: "CustomerId is a required field"}},
+	}, body)
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/boom", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+	body = Response{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, Response{Status: "FAIL", Code: CodeInternal, Message: "Internal Server Error"}, body)
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/nowhere", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,105 @@
+package apperr
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// Response is the body of every error answer of the api.
+type Response struct {
+	Status  string       `json:"status" example:"FAIL"`
+	Code    Code         `json:"code" example:"NOT_FOUND"`
+	Message string       `json:"message" example:"record not found"`
+	Errors  []FieldError `json:"errors,omitempty"`
+}
+
+var statusOf = map[Code]int{
+	CodeNotFound:          fiber.StatusNotFound,
+	CodeConflict:          fiber.StatusConflict,
+	CodeValidation:        fiber.StatusBadRequest,
+	CodeInsufficientStock: fiber.StatusConflict,
+	CodeUnauthorized:      fiber.StatusUnauthorized,
+	CodeForbidden:         fiber.StatusForbidden,
+	CodeInternal:          fiber.StatusInternalServerError,
+}
+
+// Status is the HTTP status an error is answered with.
+func Status(err error) int {
+	return From(err).httpStatus()
+}
+
+func (e *Error) httpStatus() int {
+	if e.status != 0 {
+		return e.status
+	}
+	return statusOf[e.Code]
+}
+
+// Handler is the fiber ErrorHandler of the api: handlers and middleware
+// return errors, Handler answers them with a Response.
+func Handler(c *fiber.Ctx, err error) error {
+	e := From(err)
+	if e.Code == CodeInternal {
+		log.Printf("%s %s: %v", c.Method(), c.Path(), err)
+	}
+
+	return c.Status(e.httpStatus()).JSON(Response{
+		Status:  "FAIL",
+		Code:    e.Code,
+		Message: e.Message,
+		Errors:  e.Fields,
+	})
+}
+
+// From maps any error to an Error: errors of this package as they are,
+// gorm's and fiber's to the matching code, everything else to an internal
+// error whose message hides the cause. An Error wrapped with
+// fmt.Errorf("%w: ...") keeps its code and takes the wrapping message.
+func From(err error) *Error {
+	if e, ok := As(err); ok {
+		if e == err {
+			return e
+		}
+		wrapped := *e
+		wrapped.Message = err.Error()
+		wrapped.Err = nil
+		return &wrapped
+	}
+
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return NotFound("record not found").Wrap(err)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return Conflict("record already exists").Wrap(err)
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		return Conflict("record is referenced by or refers to another record").Wrap(err)
+	case errors.Is(err, gorm.ErrCheckConstraintViolated):
+		return Validation("record violates a constraint").Wrap(err)
+	}
+
+	var fe *fiber.Error
+	if errors.As(err, &fe) {
+		switch fe.Code {
+		case fiber.StatusNotFound:
+			return NotFound(fe.Message)
+		case fiber.StatusUnauthorized:
+			return Unauthorized(fe.Message)
+		case fiber.StatusForbidden:
+			return Forbidden(fe.Message)
+		case fiber.StatusConflict:
+			return Conflict(fe.Message)
+		}
+		if fe.Code < fiber.StatusInternalServerError {
+			// e.g. 405 or 413, keep fiber's status
+			e := Validation(fe.Message)
+			e.status = fe.Code
+			return e
+		}
+	}
+
+	return &Error{Code: CodeInternal, Message: http.StatusText(http.StatusInternalServerError), Err: err}
+}
@@ -1,26 +1,25 @@
 package auth
 
 import (
+	"errors"
 	"log"
 	"net/http"
-	"sync"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/config"
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
 	mylog "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
 type AuthHandler struct {
-	svc AuthServiceInterface
+	svc    AuthServiceInterface
+	cookie config.CookieConfig
 }
 
-var (
-	hdlInstance *AuthHandler
-	hdlOnce     sync.Once
-)
-
 func init() {
 	mylog.SetReportCaller(true)
 	Formatter := new(mylog.JSONFormatter)
@@ -28,16 +27,14 @@ func init() {
 	mylog.SetFormatter(Formatter)
 }
 
-func NewAuthHandler(svc AuthServiceInterface) *AuthHandler {
+func NewAuthHandler(svc AuthServiceInterface, cfg *config.Config) *AuthHandler {
 
 	mylog.Info("AuthHandler constructor is called")
 	// log.Println(util.Red + "AuthHandler constructor is called" + util.Reset)
-	hdlOnce.Do(func() {
-		hdlInstance = &AuthHandler{
-			svc: svc,
-		}
-	})
-	return hdlInstance
+	return &AuthHandler{
+		svc:    svc,
+		cookie: cfg.Cookie,
+	}
 }
 
 // Register	godoc
@@ -48,32 +45,33 @@ func NewAuthHandler(svc AuthServiceInterface) *AuthHandler {
 // @Accept			json
 // @Param			info	body		SignUpDTO	true	"Signup Data"
 // @Success		200		{object}	SignUpResponseDTO
-// @Failure		400		{object}	httputil.HttpError400
-// @Failure		401		{object}	httputil.HttpError401
-// @Failure		500		{object}	httputil.HttpError500
-// @Failure		401		{object}	httputil.HttpError401
+// @Failure		400		{object}	apperr.Response
+// @Failure		401		{object}	apperr.Response
+// @Failure		500		{object}	apperr.Response
+// @Failure		401		{object}	apperr.Response
+// @Failure		403		{object}	apperr.Response
 // @Router			/api/auth/register [post]
+// @Security		Bearer
 func (h *AuthHandler) SignUp(c *fiber.Ctx) error {
 	var user models.User
 	if err := c.BodyParser(&user); err != nil {
-		return c.Status(http.StatusBadRequest).SendString("Invalid request body")
+		return apperr.Validation("invalid JSON format")
 	}
 	email := user.Email
-	if _, err := h.svc.FindUserByEmail(email); err != nil {
-
-		createdUser, err := h.svc.Signup(&user)
-		if err != nil {
-			return c.Status(http.StatusInternalServerError).JSON(err)
-		}
+	if _, err := h.svc.FindUserByEmail(email); err == nil {
+		return apperr.Conflict("email %s is already registered", email)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
 
-		return c.Status(http.StatusCreated).JSON(fiber.Map{
-			"status": "SUCCESS",
-			"data":   createdUser,
-		})
+	createdUser, err := h.svc.Signup(&user)
+	if err != nil {
+		return err
 	}
-	return c.Status(http.StatusInternalServerError).JSON(fiber.Map{
-		"status":  "FAIL",
-		"message": "user name has been already taken",
+
+	return c.Status(http.StatusCreated).JSON(fiber.Map{
+		"status": "SUCCESS",
+		"data":   createdUser,
 	})
 }
 
@@ -84,44 +82,40 @@ func (h *AuthHandler) SignUp(c *fiber.Ctx) error {
 //	@Accept		json
 //	@Param		info	body		SignInRequestDTO	true	"Login Data"
 //	@Success	200		{object}	SignInResponseDTO
-//	@Failure	400		{object}	httputil.HttpError400
-//	@Failure	401		{object}	httputil.HttpError401
-//	@Failure	500		{object}	httputil.HttpError500
-//	@Failure	401		{object}	httputil.HttpError401
+//	@Failure	400		{object}	apperr.Response
+//	@Failure	401		{object}	apperr.Response
+//	@Failure	500		{object}	apperr.Response
+//	@Failure	401		{object}	apperr.Response
 //	@Router		/api/auth/login [post]
 func (h *AuthHandler) SignIn(c *fiber.Ctx) error {
 	input := new(SignInRequestDTO)
 
 	if err := c.BodyParser(input); err != nil {
-		return c.JSON(fiber.Map{
-			"code":    400,
-			"message": "Invalid JSON",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
-	errors := models.ValidateStruct(input)
-	if errors != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "fail validation", "errors": errors})
+	if err := models.ValidateStruct(input); err != nil {
+		return err
 	}
 	at, rt, userName, role, err := h.svc.Signin(input.Email, input.Password)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "invalid credentials", "errors": err.Error()})
+		return err
 	}
 
 	c.Cookie(&fiber.Cookie{
 		Name:     "accessToken",
 		Value:    at,
 		Path:     "/",
-		Secure:   false,
+		Secure:   h.cookie.Secure,
 		HTTPOnly: true,
-		Domain:   "localhost",
+		Domain:   h.cookie.Domain,
 	})
 	c.Cookie(&fiber.Cookie{
 		Name:     "refreshToken",
 		Value:    rt,
 		Path:     "/",
-		Secure:   false,
+		Secure:   h.cookie.Secure,
 		HTTPOnly: true,
-		Domain:   "localhost",
+		Domain:   h.cookie.Domain,
 	})
 	return c.Status(http.StatusOK).JSON(fiber.Map{
 		"status":  "SUCCESS",
@@ -138,46 +132,46 @@ func (h *AuthHandler) SignIn(c *fiber.Ctx) error {
 // / RefreshToken godoc
 //
 //	@Summary		Refresh access token
-//	@Description	Get new access token using refresh token
+//	@Description	Get new access token using the refresh token (body or refreshToken cookie)
 //	@Tags			Auth
 //	@Accept			json
 //	@Produce		json
-//	@Param			body	body	RefreshRequestDTO	true	"Refresh token request"
+//	@Param			body	body	RefreshRequestDTO	false	"Refresh token request"
 //	@Success		200		{object}	RefreshResponseDTO
-//	@Failure		400		{object}	httputil.HttpError400
+//	@Failure		400		{object}	apperr.Response
+//	@Failure		401		{object}	apperr.Response
 //	@Router			/api/auth/refresh [post]
 func (h *AuthHandler) Refresh(c *fiber.Ctx) error {
 	var body RefreshResponseDTO
 
-	if err := c.BodyParser(&body); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status": "fail",
-			"error":  "Invalid body",
-		})
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&body); err != nil {
+			return apperr.Validation("invalid JSON format")
+		}
+	}
+	if body.RefreshToken == "" {
+		body.RefreshToken = c.Cookies("refreshToken")
 	}
 	at, rt, userName, email, role, err := h.svc.Refresh(body.RefreshToken)
 	if err != nil {
 		log.Println(util.Red + err.Error() + util.Reset)
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status": "fail",
-			"error":  err.Error(),
-		})
+		return err
 	}
 	c.Cookie(&fiber.Cookie{
 		Name:     "accessToken",
 		Value:    "",
 		Path:     "/",
-		Secure:   false,
+		Secure:   h.cookie.Secure,
 		HTTPOnly: true,
-		Domain:   "localhost",
+		Domain:   h.cookie.Domain,
 	})
 	c.Cookie(&fiber.Cookie{
 		Name:     "refreshToken",
 		Value:    rt,
 		Path:     "/",
-		Secure:   false,
+		Secure:   h.cookie.Secure,
 		HTTPOnly: true,
-		Domain:   "localhost",
+		Domain:   h.cookie.Domain,
 	})
 	return c.Status(http.StatusOK).JSON(fiber.Map{
 		"status":  "SUCCESS",
@@ -194,29 +188,50 @@ func (h *AuthHandler) Refresh(c *fiber.Ctx) error {
 // Logout	godoc
 //
 //	@Summary		Logout user
-//	@Description	Logout user
+//	@Description	Logout user, revoking the session of the refresh token (body or refreshToken cookie)
 //
 //	@Tags			Auth
+//	@Param			body	body	RefreshRequestDTO	false	"Refresh token of the session"
 //	@Success		200
-//	@Failure		400	{object}	httputil.HttpError400
-//	@Failure		401	{object}	httputil.HttpError401
-//	@Failure		500	{object}	httputil.HttpError500
-//	@Failure		401	{object}	httputil.HttpError401
+//	@Failure		400	{object}	apperr.Response
+//	@Failure		401	{object}	apperr.Response
+//	@Failure		500	{object}	apperr.Response
+//	@Failure		401	{object}	apperr.Response
 //	@Router			/api/auth/logout [post]
 func (h *AuthHandler) Logout(c *fiber.Ctx) error {
+	var body RefreshRequestDTO
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&body); err != nil {
+			return apperr.Validation("invalid JSON format")
+		}
+	}
+	if body.RefreshToken == "" {
+		body.RefreshToken = c.Cookies("refreshToken")
+	}
+	if body.RefreshToken == "" {
+		return apperr.Validation("refresh token is required")
+	}
+	if err := h.svc.Signout(body.RefreshToken); err != nil {
+		return err
+	}
+
 	expired := time.Now().Add(-time.Hour * 24)
 	c.Cookie(&fiber.Cookie{
 		Name:     "refreshToken",
 		Value:    "",
+		Path:     "/",
 		HTTPOnly: true,
-		Secure:   true,
+		Secure:   h.cookie.Secure,
+		Domain:   h.cookie.Domain,
 		Expires:  expired,
 	})
 	c.Cookie(&fiber.Cookie{
 		Name:     "accessToken",
 		Value:    "",
+		Path:     "/",
 		HTTPOnly: true,
-		Secure:   true,
+		Secure:   h.cookie.Secure,
+		Domain:   h.cookie.Domain,
 		Expires:  expired,
 	})
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success"})
@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshHandler(t *testing.T) {
+	setup := func() (*fiber.App, string) {
+		svc, _, rt := signedIn(t)
+		app := fiber.New(fiber.Config{ErrorHandler: apperr.Handler})
+		handler := &AuthHandler{svc: svc}
+		app.Post("/refresh", handler.Refresh)
+		return app, rt
+	}
+
+	t.Run("CookieOnly", func(t *testing.T) {
+		app, rt := setup()
+		req := httptest.NewRequest(http.MethodPost, "/refresh", nil)
+		req.AddCookie(&http.Cookie{Name: "refreshToken", Value: rt})
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Data RefreshResponseDTO `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.NotEmpty(t, body.Data.RefreshToken)
+		assert.NotEqual(t, rt, body.Data.RefreshToken)
+	})
+
+	t.Run("Body", func(t *testing.T) {
+		app, rt := setup()
+		req := httptest.NewRequest(http.MethodPost, "/refresh", strings.NewReader(`{"refreshToken":"`+rt+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("NoToken", func(t *testing.T) {
+		app, _ := setup()
+		resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/refresh", nil))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
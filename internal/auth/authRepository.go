@@ -2,7 +2,7 @@ package auth
 
 import (
 	"strings"
-	"sync"
+	"time"
 
 	"github.com/sankangkin/di-rest-api/internal/models"
 	mylog "github.com/sirupsen/logrus"
@@ -12,17 +12,18 @@ import (
 type AuthRepositoryInterface interface {
 	CreateUser(user *models.User) (*models.User, error)
 	GetUserByName(name string) (*models.User, error)
+	GetUserById(id uint) (*models.User, error)
+	CreateRefreshToken(token *models.RefreshToken) error
+	GetRefreshToken(tokenHash string) (*models.RefreshToken, error)
+	RotateRefreshToken(old *models.RefreshToken, next *models.RefreshToken) (bool, error)
+	RevokeFamily(familyId string) error
+	IsSessionActive(familyId string) (bool, error)
 }
 
 type AuthRepository struct {
 	db *gorm.DB
 }
 
-var (
-	repoInstance *AuthRepository
-	repoOnce     sync.Once
-)
-
 func init() {
 	mylog.SetReportCaller(true)
 	Formatter := new(mylog.JSONFormatter)
@@ -35,11 +36,7 @@ func NewAuthRepository(db *gorm.DB) AuthRepositoryInterface {
 
 	mylog.Info("AuthRepository is called")
 	// log.Println(util.Red + "AuthRepository constructor is called" + util.Reset)
-	repoOnce.Do(func() {
-		repoInstance = &AuthRepository{db: db}
-	})
-
-	return repoInstance
+	return &AuthRepository{db: db}
 }
 
 func (r *AuthRepository) CreateUser(user *models.User) (*models.User, error) {
@@ -67,3 +64,65 @@ func (r *AuthRepository) GetUserByName(email string) (*models.User, error) {
 	}
 	return &user, err
 }
+
+func (r *AuthRepository) GetUserById(id uint) (*models.User, error) {
+
+	var user models.User
+	if err := r.db.First(&user, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *AuthRepository) CreateRefreshToken(token *models.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *AuthRepository) GetRefreshToken(tokenHash string) (*models.RefreshToken, error) {
+
+	var token models.RefreshToken
+	if err := r.db.First(&token, "token_hash = ?", tokenHash).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// RotateRefreshToken marks old as used and stores next in its place. It
+// reports false, and stores nothing, when old was already used or revoked, so
+// of two concurrent refreshes with the same token only one wins.
+func (r *AuthRepository) RotateRefreshToken(old *models.RefreshToken, next *models.RefreshToken) (bool, error) {
+
+	rotated := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND used_at = 0 AND revoked_at = 0", old.ID).
+			Update("used_at", time.Now().Unix())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		rotated = true
+		return tx.Create(next).Error
+	})
+	return rotated, err
+}
+
+// RevokeFamily ends the session: every token of the family is revoked.
+func (r *AuthRepository) RevokeFamily(familyId string) error {
+	return r.db.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at = 0", familyId).
+		Update("revoked_at", time.Now().Unix()).Error
+}
+
+// IsSessionActive reports whether the family still holds a refresh token that
+// can be used, i.e. the session was neither logged out, revoked nor expired.
+func (r *AuthRepository) IsSessionActive(familyId string) (bool, error) {
+
+	var count int64
+	err := r.db.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND used_at = 0 AND revoked_at = 0 AND expires_at > ?", familyId, time.Now().Unix()).
+		Count(&count).Error
+	return count > 0, err
+}
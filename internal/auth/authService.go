@@ -1,16 +1,21 @@
 package auth
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
-	"fmt"
-	"sync"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt"
-	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/config"
 	"github.com/sankangkin/di-rest-api/internal/models"
 	mylog "github.com/sirupsen/logrus"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 type AuthServiceInterface interface {
@@ -18,18 +23,22 @@ type AuthServiceInterface interface {
 	Signin(username, password string) (string, string, string, string, error)
 	FindUserByEmail(email string) (*models.User, error)
 	Refresh(refreshToken string) (string, string, string, string, string, error)
-	Signout(accessToken string) error
+	Signout(refreshToken string) error
+	IsSessionActive(sessionId string) bool
 }
 
+var (
+	ErrInvalidCredentials  = apperr.Unauthorized("invalid credentials")
+	ErrInvalidRefreshToken = apperr.Unauthorized("invalid refresh token")
+	ErrRefreshTokenExpired = apperr.Unauthorized("refresh token expired")
+	ErrRefreshTokenReused  = apperr.Unauthorized("refresh token already used, session revoked")
+)
+
 type AuthService struct {
 	repo AuthRepositoryInterface
+	jwt  config.JWTConfig
 }
 
-var (
-	svcInstance *AuthService
-	svcOnce     sync.Once
-)
-
 func init() {
 	mylog.SetReportCaller(true)
 	Formatter := new(mylog.JSONFormatter)
@@ -37,26 +46,31 @@ func init() {
 	mylog.SetFormatter(Formatter)
 }
 
-func NewAuthService(repo AuthRepositoryInterface) AuthServiceInterface {
+func NewAuthService(repo AuthRepositoryInterface, cfg *config.Config) AuthServiceInterface {
 	mylog.Info("AuthService is called.")
 	// log.Println(util.Red + "AuthService constructor is called" + util.Reset)
 
-	svcOnce.Do(func() {
-		svcInstance = &AuthService{repo: repo}
-	})
-
-	return svcInstance
+	return &AuthService{repo: repo, jwt: cfg.JWT}
 }
 
 func (s *AuthService) Signup(user *models.User) (*models.User, error) {
 
+	// roles are stored lower case, the role claim is compared against them
+	role := models.Role(strings.ToLower(string(user.Role)))
+	if role == "" {
+		role = models.USER
+	}
+	if role != models.ADMIN && role != models.USER {
+		return nil, apperr.Validation("unknown role %s", user.Role)
+	}
+
 	password := hashAndSalt([]byte(user.Password))
 	newUser := models.User{
 		Email:    user.Email,
 		UserName: user.UserName,
 		Password: password,
-		IsAdmin:  user.IsAdmin,
-		Role:     user.Role,
+		IsAdmin:  role == models.ADMIN,
+		Role:     role,
 	}
 
 	result, err := s.repo.CreateUser(&newUser)
@@ -77,162 +91,118 @@ func (s *AuthService) Signin(email, password string) (string, string, string, st
 	found, err := s.repo.GetUserByName(email)
 
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// same answer as a wrong password, not telling which emails exist
+			return "", "", "", "", ErrInvalidCredentials
+		}
 		return "", "", "", "", err
 	}
 
 	if !comparePasswords(found.Password, []byte(password)) {
-		return "", "", "", "", errors.New("invalid credentials")
+		return "", "", "", "", ErrInvalidCredentials
 	}
 
-	at, rt, userName, role, err := generateTokens(found, util.SecreteKey)
+	// every login starts a new session, i.e. a new refresh token family
+	familyId, err := randomString(16, hex.EncodeToString)
 	if err != nil {
-		return "", "", "", "", errors.New("authentication faileds")
-	}
-	return at, rt, userName, role, nil
-}
-
-func (s *AuthService) RefreshWithoutUsernameEmailRole(refreshToken string) (string, string, error) {
-	claims := jwt.MapClaims{}
-	token, err := jwt.ParseWithClaims(refreshToken, claims, func(t *jwt.Token) (interface{}, error) {
-		return []byte(util.SecreteKey), nil
-	})
-	if err != nil || !token.Valid {
-		return "", "", fmt.Errorf("invalid token: %w", err)
+		return "", "", "", "", err
 	}
-
-	// Extract claims
-	email := claims["email"].(string)
-	found, err := s.repo.GetUserByName(email)
+	token, rt, err := newRefreshToken(found.ID, familyId, s.jwt.RefreshTokenTTL)
 	if err != nil {
-		return "", "", err
-	}
-
-	// ✅ Create Access Token
-	accessClaims := jwt.MapClaims{
-		"id":    found.ID,
-		"email": found.Email,
-		"admin": found.IsAdmin,
-		"role":  found.Role,
-		"exp":   time.Now().Add(time.Minute * 15).Unix(), // short-lived
+		return "", "", "", "", err
 	}
-	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
-	at, err := accessToken.SignedString([]byte(util.SecreteKey))
-	if err != nil {
-		return "", "", err
+	if err := s.repo.CreateRefreshToken(token); err != nil {
+		return "", "", "", "", err
 	}
 
-	// ✅ Create Refresh Token
-	refreshClaims := jwt.MapClaims{
-		"id":    found.ID,
-		"email": found.Email,
-		"admin": found.IsAdmin,
-		"role":  found.Role,
-		"exp":   time.Now().Add(time.Hour * 1).Unix(), // longer-lived
-	}
-	refreshTokenObj := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims)
-	rt, err := refreshTokenObj.SignedString([]byte(util.SecreteKey))
+	at, err := generateAccessToken(found, familyId, s.jwt)
 	if err != nil {
-		return "", "", err
+		return "", "", "", "", err
 	}
-
-	return at, rt, nil
+	return at, rt, found.UserName, string(found.Role), nil
 }
 
+// Refresh swaps a refresh token for a new access and refresh token. Every
+// refresh token works once: presenting one that was already rotated means it
+// has leaked, so the whole session is revoked.
 func (s *AuthService) Refresh(refreshToken string) (string, string, string, string, string, error) {
-	claims := jwt.MapClaims{}
-	token, err := jwt.ParseWithClaims(refreshToken, claims, func(t *jwt.Token) (interface{}, error) {
-		return []byte(util.SecreteKey), nil
-	})
-	if err != nil || !token.Valid {
-		// Check if the error is because of expiration
-		if ve, ok := err.(*jwt.ValidationError); ok && ve.Errors == jwt.ValidationErrorExpired {
-			return "", "", "", "", "", fmt.Errorf("refresh token expired")
+	stored, err := s.repo.GetRefreshToken(hashToken(refreshToken))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return "", "", "", "", "", ErrInvalidRefreshToken
 		}
-		return "", "", "", "", "", fmt.Errorf("invalid token: %w", err)
+		return "", "", "", "", "", err
 	}
-
-	// Extract email from token claims
-	email, ok := claims["email"].(string)
-	if !ok {
-		return "", "", "", "", "", fmt.Errorf("invalid email in token")
+	if stored.RevokedAt != 0 {
+		return "", "", "", "", "", ErrInvalidRefreshToken
+	}
+	if stored.UsedAt != 0 {
+		return "", "", "", "", "", s.revokeReused(stored)
+	}
+	if stored.ExpiresAt <= time.Now().Unix() {
+		return "", "", "", "", "", ErrRefreshTokenExpired
 	}
 
 	// Get user from DB
-	found, err := s.repo.GetUserByName(email)
+	found, err := s.repo.GetUserById(stored.UserId)
 	if err != nil {
 		return "", "", "", "", "", err
 	}
 
-	// Create new access token
-	accessClaims := jwt.MapClaims{
-		"id":       found.ID,
-		"email":    found.Email,
-		"username": found.UserName,
-		"role":     found.Role,
-		"exp":      time.Now().Add(time.Minute * 15).Unix(),
+	next, rt, err := newRefreshToken(found.ID, stored.FamilyId, s.jwt.RefreshTokenTTL)
+	if err != nil {
+		return "", "", "", "", "", err
 	}
-	accessTokenObj := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
-	accessToken, err := accessTokenObj.SignedString([]byte(util.SecreteKey))
+	rotated, err := s.repo.RotateRefreshToken(stored, next)
 	if err != nil {
 		return "", "", "", "", "", err
 	}
-
-	// Create new refresh token
-	refreshClaims := jwt.MapClaims{
-		"id":       found.ID,
-		"email":    found.Email,
-		"username": found.UserName,
-		"role":     found.Role,
-		"exp":      time.Now().Add(time.Hour * 24 * 7).Unix(),
+	if !rotated {
+		// somebody else refreshed with the same token first
+		return "", "", "", "", "", s.revokeReused(stored)
 	}
-	refreshTokenObj := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims)
-	newRefreshToken, err := refreshTokenObj.SignedString([]byte(util.SecreteKey))
+
+	accessToken, err := generateAccessToken(found, stored.FamilyId, s.jwt)
 	if err != nil {
 		return "", "", "", "", "", err
 	}
 
-	return accessToken, newRefreshToken, found.Email, found.UserName, string(found.Role), nil
+	return accessToken, rt, found.UserName, found.Email, string(found.Role), nil
 }
 
-func (s *AuthService) RefreshOld(refreshToken string) (string, error) {
-
-	claims := jwt.MapClaims{}
-
-	token, err := jwt.ParseWithClaims(refreshToken, claims, func(t *jwt.Token) (interface{}, error) {
-		return []byte(util.SecreteKey), nil
-	})
-	for key, val := range claims {
-		fmt.Printf("key: %v, value: %v\n", key, val)
+func (s *AuthService) revokeReused(stored *models.RefreshToken) error {
+	mylog.Warnf("refresh token %d of session %s reused, revoking the session", stored.ID, stored.FamilyId)
+	if err := s.repo.RevokeFamily(stored.FamilyId); err != nil {
+		return err
 	}
+	return ErrRefreshTokenReused
+}
+
+// Signout revokes the session the refresh token belongs to. Access tokens of
+// the session stop working with it, see IsSessionActive.
+func (s *AuthService) Signout(refreshToken string) error {
+	stored, err := s.repo.GetRefreshToken(hashToken(refreshToken))
 	if err != nil {
-		return "", err
-	}
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		query := models.User{Email: claims["email"].(string)}
-		found, err := s.repo.GetUserByName(query.Email)
-		if err != nil {
-			return "", err
-		}
-		rtClaims := jwt.MapClaims{
-			"id":    found.ID,
-			"email": found.Email,
-			"admin": found.IsAdmin,
-			"role":  found.Role,
-			"exp":   time.Now().Add(time.Hour * 1).Unix(),
+		if err == gorm.ErrRecordNotFound {
+			return ErrInvalidRefreshToken
 		}
-		refreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256, rtClaims)
-		rt, err := refreshToken.SignedString([]byte(util.SecreteKey))
-		if err != nil {
-			return "", err
-		}
-		return rt, nil
+		return err
 	}
-	return "", err
-
+	return s.repo.RevokeFamily(stored.FamilyId)
 }
 
-func (s *AuthService) Signout(accessToken string) error {
-	return nil
+// IsSessionActive reports whether the session ("sid" claim of an access
+// token) is still open. Lookup errors count as closed.
+func (s *AuthService) IsSessionActive(sessionId string) bool {
+	if sessionId == "" {
+		return false
+	}
+	active, err := s.repo.IsSessionActive(sessionId)
+	if err != nil {
+		mylog.Error(err)
+		return false
+	}
+	return active
 }
 
 func hashAndSalt(pwd []byte) string {
@@ -246,48 +216,45 @@ func comparePasswords(hashedPwd string, plainPwd []byte) bool {
 	return err == nil
 }
 
-func SessionExpires() time.Time {
-	return time.Now().Add(5 * 24 * time.Hour)
-}
-
-func generateTokens(user *models.User, secretKey string) (string, string, string, string, error) {
-	// Define signing method and create claims
+func generateAccessToken(user *models.User, sessionId string, cfg config.JWTConfig) (string, error) {
 	claims := &jwt.MapClaims{
 		"id":       user.ID,
 		"email":    user.Email,
 		"userName": user.UserName,
-		"admin":    true,
+		"admin":    user.IsAdmin,
 		"role":     user.Role,
-		"exp":      time.Now().Add(time.Minute * 30).Unix(),
+		"sid":      sessionId,
+		"exp":      time.Now().Add(cfg.AccessTokenTTL).Unix(),
 	}
 
-	// Create access token
 	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	accessTokenString, err := accessToken.SignedString([]byte(secretKey))
+	return accessToken.SignedString([]byte(cfg.Secret))
+}
+
+// newRefreshToken returns the row to store and the token to hand out. Refresh
+// tokens are opaque random strings, only their hash is kept.
+func newRefreshToken(userId uint, familyId string, ttl time.Duration) (*models.RefreshToken, string, error) {
+	plain, err := randomString(32, base64.RawURLEncoding.EncodeToString)
 	if err != nil {
-		return "", "", "", "", err
+		return nil, "", err
 	}
+	return &models.RefreshToken{
+		UserId:    userId,
+		FamilyId:  familyId,
+		TokenHash: hashToken(plain),
+		ExpiresAt: time.Now().Add(ttl).Unix(),
+	}, plain, nil
+}
 
-	// Define refresh token claims with longer expiry
-	refreshTokenClaims := &jwt.MapClaims{
-
-		"id":       user.ID,
-		"email":    user.Email,
-		"userName": user.UserName,
-		"admin":    true,
-		"role":     user.Role,
-		// "exp":      time.Now().Add(time.Hour * 12).Unix(),
-		//   "user_id": userID,
-		"exp": time.Now().Add(time.Hour * 24 * 7).Unix(), // Refresh token expires in 7 days
-	}
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
 
-	// Create refresh token
-	refreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshTokenClaims)
-	refreshTokenString, err := refreshToken.SignedString([]byte(secretKey))
-	userName := user.UserName
-	role := string(user.Role)
-	if err != nil {
-		return "", "", "", "", err
+func randomString(size int, encode func([]byte) string) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
 	}
-	return accessTokenString, refreshTokenString, userName, role, nil
+	return encode(b), nil
 }
@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sankangkin/di-rest-api/internal/config"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// memoryRepo keeps users and refresh tokens in memory.
+type memoryRepo struct {
+	users  []models.User
+	tokens []*models.RefreshToken
+}
+
+func (r *memoryRepo) CreateUser(user *models.User) (*models.User, error) {
+	user.ID = uint(len(r.users) + 1)
+	r.users = append(r.users, *user)
+	return user, nil
+}
+
+func (r *memoryRepo) GetUserByName(email string) (*models.User, error) {
+	for i := range r.users {
+		if r.users[i].Email == email {
+			return &r.users[i], nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryRepo) GetUserById(id uint) (*models.User, error) {
+	for i := range r.users {
+		if r.users[i].ID == id {
+			return &r.users[i], nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryRepo) CreateRefreshToken(token *models.RefreshToken) error {
+	token.ID = uint(len(r.tokens) + 1)
+	r.tokens = append(r.tokens, token)
+	return nil
+}
+
+func (r *memoryRepo) GetRefreshToken(tokenHash string) (*models.RefreshToken, error) {
+	for _, t := range r.tokens {
+		if t.TokenHash == tokenHash {
+			copied := *t
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryRepo) RotateRefreshToken(old *models.RefreshToken, next *models.RefreshToken) (bool, error) {
+	stored := r.tokens[old.ID-1]
+	if stored.UsedAt != 0 || stored.RevokedAt != 0 {
+		return false, nil
+	}
+	stored.UsedAt = time.Now().Unix()
+	return true, r.CreateRefreshToken(next)
+}
+
+func (r *memoryRepo) RevokeFamily(familyId string) error {
+	for _, t := range r.tokens {
+		if t.FamilyId == familyId && t.RevokedAt == 0 {
+			t.RevokedAt = time.Now().Unix()
+		}
+	}
+	return nil
+}
+
+func (r *memoryRepo) IsSessionActive(familyId string) (bool, error) {
+	for _, t := range r.tokens {
+		if t.FamilyId == familyId && t.UsedAt == 0 && t.RevokedAt == 0 && t.ExpiresAt > time.Now().Unix() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func signedIn(t *testing.T) (*AuthService, *memoryRepo, string) {
+	repo := &memoryRepo{}
+	svc := &AuthService{repo: repo, jwt: config.JWTConfig{
+		Secret:          "0123456789abcdef0123456789abcdef",
+		AccessTokenTTL:  15 * time.Minute,
+		RefreshTokenTTL: time.Hour,
+	}}
+	_, err := svc.Signup(&models.User{Email: "cashier@stt.com", UserName: "cashier", Password: "passw0rd"})
+	require.NoError(t, err)
+
+	_, rt, _, _, err := svc.Signin("cashier@stt.com", "passw0rd")
+	require.NoError(t, err)
+	return svc, repo, rt
+}
+
+func TestRefreshRotates(t *testing.T) {
+	svc, repo, rt := signedIn(t)
+	sessionId := repo.tokens[0].FamilyId
+	assert.True(t, svc.IsSessionActive(sessionId))
+
+	_, next, userName, email, role, err := svc.Refresh(rt)
+	require.NoError(t, err)
+	assert.NotEqual(t, rt, next)
+	assert.Equal(t, "cashier", userName)
+	assert.Equal(t, "cashier@stt.com", email)
+	assert.Equal(t, string(models.USER), role)
+
+	// the new token stays in the session, only its hash is stored
+	require.Len(t, repo.tokens, 2)
+	assert.Equal(t, sessionId, repo.tokens[1].FamilyId)
+	assert.Equal(t, hashToken(next), repo.tokens[1].TokenHash)
+	assert.NotEqual(t, next, repo.tokens[1].TokenHash)
+	assert.True(t, svc.IsSessionActive(sessionId))
+
+	_, _, _, _, _, err = svc.Refresh(next)
+	assert.NoError(t, err)
+}
+
+func TestRefreshReuseRevokesSession(t *testing.T) {
+	svc, repo, rt := signedIn(t)
+	sessionId := repo.tokens[0].FamilyId
+
+	_, next, _, _, _, err := svc.Refresh(rt)
+	require.NoError(t, err)
+
+	_, _, _, _, _, err = svc.Refresh(rt)
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+	assert.False(t, svc.IsSessionActive(sessionId))
+
+	// the legitimate holder is logged out as well
+	_, _, _, _, _, err = svc.Refresh(next)
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+}
+
+func TestRefreshRejects(t *testing.T) {
+	svc, repo, rt := signedIn(t)
+
+	_, _, _, _, _, err := svc.Refresh("not-a-token")
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+
+	repo.tokens[0].ExpiresAt = time.Now().Add(-time.Minute).Unix()
+	_, _, _, _, _, err = svc.Refresh(rt)
+	assert.ErrorIs(t, err, ErrRefreshTokenExpired)
+	assert.False(t, svc.IsSessionActive(repo.tokens[0].FamilyId))
+}
+
+func TestSignoutRevokesSession(t *testing.T) {
+	svc, repo, rt := signedIn(t)
+	sessionId := repo.tokens[0].FamilyId
+
+	// a second login is a separate session
+	_, other, _, _, err := svc.Signin("cashier@stt.com", "passw0rd")
+	require.NoError(t, err)
+
+	require.NoError(t, svc.Signout(rt))
+	assert.False(t, svc.IsSessionActive(sessionId))
+	_, _, _, _, _, err = svc.Refresh(rt)
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+
+	_, _, _, _, _, err = svc.Refresh(other)
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, svc.Signout("not-a-token"), ErrInvalidRefreshToken)
+}
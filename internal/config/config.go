@@ -0,0 +1,399 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+)
+
+// Config is everything the api reads from its environment. Values come from,
+// in order of precedence: command line flags, environment variables, the
+// config file (.env format) and the defaults below.
+type Config struct {
+	HTTP   HTTPConfig
+	DB     DBConfig
+	JWT    JWTConfig
+	Cookie CookieConfig
+	CORS   CORSConfig
+	Tax    TaxConfig
+	DocNo  DocNoConfig
+	Seed   SeedConfig
+	Shop   ShopConfig
+}
+
+type HTTPConfig struct {
+	Port int // API_PORT, -port
+	// ShutdownTimeout is how long in-flight requests get to finish on
+	// SIGINT/SIGTERM, HTTP_SHUTDOWN_TIMEOUT.
+	ShutdownTimeout time.Duration
+}
+
+type DBConfig struct {
+	// DSN is DB_DSN (-dsn), or built from DB_HOST, POSTGRES_PORT,
+	// POSTGRES_USER, POSTGRES_PASSWORD, POSTGRES_DB and SSLMODE.
+	DSN             string
+	MaxOpenConns    int           // DB_MAX_OPEN_CONNS
+	MaxIdleConns    int           // DB_MAX_IDLE_CONNS
+	ConnMaxLifetime time.Duration // DB_CONN_MAX_LIFETIME, e.g. "30m"
+}
+
+type JWTConfig struct {
+	Secret          string        // JWT_SECRET, at least 32 characters, not the .env.example placeholder
+	AccessTokenTTL  time.Duration // JWT_ACCESS_TTL
+	RefreshTokenTTL time.Duration // JWT_REFRESH_TTL
+}
+
+type CookieConfig struct {
+	Domain string // COOKIE_DOMAIN
+	Secure bool   // COOKIE_SECURE
+}
+
+type CORSConfig struct {
+	AllowOrigins []string // CORS_ALLOW_ORIGINS, comma separated
+}
+
+type TaxConfig struct {
+	// Rate is TAX_RATE, a percentage such as "7" or "7.5", kept in basis
+	// points (750 = 7.5%); unset means no tax.
+	Rate int64
+}
+
+// DocNoConfig holds the document number overrides by document type (SALE,
+// PURCHASE, ...), from DOCNO_<TYPE>_PREFIX, _DATE_LAYOUT, _WIDTH and _RESET.
+type DocNoConfig map[string]DocNoFormat
+
+// DocNoFormat overrides parts of the default number format of one document
+// type; nil, zero and empty fields keep the default.
+type DocNoFormat struct {
+	Prefix     *string
+	DateLayout *string // set but empty leaves the date out
+	Width      int     // digits of the running number, 1 to 12
+	Reset      string  // MONTHLY, YEARLY or NEVER
+}
+
+type SeedConfig struct {
+	AdminEmail    string // SEED_ADMIN_EMAIL
+	AdminPassword string // SEED_ADMIN_PASSWORD
+}
+
+type ShopConfig struct {
+	// TimeZone is SHOP_TIME_ZONE, an IANA name such as "Asia/Yangon"; the
+	// shop's days, e.g. of the stock valuation, run midnight to midnight in
+	// it. Unset means the server's local time zone.
+	TimeZone *time.Location
+}
+
+// DocNoTypes are the document types numbers can be configured for.
+var DocNoTypes = []string{"SALE", "PURCHASE", "TRANSFER", "STOCKTAKE", "ORDER", "RECEIPT"}
+
+const defaultConfigFile = ".env"
+
+const minSecretLength = 32
+
+// placeholderPrefix starts the sample values in .env.example that must be
+// replaced before the api runs.
+const placeholderPrefix = "change-me"
+
+// NewConfig loads the configuration from the process environment and
+// command line, for wire.
+func NewConfig() (*Config, error) {
+	log.Println(util.Yellow + "Config constructor is called" + util.Reset)
+	return Load(os.Args[1:])
+}
+
+// Load reads the config file given by -config (or CONFIG_FILE, default .env,
+// which may be missing), then the environment, then the flags in args, and
+// validates the result.
+func Load(args []string) (*Config, error) {
+	cfg, _, err := LoadCommand(args)
+	return cfg, err
+}
+
+// LoadCommand is Load for a subcommand: it also returns the arguments left
+// after the flags, e.g. "up 2" of "migrate -config prod.env up 2".
+func LoadCommand(args []string) (*Config, []string, error) {
+	fs := flag.NewFlagSet("api", flag.ContinueOnError)
+	file := fs.String("config", os.Getenv("CONFIG_FILE"), "config file in .env format (default .env when present)")
+	port := fs.Int("port", 0, "HTTP port, overrides API_PORT")
+	dsn := fs.String("dsn", "", "database DSN, overrides DB_DSN")
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	if *file == "" {
+		if _, err := os.Stat(defaultConfigFile); err == nil {
+			*file = defaultConfigFile
+		}
+	}
+	if *file != "" {
+		// variables already set in the environment win over the file
+		if err := godotenv.Load(*file); err != nil {
+			return nil, nil, fmt.Errorf("config file %s: %w", *file, err)
+		}
+	}
+
+	cfg, err := fromEnv(os.LookupEnv)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "port":
+			cfg.HTTP.Port = *port
+		case "dsn":
+			cfg.DB.DSN = *dsn
+		}
+	})
+
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return cfg, fs.Args(), nil
+}
+
+// fromEnv builds the config from lookup, collecting every malformed value
+// into one error.
+func fromEnv(lookup func(string) (string, bool)) (*Config, error) {
+	r := reader{lookup: lookup}
+
+	cfg := &Config{
+		HTTP: HTTPConfig{
+			Port:            r.int("API_PORT", 5555),
+			ShutdownTimeout: r.duration("HTTP_SHUTDOWN_TIMEOUT", 15*time.Second),
+		},
+		DB: DBConfig{
+			DSN:             r.string("DB_DSN", ""),
+			MaxOpenConns:    r.int("DB_MAX_OPEN_CONNS", 25),
+			MaxIdleConns:    r.int("DB_MAX_IDLE_CONNS", 5),
+			ConnMaxLifetime: r.duration("DB_CONN_MAX_LIFETIME", 30*time.Minute),
+		},
+		JWT: JWTConfig{
+			Secret:          r.string("JWT_SECRET", ""),
+			AccessTokenTTL:  r.duration("JWT_ACCESS_TTL", 15*time.Minute),
+			RefreshTokenTTL: r.duration("JWT_REFRESH_TTL", 7*24*time.Hour),
+		},
+		Cookie: CookieConfig{
+			Domain: r.string("COOKIE_DOMAIN", "localhost"),
+			Secure: r.bool("COOKIE_SECURE", false),
+		},
+		CORS: CORSConfig{
+			AllowOrigins: r.list("CORS_ALLOW_ORIGINS", []string{"*"}),
+		},
+		Tax: TaxConfig{
+			Rate: r.percent("TAX_RATE"),
+		},
+		DocNo: DocNoConfig{},
+		Seed: SeedConfig{
+			AdminEmail:    r.string("SEED_ADMIN_EMAIL", ""),
+			AdminPassword: r.string("SEED_ADMIN_PASSWORD", ""),
+		},
+		Shop: ShopConfig{
+			TimeZone: r.location("SHOP_TIME_ZONE", time.Local),
+		},
+	}
+	for _, docType := range DocNoTypes {
+		key := "DOCNO_" + docType + "_"
+		format := DocNoFormat{
+			Prefix:     r.optional(key + "PREFIX"),
+			DateLayout: r.optional(key + "DATE_LAYOUT"),
+			Width:      r.int(key+"WIDTH", 0),
+			Reset:      strings.ToUpper(r.string(key+"RESET", "")),
+		}
+		if format != (DocNoFormat{}) {
+			cfg.DocNo[docType] = format
+		}
+	}
+
+	if cfg.DB.DSN == "" {
+		cfg.DB.DSN = fmt.Sprintf(
+			"host=%s port=%s password=%s user=%s dbname=%s sslmode=%s",
+			r.string("DB_HOST", "localhost"),
+			r.string("POSTGRES_PORT", "5432"),
+			r.string("POSTGRES_PASSWORD", ""),
+			r.string("POSTGRES_USER", "postgres"),
+			r.string("POSTGRES_DB", ""),
+			r.string("SSLMODE", "disable"))
+	}
+
+	if len(r.problems) > 0 {
+		return nil, invalid(r.problems)
+	}
+	return cfg, nil
+}
+
+// Validate reports every setting that is missing or out of range at once.
+func (c *Config) Validate() error {
+	var problems []string
+	if c.HTTP.Port < 1 || c.HTTP.Port > 65535 {
+		problems = append(problems, fmt.Sprintf("API_PORT must be between 1 and 65535, got %d", c.HTTP.Port))
+	}
+	if c.HTTP.ShutdownTimeout <= 0 {
+		problems = append(problems, "HTTP_SHUTDOWN_TIMEOUT must be positive")
+	}
+	if c.DB.DSN == "" {
+		problems = append(problems, "DB_DSN is required")
+	}
+	if c.DB.MaxOpenConns < 1 {
+		problems = append(problems, fmt.Sprintf("DB_MAX_OPEN_CONNS must be at least 1, got %d", c.DB.MaxOpenConns))
+	}
+	if c.DB.MaxIdleConns < 0 || c.DB.MaxIdleConns > c.DB.MaxOpenConns {
+		problems = append(problems, fmt.Sprintf("DB_MAX_IDLE_CONNS must be between 0 and DB_MAX_OPEN_CONNS (%d), got %d", c.DB.MaxOpenConns, c.DB.MaxIdleConns))
+	}
+	if c.DB.ConnMaxLifetime < 0 {
+		problems = append(problems, "DB_CONN_MAX_LIFETIME must not be negative")
+	}
+	if len(c.JWT.Secret) < minSecretLength {
+		problems = append(problems, fmt.Sprintf("JWT_SECRET must be at least %d characters", minSecretLength))
+	} else if strings.HasPrefix(strings.ToLower(c.JWT.Secret), placeholderPrefix) {
+		problems = append(problems, "JWT_SECRET is still the .env.example placeholder, set a random secret")
+	}
+	if strings.HasPrefix(strings.ToLower(c.Seed.AdminPassword), placeholderPrefix) {
+		problems = append(problems, "SEED_ADMIN_PASSWORD is still the .env.example placeholder, set a password or leave it unset")
+	}
+	if c.JWT.AccessTokenTTL <= 0 {
+		problems = append(problems, "JWT_ACCESS_TTL must be positive")
+	}
+	if c.JWT.RefreshTokenTTL <= c.JWT.AccessTokenTTL {
+		problems = append(problems, "JWT_REFRESH_TTL must be longer than JWT_ACCESS_TTL")
+	}
+	if len(c.CORS.AllowOrigins) == 0 {
+		problems = append(problems, "CORS_ALLOW_ORIGINS must list at least one origin")
+	}
+	if c.Shop.TimeZone == nil {
+		problems = append(problems, "SHOP_TIME_ZONE is required")
+	}
+	if c.Tax.Rate < 0 || c.Tax.Rate > 100_00 {
+		problems = append(problems, fmt.Sprintf("TAX_RATE must be a percentage between 0 and 100, got %.2f", float64(c.Tax.Rate)/100))
+	}
+	for _, docType := range DocNoTypes {
+		format := c.DocNo[docType]
+		key := "DOCNO_" + docType + "_"
+		if format.Width < 0 || format.Width > 12 {
+			problems = append(problems, fmt.Sprintf("%sWIDTH must be between 1 and 12, got %d", key, format.Width))
+		}
+		if format.Reset != "" && format.Reset != "MONTHLY" && format.Reset != "YEARLY" && format.Reset != "NEVER" {
+			problems = append(problems, fmt.Sprintf("%sRESET must be MONTHLY, YEARLY or NEVER, got %q", key, format.Reset))
+		}
+	}
+
+	if len(problems) > 0 {
+		return invalid(problems)
+	}
+	return nil
+}
+
+func invalid(problems []string) error {
+	return errors.New("invalid configuration:\n  - " + strings.Join(problems, "\n  - "))
+}
+
+// reader looks up typed values, recording the ones that do not parse.
+type reader struct {
+	lookup   func(string) (string, bool)
+	problems []string
+}
+
+func (r *reader) string(key, def string) string {
+	if value, ok := r.lookup(key); ok && strings.TrimSpace(value) != "" {
+		return strings.TrimSpace(value)
+	}
+	return def
+}
+
+// optional returns the value of key when it is set, even to "".
+func (r *reader) optional(key string) *string {
+	if value, ok := r.lookup(key); ok {
+		value = strings.TrimSpace(value)
+		return &value
+	}
+	return nil
+}
+
+func (r *reader) int(key string, def int) int {
+	value := r.string(key, "")
+	if value == "" {
+		return def
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		r.problems = append(r.problems, fmt.Sprintf("%s must be a whole number, got %q", key, value))
+		return def
+	}
+	return n
+}
+
+func (r *reader) bool(key string, def bool) bool {
+	value := r.string(key, "")
+	if value == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		r.problems = append(r.problems, fmt.Sprintf("%s must be true or false, got %q", key, value))
+		return def
+	}
+	return b
+}
+
+// percent reads a percentage such as "7.5" into basis points, 750.
+func (r *reader) percent(key string) int64 {
+	value := r.string(key, "")
+	if value == "" {
+		return 0
+	}
+	percent, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		r.problems = append(r.problems, fmt.Sprintf("%s must be a percentage such as 7 or 7.5, got %q", key, value))
+		return 0
+	}
+	return int64(math.Round(percent * 100))
+}
+
+func (r *reader) duration(key string, def time.Duration) time.Duration {
+	value := r.string(key, "")
+	if value == "" {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		r.problems = append(r.problems, fmt.Sprintf("%s must be a duration such as 15m or 168h, got %q", key, value))
+		return def
+	}
+	return d
+}
+
+func (r *reader) location(key string, def *time.Location) *time.Location {
+	value := r.string(key, "")
+	if value == "" {
+		return def
+	}
+	loc, err := time.LoadLocation(value)
+	if err != nil {
+		r.problems = append(r.problems, fmt.Sprintf("%s must be a time zone such as Asia/Yangon or UTC, got %q", key, value))
+		return def
+	}
+	return loc
+}
+
+func (r *reader) list(key string, def []string) []string {
+	value := r.string(key, "")
+	if value == "" {
+		return def
+	}
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
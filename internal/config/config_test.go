@@ -0,0 +1,166 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func lookup(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+}
+
+func TestFromEnvDefaults(t *testing.T) {
+	cfg, err := fromEnv(lookup(map[string]string{"JWT_SECRET": testSecret, "POSTGRES_DB": "pos"}))
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	assert.Equal(t, 5555, cfg.HTTP.Port)
+	assert.Equal(t, 15*time.Second, cfg.HTTP.ShutdownTimeout)
+	assert.Equal(t, "host=localhost port=5432 password= user=postgres dbname=pos sslmode=disable", cfg.DB.DSN)
+	assert.Equal(t, 25, cfg.DB.MaxOpenConns)
+	assert.Equal(t, 15*time.Minute, cfg.JWT.AccessTokenTTL)
+	assert.Equal(t, 7*24*time.Hour, cfg.JWT.RefreshTokenTTL)
+	assert.Equal(t, "localhost", cfg.Cookie.Domain)
+	assert.False(t, cfg.Cookie.Secure)
+	assert.Equal(t, []string{"*"}, cfg.CORS.AllowOrigins)
+	assert.Equal(t, int64(0), cfg.Tax.Rate)
+	assert.Empty(t, cfg.DocNo)
+	assert.Equal(t, time.Local, cfg.Shop.TimeZone)
+}
+
+func TestFromEnv(t *testing.T) {
+	cfg, err := fromEnv(lookup(map[string]string{
+		"API_PORT":                "8080",
+		"DB_DSN":                  "postgres://pos@db/pos",
+		"DB_MAX_OPEN_CONNS":       "50",
+		"DB_CONN_MAX_LIFETIME":    "1h",
+		"JWT_SECRET":              testSecret,
+		"JWT_ACCESS_TTL":          "5m",
+		"COOKIE_SECURE":           "true",
+		"CORS_ALLOW_ORIGINS":      "https://pos.example.com, https://admin.example.com,",
+		"TAX_RATE":                " 7.5 ",
+		"DOCNO_SALE_PREFIX":       "si",
+		"DOCNO_SALE_RESET":        "yearly",
+		"DOCNO_ORDER_DATE_LAYOUT": "",
+		"SEED_ADMIN_EMAIL":        "admin@pos.example.com",
+		"SHOP_TIME_ZONE":          "Asia/Yangon",
+	}))
+	require.NoError(t, err)
+
+	assert.Equal(t, 8080, cfg.HTTP.Port)
+	assert.Equal(t, "postgres://pos@db/pos", cfg.DB.DSN)
+	assert.Equal(t, 50, cfg.DB.MaxOpenConns)
+	assert.Equal(t, time.Hour, cfg.DB.ConnMaxLifetime)
+	assert.Equal(t, 5*time.Minute, cfg.JWT.AccessTokenTTL)
+	assert.True(t, cfg.Cookie.Secure)
+	assert.Equal(t, []string{"https://pos.example.com", "https://admin.example.com"}, cfg.CORS.AllowOrigins)
+	assert.Equal(t, int64(750), cfg.Tax.Rate)
+	prefix, layout := "si", ""
+	assert.Equal(t, DocNoConfig{
+		"SALE":  {Prefix: &prefix, Reset: "YEARLY"},
+		"ORDER": {DateLayout: &layout},
+	}, cfg.DocNo)
+	assert.Equal(t, "admin@pos.example.com", cfg.Seed.AdminEmail)
+	assert.Empty(t, cfg.Seed.AdminPassword)
+	assert.Equal(t, "Asia/Yangon", cfg.Shop.TimeZone.String())
+}
+
+func TestFromEnvReportsEveryMalformedValue(t *testing.T) {
+	_, err := fromEnv(lookup(map[string]string{
+		"API_PORT":       "http",
+		"JWT_ACCESS_TTL": "15",
+		"COOKIE_SECURE":  "maybe",
+		"TAX_RATE":       "ten",
+		"SHOP_TIME_ZONE": "Mars/Olympus",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "API_PORT must be a whole number")
+	assert.Contains(t, err.Error(), "JWT_ACCESS_TTL must be a duration")
+	assert.Contains(t, err.Error(), "COOKIE_SECURE must be true or false")
+	assert.Contains(t, err.Error(), "TAX_RATE must be a percentage")
+	assert.Contains(t, err.Error(), "SHOP_TIME_ZONE must be a time zone")
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() *Config {
+		cfg, err := fromEnv(lookup(map[string]string{"JWT_SECRET": testSecret}))
+		require.NoError(t, err)
+		return cfg
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		want   string
+	}{
+		{name: "valid", modify: func(*Config) {}},
+		{name: "missing secret", modify: func(c *Config) { c.JWT.Secret = "" }, want: "JWT_SECRET"},
+		{name: "placeholder secret", modify: func(c *Config) { c.JWT.Secret = "change-me-to-a-random-string-of-32-chars-or-more" }, want: "JWT_SECRET is still the .env.example placeholder"},
+		{name: "placeholder admin password", modify: func(c *Config) { c.Seed.AdminPassword = "change-me" }, want: "SEED_ADMIN_PASSWORD"},
+		{name: "short secret", modify: func(c *Config) { c.JWT.Secret = "superSecreateKey" }, want: "JWT_SECRET"},
+		{name: "port out of range", modify: func(c *Config) { c.HTTP.Port = 70000 }, want: "API_PORT"},
+		{name: "no shutdown timeout", modify: func(c *Config) { c.HTTP.ShutdownTimeout = 0 }, want: "HTTP_SHUTDOWN_TIMEOUT"},
+		{name: "refresh not longer than access", modify: func(c *Config) { c.JWT.RefreshTokenTTL = c.JWT.AccessTokenTTL }, want: "JWT_REFRESH_TTL"},
+		{name: "more idle than open", modify: func(c *Config) { c.DB.MaxIdleConns = 100 }, want: "DB_MAX_IDLE_CONNS"},
+		{name: "no origins", modify: func(c *Config) { c.CORS.AllowOrigins = nil }, want: "CORS_ALLOW_ORIGINS"},
+		{name: "negative tax", modify: func(c *Config) { c.Tax.Rate = -100 }, want: "TAX_RATE"},
+		{name: "tax over 100%", modify: func(c *Config) { c.Tax.Rate = 100_01 }, want: "TAX_RATE"},
+		{name: "no time zone", modify: func(c *Config) { c.Shop.TimeZone = nil }, want: "SHOP_TIME_ZONE"},
+		{name: "wide number", modify: func(c *Config) { c.DocNo = DocNoConfig{"SALE": {Width: 13}} }, want: "DOCNO_SALE_WIDTH"},
+		{name: "weekly reset", modify: func(c *Config) { c.DocNo = DocNoConfig{"SALE": {Reset: "WEEKLY"}} }, want: "DOCNO_SALE_RESET"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.want == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "api.env")
+	content := strings.Join([]string{
+		"JWT_SECRET=" + testSecret,
+		"API_PORT=6000",
+		"COOKIE_DOMAIN=file.example.com",
+		"DB_DSN=postgres://file",
+	}, "\n")
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+
+	// environment wins over the file, flags win over both
+	t.Setenv("COOKIE_DOMAIN", "env.example.com")
+	t.Setenv("DB_DSN", "postgres://env")
+	for _, key := range []string{"JWT_SECRET", "API_PORT"} {
+		t.Setenv(key, "") // restored, or unset again, after the test
+		os.Unsetenv(key)
+	}
+
+	cfg, err := Load([]string{"-config", file, "-dsn", "postgres://flag"})
+	require.NoError(t, err)
+	assert.Equal(t, 6000, cfg.HTTP.Port)
+	assert.Equal(t, "env.example.com", cfg.Cookie.Domain)
+	assert.Equal(t, "postgres://flag", cfg.DB.DSN)
+}
+
+func TestLoadMissingConfigFile(t *testing.T) {
+	_, err := Load([]string{"-config", filepath.Join(t.TempDir(), "missing.env")})
+	assert.ErrorContains(t, err, "missing.env")
+}
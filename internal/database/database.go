@@ -3,73 +3,64 @@ package database
 import (
 	"fmt"
 	"log"
-	"os"
-	"sync"
 
-	"github.com/joho/godotenv"
-	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/config"
+	"github.com/sankangkin/di-rest-api/internal/database/migrate"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
 type DatabaseInterface interface {
-	NewDB() (*gorm.DB, error)
+	NewDB(cfg *config.Config) (*gorm.DB, error)
 }
 
 var (
-	db     *gorm.DB
-	dbOnce sync.Once
-	Blue   = "\033[34m"
-	Reset  = "\033[0m"
+	Blue  = "\033[34m"
+	Reset = "\033[0m"
 )
 
-func NewDB() (*gorm.DB, error) {
+// NewDB connects to the database and checks that its schema is at the
+// version of the embedded migrations; apply them with `migrate up`.
+func NewDB(cfg *config.Config) (*gorm.DB, error) {
 
-	dbOnce.Do(func() {
-		log.Println(Blue + "------> NewDB constructor is called <-----" + Reset)
-		err := godotenv.Load(".env")
-		if err != nil {
-			log.Fatal(err)
-		}
+	log.Println(Blue + "------> NewDB constructor is called <-----" + Reset)
 
-		Host := os.Getenv("DB_HOST")
-		Port := os.Getenv("POSTGRES_PORT")
-		Password := os.Getenv("POSTGRES_PASSWORD")
-		User := os.Getenv("POSTGRES_USER")
-		DBName := os.Getenv("POSTGRES_DB")
-		SSLMode := os.Getenv("SSLMODE")
+	db, err := Open(cfg)
+	if err != nil {
+		return nil, err
+	}
 
-		var dsn = fmt.Sprintf(
-			"host=%s port=%s password=%s user=%s dbname=%s sslmode=%s",
-			Host, Port, Password, User, DBName, SSLMode)
-
-		db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
-		if err != nil {
-			// return nil, err
-			log.Fatal(err)
-		}
-		err = db.AutoMigrate(
-			&models.Category{},
-			&models.Customer{},
-			&models.Supplier{},
-			&models.Product{},
-			&models.UnitOfMeasure{},
-			&models.UnitConversion{},
-			&models.ProductPrice{},
-			&models.ProductPriceHistory{},
-			&models.ProductStock{},
-			&models.Inventory{},
-			&models.Sale{},
-			&models.SaleDetail{},
-			&models.Purchase{},
-			&models.PurchaseDetail{},
-			&models.ItemTransaction{},
-			&models.User{})
-		if err != nil {
-			log.Fatal(err)
-		}
-		log.Println("Migration done.....")
-	})
+	m, err := migrate.New(db)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := m.Pending()
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) > 0 {
+		return nil, fmt.Errorf("database schema is %d migration(s) behind, next is %04d_%s: run `migrate up` first",
+			len(pending), pending[0].Version, pending[0].Name)
+	}
 	return db, nil
+}
 
+// Open connects to the database with the configured pool, without looking
+// at the schema.
+func Open(cfg *config.Config) (*gorm.DB, error) {
+	// TranslateError turns constraint violations into gorm.ErrDuplicatedKey
+	// and friends, which apperr maps to statuses
+	db, err := gorm.Open(postgres.Open(cfg.DB.DSN), &gorm.Config{TranslateError: true})
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	sqlDB.SetMaxOpenConns(cfg.DB.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.DB.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.DB.ConnMaxLifetime)
+	return db, nil
 }
@@ -0,0 +1,83 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"gorm.io/gorm"
+)
+
+const usage = "usage: migrate up [n] | down [n] | status"
+
+// Command runs the migrate subcommand: "up [n]" applies n pending migrations
+// (default all), "down [n]" reverts the last n (default 1), "status" lists
+// them.
+func Command(db *gorm.DB, args []string, out io.Writer) error {
+	if len(args) == 0 || len(args) > 2 {
+		return errors.New(usage)
+	}
+
+	steps := 0
+	if len(args) == 2 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 {
+			return fmt.Errorf("%s: n must be a positive number, got %q", usage, args[1])
+		}
+		steps = n
+	}
+
+	m, err := New(db)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		done, err := m.Up(steps)
+		for _, migration := range done {
+			fmt.Fprintf(out, "applied  %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err == nil && len(done) == 0 {
+			fmt.Fprintln(out, "schema is up to date")
+		}
+		return err
+	case "down":
+		if steps == 0 {
+			steps = 1
+		}
+		done, err := m.Down(steps)
+		for _, migration := range done {
+			fmt.Fprintf(out, "reverted %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err == nil && len(done) == 0 {
+			fmt.Fprintln(out, "nothing to revert")
+		}
+		return err
+	case "status":
+		if len(args) != 1 {
+			return errors.New(usage)
+		}
+		list, err := m.Status()
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, s := range list {
+			appliedAt := "pending"
+			if s.AppliedAt != nil {
+				appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			if s.Unknown {
+				appliedAt += " (not in this binary)"
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+		}
+		return w.Flush()
+	default:
+		return errors.New(usage)
+	}
+}
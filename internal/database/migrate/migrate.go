@@ -0,0 +1,268 @@
+package migrate
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"gorm.io/gorm"
+)
+
+// migrations holds the versioned schema changes, two files per version:
+// NNNN_name.up.sql and NNNN_name.down.sql. Versions only ever grow; never
+// edit a migration that has been released, add a new one.
+//
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// lockId serializes migration runs across processes (pg_advisory_xact_lock).
+const lockId = 727_001
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// SchemaMigration is one applied migration, a row of schema_migrations.
+type SchemaMigration struct {
+	Version   int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+// Status is a migration with whether, and when, it was applied. Unknown
+// marks versions applied by a newer binary.
+type Status struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	AppliedAt *time.Time `json:"appliedAt"`
+	Unknown   bool       `json:"unknown"`
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// New returns a migrator over the migrations embedded in the binary.
+func New(db *gorm.DB) (*Migrator, error) {
+	list, err := Load(migrations)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: list}, nil
+}
+
+// Load reads the migrations from fsys (files under migrations/), ordered by
+// version. Every version needs both an up and a down file.
+func Load(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(fsys, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, file := range files {
+		m := fileName.FindStringSubmatch(path.Base(file))
+		if m == nil {
+			return nil, fmt.Errorf("migration %s: name must look like 0001_name.up.sql", file)
+		}
+		version, _ := strconv.ParseInt(m[1], 10, 64)
+		body, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != m[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, migration.Name, m[2])
+		}
+		if m[3] == "up" {
+			migration.Up = string(body)
+		} else {
+			migration.Down = string(body)
+		}
+	}
+
+	list := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s needs both an up and a down file", migration.Version, migration.Name)
+		}
+		list = append(list, *migration)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+	return list, nil
+}
+
+// Latest is the version the embedded migrations bring the schema to.
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up applies up to steps pending migrations, all of them when steps is 0,
+// each in its own transaction.
+func (m *Migrator) Up(steps int) ([]Migration, error) {
+	if err := m.ensureTable(); err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, migration := range m.migrations {
+		if steps > 0 && len(done) == steps {
+			break
+		}
+		applied, err := m.run(migration, true)
+		if err != nil {
+			return done, fmt.Errorf("migration %04d_%s up: %w", migration.Version, migration.Name, err)
+		}
+		if applied {
+			log.Println(util.Green + fmt.Sprintf("migrated up %04d_%s", migration.Version, migration.Name) + util.Reset)
+			done = append(done, migration)
+		}
+	}
+	return done, nil
+}
+
+// Down reverts the last steps applied migrations, newest first.
+func (m *Migrator) Down(steps int) ([]Migration, error) {
+	if steps < 1 {
+		return nil, errors.New("down needs the number of migrations to revert")
+	}
+	if err := m.ensureTable(); err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		migration := m.migrations[i]
+		reverted, err := m.run(migration, false)
+		if err != nil {
+			return done, fmt.Errorf("migration %04d_%s down: %w", migration.Version, migration.Name, err)
+		}
+		if reverted {
+			log.Println(util.Yellow + fmt.Sprintf("migrated down %04d_%s", migration.Version, migration.Name) + util.Reset)
+			done = append(done, migration)
+		}
+	}
+	return done, nil
+}
+
+// run applies (up) or reverts (down) one migration under the advisory lock,
+// and reports false when there was nothing to do.
+func (m *Migrator) run(migration Migration, up bool) (bool, error) {
+	changed := false
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", lockId).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&SchemaMigration{}).Where("version = ?", migration.Version).Count(&count).Error; err != nil {
+			return err
+		}
+		if up == (count > 0) {
+			return nil
+		}
+
+		if up {
+			if err := tx.Exec(migration.Up).Error; err != nil {
+				return err
+			}
+			changed = true
+			return tx.Create(&SchemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}).Error
+		}
+		if err := tx.Exec(migration.Down).Error; err != nil {
+			return err
+		}
+		changed = true
+		return tx.Delete(&SchemaMigration{}, "version = ?", migration.Version).Error
+	})
+	return changed, err
+}
+
+// Status lists every known migration and every applied one. It only reads,
+// a database that was never migrated has every migration pending.
+func (m *Migrator) Status() ([]Status, error) {
+	if !m.db.Migrator().HasTable(&SchemaMigration{}) {
+		return status(m.migrations, nil), nil
+	}
+
+	var applied []SchemaMigration
+	if err := m.db.Order("version").Find(&applied).Error; err != nil {
+		return nil, err
+	}
+	return status(m.migrations, applied), nil
+}
+
+// Pending lists the migrations not applied yet.
+func (m *Migrator) Pending() ([]Migration, error) {
+	list, err := m.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, s := range list {
+		if s.AppliedAt == nil {
+			for _, migration := range m.migrations {
+				if migration.Version == s.Version {
+					pending = append(pending, migration)
+				}
+			}
+		}
+	}
+	return pending, nil
+}
+
+func status(migrations []Migration, applied []SchemaMigration) []Status {
+	appliedAt := make(map[int64]SchemaMigration, len(applied))
+	for _, a := range applied {
+		appliedAt[a.Version] = a
+	}
+
+	list := make([]Status, 0, len(migrations))
+	for _, migration := range migrations {
+		s := Status{Version: migration.Version, Name: migration.Name}
+		if a, ok := appliedAt[migration.Version]; ok {
+			at := a.AppliedAt
+			s.AppliedAt = &at
+			delete(appliedAt, migration.Version)
+		}
+		list = append(list, s)
+	}
+	for _, a := range applied {
+		if _, ok := appliedAt[a.Version]; ok {
+			at := a.AppliedAt
+			list = append(list, Status{Version: a.Version, Name: a.Name, AppliedAt: &at, Unknown: true})
+		}
+	}
+	return list
+}
+
+func (m *Migrator) ensureTable() error {
+	return m.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    bigint PRIMARY KEY,
+		name       text NOT NULL,
+		applied_at timestamptz NOT NULL
+	)`).Error
+}
@@ -0,0 +1,92 @@
+package migrate
+
+import (
+	"bytes"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/0002_add_barcode.up.sql":   {Data: []byte("ALTER TABLE products ADD COLUMN barcode text;")},
+		"migrations/0002_add_barcode.down.sql": {Data: []byte("ALTER TABLE products DROP COLUMN barcode;")},
+		"migrations/0001_baseline.up.sql":      {Data: []byte("CREATE TABLE a (id int);")},
+		"migrations/0001_baseline.down.sql":    {Data: []byte("DROP TABLE a;")},
+	}
+
+	list, err := Load(fsys)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, int64(1), list[0].Version)
+	assert.Equal(t, "baseline", list[0].Name)
+	assert.Equal(t, "DROP TABLE a;", list[0].Down)
+	assert.Equal(t, int64(2), list[1].Version)
+	assert.Equal(t, "add_barcode", list[1].Name)
+}
+
+func TestLoadRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+		want string
+	}{
+		{
+			name: "missing down",
+			fsys: fstest.MapFS{"migrations/0001_baseline.up.sql": {Data: []byte("SELECT 1;")}},
+			want: "needs both an up and a down file",
+		},
+		{
+			name: "bad file name",
+			fsys: fstest.MapFS{"migrations/baseline.sql": {Data: []byte("SELECT 1;")}},
+			want: "name must look like",
+		},
+		{
+			name: "two names for one version",
+			fsys: fstest.MapFS{
+				"migrations/0001_baseline.up.sql":  {Data: []byte("SELECT 1;")},
+				"migrations/0001_initial.down.sql": {Data: []byte("SELECT 1;")},
+			},
+			want: "has two names",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(tt.fsys)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	list, err := Load(migrations)
+	require.NoError(t, err)
+	require.NotEmpty(t, list)
+	assert.Equal(t, "baseline", list[0].Name)
+	for i := 1; i < len(list); i++ {
+		assert.Greater(t, list[i].Version, list[i-1].Version)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	at := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	known := []Migration{{Version: 1, Name: "baseline"}, {Version: 2, Name: "add_barcode"}}
+	applied := []SchemaMigration{{Version: 1, Name: "baseline", AppliedAt: at}, {Version: 3, Name: "from_newer_binary", AppliedAt: at}}
+
+	list := status(known, applied)
+	require.Len(t, list, 3)
+	assert.Equal(t, &at, list[0].AppliedAt)
+	assert.Nil(t, list[1].AppliedAt)
+	assert.Equal(t, int64(3), list[2].Version)
+	assert.True(t, list[2].Unknown)
+}
+
+func TestCommandUsage(t *testing.T) {
+	var out bytes.Buffer
+	for _, args := range [][]string{nil, {"sideways"}, {"up", "zero"}, {"down", "-1"}, {"up", "1", "2"}} {
+		assert.Error(t, Command(nil, args, &out), "%v", args)
+	}
+}
@@ -0,0 +1,27 @@
+-- Drops everything the baseline created, children first.
+DROP TABLE IF EXISTS "refresh_tokens";
+DROP TABLE IF EXISTS "users";
+DROP TABLE IF EXISTS "item_transactions";
+DROP TABLE IF EXISTS "document_sequences";
+DROP TABLE IF EXISTS "supplier_payment_allocations";
+DROP TABLE IF EXISTS "supplier_payments";
+DROP TABLE IF EXISTS "purchase_return_details";
+DROP TABLE IF EXISTS "purchase_returns";
+DROP TABLE IF EXISTS "purchase_details";
+DROP TABLE IF EXISTS "purchases";
+DROP TABLE IF EXISTS "payment_allocations";
+DROP TABLE IF EXISTS "payments";
+DROP TABLE IF EXISTS "sale_return_details";
+DROP TABLE IF EXISTS "sale_returns";
+DROP TABLE IF EXISTS "sale_details";
+DROP TABLE IF EXISTS "sales";
+DROP TABLE IF EXISTS "inventories";
+DROP TABLE IF EXISTS "product_stocks";
+DROP TABLE IF EXISTS "product_price_histories";
+DROP TABLE IF EXISTS "product_prices";
+DROP TABLE IF EXISTS "unit_conversions";
+DROP TABLE IF EXISTS "unit_of_measures";
+DROP TABLE IF EXISTS "products";
+DROP TABLE IF EXISTS "suppliers";
+DROP TABLE IF EXISTS "customers";
+DROP TABLE IF EXISTS "categories";
@@ -0,0 +1,393 @@
+-- Baseline: the schema GORM AutoMigrate created before versioned migrations.
+-- Everything is IF NOT EXISTS so databases created by AutoMigrate adopt it as is.
+
+CREATE TABLE IF NOT EXISTS "categories" (
+    "id" bigserial,
+    "created_at" bigint,
+    "updated_at" bigint,
+    "deleted_at" timestamptz,
+    "category_name" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_categories_deleted_at" ON "categories" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "customers" (
+    "id" bigserial,
+    "created_at" bigint,
+    "updated_at" bigint,
+    "deleted_at" timestamptz,
+    "name" text,
+    "address" text,
+    "phone" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_customers_deleted_at" ON "customers" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "suppliers" (
+    "id" bigserial,
+    "created_at" bigint,
+    "updated_at" bigint,
+    "deleted_at" timestamptz,
+    "name" text,
+    "address" text,
+    "phone" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_suppliers_deleted_at" ON "suppliers" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "products" (
+    "id" text,
+    "created_at" bigint,
+    "updated_at" bigint,
+    "deleted_at" timestamptz,
+    "product_name" text,
+    "category_id" bigint,
+    "uom" text,
+    "derive_uom" text,
+    "uom_id" bigint,
+    "derive_uom_id" bigint,
+    "buy_price" bigint,
+    "sell_price_level1" bigint,
+    "derive_unit_price" bigint,
+    "brand_name" text,
+    "is_active" boolean DEFAULT true,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_categories_products" FOREIGN KEY ("category_id") REFERENCES "categories"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_products_deleted_at" ON "products" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "unit_of_measures" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "unit_name" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_unit_of_measures_deleted_at" ON "unit_of_measures" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "unit_conversions" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "description" varchar(20),
+    "product_id" text,
+    "base_unit" text,
+    "derive_unit" text,
+    "base_unit_id" bigint,
+    "derive_unit_id" bigint,
+    "factor" bigint,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_products_unit_conversion" FOREIGN KEY ("product_id") REFERENCES "products"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_unit_of_measures_unit_conversion" FOREIGN KEY ("base_unit_id") REFERENCES "unit_of_measures"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_unit_conversions_deleted_at" ON "unit_conversions" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "product_prices" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "product_id" text,
+    "unit_id" bigint,
+    "price_type" text,
+    "unit_price" bigint,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_product_unit_type" ON "product_prices" ("product_id","unit_id","price_type");
+CREATE INDEX IF NOT EXISTS "idx_product_prices_deleted_at" ON "product_prices" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "product_price_histories" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "product_id" text,
+    "unit_id" bigint,
+    "price_type" text,
+    "unit_price" bigint,
+    "effective_date" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_product_price_histories_deleted_at" ON "product_price_histories" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "product_stocks" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "product_id" varchar(20),
+    "base_unit_id" bigint,
+    "derive_unit_id" bigint,
+    "base_qty" bigint,
+    "derived_qty" bigint,
+    "reorder_lvl" bigint DEFAULT 1,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_product_stocks_deleted_at" ON "product_stocks" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "inventories" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "out_qty" bigint,
+    "in_qty" bigint,
+    "product_id" text,
+    "remark" text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_products_inventories" FOREIGN KEY ("product_id") REFERENCES "products"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_inventories_deleted_at" ON "inventories" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "sales" (
+    "id" text,
+    "created_at" bigint,
+    "updated_at" bigint,
+    "deleted_at" timestamptz,
+    "customer_id" bigint,
+    "discount" bigint,
+    "total" bigint,
+    "tax_rate" bigint,
+    "tax" bigint,
+    "grand_total" bigint,
+    "returned_total" bigint,
+    "payment_type" varchar(20) DEFAULT 'CASH',
+    "paid_amount" bigint,
+    "status" varchar(20) DEFAULT 'ACTIVE',
+    "remark" text,
+    "sale_date" text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_customers_sales" FOREIGN KEY ("customer_id") REFERENCES "customers"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_sales_deleted_at" ON "sales" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "sale_details" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "product_id" text,
+    "product_name" text,
+    "qty" bigint,
+    "derived_qty" bigint,
+    "returned_qty" bigint,
+    "uom" text,
+    "price" bigint,
+    "discount" bigint,
+    "total" bigint,
+    "sale_id" text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_products_sale_detail" FOREIGN KEY ("product_id") REFERENCES "products"("id"),
+    CONSTRAINT "fk_sales_sale_details" FOREIGN KEY ("sale_id") REFERENCES "sales"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_sale_details_deleted_at" ON "sale_details" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "sale_returns" (
+    "id" bigserial,
+    "created_at" bigint,
+    "updated_at" bigint,
+    "deleted_at" timestamptz,
+    "sale_id" text,
+    "is_void" boolean,
+    "total" bigint,
+    "remark" text,
+    "return_date" text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_sales_sale_returns" FOREIGN KEY ("sale_id") REFERENCES "sales"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_sale_returns_deleted_at" ON "sale_returns" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "sale_return_details" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "sale_return_id" bigint,
+    "sale_detail_id" bigint,
+    "product_id" text,
+    "qty" bigint,
+    "uom" text,
+    "price" bigint,
+    "total" bigint,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_sale_returns_sale_return_details" FOREIGN KEY ("sale_return_id") REFERENCES "sale_returns"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_sale_return_details_deleted_at" ON "sale_return_details" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "payments" (
+    "id" bigserial,
+    "customer_id" bigint,
+    "amount" bigint,
+    "method" varchar(20),
+    "payment_date" text,
+    "reference_no" text,
+    "remark" text,
+    "created_at" bigint,
+    "updated_at" bigint,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_customers_payments" FOREIGN KEY ("customer_id") REFERENCES "customers"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS "payment_allocations" (
+    "id" bigserial,
+    "payment_id" bigint,
+    "sale_id" varchar(30),
+    "amount" bigint,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_payments_payment_allocations" FOREIGN KEY ("payment_id") REFERENCES "payments"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_payment_allocations_sale_id" ON "payment_allocations" ("sale_id");
+
+CREATE TABLE IF NOT EXISTS "purchases" (
+    "id" text,
+    "created_at" bigint,
+    "updated_at" bigint,
+    "deleted_at" timestamptz,
+    "supplier_id" bigint,
+    "discount" bigint,
+    "total" bigint,
+    "tax_rate" bigint,
+    "tax" bigint,
+    "grand_total" bigint,
+    "returned_total" bigint,
+    "remark" text,
+    "purchase_date" text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_suppliers_purchases" FOREIGN KEY ("supplier_id") REFERENCES "suppliers"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_purchases_deleted_at" ON "purchases" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "purchase_details" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "product_id" text,
+    "product_name" text,
+    "qty" bigint,
+    "returned_qty" bigint,
+    "price" bigint,
+    "unit_name" text,
+    "discount" bigint,
+    "total" bigint,
+    "purchase_id" text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_products_purchase_detail" FOREIGN KEY ("product_id") REFERENCES "products"("id"),
+    CONSTRAINT "fk_purchases_purchase_details" FOREIGN KEY ("purchase_id") REFERENCES "purchases"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_purchase_details_deleted_at" ON "purchase_details" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "purchase_returns" (
+    "id" bigserial,
+    "created_at" bigint,
+    "updated_at" bigint,
+    "deleted_at" timestamptz,
+    "purchase_id" text,
+    "supplier_id" bigint,
+    "total" bigint,
+    "remark" text,
+    "return_date" text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_purchases_purchase_returns" FOREIGN KEY ("purchase_id") REFERENCES "purchases"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_purchase_returns_deleted_at" ON "purchase_returns" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "purchase_return_details" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "purchase_return_id" bigint,
+    "purchase_detail_id" bigint,
+    "product_id" varchar(20),
+    "qty" bigint,
+    "unit_name" text,
+    "price" bigint,
+    "total" bigint,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_purchase_returns_purchase_return_details" FOREIGN KEY ("purchase_return_id") REFERENCES "purchase_returns"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_purchase_return_details_deleted_at" ON "purchase_return_details" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "supplier_payments" (
+    "id" bigserial,
+    "supplier_id" bigint,
+    "amount" bigint,
+    "method" varchar(20),
+    "payment_date" text,
+    "reference_no" text,
+    "remark" text,
+    "created_at" bigint,
+    "updated_at" bigint,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_suppliers_payments" FOREIGN KEY ("supplier_id") REFERENCES "suppliers"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS "supplier_payment_allocations" (
+    "id" bigserial,
+    "supplier_payment_id" bigint,
+    "purchase_id" varchar(30),
+    "amount" bigint,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_supplier_payments_supplier_payment_allocations" FOREIGN KEY ("supplier_payment_id") REFERENCES "supplier_payments"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_supplier_payment_allocations_purchase_id" ON "supplier_payment_allocations" ("purchase_id");
+
+CREATE TABLE IF NOT EXISTS "document_sequences" (
+    "id" bigserial,
+    "doc_type" varchar(20),
+    "period" varchar(10),
+    "last_no" bigint,
+    "updated_at" bigint,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_doc_type_period" ON "document_sequences" ("doc_type","period");
+
+CREATE TABLE IF NOT EXISTS "item_transactions" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "product_id" text,
+    "reference_no" text,
+    "in_qty" bigint,
+    "out_qty" bigint,
+    "uom" text,
+    "tran_type" text,
+    "remark" text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_products_item_transactions" FOREIGN KEY ("product_id") REFERENCES "products"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_item_transactions_deleted_at" ON "item_transactions" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "users" (
+    "id" bigserial,
+    "created_at" bigint,
+    "updated_at" bigint,
+    "deleted_at" timestamptz,
+    "email" text,
+    "user_name" text,
+    "password" text,
+    "is_admin" boolean,
+    "role" text DEFAULT 'user',
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_users_deleted_at" ON "users" ("deleted_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_email" ON "users" ("email");
+
+CREATE TABLE IF NOT EXISTS "refresh_tokens" (
+    "id" bigserial,
+    "user_id" bigint,
+    "family_id" varchar(32),
+    "token_hash" varchar(64),
+    "expires_at" bigint,
+    "used_at" bigint,
+    "revoked_at" bigint,
+    "created_at" bigint,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_refresh_tokens_token_hash" ON "refresh_tokens" ("token_hash");
+CREATE INDEX IF NOT EXISTS "idx_refresh_tokens_family_id" ON "refresh_tokens" ("family_id");
+CREATE INDEX IF NOT EXISTS "idx_refresh_tokens_user_id" ON "refresh_tokens" ("user_id");
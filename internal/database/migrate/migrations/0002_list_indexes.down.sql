@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "idx_item_transactions_created_at";
+DROP INDEX IF EXISTS "idx_item_transactions_product_id";
+DROP INDEX IF EXISTS "idx_purchases_purchase_date";
+DROP INDEX IF EXISTS "idx_purchases_supplier_id";
+DROP INDEX IF EXISTS "idx_sales_sale_date";
+DROP INDEX IF EXISTS "idx_sales_customer_id";
+DROP INDEX IF EXISTS "idx_products_category_id";
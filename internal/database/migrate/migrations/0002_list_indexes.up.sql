@@ -0,0 +1,8 @@
+-- Indexes behind the filters and default sorts of the list endpoints.
+CREATE INDEX IF NOT EXISTS "idx_products_category_id" ON "products" ("category_id");
+CREATE INDEX IF NOT EXISTS "idx_sales_customer_id" ON "sales" ("customer_id");
+CREATE INDEX IF NOT EXISTS "idx_sales_sale_date" ON "sales" ("sale_date");
+CREATE INDEX IF NOT EXISTS "idx_purchases_supplier_id" ON "purchases" ("supplier_id");
+CREATE INDEX IF NOT EXISTS "idx_purchases_purchase_date" ON "purchases" ("purchase_date");
+CREATE INDEX IF NOT EXISTS "idx_item_transactions_product_id" ON "item_transactions" ("product_id", "id");
+CREATE INDEX IF NOT EXISTS "idx_item_transactions_created_at" ON "item_transactions" ("created_at");
@@ -0,0 +1,5 @@
+-- Keeps pg_trgm and the normalized names, other objects may rely on them.
+DROP INDEX IF EXISTS "idx_categories_category_name_trgm";
+DROP INDEX IF EXISTS "idx_products_brand_name_trgm";
+DROP INDEX IF EXISTS "idx_products_product_name_trgm";
+DROP INDEX IF EXISTS "idx_products_id_trgm";
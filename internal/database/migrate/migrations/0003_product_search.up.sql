@@ -0,0 +1,14 @@
+-- Product search: trigram indexes serve both the ILIKE substring match and
+-- the fuzzy <% match of GET /api/products/search.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS "idx_products_id_trgm" ON "products" USING gin ("id" gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS "idx_products_product_name_trgm" ON "products" USING gin ("product_name" gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS "idx_products_brand_name_trgm" ON "products" USING gin ("brand_name" gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS "idx_categories_category_name_trgm" ON "categories" USING gin ("category_name" gin_trgm_ops);
+
+-- The api now stores names in Unicode NFC; bring older rows in line so they
+-- match normalized search terms.
+UPDATE "products" SET "product_name" = normalize("product_name", NFC) WHERE "product_name" IS NOT NFC NORMALIZED;
+UPDATE "products" SET "brand_name" = normalize("brand_name", NFC) WHERE "brand_name" IS NOT NFC NORMALIZED;
+UPDATE "categories" SET "category_name" = normalize("category_name", NFC) WHERE "category_name" IS NOT NFC NORMALIZED;
@@ -0,0 +1,2 @@
+ALTER TABLE "sale_details" DROP COLUMN IF EXISTS "barcode";
+DROP TABLE IF EXISTS "product_barcodes";
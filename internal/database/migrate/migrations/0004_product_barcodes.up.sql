@@ -0,0 +1,21 @@
+-- Barcodes per product and unit, looked up at the counter by
+-- GET /api/products/by-barcode/:code. Codes are unique across all products.
+CREATE TABLE IF NOT EXISTS "product_barcodes" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "code" varchar(64) NOT NULL,
+    "kind" varchar(20) DEFAULT 'INTERNAL',
+    "product_id" text NOT NULL,
+    "unit_id" bigint NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_product_barcodes_product" FOREIGN KEY ("product_id") REFERENCES "products"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_product_barcodes_unit" FOREIGN KEY ("unit_id") REFERENCES "unit_of_measures"("id") ON UPDATE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_product_barcodes_code" ON "product_barcodes" ("code");
+CREATE INDEX IF NOT EXISTS "idx_product_barcodes_product_id" ON "product_barcodes" ("product_id");
+CREATE INDEX IF NOT EXISTS "idx_product_barcodes_deleted_at" ON "product_barcodes" ("deleted_at");
+
+-- The code a sale line was scanned from.
+ALTER TABLE "sale_details" ADD COLUMN IF NOT EXISTS "barcode" varchar(64);
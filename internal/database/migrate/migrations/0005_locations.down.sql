@@ -0,0 +1,23 @@
+-- Folds the stock of every location back into one row per product.
+DROP TABLE IF EXISTS "stock_transfer_details";
+DROP TABLE IF EXISTS "stock_transfers";
+
+ALTER TABLE "inventories" DROP COLUMN IF EXISTS "location_id";
+ALTER TABLE "purchases" DROP COLUMN IF EXISTS "location_id";
+ALTER TABLE "sales" DROP COLUMN IF EXISTS "location_id";
+DROP INDEX IF EXISTS "idx_item_transactions_location_id";
+ALTER TABLE "item_transactions" DROP COLUMN IF EXISTS "location_id";
+
+DROP VIEW IF EXISTS "product_stock_totals";
+
+DROP INDEX IF EXISTS "idx_product_stocks_product_location";
+UPDATE "product_stocks" s SET "base_qty" = t."base_qty", "derived_qty" = t."derived_qty"
+FROM (
+    SELECT "product_id", SUM("base_qty") AS "base_qty", SUM("derived_qty") AS "derived_qty", MIN("id") AS "id"
+    FROM "product_stocks" GROUP BY "product_id"
+) t
+WHERE s."id" = t."id";
+DELETE FROM "product_stocks" a USING "product_stocks" b WHERE a."product_id" = b."product_id" AND a."id" > b."id";
+ALTER TABLE "product_stocks" DROP COLUMN IF EXISTS "location_id";
+
+DROP TABLE IF EXISTS "locations";
@@ -0,0 +1,83 @@
+-- Stock per location. Everything on hand so far was at the one place there
+-- was, which becomes the default location MAIN.
+CREATE TABLE IF NOT EXISTS "locations" (
+    "id" bigserial,
+    "created_at" bigint,
+    "updated_at" bigint,
+    "deleted_at" timestamptz,
+    "code" varchar(20) NOT NULL,
+    "name" text,
+    "is_default" boolean DEFAULT false,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_locations_code" ON "locations" ("code");
+-- at most one default location
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_locations_default" ON "locations" ("is_default") WHERE "is_default" AND "deleted_at" IS NULL;
+CREATE INDEX IF NOT EXISTS "idx_locations_deleted_at" ON "locations" ("deleted_at");
+
+INSERT INTO "locations" ("created_at", "updated_at", "code", "name", "is_default")
+VALUES (extract(epoch from now())::bigint, extract(epoch from now())::bigint, 'MAIN', 'Main store', true);
+
+-- The stock engine only ever moved the first live row of a product, extra
+-- rows never held stock; drop them before keying stock by product and location.
+DELETE FROM "product_stocks" WHERE "deleted_at" IS NOT NULL;
+DELETE FROM "product_stocks" a USING "product_stocks" b WHERE a."product_id" = b."product_id" AND a."id" > b."id";
+ALTER TABLE "product_stocks" ADD COLUMN IF NOT EXISTS "location_id" bigint;
+UPDATE "product_stocks" SET "location_id" = (SELECT "id" FROM "locations" WHERE "code" = 'MAIN');
+ALTER TABLE "product_stocks" ALTER COLUMN "location_id" SET NOT NULL;
+ALTER TABLE "product_stocks" ADD CONSTRAINT "fk_locations_product_stocks" FOREIGN KEY ("location_id") REFERENCES "locations"("id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_product_stocks_product_location" ON "product_stocks" ("product_id","location_id");
+
+-- Stock of a product over all locations.
+CREATE VIEW "product_stock_totals" AS
+SELECT MIN("id") AS "id",
+       "product_id",
+       SUM("base_qty")::bigint AS "base_qty",
+       SUM("derived_qty")::bigint AS "derived_qty",
+       SUM("reorder_lvl")::bigint AS "reorder_lvl"
+FROM "product_stocks"
+WHERE "deleted_at" IS NULL
+GROUP BY "product_id";
+
+-- The ledger and the documents that move stock name their location.
+ALTER TABLE "item_transactions" ADD COLUMN IF NOT EXISTS "location_id" bigint;
+UPDATE "item_transactions" SET "location_id" = (SELECT "id" FROM "locations" WHERE "code" = 'MAIN');
+ALTER TABLE "item_transactions" ADD CONSTRAINT "fk_locations_item_transactions" FOREIGN KEY ("location_id") REFERENCES "locations"("id");
+CREATE INDEX IF NOT EXISTS "idx_item_transactions_location_id" ON "item_transactions" ("location_id");
+
+ALTER TABLE "sales" ADD COLUMN IF NOT EXISTS "location_id" bigint;
+UPDATE "sales" SET "location_id" = (SELECT "id" FROM "locations" WHERE "code" = 'MAIN');
+ALTER TABLE "purchases" ADD COLUMN IF NOT EXISTS "location_id" bigint;
+UPDATE "purchases" SET "location_id" = (SELECT "id" FROM "locations" WHERE "code" = 'MAIN');
+ALTER TABLE "inventories" ADD COLUMN IF NOT EXISTS "location_id" bigint;
+UPDATE "inventories" SET "location_id" = (SELECT "id" FROM "locations" WHERE "code" = 'MAIN');
+
+CREATE TABLE IF NOT EXISTS "stock_transfers" (
+    "id" text,
+    "created_at" bigint,
+    "updated_at" bigint,
+    "deleted_at" timestamptz,
+    "from_location_id" bigint NOT NULL,
+    "to_location_id" bigint NOT NULL,
+    "remark" text,
+    "transfer_date" text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_stock_transfers_from_location" FOREIGN KEY ("from_location_id") REFERENCES "locations"("id"),
+    CONSTRAINT "fk_stock_transfers_to_location" FOREIGN KEY ("to_location_id") REFERENCES "locations"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_stock_transfers_deleted_at" ON "stock_transfers" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_stock_transfers_transfer_date" ON "stock_transfers" ("transfer_date");
+
+CREATE TABLE IF NOT EXISTS "stock_transfer_details" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "stock_transfer_id" text,
+    "product_id" varchar(20),
+    "qty" bigint,
+    "uom" text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_stock_transfers_stock_transfer_details" FOREIGN KEY ("stock_transfer_id") REFERENCES "stock_transfers"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_stock_transfer_details_deleted_at" ON "stock_transfer_details" ("deleted_at");
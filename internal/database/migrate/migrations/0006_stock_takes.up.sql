@@ -0,0 +1,55 @@
+-- Stock-take sessions: a physical count of one location, its expected
+-- snapshot per product and the counts entered by each counter.
+CREATE TABLE IF NOT EXISTS "stock_takes" (
+    "id" text,
+    "created_at" bigint,
+    "updated_at" bigint,
+    "deleted_at" timestamptz,
+    "location_id" bigint NOT NULL,
+    "status" varchar(10) DEFAULT 'OPEN',
+    "remark" text,
+    "posted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_stock_takes_location" FOREIGN KEY ("location_id") REFERENCES "locations"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_stock_takes_deleted_at" ON "stock_takes" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_stock_takes_location_id" ON "stock_takes" ("location_id");
+CREATE INDEX IF NOT EXISTS "idx_stock_takes_status" ON "stock_takes" ("status");
+-- one open count per location, two would post the same variance twice
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_stock_takes_open" ON "stock_takes" ("location_id") WHERE "status" = 'OPEN' AND "deleted_at" IS NULL;
+
+CREATE TABLE IF NOT EXISTS "stock_take_lines" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "stock_take_id" text,
+    "product_id" varchar(20),
+    "factor" bigint,
+    "buy_price" bigint,
+    "expected_base_qty" bigint,
+    "expected_derived_qty" bigint,
+    "counted_base_qty" bigint,
+    "counted_derived_qty" bigint,
+    "counted" boolean,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_stock_takes_stock_take_lines" FOREIGN KEY ("stock_take_id") REFERENCES "stock_takes"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_stock_take_lines_product" FOREIGN KEY ("product_id") REFERENCES "products"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_stock_take_lines_deleted_at" ON "stock_take_lines" ("deleted_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_stock_take_lines_product" ON "stock_take_lines" ("stock_take_id", "product_id");
+
+CREATE TABLE IF NOT EXISTS "stock_take_counts" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "stock_take_line_id" bigint,
+    "counted_by" text,
+    "base_qty" bigint,
+    "derived_qty" bigint,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_stock_take_lines_stock_take_counts" FOREIGN KEY ("stock_take_line_id") REFERENCES "stock_take_lines"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_stock_take_counts_deleted_at" ON "stock_take_counts" ("deleted_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_stock_take_counts_counter" ON "stock_take_counts" ("stock_take_line_id", "counted_by");
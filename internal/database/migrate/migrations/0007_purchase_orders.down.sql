@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "idx_item_transactions_type_created_at";
+DROP TABLE IF EXISTS "purchase_order_lines";
+DROP TABLE IF EXISTS "purchase_orders";
@@ -0,0 +1,42 @@
+-- Purchase orders: what is to be bought from a supplier, before any goods
+-- arrive.
+CREATE TABLE IF NOT EXISTS "purchase_orders" (
+    "id" text,
+    "created_at" bigint,
+    "updated_at" bigint,
+    "deleted_at" timestamptz,
+    "supplier_id" bigint NOT NULL,
+    "location_id" bigint NOT NULL,
+    "status" varchar(20) DEFAULT 'DRAFT',
+    "total" bigint,
+    "remark" text,
+    "order_date" text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_purchase_orders_supplier" FOREIGN KEY ("supplier_id") REFERENCES "suppliers"("id"),
+    CONSTRAINT "fk_purchase_orders_location" FOREIGN KEY ("location_id") REFERENCES "locations"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_purchase_orders_deleted_at" ON "purchase_orders" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_purchase_orders_status" ON "purchase_orders" ("status");
+CREATE INDEX IF NOT EXISTS "idx_purchase_orders_supplier_id" ON "purchase_orders" ("supplier_id");
+
+CREATE TABLE IF NOT EXISTS "purchase_order_lines" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "purchase_order_id" text,
+    "product_id" varchar(20),
+    "product_name" text,
+    "qty" bigint,
+    "unit_name" text,
+    "price" bigint,
+    "total" bigint,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_purchase_orders_purchase_order_lines" FOREIGN KEY ("purchase_order_id") REFERENCES "purchase_orders"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_purchase_order_lines_product" FOREIGN KEY ("product_id") REFERENCES "products"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_purchase_order_lines_deleted_at" ON "purchase_order_lines" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_purchase_order_lines_purchase_order_id" ON "purchase_order_lines" ("purchase_order_id");
+
+-- the recent-sales window of the reorder suggestions
+CREATE INDEX IF NOT EXISTS "idx_item_transactions_type_created_at" ON "item_transactions" ("tran_type", "created_at");
@@ -0,0 +1,13 @@
+ALTER TABLE "purchase_details" DROP COLUMN IF EXISTS "price_mismatch";
+ALTER TABLE "purchase_details" DROP COLUMN IF EXISTS "qty_mismatch";
+ALTER TABLE "purchase_details" DROP COLUMN IF EXISTS "received_price";
+ALTER TABLE "purchase_details" DROP COLUMN IF EXISTS "received_qty";
+
+DROP INDEX IF EXISTS "idx_purchases_match_status";
+ALTER TABLE "purchases" DROP COLUMN IF EXISTS "match_note";
+ALTER TABLE "purchases" DROP COLUMN IF EXISTS "match_status";
+
+DROP TABLE IF EXISTS "goods_receipt_lines";
+DROP TABLE IF EXISTS "goods_receipts";
+
+ALTER TABLE "purchase_order_lines" DROP COLUMN IF EXISTS "received_qty";
@@ -0,0 +1,55 @@
+-- Goods receipts: only they move purchased goods into stock. A purchase, the
+-- supplier's invoice, is matched against the receipts it bills.
+ALTER TABLE "purchase_order_lines" ADD COLUMN IF NOT EXISTS "received_qty" bigint NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS "goods_receipts" (
+    "id" text,
+    "created_at" bigint,
+    "updated_at" bigint,
+    "deleted_at" timestamptz,
+    "purchase_order_id" text NOT NULL,
+    "supplier_id" bigint NOT NULL,
+    "location_id" bigint NOT NULL,
+    "purchase_id" text,
+    "remark" text,
+    "receipt_date" text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_goods_receipts_purchase_order" FOREIGN KEY ("purchase_order_id") REFERENCES "purchase_orders"("id"),
+    CONSTRAINT "fk_goods_receipts_supplier" FOREIGN KEY ("supplier_id") REFERENCES "suppliers"("id"),
+    CONSTRAINT "fk_goods_receipts_location" FOREIGN KEY ("location_id") REFERENCES "locations"("id"),
+    CONSTRAINT "fk_purchases_goods_receipts" FOREIGN KEY ("purchase_id") REFERENCES "purchases"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_goods_receipts_deleted_at" ON "goods_receipts" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_goods_receipts_purchase_order_id" ON "goods_receipts" ("purchase_order_id");
+CREATE INDEX IF NOT EXISTS "idx_goods_receipts_purchase_id" ON "goods_receipts" ("purchase_id");
+CREATE INDEX IF NOT EXISTS "idx_goods_receipts_receipt_date" ON "goods_receipts" ("receipt_date");
+
+CREATE TABLE IF NOT EXISTS "goods_receipt_lines" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "goods_receipt_id" text,
+    "purchase_order_line_id" bigint,
+    "product_id" varchar(20),
+    "qty" bigint,
+    "unit_name" text,
+    "price" bigint,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_goods_receipts_goods_receipt_lines" FOREIGN KEY ("goods_receipt_id") REFERENCES "goods_receipts"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_goods_receipt_lines_purchase_order_line" FOREIGN KEY ("purchase_order_line_id") REFERENCES "purchase_order_lines"("id"),
+    CONSTRAINT "fk_goods_receipt_lines_product" FOREIGN KEY ("product_id") REFERENCES "products"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_goods_receipt_lines_deleted_at" ON "goods_receipt_lines" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_goods_receipt_lines_goods_receipt_id" ON "goods_receipt_lines" ("goods_receipt_id");
+
+-- Purchases posted before this received their goods themselves and are left
+-- unmatched, match_status ''.
+ALTER TABLE "purchases" ADD COLUMN IF NOT EXISTS "match_status" varchar(20) NOT NULL DEFAULT '';
+ALTER TABLE "purchases" ADD COLUMN IF NOT EXISTS "match_note" text NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS "idx_purchases_match_status" ON "purchases" ("match_status");
+
+ALTER TABLE "purchase_details" ADD COLUMN IF NOT EXISTS "received_qty" bigint NOT NULL DEFAULT 0;
+ALTER TABLE "purchase_details" ADD COLUMN IF NOT EXISTS "received_price" bigint NOT NULL DEFAULT 0;
+ALTER TABLE "purchase_details" ADD COLUMN IF NOT EXISTS "qty_mismatch" boolean NOT NULL DEFAULT false;
+ALTER TABLE "purchase_details" ADD COLUMN IF NOT EXISTS "price_mismatch" boolean NOT NULL DEFAULT false;
@@ -0,0 +1,2 @@
+ALTER TABLE "item_transactions" DROP COLUMN IF EXISTS "unit_cost";
+ALTER TABLE "products" DROP COLUMN IF EXISTS "avg_cost";
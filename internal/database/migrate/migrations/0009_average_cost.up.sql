@@ -0,0 +1,7 @@
+-- Moving-average cost. Products start at their buy price, and the ledger rows
+-- booked so far are costed at it as well, being the best there is.
+ALTER TABLE "products" ADD COLUMN IF NOT EXISTS "avg_cost" bigint NOT NULL DEFAULT 0;
+UPDATE "products" SET "avg_cost" = "buy_price";
+
+ALTER TABLE "item_transactions" ADD COLUMN IF NOT EXISTS "unit_cost" bigint NOT NULL DEFAULT 0;
+UPDATE "item_transactions" it SET "unit_cost" = p."buy_price" FROM "products" p WHERE p."id" = it."product_id";
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "idx_item_transactions_reference_no";
@@ -0,0 +1,3 @@
+-- The profit reports find the ledger row of each sale line by its reference,
+-- "<saleId>-<saleDetailId>".
+CREATE INDEX IF NOT EXISTS "idx_item_transactions_reference_no" ON "item_transactions" ("reference_no");
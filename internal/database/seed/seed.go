@@ -3,21 +3,27 @@ package main
 import (
 	"fmt"
 	"log"
+	"os"
 
-	"github.com/joho/godotenv"
+	"github.com/sankangkin/di-rest-api/internal/config"
 	"github.com/sankangkin/di-rest-api/internal/database"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 func main() {
 
-	err := godotenv.Load(".env")
+	cfg, err := config.Load(os.Args[1:])
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	database.NewDB()
-	load()
+	db, err := database.NewDB(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	load(db, cfg.Seed)
 }
 
 var unitOfMeasures = []models.UnitOfMeasure{
@@ -275,10 +281,9 @@ var suppliers = []models.Supplier{
 	},
 }
 
-func load() {
+func load(db *gorm.DB, seed config.SeedConfig) {
 
 	fmt.Println("......Seeding data ....")
-	db, _ := database.NewDB()
 
 	fmt.Println("Seeding categories data ....")
 	db.Create(&categories)
@@ -295,5 +300,18 @@ func load() {
 	fmt.Println("Seeding unit of measures data ....")
 	db.Create(&unitOfMeasures)
 
+	// registering users is admin only, so the first admin comes from the seed
+	email, password := seed.AdminEmail, seed.AdminPassword
+	if email != "" && password != "" {
+		fmt.Println("Seeding admin user ....")
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		if err != nil {
+			log.Fatal(err)
+		}
+		db.Create(&models.User{Email: email, UserName: "admin", Password: string(hash), IsAdmin: true, Role: models.ADMIN})
+	} else {
+		fmt.Println("SEED_ADMIN_EMAIL / SEED_ADMIN_PASSWORD not set, no admin user seeded")
+	}
+
 	fmt.Println("..... Seeding completed .....")
 }
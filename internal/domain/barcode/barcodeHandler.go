@@ -0,0 +1,137 @@
+package barcode
+
+import (
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+)
+
+type BarcodeHandler struct {
+	svc BarcodeServiceInterface
+}
+
+func NewBarcodeHandler(svc BarcodeServiceInterface) *BarcodeHandler {
+	log.Println(util.Yellow + "BarcodeHandler constructor is called" + util.Reset)
+	return &BarcodeHandler{svc: svc}
+}
+
+// GetByBarcode godoc
+//
+//	@Summary		Look up a scanned barcode
+//	@Description	Returns the product, the unit and the current sell price of the barcode
+//	@Tags			Barcodes
+//	@Accept			json
+//	@Produce		json
+//	@Param			code				path		string	true	"barcode"
+//	@Success		200					{object}	BarcodeLookupDTO
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		404					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
+//	@Router			/api/products/by-barcode/{code} [get]
+//	@Security		Bearer
+func (h *BarcodeHandler) GetByBarcode(c *fiber.Ctx) error {
+	result, err := h.svc.Lookup(c.Params("code"))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "Record found",
+		"data":    result,
+	})
+}
+
+// GetProductBarcodes godoc
+//
+//	@Summary		Fetch the barcodes of a product
+//	@Description	Fetch the barcodes of a product, of all its units
+//	@Tags			Barcodes
+//	@Accept			json
+//	@Produce		json
+//	@Param			id					path		string	true	"product Id"
+//	@Success		200					{array}		BarcodeResponseDTO
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		404					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
+//	@Router			/api/products/{id}/barcodes [get]
+//	@Security		Bearer
+func (h *BarcodeHandler) GetProductBarcodes(c *fiber.Ctx) error {
+	barcodes, err := h.svc.GetByProductId(c.Params("id"))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "All barcodes of the product",
+		"data":    barcodes,
+	})
+}
+
+// CreateBarcode godoc
+//
+//	@Summary		Add a barcode to a product
+//	@Description	Add an EAN, UPC or INTERNAL barcode to the base or derived unit of a product. Codes are unique.
+//	@Tags			Barcodes
+//	@Accept			json
+//	@Produce		json
+//	@Param			id					path		string					true	"product Id"
+//	@Param			barcode				body		CreateBarcodeRequestDTO	true	"Barcode Input Data"
+//	@Success		201					{object}	models.ProductBarcode
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		403					{object}	apperr.Response
+//	@Failure		404					{object}	apperr.Response
+//	@Failure		409					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
+//	@Router			/api/products/{id}/barcodes [post]
+//	@Security		Bearer
+func (h *BarcodeHandler) CreateBarcode(c *fiber.Ctx) error {
+	input := new(CreateBarcodeRequestDTO)
+	if err := c.BodyParser(input); err != nil {
+		return apperr.Validation("invalid JSON format")
+	}
+
+	barcode, err := h.svc.Create(&models.ProductBarcode{
+		Code:      input.Code,
+		Kind:      input.Kind,
+		ProductId: c.Params("id"),
+		UnitId:    input.UnitId,
+	})
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "new BARCODE has been created successfully",
+		"data":    barcode,
+	})
+}
+
+// DeleteBarcode godoc
+//
+//	@Summary		Remove a barcode from a product
+//	@Description	Remove a barcode from a product; the code can be given out again
+//	@Tags			Barcodes
+//	@Accept			json
+//	@Produce		json
+//	@Param			id					path		string	true	"product Id"
+//	@Param			code				path		string	true	"barcode"
+//	@Success		200					{object}	map[string]interface{}
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		403					{object}	apperr.Response
+//	@Failure		404					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
+//	@Router			/api/products/{id}/barcodes/{code} [delete]
+//	@Security		Bearer
+func (h *BarcodeHandler) DeleteBarcode(c *fiber.Ctx) error {
+	if err := h.svc.Delete(c.Params("id"), c.Params("code")); err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "Delete successfully",
+	})
+}
@@ -0,0 +1,103 @@
+package barcode
+
+import (
+	"log"
+
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/pricing"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"gorm.io/gorm"
+)
+
+type BarcodeRepositoryInterface interface {
+	Create(barcode *models.ProductBarcode) (*models.ProductBarcode, error)
+	GetByCode(code string) (*models.ProductBarcode, error)
+	GetByProductId(productId string) ([]BarcodeResponseDTO, error)
+	Delete(productId string, code string) error
+	GetProduct(productId string) (*models.Product, error)
+	GetSellPrice(productId string, unitId uint) (*models.ProductPrice, error)
+}
+
+type BarcodeRepository struct {
+	db *gorm.DB
+}
+
+//! constructor must be return the Interface, NOT struct, if not, google wire generate fail
+
+func NewBarcodeRepository(db *gorm.DB) BarcodeRepositoryInterface {
+	log.Println(util.Yellow + "BarcodeRepository constructor is called" + util.Reset)
+	return &BarcodeRepository{db: db}
+}
+
+func (r *BarcodeRepository) Create(barcode *models.ProductBarcode) (*models.ProductBarcode, error) {
+	if err := r.db.Create(barcode).Error; err != nil {
+		return nil, err
+	}
+	return barcode, nil
+}
+
+// GetByCode returns the barcode with its product and unit.
+func (r *BarcodeRepository) GetByCode(code string) (*models.ProductBarcode, error) {
+	var barcode models.ProductBarcode
+	err := r.db.Preload("Product").Preload("Unit").First(&barcode, "code = ?", code).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, apperr.NotFound("barcode %s not found", code)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if barcode.Product == nil || barcode.Unit == nil {
+		// the product or unit was deleted after the barcode was made
+		return nil, apperr.NotFound("barcode %s not found", code)
+	}
+	return &barcode, nil
+}
+
+func (r *BarcodeRepository) GetByProductId(productId string) ([]BarcodeResponseDTO, error) {
+	results := []BarcodeResponseDTO{}
+	err := r.db.Table("product_barcodes AS b").
+		Select("b.id, b.code, b.kind, b.product_id, b.unit_id, u.unit_name").
+		Joins("LEFT JOIN unit_of_measures u ON u.id = b.unit_id").
+		Where("b.product_id = ? AND b.deleted_at IS NULL", productId).
+		Order("b.id").
+		Scan(&results).Error
+	return results, err
+}
+
+// Delete removes the barcode for good, so the code can be given out again.
+func (r *BarcodeRepository) Delete(productId string, code string) error {
+	result := r.db.Unscoped().Where("product_id = ? AND code = ?", productId, code).Delete(&models.ProductBarcode{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperr.NotFound("barcode %s of product %s not found", code, productId)
+	}
+	return nil
+}
+
+func (r *BarcodeRepository) GetProduct(productId string) (*models.Product, error) {
+	var product models.Product
+	err := r.db.First(&product, "id = ?", productId).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, apperr.NotFound("product %s not found", productId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// GetSellPrice returns the SELL entry of the price list, nil when there is none.
+func (r *BarcodeRepository) GetSellPrice(productId string, unitId uint) (*models.ProductPrice, error) {
+	var price models.ProductPrice
+	err := r.db.First(&price, "product_id = ? AND unit_id = ? AND price_type = ?", productId, unitId, pricing.PriceTypeSell).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &price, nil
+}
@@ -0,0 +1,169 @@
+package barcode
+
+import (
+	"errors"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/pricing"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+)
+
+type BarcodeServiceInterface interface {
+	Create(barcode *models.ProductBarcode) (*models.ProductBarcode, error)
+	GetByProductId(productId string) ([]BarcodeResponseDTO, error)
+	Delete(productId string, code string) error
+	Lookup(code string) (*BarcodeLookupDTO, error)
+	Resolve(code string) (*models.ProductBarcode, error)
+}
+
+type BarcodeService struct {
+	repo    BarcodeRepositoryInterface
+	pricing pricing.PricingRepositoryInterface
+}
+
+//! constructor must be return the Interface, NOT struct, if not, google wire generate fail
+
+func NewBarcodeService(repo BarcodeRepositoryInterface, pricing pricing.PricingRepositoryInterface) BarcodeServiceInterface {
+	log.Println(util.Yellow + "BarcodeService constructor is called" + util.Reset)
+	return &BarcodeService{repo: repo, pricing: pricing}
+}
+
+// printable ASCII without spaces, what scanners send for Code 128 and the like
+var codePattern = regexp.MustCompile(`^[!-~]+$`)
+
+// NormalizeCode is how codes are stored and looked up: trimmed, upper-cased.
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Create adds a barcode to one of the two units of the product.
+func (s *BarcodeService) Create(barcode *models.ProductBarcode) (*models.ProductBarcode, error) {
+	barcode.Code = NormalizeCode(barcode.Code)
+	barcode.ProductId = strings.ToUpper(barcode.ProductId)
+	barcode.Kind = models.BarcodeKind(strings.ToUpper(string(barcode.Kind)))
+	if barcode.Kind == "" {
+		barcode.Kind = models.BarcodeInternal
+	}
+	if err := models.ValidateStruct(*barcode); err != nil {
+		return nil, err
+	}
+	if err := validateCode(barcode.Code, barcode.Kind); err != nil {
+		return nil, err
+	}
+
+	product, err := s.repo.GetProduct(barcode.ProductId)
+	if err != nil {
+		return nil, err
+	}
+	if barcode.UnitId != product.UomId && barcode.UnitId != product.DeriveUomId {
+		return nil, apperr.Validation("unit %d is not a unit of product %s, use %d or %d", barcode.UnitId, product.ID, product.UomId, product.DeriveUomId)
+	}
+
+	existing, err := s.repo.GetByCode(barcode.Code)
+	if err != nil && !errors.Is(err, apperr.ErrNotFound) {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, apperr.Conflict("barcode %s already belongs to product %s", barcode.Code, existing.ProductId)
+	}
+	// the unique index still guards against two concurrent creates
+	return s.repo.Create(barcode)
+}
+
+// validateCode checks the length and the check digit of EAN and UPC codes.
+func validateCode(code string, kind models.BarcodeKind) error {
+	if !codePattern.MatchString(code) {
+		return apperr.Validation("barcode %q must be printable characters without spaces", code)
+	}
+	switch kind {
+	case models.BarcodeEAN:
+		if (len(code) != 8 && len(code) != 13) || !isDigits(code) {
+			return apperr.Validation("EAN barcode %s must be 8 or 13 digits", code)
+		}
+	case models.BarcodeUPC:
+		if len(code) != 12 || !isDigits(code) {
+			return apperr.Validation("UPC barcode %s must be 12 digits", code)
+		}
+	case models.BarcodeInternal:
+		return nil
+	default:
+		return apperr.Validation("unknown barcode kind %s, use EAN, UPC or INTERNAL", kind)
+	}
+	if checkDigit(code[:len(code)-1]) != code[len(code)-1] {
+		return apperr.Validation("%s barcode %s has a wrong check digit", kind, code)
+	}
+	return nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// checkDigit is the GS1 check digit of the digits before it: weights 3 and
+// 1 alternate from the right.
+func checkDigit(digits string) byte {
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		d := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+func (s *BarcodeService) GetByProductId(productId string) ([]BarcodeResponseDTO, error) {
+	productId = strings.ToUpper(productId)
+	if _, err := s.repo.GetProduct(productId); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByProductId(productId)
+}
+
+func (s *BarcodeService) Delete(productId string, code string) error {
+	return s.repo.Delete(strings.ToUpper(productId), NormalizeCode(code))
+}
+
+// Resolve returns the barcode with its product and unit, for sale lines
+// given by barcode.
+func (s *BarcodeService) Resolve(code string) (*models.ProductBarcode, error) {
+	return s.repo.GetByCode(NormalizeCode(code))
+}
+
+// Lookup is a scan at the counter: the product, the unit and its sell price.
+func (s *BarcodeService) Lookup(code string) (*BarcodeLookupDTO, error) {
+	barcode, err := s.Resolve(code)
+	if err != nil {
+		return nil, err
+	}
+	price, err := s.repo.GetSellPrice(barcode.ProductId, barcode.UnitId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BarcodeLookupDTO{
+		Code:    barcode.Code,
+		Kind:    barcode.Kind,
+		Product: barcode.Product,
+		Unit:    barcode.Unit,
+		Price:   price,
+	}
+	unitPrice, err := s.pricing.GetUnitPrice(barcode.ProductId, barcode.Unit.UnitName, pricing.PriceTypeSell)
+	switch {
+	case err == nil:
+		result.UnitPrice = &unitPrice
+	case !errors.Is(err, apperr.ErrValidation):
+		return nil, err
+	}
+	return result, nil
+}
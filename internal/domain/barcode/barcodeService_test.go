@@ -0,0 +1,89 @@
+package barcode
+
+import (
+	"testing"
+
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCode(t *testing.T) {
+	valid := []struct {
+		code string
+		kind models.BarcodeKind
+	}{
+		{"4006381333931", models.BarcodeEAN},
+		{"73513537", models.BarcodeEAN},
+		{"036000291452", models.BarcodeUPC},
+		{"P001-PACK", models.BarcodeInternal},
+	}
+	for _, tt := range valid {
+		assert.NoError(t, validateCode(tt.code, tt.kind), tt.code)
+	}
+
+	invalid := []struct {
+		code string
+		kind models.BarcodeKind
+	}{
+		{"4006381333932", models.BarcodeEAN}, // wrong check digit
+		{"400638133393", models.BarcodeEAN},  // 12 digits
+		{"03600029145A", models.BarcodeUPC},
+		{"036000291453", models.BarcodeUPC},
+		{"P001 PACK", models.BarcodeInternal},
+		{"P001", "QR"},
+	}
+	for _, tt := range invalid {
+		assert.ErrorIs(t, validateCode(tt.code, tt.kind), apperr.ErrValidation, tt.code)
+	}
+}
+
+type fakeRepo struct {
+	BarcodeRepositoryInterface
+	product *models.Product
+	codes   map[string]*models.ProductBarcode
+}
+
+func (r *fakeRepo) GetProduct(productId string) (*models.Product, error) {
+	if r.product == nil || r.product.ID != productId {
+		return nil, apperr.NotFound("product %s not found", productId)
+	}
+	return r.product, nil
+}
+
+func (r *fakeRepo) GetByCode(code string) (*models.ProductBarcode, error) {
+	if bc, ok := r.codes[code]; ok {
+		return bc, nil
+	}
+	return nil, apperr.NotFound("barcode %s not found", code)
+}
+
+func (r *fakeRepo) Create(barcode *models.ProductBarcode) (*models.ProductBarcode, error) {
+	r.codes[barcode.Code] = barcode
+	return barcode, nil
+}
+
+func TestCreate(t *testing.T) {
+	repo := &fakeRepo{
+		product: &models.Product{ID: "P001", UomId: 1, DeriveUomId: 2},
+		codes:   map[string]*models.ProductBarcode{},
+	}
+	svc := &BarcodeService{repo: repo}
+
+	bc, err := svc.Create(&models.ProductBarcode{Code: " p001-each ", ProductId: "p001", UnitId: 2})
+	require.NoError(t, err)
+	assert.Equal(t, "P001-EACH", bc.Code)
+	assert.Equal(t, models.BarcodeInternal, bc.Kind)
+
+	// codes are unique, whatever the case they are typed in
+	_, err = svc.Create(&models.ProductBarcode{Code: "P001-each", ProductId: "P001", UnitId: 1})
+	assert.ErrorIs(t, err, apperr.ErrConflict)
+
+	// only the base and derived unit of the product
+	_, err = svc.Create(&models.ProductBarcode{Code: "P001-BOX", ProductId: "P001", UnitId: 3})
+	assert.ErrorIs(t, err, apperr.ErrValidation)
+
+	_, err = svc.Create(&models.ProductBarcode{Code: "X", ProductId: "P404", UnitId: 1})
+	assert.ErrorIs(t, err, apperr.ErrNotFound)
+}
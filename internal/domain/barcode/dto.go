@@ -0,0 +1,32 @@
+package barcode
+
+import "github.com/sankangkin/di-rest-api/internal/models"
+
+type CreateBarcodeRequestDTO struct {
+	Code   string             `json:"code" example:"8851234567892"`
+	Kind   models.BarcodeKind `json:"kind" example:"EAN"` // EAN, UPC or INTERNAL (default)
+	UnitId uint               `json:"unitId" example:"1"`
+}
+
+type BarcodeResponseDTO struct {
+	ID        uint               `json:"id"`
+	Code      string             `json:"code"`
+	Kind      models.BarcodeKind `json:"kind"`
+	ProductId string             `json:"productId"`
+	UnitId    uint               `json:"unitId"`
+	UnitName  string             `json:"unitName"`
+}
+
+// BarcodeLookupDTO is what the counter needs to sell a scanned item.
+type BarcodeLookupDTO struct {
+	Code    string                `json:"code"`
+	Kind    models.BarcodeKind    `json:"kind"`
+	Product *models.Product       `json:"product"`
+	Unit    *models.UnitOfMeasure `json:"unit"`
+	// Price is the SELL entry of the price list for the unit, null when the
+	// list has none and the price comes from the product itself.
+	Price *models.ProductPrice `json:"price"`
+	// UnitPrice is what a sale line of this unit is priced at, null when
+	// the product has no sell price for the unit at all.
+	UnitPrice *int64 `json:"unitPrice"`
+}
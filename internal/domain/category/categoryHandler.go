@@ -4,32 +4,23 @@ import (
 	"log"
 	"net/http"
 	"strconv"
-	"sync"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
-	"gorm.io/gorm"
+	"github.com/sankangkin/di-rest-api/internal/query"
 )
 
 type CategoryHandler struct {
 	Svc CategoryServiceInterface
 }
 
-// ! singleton pattern
-var (
-	hdlInstance *CategoryHandler
-	hdlOnce     sync.Once
-)
-
 // constructor
 func NewCategoryHandler(svc CategoryServiceInterface) *CategoryHandler {
 
 	log.Println(util.Blue + "CategoryHandler constructor is called" + util.Reset)
-	hdlOnce.Do(func() {
-		hdlInstance = &CategoryHandler{Svc: svc}
-	})
-	return hdlInstance
+	return &CategoryHandler{Svc: svc}
 	// return &CategoryHandler{svc: svc}
 }
 
@@ -41,10 +32,10 @@ func NewCategoryHandler(svc CategoryServiceInterface) *CategoryHandler {
 //	@Accept			json
 //	@Param			category	body		CreateCategoryRequestDTO	true	"Category Data"
 //	@Success		200			{object}	models.Category
-//	@Failure		400			{object}	httputil.HttpError400
-//	@Failure		401			{object}	httputil.HttpError401
-//	@Failure		500			{object}	httputil.HttpError500
-//	@Failure		401			{object}	httputil.HttpError401
+//	@Failure		400			{object}	apperr.Response
+//	@Failure		401			{object}	apperr.Response
+//	@Failure		500			{object}	apperr.Response
+//	@Failure		401			{object}	apperr.Response
 //	@Router			/api/categories [post]
 //
 //	@Security		ApiKeyAuth
@@ -55,19 +46,15 @@ func NewCategoryHandler(svc CategoryServiceInterface) *CategoryHandler {
 func (h *CategoryHandler) CreateCategory(c *fiber.Ctx) error {
 	newCategory := new(models.Category)
 	if err := c.BodyParser(newCategory); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  400,
-			"message": "Invalid JSON format",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 
-	errors := models.ValidateStruct(newCategory)
-	if errors != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(errors)
+	if err := models.ValidateStruct(newCategory); err != nil {
+		return err
 	}
 
 	if _, err := h.Svc.CreateCategory(newCategory); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return err
 	}
 	return c.Status(http.StatusOK).JSON(
 		&fiber.Map{
@@ -84,23 +71,26 @@ func (h *CategoryHandler) CreateCategory(c *fiber.Ctx) error {
 //	@Tags			Categories
 //	@Accept			json
 //	@Produce		json
+//	@Param			page	query		int		false	"page number, from 1"
+//	@Param			limit	query		int		false	"rows per page, 20 by default and 100 at most"
+//	@Param			sort	query		string	false	"comma separated fields of id, categoryName; prefix - for descending"
 //	@Success		200				{array}		models.Category
-//	@Failure		400				{object}	httputil.HttpError400
-//	@Failure		401				{object}	httputil.HttpError401
-//	@Failure		500				{object}	httputil.HttpError500
+//	@Failure		400				{object}	apperr.Response
+//	@Failure		401				{object}	apperr.Response
+//	@Failure		500				{object}	apperr.Response
 //	@Router			/api/categories	[get]
 //
 //	@Security		ApiKeyAuth
 //
 //	@Security		Bearer  <-----------------------------------------add this in all controllers that need authentication
 func (h *CategoryHandler) GetAllCategorie(c *fiber.Ctx) error {
-	categories, err := h.Svc.GetAllCategories()
+	spec, err := query.Parse(c.Queries(), categoryList)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return err
 	}
-
-	if categories == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "No categories found"})
+	categories, page, err := h.Svc.GetAllCategories(spec)
+	if err != nil {
+		return err
 	}
 
 	return c.Status(http.StatusOK).JSON(
@@ -108,6 +98,7 @@ func (h *CategoryHandler) GetAllCategorie(c *fiber.Ctx) error {
 			"status":  "SUCCESS",
 			"message": strconv.Itoa(len(categories)) + " records found",
 			"data":    categories,
+			"meta":    page,
 		})
 }
 
@@ -120,9 +111,9 @@ func (h *CategoryHandler) GetAllCategorie(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Param			id					path		string	true	"category Id"
 //	@Success		200					{object}	models.Category
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/categories/{id}	[get]
 //
 //	@Security		ApiKeyAuth
@@ -131,24 +122,12 @@ func (h *CategoryHandler) GetAllCategorie(c *fiber.Ctx) error {
 func (h *CategoryHandler) GetCategoryById(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status": "fail",
-			"error":  "invalid ID parameter",
-			"detail": err.Error(),
-		})
+		return apperr.Validation("invalid ID parameter").Wrap(err)
 	}
 
 	category, err := h.Svc.GetCategoryById(uint(id))
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "Record not found",
-			})
-		}
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
-			"status": "FAIL", "message": err.Error(),
-		})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "SUCCESS",
@@ -167,9 +146,9 @@ func (h *CategoryHandler) GetCategoryById(c *fiber.Ctx) error {
 //	@Param			id					path		string							true	"category Id"
 //	@Param			category			body		dto.UpdateCategoryRequestDTO	true	"Category Data"
 //	@Success		200					{object}	models.Category
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/categories/{id}	[put]
 //
 //	@Security		ApiKeyAuth
@@ -178,33 +157,18 @@ func (h *CategoryHandler) GetCategoryById(c *fiber.Ctx) error {
 func (h *CategoryHandler) UpdateCatagory(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status": "fail",
-			"error":  "invalid ID parameter",
-			"detail": err.Error(),
-		})
+		return apperr.Validation("invalid ID parameter").Wrap(err)
 	}
 
 	foundCategory, err := h.Svc.GetCategoryById(uint(id))
 	log.Println("foundCategory: ", foundCategory)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "ရှာမတွေ့ပါ။",
-			})
-		}
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
-			"status": "FAIL", "message": err.Error(),
-		})
+		return err
 	}
 
 	input := new(UpdateCategoryRequestDTO)
 	if err := c.BodyParser(input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  400,
-			"message": "Invalid JSON format",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 	updateCategory := models.Category{
 		ID:           foundCategory.ID,
@@ -212,14 +176,11 @@ func (h *CategoryHandler) UpdateCatagory(c *fiber.Ctx) error {
 	}
 
 	if err := c.BodyParser(&updateCategory); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  400,
-			"message": "Invalid JSON format",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 	result, err := h.Svc.UpdateCategory(&updateCategory)
 	if err != nil {
-		log.Fatalf(err.Error())
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -239,9 +200,9 @@ func (h *CategoryHandler) UpdateCatagory(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Param			id					path		string	true	"category Id"
 //	@Success		200					{object}	models.Category
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/categories/{id}	[delete]
 //
 //	@Security		ApiKeyAuth
@@ -250,30 +211,15 @@ func (h *CategoryHandler) UpdateCatagory(c *fiber.Ctx) error {
 func (h *CategoryHandler) DeleteCategory(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status": "fail",
-			"error":  "invalid ID parameter",
-			"detail": err.Error(),
-		})
+		return apperr.Validation("invalid ID parameter").Wrap(err)
 	}
 	category, err := h.Svc.GetCategoryById(uint(id))
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "Record not found",
-			})
-		}
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
-			"status": "FAIL", "message": err.Error(),
-		})
+		return err
 	}
 	err = h.Svc.DeleteCategory(uint(category.ID))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "FAIL",
-			"message": "Internal server error",
-		})
+		return err
 	}
 	return c.JSON(fiber.Map{
 		"code":    200,
@@ -1,7 +1,6 @@
 package category_test
 
 import (
-	"bytes"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -10,10 +9,13 @@ import (
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
 	c "github.com/sankangkin/di-rest-api/internal/domain/category"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 // Mock service for CategoryService
@@ -22,9 +24,11 @@ type MockCategoryService struct {
 	c.CategoryServiceInterface
 }
 
-func (m *MockCategoryService) GetAllCategories() ([]models.Category, error) {
-	args := m.Called()
-	return args.Get(0).([]models.Category), args.Error(1)
+func (m *MockCategoryService) GetAllCategories(spec query.Spec) ([]models.Category, query.Page, error) {
+	args := m.Called(spec)
+	categories, _ := args.Get(0).([]models.Category)
+	page, _ := args.Get(1).(query.Page)
+	return categories, page, args.Error(2)
 }
 
 func (m *MockCategoryService) GetCategoryById(id uint) (*models.Category, error) {
@@ -32,33 +36,32 @@ func (m *MockCategoryService) GetCategoryById(id uint) (*models.Category, error)
 	if res := args.Get(0); res != nil {
 		return res.(*models.Category), nil
 	}
-	return &models.Category{}, args.Error(1)
+	return nil, args.Error(1)
 }
 
 // Test GetAllCategories Handler
 func TestGetAllCategories(t *testing.T) {
-	// Set up the Fiber app
-	app := fiber.New()
-
-	// Create the mock service
-	mockService := new(MockCategoryService)
-
-	// Create the handler and inject the mock service
-	handler := &c.CategoryHandler{Svc: mockService}
-
-	// Register the handler to the app
-	app.Get("/category", handler.GetAllCategorie)
+	// Set up the Fiber app with the api's error handler and the handler on
+	// a fresh mock service
+	setup := func() (*fiber.App, *MockCategoryService) {
+		app := fiber.New(fiber.Config{ErrorHandler: apperr.Handler})
+		mockService := new(MockCategoryService)
+		handler := &c.CategoryHandler{Svc: mockService}
+		app.Get("/categories", handler.GetAllCategorie)
+		return app, mockService
+	}
 
 	t.Run("Success", func(t *testing.T) {
+		app, mockService := setup()
 		// Define expected response
 		mockCategories := []models.Category{
 			{ID: 1, CategoryName: "Category 1"},
 			{ID: 2, CategoryName: "Category 2"},
 		}
-		mockService.On("GetAllCategories").Return(mockCategories, nil)
+		mockService.On("GetAllCategories", mock.Anything).Return(mockCategories, query.Page{Page: 1, Limit: 20, Total: 2, TotalPages: 1}, nil)
 
 		// Create request
-		req := httptest.NewRequest(http.MethodGet, "/category", nil)
+		req := httptest.NewRequest(http.MethodGet, "/categories", nil)
 		resp, _ := app.Test(req, -1) // -1 disables request timeout
 
 		// Assert status code
@@ -72,34 +75,58 @@ func TestGetAllCategories(t *testing.T) {
 		assert.Equal(t, "SUCCESS", response["status"])
 		assert.Equal(t, strconv.Itoa(len(mockCategories))+" records found", response["message"])
 		assert.NotNil(t, response["data"])
+		assert.Equal(t, map[string]interface{}{"page": float64(1), "limit": float64(20), "total": float64(2), "totalPages": float64(1)}, response["meta"])
 
 		mockService.AssertExpectations(t)
 	})
 
 	t.Run("No Categories Found", func(t *testing.T) {
-		// Define nil response for categories
-		mockService.On("GetAllCategories").Return([]models.Category{}, nil)
+		app, mockService := setup()
+		// an empty list is not an error
+		mockService.On("GetAllCategories", mock.Anything).Return([]models.Category{}, query.Page{Page: 1, Limit: 20}, nil)
 
 		// Create request
-		req := httptest.NewRequest(http.MethodGet, "/category", nil)
+		req := httptest.NewRequest(http.MethodGet, "/categories", nil)
 		resp, _ := app.Test(req, -1)
 
 		// Assert status code
-		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
 
 		// Parse the response body
 		var response map[string]interface{}
 		json.NewDecoder(resp.Body).Decode(&response)
 
 		// Assert the response
-		assert.Equal(t, "No categories found", response["error"])
+		assert.Equal(t, "0 records found", response["message"])
+		assert.Equal(t, []interface{}{}, response["data"])
+
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Paging", func(t *testing.T) {
+		app, mockService := setup()
+		// the handler hands the parsed page, limit and sort to the service
+		mockService.On("GetAllCategories", mock.MatchedBy(func(spec query.Spec) bool {
+			return spec.Page == 2 && spec.Limit == 5 &&
+				assert.ObjectsAreEqual([]query.SortField{{Column: "category_name", Desc: true}}, spec.Sort)
+		})).Return([]models.Category{}, query.Page{Page: 2, Limit: 5, Total: 6, TotalPages: 2}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/categories?page=2&limit=5&sort=-categoryName", nil)
+		resp, _ := app.Test(req, -1)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		// a field outside the whitelist never reaches the service
+		req = httptest.NewRequest(http.MethodGet, "/categories?sort=password", nil)
+		resp, _ = app.Test(req, -1)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
 
 		mockService.AssertExpectations(t)
 	})
 
 	t.Run("Error", func(t *testing.T) {
+		app, mockService := setup()
 		// Define expected error response
-		mockService.On("GetAllCategories").Return(nil, errors.New("database error"))
+		mockService.On("GetAllCategories", mock.Anything).Return(nil, query.Page{}, errors.New("database error"))
 
 		// Create request
 		req := httptest.NewRequest(http.MethodGet, "/categories", nil)
@@ -109,44 +136,38 @@ func TestGetAllCategories(t *testing.T) {
 		assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
 
 		// Parse the response body
-		var response map[string]interface{}
+		var response apperr.Response
 		json.NewDecoder(resp.Body).Decode(&response)
 
-		// Assert the error message
-		assert.Equal(t, "database error", response["error"])
+		// Assert the error envelope, the cause stays in the log
+		assert.Equal(t, "FAIL", response.Status)
+		assert.Equal(t, apperr.CodeInternal, response.Code)
+		assert.Equal(t, "Internal Server Error", response.Message)
 
 		mockService.AssertExpectations(t)
 	})
 }
 
-func TestGetAllCategories_Error(t *testing.T) {
-	// Create mock service
-	mockSvc := &MockCategoryService{}
-
-	// Mock service to return error
-	mockSvc.On("GetAllCategories").Return(nil, errors.New("internal error"))
-
-	// Create handler with mock service
-	handler := c.CategoryHandler{Svc: mockSvc}
-
-	// Create fiber app for testing
-	app := fiber.New()
-	app.Get("/categories", handler.GetAllCategorie)
+func TestGetCategoryById_NotFound(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: apperr.Handler})
+	mockService := new(MockCategoryService)
+	handler := &c.CategoryHandler{Svc: mockService}
+	app.Get("/categories/:id", handler.GetCategoryById)
 
-	// Simulate GET request
-	req, err := http.NewRequest(http.MethodGet, "/categories", nil)
-	assert.NoError(t, err)
+	mockService.On("GetCategoryById", uint(7)).Return(nil, gorm.ErrRecordNotFound)
 
-	// Record the response
-	recorder := httptest.NewRecorder()
-	app.Test(req, 1)
+	resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/categories/7", nil), -1)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
 
-	// Assert response status code
-	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	var response apperr.Response
+	json.NewDecoder(resp.Body).Decode(&response)
+	assert.Equal(t, apperr.CodeNotFound, response.Code)
 
-	// Assert error message in response body
-	body := bytes.TrimSpace(recorder.Body.Bytes())
-	assert.Equal(t, `{"error":"internal error"}`, string(body))
+	resp, _ = app.Test(httptest.NewRequest(http.MethodGet, "/categories/abc", nil), -1)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	json.NewDecoder(resp.Body).Decode(&response)
+	assert.Equal(t, apperr.CodeValidation, response.Code)
+	assert.Equal(t, "invalid ID parameter", response.Message)
 }
 
 func TestGetCategoryById_Success(t *testing.T) {
@@ -1,18 +1,17 @@
 package category
 
 import (
-	"errors"
 	"log"
-	"sync"
 
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 	"gorm.io/gorm"
 )
 
 type CategoryRepositoryInterface interface {
 	Create(category *models.Category) (*models.Category, error)
-	GetAll() ([]models.Category, error)
+	GetAll(spec query.Spec) ([]models.Category, query.Page, error)
 	GetById(id uint) (*models.Category, error)
 	Update(category *models.Category) (*models.Category, error)
 	Delete(id uint) error
@@ -22,35 +21,32 @@ type CategoryRepository struct{
 	db *gorm.DB
 }
 
-var (
-	repoInstance *CategoryRepository
-	repoOnce sync.Once
-)
-
 //! constructor must be return the Interface, NOT struct, if not, google wire generate fail
 func NewCategoryRepository(db *gorm.DB) CategoryRepositoryInterface {
 	log.Println(util.Blue+"CategoryRepository constructor is called"+util.Reset)
-	repoOnce.Do(func(){
-		repoInstance = &CategoryRepository{db: db}
-	})
-	return repoInstance
+	return &CategoryRepository{db: db}
 }
 
 func (r *CategoryRepository)Create(category *models.Category) (*models.Category, error) {
 
+	category.CategoryName = util.NormalizeText(category.CategoryName)
 	err := r.db.Create(&category).Error
 
 	return category, err
 }
 
-func (r *CategoryRepository)GetAll() ([]models.Category, error){
+// categoryList is what GET /categories sorts on.
+var categoryList = query.Resource{
+	Sort:        map[string]string{"id": "id", "categoryName": "category_name"},
+	DefaultSort: "id",
+	Key:         "id",
+}
+
+func (r *CategoryRepository)GetAll(spec query.Spec) ([]models.Category, query.Page, error){
 
 	categories := []models.Category{}
-	r.db.Model(&models.Category{}).Order("ID asc").Limit(100).Find(&categories)
-	if len(categories) == 0 {
-		return nil, errors.New("no record found")
-	}
-	return categories, nil
+	page, err := query.Find(r.db.Model(&models.Category{}), spec, &categories)
+	return categories, page, err
 
 }
 
@@ -80,7 +76,7 @@ func (r *CategoryRepository) Update(input *models.Category) (*models.Category, e
 			return nil, err
 		}
 		// Update relevant fields from input data
-		existingCategory.CategoryName = input.CategoryName  // Update other fields as needed
+		existingCategory.CategoryName = util.NormalizeText(input.CategoryName)  // Update other fields as needed
 
 		// Save the updated customer data
 		log.Println("existingCustomer: ", existingCategory)
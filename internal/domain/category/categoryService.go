@@ -2,15 +2,15 @@ package category
 
 import (
 	"log"
-	"sync"
 
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 )
 
 type CategoryServiceInterface interface {
 	CreateCategory(category *models.Category) (*models.Category, error)
-	GetAllCategories() ([]models.Category, error)
+	GetAllCategories(spec query.Spec) ([]models.Category, query.Page, error)
 	GetCategoryById(id uint) (*models.Category, error)
 	UpdateCategory(category *models.Category) (*models.Category, error)
 	DeleteCategory(id uint) error
@@ -19,29 +19,20 @@ type CategoryServiceInterface interface {
 type CategoryService struct {
 	repo CategoryRepositoryInterface
 }
-//! singleton pattern
-var (
-	svcInstance *CategoryService
-	svcOnce sync.Once
-)
-
 //! constructor must be return the Interface, NOT struct, if not, google wire generate fail
 func NewCategoryService(repo CategoryRepositoryInterface) CategoryServiceInterface{
 
 	log.Println(util.Blue + "CategoryService constructor is called" + util.Reset)
 
-	svcOnce.Do(func() {
-		svcInstance = &CategoryService{repo: repo}
-	})
-	return svcInstance
+	return &CategoryService{repo: repo}
 }
 
 func(s *CategoryService)CreateCategory(category *models.Category) (*models.Category, error) {
 	return s.repo.Create(category)
 }
 
-func(s *CategoryService) GetAllCategories() ([]models.Category, error) {
-	return s.repo.GetAll()
+func(s *CategoryService) GetAllCategories(spec query.Spec) ([]models.Category, query.Page, error) {
+	return s.repo.GetAll(spec)
 }
 
 func(s *CategoryService) GetCategoryById(id uint) (*models.Category, error) {
@@ -4,6 +4,7 @@ package mocks
 
 import (
 	models "github.com/sankangkin/di-rest-api/internal/models"
+	query "github.com/sankangkin/di-rest-api/internal/query"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -60,34 +61,41 @@ func (_m *CategoryRepositoryInterface) Delete(id uint) error {
 	return r0
 }
 
-// GetAll provides a mock function with given fields:
-func (_m *CategoryRepositoryInterface) GetAll() ([]models.Category, error) {
-	ret := _m.Called()
+// GetAll provides a mock function with given fields: spec
+func (_m *CategoryRepositoryInterface) GetAll(spec query.Spec) ([]models.Category, query.Page, error) {
+	ret := _m.Called(spec)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAll")
 	}
 
 	var r0 []models.Category
-	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]models.Category, error)); ok {
-		return rf()
+	var r1 query.Page
+	var r2 error
+	if rf, ok := ret.Get(0).(func(query.Spec) ([]models.Category, query.Page, error)); ok {
+		return rf(spec)
 	}
-	if rf, ok := ret.Get(0).(func() []models.Category); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(query.Spec) []models.Category); ok {
+		r0 = rf(spec)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Category)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(query.Spec) query.Page); ok {
+		r1 = rf(spec)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(query.Page)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(query.Spec) error); ok {
+		r2 = rf(spec)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // GetById provides a mock function with given fields: id
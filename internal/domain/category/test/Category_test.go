@@ -5,6 +5,7 @@ import (
 
 	"github.com/sankangkin/di-rest-api/internal/domain/category/mocks"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -17,11 +18,13 @@ func TestGetAll(t *testing.T) {
 		{ID: 2, CategoryName: "Category 2"},
 	}
 
-	mockTestCategoryRepo.On("GetAll", mock.Anything).Return(categories, nil).Once()
-	result, err := mockTestCategoryRepo.GetAll()
+	page := query.Page{Page: 1, Limit: 20, Total: 2, TotalPages: 1}
+	mockTestCategoryRepo.On("GetAll", mock.Anything).Return(categories, page, nil).Once()
+	result, gotPage, err := mockTestCategoryRepo.GetAll(query.Spec{})
 
 	assert.NoError(t, err)
 	assert.Equal(t, categories, result)
+	assert.Equal(t, page, gotPage)
 
 	mockTestCategoryRepo.AssertExpectations(t)
 }
@@ -4,31 +4,22 @@ import (
 	"log"
 	"net/http"
 	"strconv"
-	"sync"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
-	"gorm.io/gorm"
+	"github.com/sankangkin/di-rest-api/internal/query"
 )
 
 type CustomerHandler struct {
 	svc CustomerServiceInterface
 }
 
-// ! singleton pattern
-var (
-	hdlInstance *CustomerHandler
-	hdlOnce     sync.Once
-)
-
 // constructor
 func NewCustomerHandler(svc CustomerServiceInterface) *CustomerHandler {
 	log.Println(util.Gray + "CustomerHandler constructor is called " + util.Reset)
-	hdlOnce.Do(func() {
-		hdlInstance = &CustomerHandler{svc: svc}
-	})
-	return hdlInstance
+	return &CustomerHandler{svc: svc}
 }
 
 // func NewCustomerHandler(svc CustomerServiceInterface) *CustomerHandler{
@@ -43,20 +34,17 @@ func NewCustomerHandler(svc CustomerServiceInterface) *CustomerHandler {
 //	@Accept			json
 //	@Param			product	body		CreateCustomerRequestDTO	true	"Product Data"
 //	@Success		200		{object}	models.Customer
-//	@Failure		400		{object}	httputil.HttpError400
-//	@Failure		401		{object}	httputil.HttpError401
-//	@Failure		500		{object}	httputil.HttpError500
-//	@Failure		401		{object}	httputil.HttpError401
+//	@Failure		400		{object}	apperr.Response
+//	@Failure		401		{object}	apperr.Response
+//	@Failure		500		{object}	apperr.Response
+//	@Failure		401		{object}	apperr.Response
 //	@Router			/api/customers [post]
 //	@Security		Bearer
 func (h *CustomerHandler) CreateCustomer(c *fiber.Ctx) error {
 
 	input := new(CreateCustomerRequestDTO)
 	if err := c.BodyParser(input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  400,
-			"message": "Invalid JSON format",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 
 	newCustomer := models.Customer{
@@ -72,13 +60,12 @@ func (h *CustomerHandler) CreateCustomer(c *fiber.Ctx) error {
 		return err
 	}
 
-	errors := models.ValidateStruct(newCustomer)
-	if errors != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(errors)
+	if err := models.ValidateStruct(newCustomer); err != nil {
+		return err
 	}
 
 	if _, err := h.svc.CreateCustomer(&newCustomer); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return err
 	}
 	return c.Status(http.StatusOK).JSON(
 		&fiber.Map{
@@ -95,22 +82,31 @@ func (h *CustomerHandler) CreateCustomer(c *fiber.Ctx) error {
 //	@Tags			Customers
 //	@Accept			json
 //	@Produce		json
+//	@Param			page	query		int		false	"page number, from 1"
+//	@Param			limit	query		int		false	"rows per page, 20 by default and 100 at most"
+//	@Param			sort	query		string	false	"comma separated fields of id, name; prefix - for descending"
+//	@Param			phone	query		string	false	"only the customer with this phone number"
 //	@Success		200				{array}		models.Customer
-//	@Failure		400				{object}	httputil.HttpError400
-//	@Failure		401				{object}	httputil.HttpError401
-//	@Failure		500				{object}	httputil.HttpError500
+//	@Failure		400				{object}	apperr.Response
+//	@Failure		401				{object}	apperr.Response
+//	@Failure		500				{object}	apperr.Response
 //	@Router			/api/customers	[get]
 //	@Security		Bearer
 func (h *CustomerHandler) GetAllCustomers(c *fiber.Ctx) error {
-	customers, err := h.svc.GetAllCustomers()
+	spec, err := query.Parse(c.Queries(), customerList)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return err
+	}
+	customers, page, err := h.svc.GetAllCustomers(spec)
+	if err != nil {
+		return err
 	}
 	return c.Status(http.StatusOK).JSON(
 		&fiber.Map{
 			"status":  "SUCCESS",
 			"message": strconv.Itoa(len(customers)) + " records found",
 			"data":    customers,
+			"meta":    page,
 		})
 }
 
@@ -123,33 +119,21 @@ func (h *CustomerHandler) GetAllCustomers(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Param			id					path		string	true	"customer Id"
 //	@Success		200					{object}	models.Customer
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/customers/{id}	[get]
 //	@Security		Bearer
 func (h *CustomerHandler) GetCustomerById(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status": "fail",
-			"error":  "invalid ID parameter",
-			"detail": err.Error(),
-		})
+		return apperr.Validation("invalid ID parameter").Wrap(err)
 	}
 
 	customer, err := h.svc.GetCustomerById(uint(id))
 	log.Println("id : ", id)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "Record not found",
-			})
-		}
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
-			"status": "FAIL", "message": err.Error(),
-		})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "SUCCESS",
@@ -168,43 +152,28 @@ func (h *CustomerHandler) GetCustomerById(c *fiber.Ctx) error {
 //	@Param			id					path		string						true	"customer Id"
 //	@Param			product				body		UpdateCustomerRequstDTO	true	"Product Data"
 //	@Success		200					{object}	models.Customer
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/customers/{id}	[put]
 //	@Security		Bearer
 func (h *CustomerHandler) UpdateCustomer(c *fiber.Ctx) error {
 
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status": "fail",
-			"error":  "invalid ID parameter",
-			"detail": err.Error(),
-		})
+		return apperr.Validation("invalid ID parameter").Wrap(err)
 	}
 
 	foundCustomer, err := h.svc.GetCustomerById(uint(id))
 	log.Println("id : ", id)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "Record not found",
-			})
-		}
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
-			"status": "FAIL", "message": err.Error(),
-		})
+		return err
 	}
 
 	input := new(UpdateCustomerRequstDTO)
 	log.Println("input: ", input)
 	if err := c.BodyParser(input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  400,
-			"message": "Invalid JSON format",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 
 	updateCustomer := models.Customer{
@@ -215,15 +184,12 @@ func (h *CustomerHandler) UpdateCustomer(c *fiber.Ctx) error {
 	}
 	log.Println("updateCustomer: ", &updateCustomer)
 	if err := c.BodyParser(&updateCustomer); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  400,
-			"message": "Invalid JSON format",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 
 	result, err := h.svc.UpdateCustomer(&updateCustomer)
 	if err != nil {
-		log.Fatal(err.Error())
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "SUCCESS",
@@ -242,38 +208,23 @@ func (h *CustomerHandler) UpdateCustomer(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Param			id					path		string	true	"customer Id"
 //	@Success		200					{object}	models.Customer
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/customers/{id}	[delete]
 //	@Security		Bearer
 func (h *CustomerHandler) DeleteCustomer(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status": "fail",
-			"error":  "invalid ID parameter",
-			"detail": err.Error(),
-		})
+		return apperr.Validation("invalid ID parameter").Wrap(err)
 	}
 	customer, err := h.svc.GetCustomerById(uint(id))
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "Record not found",
-			})
-		}
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
-			"status": "FAIL", "message": err.Error(),
-		})
+		return err
 	}
 	err = h.svc.DeleteCustomer(uint(customer.ID))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "FAIL",
-			"message": "Internal server error",
-		})
+		return err
 	}
 	return c.JSON(fiber.Map{
 		"code":    200,
@@ -1,18 +1,17 @@
 package customer
 
 import (
-	"errors"
 	"log"
-	"sync"
 
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 	"gorm.io/gorm"
 )
 
 type CustomerRepositoryInterface interface{
 	CreateCustomer(customer *models.Customer) (*models.Customer, error)
-	GetAllCustomers() ([]models.Customer, error)
+	GetAllCustomers(spec query.Spec) ([]models.Customer, query.Page, error)
 	GetCustomerById(id uint) (*models.Customer, error)
 	UpdateCustomer(customer *models.Customer) (*models.Customer, error)
 	DeleteCustomer(id uint) error
@@ -23,22 +22,13 @@ type CustomerRepository struct {
 }
 
 
-var(
-	repoInstance *CustomerRepository
-	repoOnce sync.Once
-)
-
 // func NewCustomerRepository(db *gorm.DB) CustomerRepositoryInterface{
 // 	return &CustomerRepository{db: db}
 // }
 
 func NewCustomerRepository(db *gorm.DB) CustomerRepositoryInterface{
 	log.Println(util.Gray + "CustomerRepository constructor is called" + util.Reset)
-	repoOnce.Do(func(){
-		repoInstance = &CustomerRepository{db: db}
-	})
-	return repoInstance
-	// return &CustomerRepository{db: db}
+	return &CustomerRepository{db: db}
 }
 
 func(r *CustomerRepository)CreateCustomer(customer *models.Customer) (*models.Customer, error){
@@ -60,13 +50,18 @@ func(r *CustomerRepository)CreateCustomer(customer *models.Customer) (*models.Cu
 	// return newCustomer, nil
 }
 
-	func(r *CustomerRepository)GetAllCustomers() ([]models.Customer, error){
+	// customerList is what GET /customers sorts and filters on.
+	var customerList = query.Resource{
+		Sort:        map[string]string{"id": "id", "name": "name"},
+		DefaultSort: "id",
+		Filters:     map[string]query.Filter{"phone": {Column: "phone"}},
+		Key:         "id",
+	}
+
+	func(r *CustomerRepository)GetAllCustomers(spec query.Spec) ([]models.Customer, query.Page, error){
 		customers := []models.Customer{}
-		r.db.Model(&models.Customer{}).Order("ID asc").Find(&customers)
-		if len(customers) == 0 {
-			return nil, errors.New("NO records found")
-		}
-		return customers, nil
+		page, err := query.Find(r.db.Model(&models.Customer{}), spec, &customers)
+		return customers, page, err
 	}
 
 	func(r *CustomerRepository)GetCustomerById(id uint) (*models.Customer, error){
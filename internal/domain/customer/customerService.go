@@ -2,15 +2,15 @@ package customer
 
 import (
 	"log"
-	"sync"
 
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 )
 
 type CustomerServiceInterface interface {
 	CreateCustomer(customer *models.Customer) (*models.Customer, error)
-	GetAllCustomers() ([]models.Customer, error)
+	GetAllCustomers(spec query.Spec) ([]models.Customer, query.Page, error)
 	GetCustomerById(id uint) (*models.Customer, error)
 	UpdateCustomer(customer *models.Customer) (*models.Customer, error)
 	DeleteCustomer(id uint) error
@@ -21,11 +21,6 @@ type CustomerService struct {
 }
 
 //! for singletone pattern
-var (
-	svcInstance *CustomerService
-	svcOnce sync.Once
-)
-
 // func NewCustomerService(repo CustomerRepositoryInterface) CustomerServiceInterface{
 // 	return &CustomerService{repo:repo}
 // }
@@ -33,10 +28,7 @@ var (
 //! constructor must be return the Interface, NOT struct, if not, google wire generate fail
 func NewCustomerService(repo CustomerRepositoryInterface) CustomerServiceInterface {
 	log.Println(util.Gray + "CustomerService constructor is called " + util.Reset)
-	svcOnce.Do(func() {
-		svcInstance = &CustomerService{repo: repo}
-	})
-	return svcInstance
+	return &CustomerService{repo: repo}
 }
 
 func (s *CustomerService)CreateCustomer(customer *models.Customer) (*models.Customer, error){
@@ -45,8 +37,8 @@ func (s *CustomerService)CreateCustomer(customer *models.Customer) (*models.Cust
 
 
 
-func (s *CustomerService)GetAllCustomers() ([]models.Customer, error){
-	return s.repo.GetAllCustomers()
+func (s *CustomerService)GetAllCustomers(spec query.Spec) ([]models.Customer, query.Page, error){
+	return s.repo.GetAllCustomers(spec)
 }
 
 func (s *CustomerService)GetCustomerById(id uint) (*models.Customer, error){
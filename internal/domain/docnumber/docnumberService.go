@@ -0,0 +1,166 @@
+package docnumber
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/sankangkin/di-rest-api/internal/config"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"gorm.io/gorm"
+)
+
+const (
+	DocTypeSale      = "SALE"
+	DocTypePurchase  = "PURCHASE"
+	DocTypeTransfer  = "TRANSFER"
+	DocTypeStockTake = "STOCKTAKE"
+	DocTypeOrder     = "ORDER"
+	DocTypeReceipt   = "RECEIPT"
+)
+
+const (
+	ResetMonthly = "MONTHLY"
+	ResetYearly  = "YEARLY"
+	ResetNever   = "NEVER"
+)
+
+// Format describes how numbers of one document type look, e.g. prefix "INV",
+// date layout "2006-01" and width 6 give INV-2026-10-000123.
+type Format struct {
+	Prefix     string
+	DateLayout string // Go time layout, empty leaves the date part out
+	Width      int    // zero padded width of the running number
+	Reset      string // MONTHLY, YEARLY or NEVER
+}
+
+var defaultFormats = map[string]Format{
+	DocTypeSale:      {Prefix: "INV", DateLayout: "2006-01", Width: 6, Reset: ResetMonthly},
+	DocTypePurchase:  {Prefix: "PO", DateLayout: "2006-01", Width: 6, Reset: ResetMonthly},
+	DocTypeTransfer:  {Prefix: "TRF", DateLayout: "2006-01", Width: 6, Reset: ResetMonthly},
+	DocTypeStockTake: {Prefix: "STK", DateLayout: "2006-01", Width: 6, Reset: ResetMonthly},
+	DocTypeOrder:     {Prefix: "ORD", DateLayout: "2006-01", Width: 6, Reset: ResetMonthly},
+	DocTypeReceipt:   {Prefix: "GRN", DateLayout: "2006-01", Width: 6, Reset: ResetMonthly},
+}
+
+type DocNumberServiceInterface interface {
+	Next(tx *gorm.DB, docType string) (string, error)
+}
+
+type DocNumberService struct {
+	formats map[string]Format
+	now     func() time.Time
+}
+
+//! constructor must be return the Interface, NOT struct, if not, google wire generate fail
+
+// NewDocNumberService starts from the default formats; each part can be
+// overridden per document type in the config, e.g. DOCNO_SALE_RESET=YEARLY.
+func NewDocNumberService(cfg *config.Config) (DocNumberServiceInterface, error) {
+	log.Println(util.White + "DocNumberService constructor is called" + util.Reset)
+	formats := make(map[string]Format, len(defaultFormats))
+	for docType, format := range defaultFormats {
+		format, err := withOverride(docType, format, cfg.DocNo[docType])
+		if err != nil {
+			return nil, err
+		}
+		formats[docType] = format
+	}
+	return &DocNumberService{formats: formats, now: time.Now}, nil
+}
+
+// Next allocates the next number of docType on tx. The sequence row stays
+// locked until tx ends, so concurrent documents are numbered one after the
+// other, and a rolled back document gives its number back: no gaps.
+func (s *DocNumberService) Next(tx *gorm.DB, docType string) (string, error) {
+	format, ok := s.formats[docType]
+	if !ok {
+		return "", fmt.Errorf("no number format for document type %s", docType)
+	}
+
+	now := s.now()
+	period := periodOf(format.Reset, now)
+
+	var lastNo int64
+	err := tx.Raw(`
+		INSERT INTO document_sequences (doc_type, period, last_no, updated_at)
+		VALUES (?, ?, 1, ?)
+		ON CONFLICT (doc_type, period)
+		DO UPDATE SET last_no = document_sequences.last_no + 1, updated_at = EXCLUDED.updated_at
+		RETURNING last_no`,
+		docType, period, now.UnixMilli(),
+	).Scan(&lastNo).Error
+	if err != nil {
+		return "", err
+	}
+
+	return format.render(now, lastNo), nil
+}
+
+func (f Format) render(at time.Time, no int64) string {
+	parts := []string{}
+	if f.Prefix != "" {
+		parts = append(parts, f.Prefix)
+	}
+	if f.DateLayout != "" {
+		parts = append(parts, at.Format(f.DateLayout))
+	}
+	parts = append(parts, fmt.Sprintf("%0*d", f.Width, no))
+	return strings.Join(parts, "-")
+}
+
+func periodOf(reset string, at time.Time) string {
+	switch reset {
+	case ResetMonthly:
+		return at.Format("2006-01")
+	case ResetYearly:
+		return at.Format("2006")
+	}
+	return ""
+}
+
+// withOverride applies the configured parts of docType's format.
+func withOverride(docType string, format Format, override config.DocNoFormat) (Format, error) {
+	if override.Prefix != nil {
+		format.Prefix = strings.ToUpper(*override.Prefix)
+	}
+	if override.DateLayout != nil {
+		format.DateLayout = *override.DateLayout
+	}
+	if override.Width != 0 {
+		format.Width = override.Width
+	}
+	if override.Reset != "" {
+		format.Reset = override.Reset
+	}
+	if err := format.validate(); err != nil {
+		return format, fmt.Errorf("DOCNO_%s_DATE_LAYOUT: %w", docType, err)
+	}
+	return format, nil
+}
+
+// validate makes sure the date part tells the reset periods apart. The
+// running number starts over each period, so a layout that leaves the
+// period out would hand out a number the previous period already used.
+func (f Format) validate() error {
+	if f.Reset == ResetNever {
+		return nil
+	}
+	// three years of periods, each rendered mid-period, must all differ
+	seen := map[string]bool{}
+	from := time.Date(2025, time.January, 15, 12, 0, 0, 0, time.UTC)
+	for at := from; at.Year() < 2028; {
+		date := at.Format(f.DateLayout)
+		if f.DateLayout == "" || seen[date] {
+			return fmt.Errorf("layout %q does not encode the %s reset period, numbers would repeat", f.DateLayout, f.Reset)
+		}
+		seen[date] = true
+		if f.Reset == ResetMonthly {
+			at = at.AddDate(0, 1, 0)
+		} else {
+			at = at.AddDate(1, 0, 0)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package docnumber
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sankangkin/di-rest-api/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	at := time.Date(2026, time.October, 18, 9, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, "INV-2026-10-000123", defaultFormats[DocTypeSale].render(at, 123))
+	assert.Equal(t, "PO-2026-10-000001", defaultFormats[DocTypePurchase].render(at, 1))
+	assert.Equal(t, "TRF-2026-10-000007", defaultFormats[DocTypeTransfer].render(at, 7))
+	assert.Equal(t, "STK-2026-10-000002", defaultFormats[DocTypeStockTake].render(at, 2))
+	assert.Equal(t, "ORD-2026-10-000003", defaultFormats[DocTypeOrder].render(at, 3))
+	assert.Equal(t, "GRN-2026-10-000004", defaultFormats[DocTypeReceipt].render(at, 4))
+	assert.Equal(t, "GRN-2026-0042", Format{Prefix: "GRN", DateLayout: "2006", Width: 4}.render(at, 42))
+	assert.Equal(t, "00007", Format{Width: 5}.render(at, 7))
+	assert.Equal(t, "INV-1234567", Format{Prefix: "INV", Width: 3}.render(at, 1234567))
+}
+
+func TestPeriodOf(t *testing.T) {
+	at := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "2026-01", periodOf(ResetMonthly, at))
+	assert.Equal(t, "2026", periodOf(ResetYearly, at))
+	assert.Equal(t, "", periodOf(ResetNever, at))
+}
+
+func TestWithOverride(t *testing.T) {
+	prefix, layout := "si", "2006"
+	format, err := withOverride(DocTypeSale, defaultFormats[DocTypeSale], config.DocNoFormat{
+		Prefix: &prefix, DateLayout: &layout, Reset: ResetYearly,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, Format{Prefix: "SI", DateLayout: "2006", Width: 6, Reset: ResetYearly}, format)
+
+	format, err = withOverride(DocTypeSale, defaultFormats[DocTypeSale], config.DocNoFormat{})
+	assert.NoError(t, err)
+	assert.Equal(t, defaultFormats[DocTypeSale], format)
+
+	for _, layout := range []string{"", "2006", "01", "Jan-02"} {
+		_, err = withOverride(DocTypeSale, defaultFormats[DocTypeSale], config.DocNoFormat{DateLayout: &layout})
+		assert.Error(t, err, layout)
+	}
+	layout = "200601"
+	_, err = withOverride(DocTypeSale, defaultFormats[DocTypeSale], config.DocNoFormat{DateLayout: &layout})
+	assert.NoError(t, err)
+
+	layout = ""
+	_, err = withOverride(DocTypeSale, defaultFormats[DocTypeSale], config.DocNoFormat{DateLayout: &layout, Reset: ResetNever})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,13 @@
+package goodsreceipt
+
+type GoodsReceiptRequestDTO struct {
+	PurchaseOrderId string                       `json:"purchaseOrderId"`
+	Items           []GoodsReceiptItemRequestDTO `json:"items"`
+	Remark          string                       `json:"remark"`
+	ReceiptDate     string                       `json:"receiptDate"`
+}
+
+type GoodsReceiptItemRequestDTO struct {
+	PurchaseOrderLineId uint `json:"purchaseOrderLineId"`
+	Qty                 int  `json:"qty"` // in the unit of the order line, at most what is still to come
+}
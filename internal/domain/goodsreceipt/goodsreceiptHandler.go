@@ -0,0 +1,132 @@
+package goodsreceipt
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
+)
+
+type GoodsReceiptHandler struct {
+	svc GoodsReceiptServiceInterface
+}
+
+func NewGoodsReceiptHandler(svc GoodsReceiptServiceInterface) *GoodsReceiptHandler {
+	log.Println(util.Magenta + "GoodsReceiptHandler constructor is called" + util.Reset)
+	return &GoodsReceiptHandler{svc: svc}
+}
+
+// CreateGoodsReceipt godoc
+//
+//	@Summary		Receive goods of a purchase order
+//	@Description	Book goods that arrived for a sent purchase order into the stock of its location; a line may receive part of what is still to come
+//	@Tags			GoodsReceipts
+//	@Accept			json
+//	@Produce		json
+//	@Param			receipt	body		GoodsReceiptRequestDTO	true	"Goods receipt"
+//	@Success		201		{object}	models.GoodsReceipt
+//	@Failure		400		{object}	apperr.Response
+//	@Failure		401		{object}	apperr.Response
+//	@Failure		404		{object}	apperr.Response
+//	@Failure		409		{object}	apperr.Response
+//	@Failure		500		{object}	apperr.Response
+//	@Router			/api/goodsreceipts [post]
+//	@Security		Bearer
+func (h *GoodsReceiptHandler) CreateGoodsReceipt(c *fiber.Ctx) error {
+	input := new(GoodsReceiptRequestDTO)
+	if err := c.BodyParser(input); err != nil {
+		return apperr.Validation("invalid JSON format")
+	}
+
+	receipt := models.GoodsReceipt{
+		PurchaseOrderId: input.PurchaseOrderId,
+		Remark:          input.Remark,
+		ReceiptDate:     input.ReceiptDate,
+	}
+	for _, item := range input.Items {
+		receipt.GoodsReceiptLines = append(receipt.GoodsReceiptLines, models.GoodsReceiptLine{
+			PurchaseOrderLineId: item.PurchaseOrderLineId,
+			Qty:                 item.Qty,
+		})
+	}
+
+	created, err := h.svc.Create(&receipt)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "goods receipt " + created.ID + " has been created successfully",
+		"data":    created,
+	})
+}
+
+// GetAllGoodsReceipts godoc
+//
+//	@Summary		Fetch all goods receipts
+//	@Description	Fetch all goods receipts with their lines
+//	@Tags			GoodsReceipts
+//	@Accept			json
+//	@Produce		json
+//	@Param			page			query		int		false	"page number, from 1"
+//	@Param			limit			query		int		false	"rows per page, 20 by default and 100 at most"
+//	@Param			sort			query		string	false	"comma separated fields of id, receiptDate, createdAt; prefix - for descending"
+//	@Param			purchaseOrderId	query		string	false	"only the receipts of this purchase order"
+//	@Param			purchaseId		query		string	false	"only the receipts billed by this purchase invoice"
+//	@Param			supplierId		query		int		false	"only the receipts from this supplier"
+//	@Param			locationId		query		int		false	"only the receipts into this location"
+//	@Param			from			query		string	false	"first receipt date, yyyy-mm-dd"
+//	@Param			to				query		string	false	"last receipt date, yyyy-mm-dd"
+//	@Success		200				{array}		models.GoodsReceipt
+//	@Failure		400				{object}	apperr.Response
+//	@Failure		401				{object}	apperr.Response
+//	@Failure		500				{object}	apperr.Response
+//	@Router			/api/goodsreceipts [get]
+//	@Security		Bearer
+func (h *GoodsReceiptHandler) GetAllGoodsReceipts(c *fiber.Ctx) error {
+	spec, err := query.Parse(c.Queries(), receiptList)
+	if err != nil {
+		return err
+	}
+	receipts, page, err := h.svc.GetAll(spec)
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": strconv.Itoa(len(receipts)) + " records found",
+		"data":    receipts,
+		"meta":    page,
+	})
+}
+
+// GetGoodsReceiptById godoc
+//
+//	@Summary		Fetch individual goods receipt by Id
+//	@Description	Fetch individual goods receipt by Id
+//	@Tags			GoodsReceipts
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"goods receipt Id"
+//	@Success		200	{object}	models.GoodsReceipt
+//	@Failure		401	{object}	apperr.Response
+//	@Failure		404	{object}	apperr.Response
+//	@Failure		500	{object}	apperr.Response
+//	@Router			/api/goodsreceipts/{id} [get]
+//	@Security		Bearer
+func (h *GoodsReceiptHandler) GetGoodsReceiptById(c *fiber.Ctx) error {
+	receipt, err := h.svc.GetById(c.Params("id"))
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "Record found",
+		"data":    receipt,
+	})
+}
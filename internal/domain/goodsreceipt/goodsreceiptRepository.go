@@ -0,0 +1,171 @@
+package goodsreceipt
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/docnumber"
+	"github.com/sankangkin/di-rest-api/internal/domain/stockmovement"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GoodsReceiptRepositoryInterface interface {
+	Create(receipt *models.GoodsReceipt) (*models.GoodsReceipt, error)
+	GetAll(spec query.Spec) ([]models.GoodsReceipt, query.Page, error)
+	GetById(id string) (*models.GoodsReceipt, error)
+}
+
+type GoodsReceiptRepository struct {
+	db    *gorm.DB
+	stock stockmovement.StockMovementServiceInterface
+	docNo docnumber.DocNumberServiceInterface
+}
+
+func NewGoodsReceiptRepository(db *gorm.DB, stock stockmovement.StockMovementServiceInterface, docNo docnumber.DocNumberServiceInterface) GoodsReceiptRepositoryInterface {
+	log.Println(util.Magenta + "GoodsReceiptRepository constructor is called" + util.Reset)
+	return &GoodsReceiptRepository{db: db, stock: stock, docNo: docNo}
+}
+
+// Create receives goods for a sent purchase order: every line books its
+// quantity into the stock of the order's location at the order price, which
+// moves the product's average cost, and onto the received quantity of its
+// order line, and the order becomes partially received or received. The order is locked for the whole receipt, so two receipts
+// cannot both take what is left of a line.
+func (r *GoodsReceiptRepository) Create(input *models.GoodsReceipt) (*models.GoodsReceipt, error) {
+	receipt := models.GoodsReceipt{
+		Remark:      input.Remark,
+		ReceiptDate: input.ReceiptDate,
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var order models.PurchaseOrder
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Preload("PurchaseOrderLines").
+			First(&order, "id = ?", input.PurchaseOrderId).Error; err != nil {
+			return err
+		}
+		if order.Status != models.PurchaseOrderSent && order.Status != models.PurchaseOrderPartiallyReceived {
+			return apperr.Conflict("purchase order %s is %s, only a sent order can be received", order.ID, order.Status)
+		}
+		receipt.PurchaseOrderId = order.ID
+		receipt.SupplierId = order.SupplierId
+		receipt.LocationId = order.LocationId
+
+		for _, item := range input.GoodsReceiptLines {
+			ol := findOrderLine(order.PurchaseOrderLines, item.PurchaseOrderLineId)
+			if ol == nil {
+				return apperr.Validation("purchase order line %d does not belong to purchase order %s", item.PurchaseOrderLineId, order.ID)
+			}
+			if remaining := ol.Qty - ol.ReceivedQty; item.Qty > remaining {
+				return apperr.Conflict("cannot receive %d %s of purchase order line %d: only %d still to come", item.Qty, ol.UnitName, ol.ID, remaining)
+			}
+			ol.ReceivedQty += item.Qty
+
+			receipt.GoodsReceiptLines = append(receipt.GoodsReceiptLines, models.GoodsReceiptLine{
+				PurchaseOrderLineId: ol.ID,
+				ProductId:           ol.ProductId,
+				Qty:                 item.Qty,
+				UnitName:            ol.UnitName,
+				Price:               ol.Price,
+			})
+		}
+
+		// the receipt number is allocated on tx, a failed receipt gives it back
+		id, err := r.docNo.Next(tx, docnumber.DocTypeReceipt)
+		if err != nil {
+			return err
+		}
+		receipt.ID = id
+
+		if err := tx.Create(&receipt).Error; err != nil {
+			return err
+		}
+
+		for i := range receipt.GoodsReceiptLines {
+			rl := &receipt.GoodsReceiptLines[i]
+			in, err := r.stock.Apply(tx, stockmovement.Movement{
+				ProductId:   rl.ProductId,
+				LocationId:  receipt.LocationId,
+				Uom:         rl.UnitName,
+				Qty:         rl.Qty,
+				TranType:    "DEBIT",
+				UnitCost:    rl.Price,
+				ReferenceNo: receipt.ID + "-" + strconv.Itoa(int(rl.ID)),
+				Remark: fmt.Sprintf(
+					"GoodsReceiptId:%s, PurchaseOrderId:%s, line item id:%d, increase %d %s",
+					receipt.ID, order.ID, rl.ID, rl.Qty, rl.UnitName,
+				),
+			})
+			if err != nil {
+				return err
+			}
+			// the line keeps the unit name the stock was actually booked in,
+			// the invoice is matched on it
+			rl.UnitName = in.Uom
+			if err := tx.Model(rl).Update("unit_name", rl.UnitName).Error; err != nil {
+				return err
+			}
+		}
+
+		for i := range order.PurchaseOrderLines {
+			ol := &order.PurchaseOrderLines[i]
+			if err := tx.Model(ol).Update("received_qty", ol.ReceivedQty).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&order).Update("status", receivedStatus(order.PurchaseOrderLines)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetById(receipt.ID)
+}
+
+func findOrderLine(lines []models.PurchaseOrderLine, id uint) *models.PurchaseOrderLine {
+	for i := range lines {
+		if lines[i].ID == id {
+			return &lines[i]
+		}
+	}
+	return nil
+}
+
+// receiptList is what GET /goodsreceipts sorts and filters on.
+var receiptList = query.Resource{
+	Sort: map[string]string{
+		"id":          "id",
+		"receiptDate": "receipt_date",
+		"createdAt":   "created_at",
+	},
+	DefaultSort: "-createdAt",
+	Filters: map[string]query.Filter{
+		"purchaseOrderId": {Column: "purchase_order_id", Kind: query.Code},
+		"purchaseId":      {Column: "purchase_id", Kind: query.Code},
+		"supplierId":      {Column: "supplier_id", Kind: query.Uint},
+		"locationId":      {Column: "location_id", Kind: query.Uint},
+	},
+	Dates: query.Filter{Column: "receipt_date", Kind: query.DateText},
+	Key:   "id",
+}
+
+func (r *GoodsReceiptRepository) GetAll(spec query.Spec) ([]models.GoodsReceipt, query.Page, error) {
+	receipts := []models.GoodsReceipt{}
+	page, err := query.Find(r.db.Model(&models.GoodsReceipt{}), spec, &receipts, "GoodsReceiptLines")
+	return receipts, page, err
+}
+
+func (r *GoodsReceiptRepository) GetById(id string) (*models.GoodsReceipt, error) {
+	var receipt models.GoodsReceipt
+	if err := r.db.Preload("GoodsReceiptLines").First(&receipt, "id = ?", strings.ToUpper(id)).Error; err != nil {
+		return nil, err
+	}
+	return &receipt, nil
+}
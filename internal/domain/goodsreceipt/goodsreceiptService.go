@@ -0,0 +1,73 @@
+package goodsreceipt
+
+import (
+	"log"
+	"strings"
+
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
+)
+
+type GoodsReceiptServiceInterface interface {
+	Create(receipt *models.GoodsReceipt) (*models.GoodsReceipt, error)
+	GetAll(spec query.Spec) ([]models.GoodsReceipt, query.Page, error)
+	GetById(id string) (*models.GoodsReceipt, error)
+}
+
+type GoodsReceiptService struct {
+	repo GoodsReceiptRepositoryInterface
+}
+
+func NewGoodsReceiptService(repo GoodsReceiptRepositoryInterface) GoodsReceiptServiceInterface {
+	log.Println(util.Magenta + "GoodsReceiptService constructor is called" + util.Reset)
+	return &GoodsReceiptService{repo: repo}
+}
+
+// validate checks a receipt before the order is locked: it names an order
+// and receives a positive quantity of at least one order line.
+func validate(receipt *models.GoodsReceipt) error {
+	receipt.PurchaseOrderId = strings.ToUpper(strings.TrimSpace(receipt.PurchaseOrderId))
+	if receipt.PurchaseOrderId == "" {
+		return apperr.Validation("a goods receipt needs a purchase order")
+	}
+	if len(receipt.GoodsReceiptLines) == 0 {
+		return apperr.Validation("a goods receipt needs at least one line item")
+	}
+	for _, rl := range receipt.GoodsReceiptLines {
+		if rl.PurchaseOrderLineId == 0 {
+			return apperr.Validation("every goods receipt line needs a purchase order line")
+		}
+		if rl.Qty <= 0 {
+			return apperr.Validation("received quantity for purchase order line %d must be greater than zero", rl.PurchaseOrderLineId)
+		}
+	}
+	return nil
+}
+
+// receivedStatus is the status of an order after a receipt: RECEIVED once
+// every line has come in full, PARTIALLY_RECEIVED before that.
+func receivedStatus(lines []models.PurchaseOrderLine) string {
+	for _, l := range lines {
+		if l.ReceivedQty < l.Qty {
+			return models.PurchaseOrderPartiallyReceived
+		}
+	}
+	return models.PurchaseOrderReceived
+}
+
+func (s *GoodsReceiptService) Create(receipt *models.GoodsReceipt) (*models.GoodsReceipt, error) {
+	if err := validate(receipt); err != nil {
+		return nil, err
+	}
+	return s.repo.Create(receipt)
+}
+
+func (s *GoodsReceiptService) GetAll(spec query.Spec) ([]models.GoodsReceipt, query.Page, error) {
+	return s.repo.GetAll(spec)
+}
+
+func (s *GoodsReceiptService) GetById(id string) (*models.GoodsReceipt, error) {
+	return s.repo.GetById(id)
+}
@@ -0,0 +1,44 @@
+package goodsreceipt
+
+import (
+	"testing"
+
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	receipt := &models.GoodsReceipt{
+		PurchaseOrderId:   " ord-2026-10-000001 ",
+		GoodsReceiptLines: []models.GoodsReceiptLine{{PurchaseOrderLineId: 4, Qty: 2}},
+	}
+	require.NoError(t, validate(receipt))
+	assert.Equal(t, "ORD-2026-10-000001", receipt.PurchaseOrderId)
+
+	for name, receipt := range map[string]*models.GoodsReceipt{
+		"no order":     {GoodsReceiptLines: []models.GoodsReceiptLine{{PurchaseOrderLineId: 4, Qty: 2}}},
+		"no lines":     {PurchaseOrderId: "ORD-1"},
+		"no line id":   {PurchaseOrderId: "ORD-1", GoodsReceiptLines: []models.GoodsReceiptLine{{Qty: 2}}},
+		"zero qty":     {PurchaseOrderId: "ORD-1", GoodsReceiptLines: []models.GoodsReceiptLine{{PurchaseOrderLineId: 4}}},
+		"negative qty": {PurchaseOrderId: "ORD-1", GoodsReceiptLines: []models.GoodsReceiptLine{{PurchaseOrderLineId: 4, Qty: -1}}},
+	} {
+		assert.ErrorIs(t, validate(receipt), apperr.ErrValidation, name)
+	}
+}
+
+func TestReceivedStatus(t *testing.T) {
+	assert.Equal(t, models.PurchaseOrderPartiallyReceived, receivedStatus([]models.PurchaseOrderLine{
+		{Qty: 10, ReceivedQty: 10},
+		{Qty: 5, ReceivedQty: 2},
+	}))
+	assert.Equal(t, models.PurchaseOrderPartiallyReceived, receivedStatus([]models.PurchaseOrderLine{
+		{Qty: 10, ReceivedQty: 4},
+		{Qty: 5},
+	}))
+	assert.Equal(t, models.PurchaseOrderReceived, receivedStatus([]models.PurchaseOrderLine{
+		{Qty: 10, ReceivedQty: 10},
+		{Qty: 5, ReceivedQty: 5},
+	}))
+}
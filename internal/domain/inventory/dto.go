@@ -1,10 +1,11 @@
 package inventory
 
 type IncreaseInventoryDTO struct {
-	OutQty    int    `json:"outQty"`
-	InQty     int    `json:"inQty"`
-	ProductId string `json:"productId"`
-	Remark    string `json:"remark"`
+	OutQty     int    `json:"outQty"`
+	InQty      int    `json:"inQty"`
+	ProductId  string `json:"productId"`
+	LocationId uint   `json:"locationId"` // the default location when empty
+	Remark     string `json:"remark"`
 }
 
 type ResponseInventoryDTO struct {
@@ -4,28 +4,21 @@ import (
 	"log"
 	"net/http"
 	"strconv"
-	"sync"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 )
 
 type InventoryHandler struct {
 	svc InventoryServiceInterface
 }
 
-var (
-	hdlInstance *InventoryHandler
-	hdlOnce     sync.Once
-)
-
 func NewInventoryHandler(svc InventoryServiceInterface) *InventoryHandler {
 	log.Println(util.Cyan + "InventoryHandler constructor is called" + util.Reset)
-	hdlOnce.Do(func() {
-		hdlInstance = &InventoryHandler{svc: svc}
-	})
-	return hdlInstance
+	return &InventoryHandler{svc: svc}
 }
 
 // GetAllInventories godoc
@@ -35,23 +28,36 @@ func NewInventoryHandler(svc InventoryServiceInterface) *InventoryHandler {
 //	@Tags			Inventories
 //	@Accept			json
 //	@Produce		json
+//	@Param			page	query		int		false	"page number, from 1"
+//	@Param			limit	query		int		false	"rows per page, 20 by default and 100 at most"
+//	@Param			sort	query		string	false	"comma separated fields of createdAt, productId; prefix - for descending"
+//	@Param			productId	query		string	false	"only the movements of this product"
+//	@Param			tranType	query		string	false	"DEBIT, CREDIT, ADJUSTMENT or TRANSFER"
+//	@Param			locationId	query		int	false	"only the movements at this location"
+//	@Param			from	query		string	false	"first day, yyyy-mm-dd"
+//	@Param			to	query		string	false	"last day, yyyy-mm-dd"
 //	@Success		200				{array}		models.Inventory
-//	@Failure		400				{object}	httputil.HttpError400
-//	@Failure		401				{object}	httputil.HttpError401
-//	@Failure		500				{object}	httputil.HttpError500
+//	@Failure		400				{object}	apperr.Response
+//	@Failure		401				{object}	apperr.Response
+//	@Failure		500				{object}	apperr.Response
 //	@Router			/api/inventories	[get]
 //	@Security		Bearer
 func (h *InventoryHandler) GetAllInventories(c *fiber.Ctx) error {
 	// inventories, err := h.svc.GetAllService()
-	inventories, err := h.svc.GetInvData()
+	spec, err := query.Parse(c.Queries(), inventoryList)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return err
+	}
+	inventories, page, err := h.svc.GetInvData(spec)
+	if err != nil {
+		return err
 	}
 	return c.Status(http.StatusOK).JSON(
 		&fiber.Map{
 			"status":  "SUCCESS",
 			"message": strconv.Itoa(len(inventories)) + " records found",
 			"data":    inventories,
+			"meta":    page,
 		})
 }
 
@@ -63,40 +69,32 @@ func (h *InventoryHandler) GetAllInventories(c *fiber.Ctx) error {
 //	@Accept			json
 //	@Param			inventory	body		IncreaseInventoryDTO	true	"Inventory Data"
 //	@Success		200		{object}	models.Inventory
-//	@Failure		400		{object}	httputil.HttpError400
-//	@Failure		401		{object}	httputil.HttpError401
-//	@Failure		500		{object}	httputil.HttpError500
-//	@Failure		401		{object}	httputil.HttpError401
+//	@Failure		400		{object}	apperr.Response
+//	@Failure		401		{object}	apperr.Response
+//	@Failure		500		{object}	apperr.Response
+//	@Failure		401		{object}	apperr.Response
 //	@Router			/api/inventories/increase [post]
 //	@Security		Bearer
 func (h *InventoryHandler) IncreaseInventory(c *fiber.Ctx) error {
 
 	input := new(IncreaseInventoryDTO)
 	if err := c.BodyParser(input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  400,
-			"message": "Invalid JSON format",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 	newInventory := models.Inventory{
-		InQty:     input.InQty,
-		OutQty:    input.OutQty,
-		ProductId: input.ProductId,
-		Remark:    input.Remark,
+		InQty:      input.InQty,
+		OutQty:     input.OutQty,
+		ProductId:  input.ProductId,
+		LocationId: input.LocationId,
+		Remark:     input.Remark,
 	}
-	errors := models.ValidateStruct(newInventory)
-	if errors != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "operation failed",
-		})
+	if err := models.ValidateStruct(newInventory); err != nil {
+		return err
 	}
 
 	msg, err := h.svc.IncreaseInventoryService(&newInventory)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "FAIL",
-			"message": err.Error(),
-		})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "success",
@@ -112,40 +110,32 @@ func (h *InventoryHandler) IncreaseInventory(c *fiber.Ctx) error {
 //	@Accept			json
 //	@Param			inventory	body		IncreaseInventoryDTO	true	"Inventory Data"
 //	@Success		200		{object}	models.Inventory
-//	@Failure		400		{object}	httputil.HttpError400
-//	@Failure		401		{object}	httputil.HttpError401
-//	@Failure		500		{object}	httputil.HttpError500
-//	@Failure		401		{object}	httputil.HttpError401
+//	@Failure		400		{object}	apperr.Response
+//	@Failure		401		{object}	apperr.Response
+//	@Failure		500		{object}	apperr.Response
+//	@Failure		401		{object}	apperr.Response
 //	@Router			/api/inventories/decrease [post]
 //	@Security		Bearer
 func (h *InventoryHandler) DecreaseInventory(c *fiber.Ctx) error {
 
 	input := new(IncreaseInventoryDTO)
 	if err := c.BodyParser(input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  400,
-			"message": "Invalid JSON format",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 	newInventory := models.Inventory{
-		InQty:     input.InQty,
-		OutQty:    input.OutQty,
-		ProductId: input.ProductId,
-		Remark:    input.Remark,
+		InQty:      input.InQty,
+		OutQty:     input.OutQty,
+		ProductId:  input.ProductId,
+		LocationId: input.LocationId,
+		Remark:     input.Remark,
 	}
-	errors := models.ValidateStruct(newInventory)
-	if errors != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "operation failed",
-		})
+	if err := models.ValidateStruct(newInventory); err != nil {
+		return err
 	}
 
 	msg, err := h.svc.DecreaseInventoryService(&newInventory)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "FAIL",
-			"message": err.Error(),
-		})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "success",
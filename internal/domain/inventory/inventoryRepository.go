@@ -1,13 +1,15 @@
 package inventory
 
 import (
-	"errors"
 	"log"
 	"strconv"
-	"sync"
+	"time"
 
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/stockmovement"
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 	"gorm.io/gorm"
 )
 
@@ -15,42 +17,30 @@ type InventoryRepositoryInterface interface {
 	Increase(inventory *models.Inventory) (string, error)
 	Decrease(inventory *models.Inventory) (string, error)
 	Get() ([]models.Product, error)
-	GetInvData() ([]ResponseInventoryDTO, error)
+	GetInvData(spec query.Spec) ([]ResponseInventoryDTO, query.Page, error)
 }
 
 type InventoryRepository struct {
-	db *gorm.DB
+	db    *gorm.DB
+	stock stockmovement.StockMovementServiceInterface
 }
 
-var (
-	repoInstance *InventoryRepository
-	repoOnce     sync.Once
-)
-
-func NewInventoryRepository(db *gorm.DB) InventoryRepositoryInterface {
+func NewInventoryRepository(db *gorm.DB, stock stockmovement.StockMovementServiceInterface) InventoryRepositoryInterface {
 	log.Println(util.Cyan + "InventoryRepository constructor is called" + util.Reset)
-	repoOnce.Do(func() {
-		repoInstance = &InventoryRepository{db: db}
-	})
-	return repoInstance
+	return &InventoryRepository{db: db, stock: stock}
 }
 
 func (r *InventoryRepository) Increase(input *models.Inventory) (string, error) {
 
 	newInventory := models.Inventory{
-		InQty:     input.InQty,
-		OutQty:    input.OutQty,
-		ProductId: input.ProductId,
-		Remark:    input.Remark,
+		InQty:      input.InQty,
+		OutQty:     input.OutQty,
+		ProductId:  input.ProductId,
+		LocationId: input.LocationId,
+		Remark:     input.Remark,
 	}
-
-	newItemTransaction := models.ItemTransaction{
-		InQty:       input.InQty,
-		OutQty:      input.OutQty,
-		ProductId:   input.ProductId,
-		TranType:    "DEBIT",
-		ReferenceNo: strconv.Itoa(int(input.ID)),
-		Remark:      input.Remark,
+	if newInventory.InQty <= 0 {
+		return "", apperr.Validation("inQty must be greater than zero")
 	}
 
 	tx := r.db.Begin()
@@ -63,47 +53,47 @@ func (r *InventoryRepository) Increase(input *models.Inventory) (string, error)
 	if err := tx.Error; err != nil {
 		return "", err
 	}
-	if err := tx.Create(&newInventory).Error; err != nil {
+	location, err := r.stock.ResolveLocation(tx, newInventory.LocationId)
+	if err != nil {
 		tx.Rollback()
 		return "", err
 	}
-	if err := tx.Create(&newItemTransaction).Error; err != nil {
+	newInventory.LocationId = location.ID
+	if err := tx.Create(&newInventory).Error; err != nil {
 		tx.Rollback()
 		return "", err
 	}
 
-	var product models.Product
-	result := tx.First(&product, "id = ?", input.ProductId)
-	if err := result.Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			tx.Rollback()
-			return "", err
-		}
+	trx, err := r.stock.Apply(tx, stockmovement.Movement{
+		ProductId:   input.ProductId,
+		LocationId:  location.ID,
+		Qty:         input.InQty,
+		TranType:    "DEBIT",
+		ReferenceNo: strconv.Itoa(int(newInventory.ID)),
+		Remark:      input.Remark,
+	})
+	if err != nil {
+		tx.Rollback()
 		return "", err
 	}
-	// product.QtyOnHand += int(input.InQty)
-	tx.Save(&product)
-	tx.Commit()
-	message := input.ProductId + " is increased by " + strconv.Itoa(int(input.InQty)) + " EACH"
+	if err := tx.Commit().Error; err != nil {
+		return "", err
+	}
+	message := input.ProductId + " is increased by " + strconv.Itoa(int(input.InQty)) + " " + trx.Uom + " at " + location.Code
 
 	return message, nil
 }
 
 func (r *InventoryRepository) Decrease(input *models.Inventory) (string, error) {
 	newInventory := models.Inventory{
-		InQty:     input.InQty,
-		OutQty:    input.OutQty,
-		ProductId: input.ProductId,
-		Remark:    input.Remark,
+		InQty:      input.InQty,
+		OutQty:     input.OutQty,
+		ProductId:  input.ProductId,
+		LocationId: input.LocationId,
+		Remark:     input.Remark,
 	}
-
-	newItemTransaction := models.ItemTransaction{
-		InQty:       int(input.InQty),
-		OutQty:      int(input.OutQty),
-		ProductId:   input.ProductId,
-		TranType:    "CREDIT",
-		ReferenceNo: strconv.Itoa(int(input.ID)),
-		Remark:      input.Remark,
+	if newInventory.OutQty <= 0 {
+		return "", apperr.Validation("outQty must be greater than zero")
 	}
 
 	tx := r.db.Begin()
@@ -116,71 +106,95 @@ func (r *InventoryRepository) Decrease(input *models.Inventory) (string, error)
 	if err := tx.Error; err != nil {
 		return "", err
 	}
-	if err := tx.Create(&newInventory).Error; err != nil {
+	location, err := r.stock.ResolveLocation(tx, newInventory.LocationId)
+	if err != nil {
 		tx.Rollback()
 		return "", err
 	}
-	if err := tx.Create(&newItemTransaction).Error; err != nil {
+	newInventory.LocationId = location.ID
+	if err := tx.Create(&newInventory).Error; err != nil {
 		tx.Rollback()
 		return "", err
 	}
 
-	var product models.Product
-	result := tx.First(&product, "id = ?", input.ProductId)
-	if err := result.Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			tx.Rollback()
-			return "", err
-		}
+	trx, err := r.stock.Apply(tx, stockmovement.Movement{
+		ProductId:   input.ProductId,
+		LocationId:  location.ID,
+		Qty:         -input.OutQty,
+		TranType:    "CREDIT",
+		ReferenceNo: strconv.Itoa(int(newInventory.ID)),
+		Remark:      input.Remark,
+	})
+	if err != nil {
+		tx.Rollback()
+		return "", err
+	}
+	if err := tx.Commit().Error; err != nil {
 		return "", err
 	}
-	// product.QtyOnHand -= int(input.OutQty)
-	tx.Save(&product)
-	tx.Commit()
-	message := input.ProductId + " is decrease by " + strconv.Itoa(int(input.OutQty)) + " EACH"
+	message := input.ProductId + " is decrease by " + strconv.Itoa(int(input.OutQty)) + " " + trx.Uom + " at " + location.Code
 
 	return message, nil
 }
 
 func (r *InventoryRepository) Get() ([]models.Product, error) {
 	inventories := []models.Product{}
-	r.db.Preload("Product").Model(&models.Inventory{}).Order("ID desc").Find(&inventories)
-	if len(inventories) == 0 {
-		return nil, errors.New("NO records found")
-	}
+	err := r.db.Preload("Product").Model(&models.Inventory{}).Order("ID desc").Find(&inventories).Error
+	return inventories, err
+}
 
-	return inventories, nil
+// inventoryList is what GET /inventories sorts and filters on.
+var inventoryList = query.Resource{
+	Sort: map[string]string{
+		"createdAt": "it.created_at",
+		"productId": "it.product_id",
+	},
+	DefaultSort: "-createdAt",
+	Filters: map[string]query.Filter{
+		"productId":  {Column: "it.product_id", Kind: query.Code},
+		"tranType":   {Column: "it.tran_type", Kind: query.Code},
+		"locationId": {Column: "it.location_id", Kind: query.Uint},
+	},
+	Dates: query.Filter{Column: "it.created_at", Kind: query.Date},
+	Key:   "it.id",
 }
 
-func (r *InventoryRepository) GetInvData() ([]ResponseInventoryDTO, error) {
-	var products []models.Product
-	var result []ResponseInventoryDTO
+type inventoryRow struct {
+	ProductId   string
+	ProductName string
+	InQty       int
+	OutQty      int
+	TranType    string
+	Remark      string
+	CreatedAt   time.Time
+}
 
-	err := r.db.
-		Preload("ItemTransactions", func(db *gorm.DB) *gorm.DB {
-			return db.Order("created_at DESC")
-		}).
-		Find(&products).Error
+func (r *InventoryRepository) GetInvData(spec query.Spec) ([]ResponseInventoryDTO, query.Page, error) {
+	var rows []inventoryRow
+
+	db := r.db.
+		Table("item_transactions AS it").
+		Select("it.product_id, p.product_name, it.in_qty, it.out_qty, it.tran_type, it.remark, it.created_at").
+		Joins("JOIN products AS p ON p.id = it.product_id").
+		Where("it.deleted_at IS NULL AND p.deleted_at IS NULL")
+
+	page, err := query.Find(db, spec, &rows)
 	if err != nil {
-		return nil, err
-	}
-
-	for _, p := range products {
-		for _, it := range p.ItemTransactions {
-			dto := ResponseInventoryDTO{
-				ProductName: p.ProductName,
-				OutQty:      it.OutQty, // Fixed: using it instead of inv
-				InQty:       it.InQty,  // Fixed: using it instead of inv
-				ProductId:   p.ID,
-				Remark:      it.Remark,   // Fixed: using it instead of inv
-				TranType:    it.TranType, // Fixed: using it instead of inv
-				// QtyOnHand:   p.QtyOnHand,
-				// CreatedAt:   time.Unix(int64(it.CreatedAt), 0), // Fixed: using it instead of inv
-				CreatedAt: it.CreatedAt.Format("15:04:05 2006-01-02"),
-			}
-			result = append(result, dto)
-		}
+		return nil, query.Page{}, err
+	}
+
+	result := []ResponseInventoryDTO{}
+	for _, it := range rows {
+		result = append(result, ResponseInventoryDTO{
+			ProductName: it.ProductName,
+			OutQty:      it.OutQty,
+			InQty:       it.InQty,
+			ProductId:   it.ProductId,
+			Remark:      it.Remark,
+			TranType:    it.TranType,
+			CreatedAt:   it.CreatedAt.Format("15:04:05 2006-01-02"),
+		})
 	}
 
-	return result, nil
+	return result, page, nil
 }
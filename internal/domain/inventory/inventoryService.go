@@ -2,35 +2,27 @@ package inventory
 
 import (
 	"log"
-	"sync"
 
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 )
 
 type InventoryServiceInterface interface {
 	IncreaseInventoryService(inventory *models.Inventory) (string, error)
 	DecreaseInventoryService(inventory *models.Inventory) (string, error)
 	GetAllService() ([]models.Product, error)
-	GetInvData() ([]ResponseInventoryDTO, error)
+	GetInvData(spec query.Spec) ([]ResponseInventoryDTO, query.Page, error)
 }
 
 type InventoryService struct {
 	repo InventoryRepositoryInterface
 }
 
-var (
-	svcInstance *InventoryService
-	svcOnce     sync.Once
-)
-
 func NewInventoryService(repo InventoryRepositoryInterface) InventoryServiceInterface {
 	log.Println(util.Cyan + "InventoryService constructor is called" + util.Reset)
 
-	svcOnce.Do(func() {
-		svcInstance = &InventoryService{repo: repo}
-	})
-	return svcInstance
+	return &InventoryService{repo: repo}
 }
 
 func (s *InventoryService) IncreaseInventoryService(inventory *models.Inventory) (string, error) {
@@ -42,6 +34,6 @@ func (s *InventoryService) DecreaseInventoryService(inventory *models.Inventory)
 func (s *InventoryService) GetAllService() ([]models.Product, error) {
 	return s.repo.Get()
 }
-func (s *InventoryService) GetInvData() ([]ResponseInventoryDTO, error) {
-	return s.repo.GetInvData()
+func (s *InventoryService) GetInvData(spec query.Spec) ([]ResponseInventoryDTO, query.Page, error) {
+	return s.repo.GetInvData(spec)
 }
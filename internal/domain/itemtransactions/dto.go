@@ -12,6 +12,7 @@ type ItemTransactionDTO struct {
 
 type ResquestAdjustInventoryDTO struct {
 	ProductId   string `json:"productId"`
+	LocationId  uint   `json:"locationId"` // the default location when empty
 	BaseQty     int    `json:"baseQty"`
 	DerivedQty  int    `json:"derivedQty"`
 	InQty       int    `json:"inQty"`  // Quantity to be added
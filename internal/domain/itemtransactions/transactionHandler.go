@@ -4,29 +4,21 @@ import (
 	"log"
 	"net/http"
 	"strconv"
-	"sync"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
-	"gorm.io/gorm"
+	"github.com/sankangkin/di-rest-api/internal/query"
 )
 
 type TransactionHandler struct {
 	svc TransactionServiceInterface
 }
 
-var (
-	hdlInstance *TransactionHandler
-	hdlOnce     sync.Once
-)
-
 func NewTransactionHandler(svc TransactionServiceInterface) *TransactionHandler {
 	log.Println(util.Green + "TransactionHandler constructor is called" + util.Reset)
-	hdlOnce.Do(func() {
-		hdlInstance = &TransactionHandler{svc: svc}
-	})
-	return hdlInstance
+	return &TransactionHandler{svc: svc}
 }
 
 // GetAllTransactions godoc
@@ -36,26 +28,38 @@ func NewTransactionHandler(svc TransactionServiceInterface) *TransactionHandler
 //	@Tags			Transactions
 //	@Accept			json
 //	@Produce		json
+//	@Param			cursor	query		string	false	"nextCursor of the previous page"
+//	@Param			limit	query		int		false	"rows per page, 20 by default and 100 at most"
+//	@Param			sort	query		string	false	"id for oldest first, -id for newest first"
+//	@Param			productId	query		string	false	"only the movements of this product"
+//	@Param			tranType	query		string	false	"DEBIT, CREDIT, ADJUSTMENT or TRANSFER"
+//	@Param			locationId	query		int	false	"only the movements at this location"
+//	@Param			referenceNo	query		string	false	"only the movements of this reference"
+//	@Param			from	query		string	false	"first day, yyyy-mm-dd"
+//	@Param			to	query		string	false	"last day, yyyy-mm-dd"
 //	@Success		200				{array}		models.ItemTransaction
-//	@Failure		400				{object}	httputil.HttpError400
-//	@Failure		401				{object}	httputil.HttpError401
-//	@Failure		500				{object}	httputil.HttpError500
+//	@Failure		400				{object}	apperr.Response
+//	@Failure		401				{object}	apperr.Response
+//	@Failure		500				{object}	apperr.Response
 //	@Router			/api/transactions	[get]
 //	@Security		Bearer
 func (h *TransactionHandler) GetAll(c *fiber.Ctx) error {
 	newTransaction := models.ItemTransaction{}
 	log.Println(newTransaction)
-	transactions, err := h.svc.GetAll()
+	spec, err := query.Parse(c.Queries(), ledgerList)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
+	}
+	transactions, page, err := h.svc.GetAll(spec)
+	if err != nil {
+		return err
 	}
 	return c.Status(http.StatusOK).JSON(
 		&fiber.Map{
 			"status":  "SUCCESS",
 			"message": strconv.Itoa(len(transactions)) + " records found",
 			"data":    transactions,
+			"meta":    page,
 			"count":   len(transactions),
 		})
 }
@@ -68,33 +72,35 @@ func (h *TransactionHandler) GetAll(c *fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Param			id					path		string	true	"product Id"
+//	@Param			cursor	query		string	false	"nextCursor of the previous page"
+//	@Param			limit	query		int		false	"rows per page, 20 by default and 100 at most"
+//	@Param			sort	query		string	false	"-id for newest first (default), id for oldest first"
+//	@Param			locationId	query		int	false	"only the movements at this location"
+//	@Param			from	query		string	false	"first day, yyyy-mm-dd"
+//	@Param			to	query		string	false	"last day, yyyy-mm-dd"
 //	@Success		200					{array}	models.ItemTransaction
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/transactions/by-product/{productId} [get]
 //	@Security		Bearer
 func (h *TransactionHandler) GetTransactionsByProductId(c *fiber.Ctx) error {
 	productId := c.Params("productId")
 
-	transactions, err := h.svc.GetByProductId(productId)
+	spec, err := query.Parse(c.Queries(), ledgerHistory)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound || len(transactions) == 0 {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "No transactions found for the given product ID",
-			})
-		}
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
-			"status":  "FAIL",
-			"message": err.Error(),
-		})
+		return err
+	}
+	transactions, page, err := h.svc.GetByProductId(productId, spec)
+	if err != nil {
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "SUCCESS",
 		"message": "Transactions retrieved successfully",
 		"data":    transactions,
+		"meta":    page,
 		"count":   len(transactions),
 	})
 }
@@ -107,33 +113,35 @@ func (h *TransactionHandler) GetTransactionsByProductId(c *fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Param			id					path		string	true	"transactionType"
+//	@Param			cursor	query		string	false	"nextCursor of the previous page"
+//	@Param			limit	query		int		false	"rows per page, 20 by default and 100 at most"
+//	@Param			sort	query		string	false	"-id for newest first (default), id for oldest first"
+//	@Param			locationId	query		int	false	"only the movements at this location"
+//	@Param			from	query		string	false	"first day, yyyy-mm-dd"
+//	@Param			to	query		string	false	"last day, yyyy-mm-dd"
 //	@Success		200					{array}	models.ItemTransaction
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/transactions/by-type/{tranType} [get]
 //	@Security		Bearer
 func (h *TransactionHandler) GetTransactionsByTransactionType(c *fiber.Ctx) error {
 	tranType := c.Params("tranType")
 
-	transactions, err := h.svc.GetByTransactionType(tranType)
+	spec, err := query.Parse(c.Queries(), ledgerHistory)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound || len(transactions) == 0 {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "No transactions found for the given product ID",
-			})
-		}
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
-			"status":  "FAIL",
-			"message": err.Error(),
-		})
+		return err
+	}
+	transactions, page, err := h.svc.GetByTransactionType(tranType, spec)
+	if err != nil {
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "SUCCESS",
 		"message": "Transactions retrieved successfully",
 		"data":    transactions,
+		"meta":    page,
 		"count":   len(transactions),
 	})
 }
@@ -147,34 +155,36 @@ func (h *TransactionHandler) GetTransactionsByTransactionType(c *fiber.Ctx) erro
 //	@Produce		json
 //	@Param			productId					path		string	true	"Product ID"
 //	@Param			tranType					path		string	true	"Transaction Type"
+//	@Param			cursor	query		string	false	"nextCursor of the previous page"
+//	@Param			limit	query		int		false	"rows per page, 20 by default and 100 at most"
+//	@Param			sort	query		string	false	"-id for newest first (default), id for oldest first"
+//	@Param			locationId	query		int	false	"only the movements at this location"
+//	@Param			from	query		string	false	"first day, yyyy-mm-dd"
+//	@Param			to	query		string	false	"last day, yyyy-mm-dd"
 //	@Success		200					{array}	models.ItemTransaction
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/transactions/by-product-type/{productId}/{tranType} [get]
 //	@Security		Bearer
 func (h *TransactionHandler) GetByProductIdAndTranType(c *fiber.Ctx) error {
 	tranType := c.Params("tranType")
 	productId := c.Params("productId")
 
-	transactions, err := h.svc.GetByProductIdAndTranType(productId, tranType)
+	spec, err := query.Parse(c.Queries(), ledgerHistory)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound || len(transactions) == 0 {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "No transactions found for the given product ID",
-			})
-		}
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
-			"status":  "FAIL",
-			"message": err.Error(),
-		})
+		return err
+	}
+	transactions, page, err := h.svc.GetByProductIdAndTranType(productId, tranType, spec)
+	if err != nil {
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "SUCCESS",
 		"message": "Transactions retrieved successfully",
 		"data":    transactions,
+		"meta":    page,
 		"count":   len(transactions),
 	})
 }
@@ -188,27 +198,21 @@ func (h *TransactionHandler) GetByProductIdAndTranType(c *fiber.Ctx) error {
 // @Param        Authorization header   string                      true  "Bearer token"
 // @Param        transaction      body      ResquestAdjustInventoryDTO     true  "Product input data"
 // @Success      200          {object}  models.ItemTransaction
-// @Failure      400          {object}  httputil.HttpError400
-// @Failure      401          {object}  httputil.HttpError401
-// @Failure      500          {object}  httputil.HttpError500
+// @Failure      400          {object}  apperr.Response
+// @Failure      401          {object}  apperr.Response
+// @Failure      500          {object}  apperr.Response
 // @Router       /api/transactions/adjustment [post]
 //
 //	@Security		Bearer
 func (h *TransactionHandler) CreateAdjustmentTransaction(c *fiber.Ctx) error {
 	var transaction ResquestAdjustInventoryDTO
 	if err := c.BodyParser(&transaction); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "FAIL",
-			"message": "Invalid request body",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 
 	createdTransaction, err := h.svc.CreateAdjustmentTransaction(transaction)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "FAIL",
-			"message": err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
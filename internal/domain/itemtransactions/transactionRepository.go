@@ -1,129 +1,111 @@
 package itemtransactions
 
 import (
-	"errors"
 	"log"
 	"strings"
-	"sync"
 
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/stockmovement"
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 	"gorm.io/gorm"
 )
 
 type TransactionRepositoryInterface interface {
-	GetAll() ([]models.ItemTransaction, error)
-	GetByProductId(id string) ([]models.ItemTransaction, error)
-	GetByTransactionType(tranType string) ([]models.ItemTransaction, error)
-	GetByProductIdAndTranType(productId string, tran_type string) ([]models.ItemTransaction, error)
+	GetAll(spec query.Spec) ([]models.ItemTransaction, query.Page, error)
+	GetByProductId(id string, spec query.Spec) ([]models.ItemTransaction, query.Page, error)
+	GetByTransactionType(tranType string, spec query.Spec) ([]models.ItemTransaction, query.Page, error)
+	GetByProductIdAndTranType(productId string, tran_type string, spec query.Spec) ([]models.ItemTransaction, query.Page, error)
 	CreateAdjustmentTransaction(transaction ResquestAdjustInventoryDTO) (*models.ItemTransaction, error)
 }
 
 type TransactionRepository struct {
-	db *gorm.DB
+	db    *gorm.DB
+	stock stockmovement.StockMovementServiceInterface
 }
 
-var (
-	repoInstance *TransactionRepository
-	repoOnce     sync.Once
-)
-
-func NewTransactionRepository(db *gorm.DB) TransactionRepositoryInterface {
+func NewTransactionRepository(db *gorm.DB, stock stockmovement.StockMovementServiceInterface) TransactionRepositoryInterface {
 	log.Println(util.Green + "TransactionRepository constructor is called" + util.Reset)
-	repoOnce.Do(func() {
-		repoInstance = &TransactionRepository{db: db}
-	})
-	return repoInstance
+	return &TransactionRepository{db: db, stock: stock}
 }
 
-func (r *TransactionRepository) GetAll() ([]models.ItemTransaction, error) {
-	transactions := []models.ItemTransaction{}
-	r.db.Model(&models.ItemTransaction{}).Order("ID asc").Find(&transactions)
-	if len(transactions) == 0 {
-		return nil, errors.New("NO records found")
-	}
-	return transactions, nil
+// ledgerList is what GET /transactions filters on. The ledger only grows,
+// so it pages with a cursor on the id rather than with an offset.
+var ledgerList = query.Resource{
+	Sort:        map[string]string{"id": "id"},
+	DefaultSort: "id",
+	Filters: map[string]query.Filter{
+		"productId":   {Column: "product_id", Kind: query.Code},
+		"tranType":    {Column: "tran_type", Kind: query.Code},
+		"referenceNo": {Column: "reference_no"},
+		"locationId":  {Column: "location_id", Kind: query.Uint},
+	},
+	Dates:  query.Filter{Column: "created_at", Kind: query.Date},
+	Key:    "id",
+	Cursor: true,
 }
 
-func (r *TransactionRepository) GetByProductId(productId string) ([]models.ItemTransaction, error) {
-	var transactions []models.ItemTransaction
-
-	result := r.db.Where("product_id = ?",
-		strings.ToUpper(productId)).
-		Order("created_at DESC").
-		Find(&transactions)
-	if err := result.Error; err != nil {
-		return nil, err
-	}
+// ledgerHistory is ledgerList newest first, for the lookups by product and
+// transaction type.
+var ledgerHistory = func() query.Resource {
+	res := ledgerList
+	res.DefaultSort = "-id"
+	return res
+}()
 
-	return transactions, nil
+func (r *TransactionRepository) GetAll(spec query.Spec) ([]models.ItemTransaction, query.Page, error) {
+	return r.find(r.db, spec)
 }
 
-func (r *TransactionRepository) GetByTransactionType(tran_type string) ([]models.ItemTransaction, error) {
-	var transactions []models.ItemTransaction
+func (r *TransactionRepository) GetByProductId(productId string, spec query.Spec) ([]models.ItemTransaction, query.Page, error) {
+	return r.find(r.db.Where("product_id = ?", strings.ToUpper(productId)), spec)
+}
 
-	result := r.db.Where("tran_type = ?", strings.ToUpper(tran_type)).Find(&transactions)
-	if err := result.Error; err != nil {
-		return nil, err
-	}
-	return transactions, nil
+func (r *TransactionRepository) GetByTransactionType(tran_type string, spec query.Spec) ([]models.ItemTransaction, query.Page, error) {
+	return r.find(r.db.Where("tran_type = ?", strings.ToUpper(tran_type)), spec)
 }
 
-func (r *TransactionRepository) GetByProductIdAndTranType(productId string, tran_type string) ([]models.ItemTransaction, error) {
+func (r *TransactionRepository) GetByProductIdAndTranType(productId string, tran_type string, spec query.Spec) ([]models.ItemTransaction, query.Page, error) {
+	return r.find(r.db.Where("product_id = ? AND tran_type = ?", strings.ToUpper(productId), strings.ToUpper(tran_type)), spec)
+}
 
-	var transactions []models.ItemTransaction
-	result := r.db.Where("product_id = ? AND tran_type = ?", strings.ToUpper(productId), strings.ToUpper(tran_type)).Find(&transactions).Order("created_at DESC")
-	if err := result.Error; err != nil {
-		return nil, err
-	}
-	return transactions, nil
+// find loads a page of the ledger rows db is narrowed to.
+func (r *TransactionRepository) find(db *gorm.DB, spec query.Spec) ([]models.ItemTransaction, query.Page, error) {
+	transactions := []models.ItemTransaction{}
+	page, err := query.FindAfter(db.Model(&models.ItemTransaction{}), spec, &transactions,
+		func(t models.ItemTransaction) uint64 { return uint64(t.ID) })
+	return transactions, page, err
 }
-func (r *TransactionRepository) CreateAdjustmentTransaction(transaction ResquestAdjustInventoryDTO) (*models.ItemTransaction, error) {
-	// Make a copy to return after transaction
-	var createdTransaction models.ItemTransaction
 
-	newItemTran := models.ItemTransaction{
-		ProductId:   transaction.ProductId,
-		ReferenceNo: transaction.ReferenceNo,
-		OutQty:      transaction.OutQty,
-		InQty:       transaction.InQty,
-		TranType:    transaction.TranType,
-		Remark:      transaction.Remark,
+// CreateAdjustmentTransaction applies InQty - OutQty of the given unit as a
+// delta on top of the current stock; it never overwrites the on-hand figure.
+func (r *TransactionRepository) CreateAdjustmentTransaction(transaction ResquestAdjustInventoryDTO) (*models.ItemTransaction, error) {
+	delta := transaction.InQty - transaction.OutQty
+	if delta == 0 {
+		return nil, apperr.Validation("adjustment needs a non-zero inQty or outQty")
 	}
 
+	var createdTransaction *models.ItemTransaction
 	err := r.db.Transaction(func(tx *gorm.DB) error {
-		// Step 1: Create ItemTransaction
-		if err := tx.Create(&newItemTran).Error; err != nil {
-			return err
-		}
-
-		// Keep the created transaction (with auto-generated ID, etc.)
-		createdTransaction = newItemTran
-
-		// Step 2: Fetch ProductStock
-		var stock models.ProductStock
-		if err := tx.Where("product_id = ?", transaction.ProductId).First(&stock).Error; err != nil {
+		trx, err := r.stock.Apply(tx, stockmovement.Movement{
+			ProductId:   strings.ToUpper(transaction.ProductId),
+			LocationId:  transaction.LocationId,
+			Uom:         transaction.Uom,
+			Qty:         delta,
+			TranType:    "ADJUSTMENT",
+			ReferenceNo: transaction.ReferenceNo,
+			Remark:      transaction.Remark,
+		})
+		if err != nil {
 			return err
 		}
-
-		// Step 3: Set new quantity based on UOM
-		if transaction.Uom == "EACH" {
-			stock.BaseQty = transaction.InQty
-
-		} else {
-			stock.DerivedQty = transaction.InQty
-		}
-
-		// Step 4: Save updated stock
-		if err := tx.Save(&stock).Error; err != nil {
-			return err
-		}
-
+		createdTransaction = trx
 		return nil // commit transaction
 	})
 
 	if err != nil {
 		return nil, err
 	}
-	return &createdTransaction, nil
+	return createdTransaction, nil
 }
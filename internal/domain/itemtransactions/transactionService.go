@@ -2,17 +2,17 @@ package itemtransactions
 
 import (
 	"log"
-	"sync"
 
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 )
 
 type TransactionServiceInterface interface {
-	GetAll() ([]models.ItemTransaction, error)
-	GetByProductId(id string) ([]models.ItemTransaction, error)
-	GetByTransactionType(tranType string) ([]models.ItemTransaction, error)
-	GetByProductIdAndTranType(productId string, tran_type string) ([]models.ItemTransaction, error)
+	GetAll(spec query.Spec) ([]models.ItemTransaction, query.Page, error)
+	GetByProductId(id string, spec query.Spec) ([]models.ItemTransaction, query.Page, error)
+	GetByTransactionType(tranType string, spec query.Spec) ([]models.ItemTransaction, query.Page, error)
+	GetByProductIdAndTranType(productId string, tran_type string, spec query.Spec) ([]models.ItemTransaction, query.Page, error)
 	CreateAdjustmentTransaction(transaction ResquestAdjustInventoryDTO) (*models.ItemTransaction, error)
 }
 
@@ -20,33 +20,25 @@ type TransactionService struct {
 	repo TransactionRepositoryInterface
 }
 
-var (
-	svcInstnace *TransactionService
-	svcOnce     sync.Once
-)
-
 func NewTransactionService(repo TransactionRepositoryInterface) TransactionServiceInterface {
 	log.Println(util.Green + "TransactionService constructor is called" + util.Reset)
-	svcOnce.Do(func() {
-		svcInstnace = &TransactionService{repo: repo}
-	})
-	return svcInstnace
+	return &TransactionService{repo: repo}
 }
 
-func (s *TransactionService) GetAll() ([]models.ItemTransaction, error) {
-	return s.repo.GetAll()
+func (s *TransactionService) GetAll(spec query.Spec) ([]models.ItemTransaction, query.Page, error) {
+	return s.repo.GetAll(spec)
 }
 
-func (s *TransactionService) GetByProductId(id string) ([]models.ItemTransaction, error) {
-	return s.repo.GetByProductId(id)
+func (s *TransactionService) GetByProductId(id string, spec query.Spec) ([]models.ItemTransaction, query.Page, error) {
+	return s.repo.GetByProductId(id, spec)
 }
 
-func (s *TransactionService) GetByTransactionType(tranType string) ([]models.ItemTransaction, error) {
-	return s.repo.GetByTransactionType(tranType)
+func (s *TransactionService) GetByTransactionType(tranType string, spec query.Spec) ([]models.ItemTransaction, query.Page, error) {
+	return s.repo.GetByTransactionType(tranType, spec)
 }
 
-func (s *TransactionService) GetByProductIdAndTranType(productId string, tran_type string) ([]models.ItemTransaction, error) {
-	return s.repo.GetByProductIdAndTranType(productId, tran_type)
+func (s *TransactionService) GetByProductIdAndTranType(productId string, tran_type string, spec query.Spec) ([]models.ItemTransaction, query.Page, error) {
+	return s.repo.GetByProductIdAndTranType(productId, tran_type, spec)
 }
 
 func (s *TransactionService) CreateAdjustmentTransaction(transaction ResquestAdjustInventoryDTO) (*models.ItemTransaction, error) {
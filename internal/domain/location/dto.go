@@ -0,0 +1,24 @@
+package location
+
+type CreateLocationRequestDTO struct {
+	Code      string `json:"code" example:"YARD"`
+	Name      string `json:"name" example:"Back yard"`
+	IsDefault bool   `json:"isDefault"`
+}
+
+type UpdateLocationRequestDTO struct {
+	Code      string `json:"code" example:"YARD"`
+	Name      string `json:"name" example:"Back yard"`
+	IsDefault bool   `json:"isDefault"`
+}
+
+// LocationStockResponseDTO is the stock of one product at the location.
+type LocationStockResponseDTO struct {
+	ProductID   string `json:"productId"`
+	ProductName string `json:"productName"`
+	BaseUnit    string `json:"baseUnit"`
+	BaseQty     int    `json:"baseQty"`
+	DeriveUnit  string `json:"deriveUnit"`
+	DerivedQty  int    `json:"derivedQty"`
+	ReorderLvl  int    `json:"reorderlvl"`
+}
@@ -0,0 +1,246 @@
+package location
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
+)
+
+type LocationHandler struct {
+	svc LocationServiceInterface
+}
+
+func NewLocationHandler(svc LocationServiceInterface) *LocationHandler {
+	log.Println(util.Green + "LocationHandler constructor is called" + util.Reset)
+	return &LocationHandler{svc: svc}
+}
+
+func locationId(c *fiber.Ctx) (uint, error) {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return 0, apperr.Validation("invalid ID parameter").Wrap(err)
+	}
+	return uint(id), nil
+}
+
+// CreateLocation godoc
+//
+//	@Summary		Create new location
+//	@Description	Create a place stock is kept. A new default location takes over from the old one.
+//	@Tags			Locations
+//	@Accept			json
+//	@Produce		json
+//	@Param			location	body		CreateLocationRequestDTO	true	"Location Data"
+//	@Success		201			{object}	models.Location
+//	@Failure		400			{object}	apperr.Response
+//	@Failure		401			{object}	apperr.Response
+//	@Failure		403			{object}	apperr.Response
+//	@Failure		409			{object}	apperr.Response
+//	@Failure		500			{object}	apperr.Response
+//	@Router			/api/locations [post]
+//	@Security		Bearer
+func (h *LocationHandler) CreateLocation(c *fiber.Ctx) error {
+	input := new(CreateLocationRequestDTO)
+	if err := c.BodyParser(input); err != nil {
+		return apperr.Validation("invalid JSON format")
+	}
+
+	location, err := h.svc.Create(&models.Location{
+		Code:      input.Code,
+		Name:      input.Name,
+		IsDefault: input.IsDefault,
+	})
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "new LOCATION has been created successfully",
+		"data":    location,
+	})
+}
+
+// GetAllLocations godoc
+//
+//	@Summary		Fetch all locations
+//	@Description	Fetch all locations
+//	@Tags			Locations
+//	@Accept			json
+//	@Produce		json
+//	@Param			page	query		int		false	"page number, from 1"
+//	@Param			limit	query		int		false	"rows per page, 20 by default and 100 at most"
+//	@Param			sort	query		string	false	"comma separated fields of id, code, name; prefix - for descending"
+//	@Success		200		{array}		models.Location
+//	@Failure		400		{object}	apperr.Response
+//	@Failure		401		{object}	apperr.Response
+//	@Failure		500		{object}	apperr.Response
+//	@Router			/api/locations [get]
+//	@Security		Bearer
+func (h *LocationHandler) GetAllLocations(c *fiber.Ctx) error {
+	spec, err := query.Parse(c.Queries(), locationList)
+	if err != nil {
+		return err
+	}
+	locations, page, err := h.svc.GetAll(spec)
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": strconv.Itoa(len(locations)) + " records found",
+		"data":    locations,
+		"meta":    page,
+	})
+}
+
+// GetLocationById godoc
+//
+//	@Summary		Fetch individual location by Id
+//	@Description	Fetch individual location by Id
+//	@Tags			Locations
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"location Id"
+//	@Success		200	{object}	models.Location
+//	@Failure		400	{object}	apperr.Response
+//	@Failure		401	{object}	apperr.Response
+//	@Failure		404	{object}	apperr.Response
+//	@Failure		500	{object}	apperr.Response
+//	@Router			/api/locations/{id} [get]
+//	@Security		Bearer
+func (h *LocationHandler) GetLocationById(c *fiber.Ctx) error {
+	id, err := locationId(c)
+	if err != nil {
+		return err
+	}
+	location, err := h.svc.GetById(id)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "Record found",
+		"data":    location,
+	})
+}
+
+// UpdateLocation godoc
+//
+//	@Summary		Update individual location
+//	@Description	Update the code, name or default flag of a location
+//	@Tags			Locations
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int							true	"location Id"
+//	@Param			location	body		UpdateLocationRequestDTO	true	"Location Data"
+//	@Success		200			{object}	models.Location
+//	@Failure		400			{object}	apperr.Response
+//	@Failure		401			{object}	apperr.Response
+//	@Failure		403			{object}	apperr.Response
+//	@Failure		404			{object}	apperr.Response
+//	@Failure		409			{object}	apperr.Response
+//	@Failure		500			{object}	apperr.Response
+//	@Router			/api/locations/{id} [put]
+//	@Security		Bearer
+func (h *LocationHandler) UpdateLocation(c *fiber.Ctx) error {
+	id, err := locationId(c)
+	if err != nil {
+		return err
+	}
+	input := new(UpdateLocationRequestDTO)
+	if err := c.BodyParser(input); err != nil {
+		return apperr.Validation("invalid JSON format")
+	}
+
+	location, err := h.svc.Update(&models.Location{
+		ID:        id,
+		Code:      input.Code,
+		Name:      input.Name,
+		IsDefault: input.IsDefault,
+	})
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "Update Successfully",
+		"data":    location,
+	})
+}
+
+// DeleteLocation godoc
+//
+//	@Summary		Delete individual location
+//	@Description	Delete a location that is not the default one and holds no stock
+//	@Tags			Locations
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"location Id"
+//	@Success		200	{object}	map[string]interface{}
+//	@Failure		400	{object}	apperr.Response
+//	@Failure		401	{object}	apperr.Response
+//	@Failure		403	{object}	apperr.Response
+//	@Failure		404	{object}	apperr.Response
+//	@Failure		409	{object}	apperr.Response
+//	@Failure		500	{object}	apperr.Response
+//	@Router			/api/locations/{id} [delete]
+//	@Security		Bearer
+func (h *LocationHandler) DeleteLocation(c *fiber.Ctx) error {
+	id, err := locationId(c)
+	if err != nil {
+		return err
+	}
+	if err := h.svc.Delete(id); err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "Delete successfully",
+	})
+}
+
+// GetLocationStocks godoc
+//
+//	@Summary		Fetch the stock at a location
+//	@Description	Fetch the stock of every product at the location
+//	@Tags			Locations
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int		true	"location Id"
+//	@Param			page		query		int		false	"page number, from 1"
+//	@Param			limit		query		int		false	"rows per page, 20 by default and 100 at most"
+//	@Param			sort		query		string	false	"comma separated fields of productId, productName, baseQty, derivedQty; prefix - for descending"
+//	@Param			productId	query		string	false	"only the stock of this product"
+//	@Success		200			{array}		LocationStockResponseDTO
+//	@Failure		400			{object}	apperr.Response
+//	@Failure		401			{object}	apperr.Response
+//	@Failure		404			{object}	apperr.Response
+//	@Failure		500			{object}	apperr.Response
+//	@Router			/api/locations/{id}/stocks [get]
+//	@Security		Bearer
+func (h *LocationHandler) GetLocationStocks(c *fiber.Ctx) error {
+	id, err := locationId(c)
+	if err != nil {
+		return err
+	}
+	spec, err := query.Parse(c.Queries(), locationStockList)
+	if err != nil {
+		return err
+	}
+	stocks, page, err := h.svc.GetStocks(id, spec)
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": strconv.Itoa(len(stocks)) + " records found",
+		"data":    stocks,
+		"meta":    page,
+	})
+}
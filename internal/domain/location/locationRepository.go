@@ -0,0 +1,171 @@
+package location
+
+import (
+	"log"
+
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
+	"gorm.io/gorm"
+)
+
+type LocationRepositoryInterface interface {
+	Create(location *models.Location) (*models.Location, error)
+	GetAll(spec query.Spec) ([]models.Location, query.Page, error)
+	GetById(id uint) (*models.Location, error)
+	Update(location *models.Location) (*models.Location, error)
+	Delete(id uint) error
+	GetStocks(id uint, spec query.Spec) ([]LocationStockResponseDTO, query.Page, error)
+}
+
+type LocationRepository struct {
+	db *gorm.DB
+}
+
+//! constructor must be return the Interface, NOT struct, if not, google wire generate fail
+
+func NewLocationRepository(db *gorm.DB) LocationRepositoryInterface {
+	log.Println(util.Green + "LocationRepository constructor is called" + util.Reset)
+	return &LocationRepository{db: db}
+}
+
+// Create adds a location; a new default location takes over from the old one.
+func (r *LocationRepository) Create(location *models.Location) (*models.Location, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if location.IsDefault {
+			if err := clearDefault(tx, 0); err != nil {
+				return err
+			}
+		}
+		return tx.Create(location).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return location, nil
+}
+
+// clearDefault makes every location but id a non-default one, before id
+// becomes the default: the database allows only one at a time.
+func clearDefault(tx *gorm.DB, id uint) error {
+	return tx.Model(&models.Location{}).Where("is_default AND id <> ?", id).Update("is_default", false).Error
+}
+
+// locationList is what GET /locations sorts on.
+var locationList = query.Resource{
+	Sort:        map[string]string{"id": "id", "code": "code", "name": "name"},
+	DefaultSort: "id",
+	Key:         "id",
+}
+
+func (r *LocationRepository) GetAll(spec query.Spec) ([]models.Location, query.Page, error) {
+	locations := []models.Location{}
+	page, err := query.Find(r.db.Model(&models.Location{}), spec, &locations)
+	return locations, page, err
+}
+
+func (r *LocationRepository) GetById(id uint) (*models.Location, error) {
+	var location models.Location
+	err := r.db.First(&location, "id = ?", id).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, apperr.NotFound("location %d not found", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &location, nil
+}
+
+func (r *LocationRepository) Update(input *models.Location) (*models.Location, error) {
+	var existing models.Location
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&existing, "id = ?", input.ID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return apperr.NotFound("location %d not found", input.ID)
+			}
+			return err
+		}
+		if existing.IsDefault && !input.IsDefault {
+			return apperr.Validation("location %s is the default location, make another location the default instead", existing.Code)
+		}
+		if input.IsDefault {
+			if err := clearDefault(tx, existing.ID); err != nil {
+				return err
+			}
+		}
+		return tx.Model(&existing).Updates(map[string]interface{}{
+			"code":       input.Code,
+			"name":       input.Name,
+			"is_default": input.IsDefault,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetById(existing.ID)
+}
+
+// Delete removes a location that is not the default one and holds no stock.
+func (r *LocationRepository) Delete(id uint) error {
+	location, err := r.GetById(id)
+	if err != nil {
+		return err
+	}
+	if location.IsDefault {
+		return apperr.Conflict("location %s is the default location and cannot be deleted", location.Code)
+	}
+
+	var onHand int64
+	err = r.db.Model(&models.ProductStock{}).
+		Where("location_id = ? AND (base_qty <> 0 OR derived_qty <> 0)", id).
+		Count(&onHand).Error
+	if err != nil {
+		return err
+	}
+	if onHand > 0 {
+		return apperr.Conflict("location %s still holds stock of %d products, transfer it first", location.Code, onHand)
+	}
+	return r.db.Delete(&models.Location{}, id).Error
+}
+
+// locationStockList is what GET /locations/:id/stocks sorts and filters on.
+var locationStockList = query.Resource{
+	Sort: map[string]string{
+		"productId":   "s.product_id",
+		"productName": "item.product_name",
+		"baseQty":     "s.base_qty",
+		"derivedQty":  "s.derived_qty",
+	},
+	DefaultSort: "productId",
+	Filters:     map[string]query.Filter{"productId": {Column: "s.product_id", Kind: query.Code}},
+	Key:         "s.id",
+}
+
+// GetStocks lists the stock of every product at the location.
+func (r *LocationRepository) GetStocks(id uint, spec query.Spec) ([]LocationStockResponseDTO, query.Page, error) {
+	if _, err := r.GetById(id); err != nil {
+		return nil, query.Page{}, err
+	}
+
+	results := []LocationStockResponseDTO{}
+	db := r.db.
+		Table("product_stocks AS s").
+		Select(`
+			s.product_id,
+			item.product_name,
+			item.uom AS base_unit,
+			s.base_qty,
+			item.derive_uom AS derive_unit,
+			s.derived_qty,
+			s.reorder_lvl
+		`).
+		Joins("JOIN products item ON s.product_id = item.id").
+		Where("s.location_id = ? AND s.deleted_at IS NULL", id)
+
+	page, err := query.Find(db, spec, &results)
+	if err != nil {
+		return nil, query.Page{}, err
+	}
+	return results, page, nil
+}
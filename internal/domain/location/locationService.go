@@ -0,0 +1,67 @@
+package location
+
+import (
+	"log"
+	"strings"
+
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
+)
+
+type LocationServiceInterface interface {
+	Create(location *models.Location) (*models.Location, error)
+	GetAll(spec query.Spec) ([]models.Location, query.Page, error)
+	GetById(id uint) (*models.Location, error)
+	Update(location *models.Location) (*models.Location, error)
+	Delete(id uint) error
+	GetStocks(id uint, spec query.Spec) ([]LocationStockResponseDTO, query.Page, error)
+}
+
+type LocationService struct {
+	repo LocationRepositoryInterface
+}
+
+//! constructor must be return the Interface, NOT struct, if not, google wire generate fail
+
+func NewLocationService(repo LocationRepositoryInterface) LocationServiceInterface {
+	log.Println(util.Green + "LocationService constructor is called" + util.Reset)
+	return &LocationService{repo: repo}
+}
+
+// normalize stores codes upper-cased, like product ids, and names in NFC.
+func normalize(location *models.Location) error {
+	location.Code = strings.ToUpper(strings.TrimSpace(location.Code))
+	location.Name = util.NormalizeText(location.Name)
+	return models.ValidateStruct(*location)
+}
+
+func (s *LocationService) Create(location *models.Location) (*models.Location, error) {
+	if err := normalize(location); err != nil {
+		return nil, err
+	}
+	return s.repo.Create(location)
+}
+
+func (s *LocationService) GetAll(spec query.Spec) ([]models.Location, query.Page, error) {
+	return s.repo.GetAll(spec)
+}
+
+func (s *LocationService) GetById(id uint) (*models.Location, error) {
+	return s.repo.GetById(id)
+}
+
+func (s *LocationService) Update(location *models.Location) (*models.Location, error) {
+	if err := normalize(location); err != nil {
+		return nil, err
+	}
+	return s.repo.Update(location)
+}
+
+func (s *LocationService) Delete(id uint) error {
+	return s.repo.Delete(id)
+}
+
+func (s *LocationService) GetStocks(id uint, spec query.Spec) ([]LocationStockResponseDTO, query.Page, error) {
+	return s.repo.GetStocks(id, spec)
+}
@@ -0,0 +1,58 @@
+package payable
+
+type SupplierPaymentRequestDTO struct {
+	Amount      int64                                 `json:"amount"`
+	Method      string                                `json:"method"` // CASH (default), BANK, MOBILE, CHEQUE
+	PaymentDate string                                `json:"paymentDate"`
+	ReferenceNo string                                `json:"referenceNo"`
+	Remark      string                                `json:"remark"`
+	Allocations []SupplierPaymentAllocationRequestDTO `json:"allocations"` // empty: oldest open purchases first
+}
+
+type SupplierPaymentAllocationRequestDTO struct {
+	PurchaseId string `json:"purchaseId"`
+	Amount     int64  `json:"amount"`
+}
+
+type OpenPurchaseDTO struct {
+	PurchaseId    string `json:"purchaseId"`
+	SupplierId    uint   `json:"-"`
+	PurchaseDate  string `json:"purchaseDate"`
+	GrandTotal    int64  `json:"grandTotal"`
+	ReturnedTotal int64  `json:"returnedTotal"`
+	PaidAmount    int64  `json:"paidAmount"`
+	Outstanding   int64  `json:"outstanding"`
+	AgeDays       int    `json:"ageDays"`
+}
+
+// SupplierAgingDTO splits what is owed to a supplier by the age of the
+// purchase invoice: 0-30 days is current, then 31-60, 61-90 and over 90.
+type SupplierAgingDTO struct {
+	SupplierId uint              `json:"supplierId"`
+	Name       string            `json:"name"`
+	Current    int64             `json:"current"`
+	Days30     int64             `json:"days30"`
+	Days60     int64             `json:"days60"`
+	Days90Plus int64             `json:"days90Plus"`
+	Total      int64             `json:"total"`
+	Purchases  []OpenPurchaseDTO `json:"purchases,omitempty"`
+}
+
+// StatementEntryDTO is one line of the supplier account as we keep it:
+// purchases are credited (we owe more), payments and returns debited.
+type StatementEntryDTO struct {
+	Date        string `json:"date"`
+	Type        string `json:"type"` // PURCHASE, RETURN or PAYMENT
+	ReferenceNo string `json:"referenceNo"`
+	Debit       int64  `json:"debit"`
+	Credit      int64  `json:"credit"`
+	Balance     int64  `json:"balance"`
+	createdAt   int64
+}
+
+type SupplierStatementDTO struct {
+	SupplierId uint                `json:"supplierId"`
+	Name       string              `json:"name"`
+	Entries    []StatementEntryDTO `json:"entries"`
+	Balance    int64               `json:"balance"`
+}
@@ -0,0 +1,212 @@
+package payable
+
+import (
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+)
+
+type PayableHandler struct {
+	svc PayableServiceInterface
+}
+
+func NewPayableHandler(svc PayableServiceInterface) *PayableHandler {
+	log.Println(util.Green + "PayableHandler constructor is called" + util.Reset)
+	return &PayableHandler{svc: svc}
+}
+
+// GetAgingOverview godoc
+//
+//	@Summary		Fetch the payables aging of all suppliers
+//	@Description	Fetch what is owed to each supplier split into current, 30, 60 and 90+ days
+//	@Tags			Payables
+//	@Accept			json
+//	@Produce		json
+//	@Param			asOf				query		string	false	"aging date, yyyy-mm-dd (default today)"
+//	@Success		200					{array}		SupplierAgingDTO
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
+//	@Router			/api/payables/aging	[get]
+//	@Security		Bearer
+func (h *PayableHandler) GetAgingOverview(c *fiber.Ctx) error {
+	asOf, err := parseAsOf(c.Query("asOf"))
+	if err != nil {
+		return err
+	}
+
+	aging, err := h.svc.GetAging(0, asOf)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "Total " + strconv.Itoa(len(aging)) + " records found",
+		"data":    aging,
+	})
+}
+
+// GetSupplierAging godoc
+//
+//	@Summary		Fetch the payables aging of a supplier
+//	@Description	Fetch the open purchases of a supplier split into current, 30, 60 and 90+ days
+//	@Tags			Suppliers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id						path		string	true	"supplier Id"
+//	@Param			asOf					query		string	false	"aging date, yyyy-mm-dd (default today)"
+//	@Success		200						{object}	SupplierAgingDTO
+//	@Failure		400						{object}	apperr.Response
+//	@Failure		401						{object}	apperr.Response
+//	@Failure		500						{object}	apperr.Response
+//	@Router			/api/suppliers/{id}/aging	[get]
+//	@Security		Bearer
+func (h *PayableHandler) GetSupplierAging(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return apperr.Validation("invalid ID parameter").Wrap(err)
+	}
+	asOf, err := parseAsOf(c.Query("asOf"))
+	if err != nil {
+		return err
+	}
+
+	aging, err := h.svc.GetAging(uint(id), asOf)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "Record found",
+		"data":    aging[0],
+	})
+}
+
+// GetSupplierStatement godoc
+//
+//	@Summary		Fetch the account statement of a supplier
+//	@Description	Fetch purchases, returns and payments of a supplier in booking order with a running balance
+//	@Tags			Suppliers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id							path		string	true	"supplier Id"
+//	@Success		200							{object}	SupplierStatementDTO
+//	@Failure		400							{object}	apperr.Response
+//	@Failure		401							{object}	apperr.Response
+//	@Failure		500							{object}	apperr.Response
+//	@Router			/api/suppliers/{id}/statement	[get]
+//	@Security		Bearer
+func (h *PayableHandler) GetSupplierStatement(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return apperr.Validation("invalid ID parameter").Wrap(err)
+	}
+
+	statement, err := h.svc.GetStatement(uint(id))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "Record found",
+		"data":    statement,
+	})
+}
+
+// GetSupplierPayments godoc
+//
+//	@Summary		Fetch the payments made to a supplier
+//	@Description	Fetch the payments made to a supplier with the purchases each one was applied to
+//	@Tags			Suppliers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id							path		string	true	"supplier Id"
+//	@Success		200							{array}		models.SupplierPayment
+//	@Failure		400							{object}	apperr.Response
+//	@Failure		401							{object}	apperr.Response
+//	@Failure		500							{object}	apperr.Response
+//	@Router			/api/suppliers/{id}/payments	[get]
+//	@Security		Bearer
+func (h *PayableHandler) GetSupplierPayments(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return apperr.Validation("invalid ID parameter").Wrap(err)
+	}
+
+	payments, err := h.svc.GetPayments(uint(id))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "Total " + strconv.Itoa(len(payments)) + " records found",
+		"data":    payments,
+	})
+}
+
+// CreateSupplierPayment godoc
+//
+//	@Summary		Record a payment made to a supplier
+//	@Description	Record a payment and apply it to the given purchases, or to the oldest open purchases when none are given
+//	@Tags			Suppliers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id							path		string						true	"supplier Id"
+//	@Param			payment						body		SupplierPaymentRequestDTO	true	"Payment Data"
+//	@Success		200							{object}	models.SupplierPayment
+//	@Failure		400							{object}	apperr.Response
+//	@Failure		401							{object}	apperr.Response
+//	@Failure		500							{object}	apperr.Response
+//	@Router			/api/suppliers/{id}/payments	[post]
+//	@Security		Bearer
+func (h *PayableHandler) CreateSupplierPayment(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return apperr.Validation("invalid ID parameter").Wrap(err)
+	}
+
+	input := new(SupplierPaymentRequestDTO)
+	if err := c.BodyParser(input); err != nil {
+		return apperr.Validation("invalid JSON format")
+	}
+
+	payment := models.SupplierPayment{
+		Amount:      input.Amount,
+		Method:      input.Method,
+		PaymentDate: input.PaymentDate,
+		ReferenceNo: input.ReferenceNo,
+		Remark:      input.Remark,
+	}
+	for _, a := range input.Allocations {
+		payment.SupplierPaymentAllocations = append(payment.SupplierPaymentAllocations, models.SupplierPaymentAllocation{
+			PurchaseId: a.PurchaseId,
+			Amount:     a.Amount,
+		})
+	}
+
+	created, err := h.svc.CreatePayment(uint(id), &payment)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "supplier payment has been recorded successfully",
+		"data":    created,
+	})
+}
+
+func parseAsOf(value string) (time.Time, error) {
+	if value == "" {
+		return time.Now(), nil
+	}
+	asOf, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, apperr.Validation("asOf must be a yyyy-mm-dd date, got %q", value)
+	}
+	return asOf, nil
+}
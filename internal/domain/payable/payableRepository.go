@@ -0,0 +1,307 @@
+package payable
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PayableRepositoryInterface interface {
+	GetAging(supplierId uint, asOf time.Time) ([]SupplierAgingDTO, error)
+	GetStatement(supplierId uint) (*SupplierStatementDTO, error)
+	GetPayments(supplierId uint) ([]models.SupplierPayment, error)
+	CreatePayment(supplierId uint, input *models.SupplierPayment) (*models.SupplierPayment, error)
+}
+
+type PayableRepository struct {
+	db *gorm.DB
+}
+
+//! constructor must be return the Interface, NOT struct, if not, google wire generate fail
+
+func NewPayableRepository(db *gorm.DB) PayableRepositoryInterface {
+	log.Println(util.Green + "PayableRepository constructor is called" + util.Reset)
+	return &PayableRepository{db: db}
+}
+
+// openPurchasesSql lists purchases that still owe money, paid amounts summed
+// from the supplier payment allocations. The invoice date is purchase_date
+// when it is a yyyy-mm-dd date, the booking time otherwise.
+const openPurchasesSql = `
+	SELECT p.id AS purchase_id, p.supplier_id, p.purchase_date, p.grand_total, p.returned_total,
+	       COALESCE(a.paid, 0) AS paid_amount,
+	       p.grand_total - p.returned_total - COALESCE(a.paid, 0) AS outstanding,
+	       CAST(? AS date) - CASE
+	           WHEN p.purchase_date ~ '^\d{4}-\d{2}-\d{2}' THEN to_date(substr(p.purchase_date, 1, 10), 'YYYY-MM-DD')
+	           ELSE to_timestamp(p.created_at)::date
+	       END AS age_days
+	FROM purchases p
+	LEFT JOIN (
+	    SELECT purchase_id, SUM(amount) AS paid FROM supplier_payment_allocations GROUP BY purchase_id
+	) a ON a.purchase_id = p.id
+	WHERE p.deleted_at IS NULL
+	  AND p.grand_total - p.returned_total - COALESCE(a.paid, 0) > 0`
+
+func (r *PayableRepository) openPurchases(db *gorm.DB, supplierId uint, asOf time.Time) ([]OpenPurchaseDTO, error) {
+	query := openPurchasesSql
+	args := []interface{}{asOf.Format("2006-01-02")}
+	if supplierId != 0 {
+		query += " AND p.supplier_id = ?"
+		args = append(args, supplierId)
+	}
+	query += " ORDER BY p.created_at, p.id"
+
+	rows := []OpenPurchaseDTO{}
+	err := db.Raw(query, args...).Scan(&rows).Error
+	return rows, err
+}
+
+// GetAging returns the aging of one supplier, or of every supplier with
+// something outstanding when supplierId is 0.
+func (r *PayableRepository) GetAging(supplierId uint, asOf time.Time) ([]SupplierAgingDTO, error) {
+	if supplierId != 0 {
+		if err := r.db.First(&models.Supplier{}, "id = ?", supplierId).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := r.openPurchases(r.db, supplierId, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	supplierIds := []uint{supplierId}
+	if supplierId == 0 {
+		supplierIds = []uint{}
+		for _, row := range rows {
+			supplierIds = append(supplierIds, row.SupplierId)
+		}
+	}
+	var suppliers []models.Supplier
+	if err := r.db.Where("id IN ?", supplierIds).Order("name").Find(&suppliers).Error; err != nil {
+		return nil, err
+	}
+
+	aging := summarizeAging(suppliers, rows)
+	if supplierId == 0 {
+		// the overview only needs the buckets
+		for i := range aging {
+			aging[i].Purchases = nil
+		}
+	}
+	return aging, nil
+}
+
+// GetStatement lists the supplier's purchases, returns and payments in the
+// order they were booked, with a running balance of what we owe.
+func (r *PayableRepository) GetStatement(supplierId uint) (*SupplierStatementDTO, error) {
+	var supplier models.Supplier
+	if err := r.db.First(&supplier, "id = ?", supplierId).Error; err != nil {
+		return nil, err
+	}
+
+	var purchases []models.Purchase
+	if err := r.db.Where("supplier_id = ?", supplierId).Find(&purchases).Error; err != nil {
+		return nil, err
+	}
+	var returns []models.PurchaseReturn
+	if err := r.db.Where("supplier_id = ?", supplierId).Find(&returns).Error; err != nil {
+		return nil, err
+	}
+	var payments []models.SupplierPayment
+	if err := r.db.Where("supplier_id = ?", supplierId).Find(&payments).Error; err != nil {
+		return nil, err
+	}
+
+	entries := []StatementEntryDTO{}
+	for _, purchase := range purchases {
+		entries = append(entries, StatementEntryDTO{
+			Date: purchase.PurchaseDate, Type: "PURCHASE", ReferenceNo: purchase.ID, Credit: purchase.GrandTotal, createdAt: purchase.CreatedAt,
+		})
+	}
+	for _, ret := range returns {
+		entries = append(entries, StatementEntryDTO{
+			Date: ret.ReturnDate, Type: "RETURN", ReferenceNo: fmt.Sprintf("%s/R%d", ret.PurchaseId, ret.ID), Debit: ret.Total, createdAt: ret.CreatedAt,
+		})
+	}
+	for _, payment := range payments {
+		entries = append(entries, StatementEntryDTO{
+			Date: payment.PaymentDate, Type: "PAYMENT", ReferenceNo: payment.ReferenceNo, Debit: payment.Amount, createdAt: payment.CreatedAt,
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].createdAt < entries[j].createdAt })
+
+	var balance int64
+	for i := range entries {
+		balance += entries[i].Credit - entries[i].Debit
+		entries[i].Balance = balance
+	}
+
+	return &SupplierStatementDTO{
+		SupplierId: supplier.ID,
+		Name:       supplier.Name,
+		Entries:    entries,
+		Balance:    balance,
+	}, nil
+}
+
+func (r *PayableRepository) GetPayments(supplierId uint) ([]models.SupplierPayment, error) {
+	if err := r.db.First(&models.Supplier{}, "id = ?", supplierId).Error; err != nil {
+		return nil, err
+	}
+
+	payments := []models.SupplierPayment{}
+	err := r.db.
+		Preload("SupplierPaymentAllocations").
+		Where("supplier_id = ?", supplierId).
+		Order("created_at DESC").
+		Find(&payments).Error
+	return payments, err
+}
+
+// CreatePayment records a payment to the supplier and applies it to the given
+// purchases, or to the oldest open purchases when none are given. Anything
+// left over is an advance that stays on the supplier's account.
+func (r *PayableRepository) CreatePayment(supplierId uint, input *models.SupplierPayment) (*models.SupplierPayment, error) {
+	if input.Amount <= 0 {
+		return nil, apperr.Validation("payment amount must be greater than zero")
+	}
+
+	payment := models.SupplierPayment{
+		SupplierId:  supplierId,
+		Amount:      input.Amount,
+		Method:      strings.ToUpper(input.Method),
+		PaymentDate: input.PaymentDate,
+		ReferenceNo: input.ReferenceNo,
+		Remark:      input.Remark,
+	}
+	if payment.Method == "" {
+		payment.Method = "CASH"
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&models.Supplier{}, "id = ?", supplierId).Error; err != nil {
+			return err
+		}
+
+		remaining := payment.Amount
+		if len(input.SupplierPaymentAllocations) > 0 {
+			// allocated by this payment so far, the same purchase may be listed twice
+			pending := map[string]int64{}
+			for _, a := range input.SupplierPaymentAllocations {
+				if a.Amount <= 0 {
+					return apperr.Validation("allocation to purchase %s must be greater than zero", a.PurchaseId)
+				}
+				if a.Amount > remaining {
+					return apperr.Validation("allocations exceed the payment amount %d", payment.Amount)
+				}
+				purchaseId := strings.ToUpper(a.PurchaseId)
+				outstanding, err := lockOutstanding(tx, supplierId, purchaseId)
+				if err != nil {
+					return err
+				}
+				if outstanding -= pending[purchaseId]; a.Amount > outstanding {
+					return apperr.Conflict("cannot apply %d to purchase %s: only %d outstanding", a.Amount, purchaseId, outstanding)
+				}
+				payment.SupplierPaymentAllocations = append(payment.SupplierPaymentAllocations, models.SupplierPaymentAllocation{
+					PurchaseId: purchaseId,
+					Amount:     a.Amount,
+				})
+				pending[purchaseId] += a.Amount
+				remaining -= a.Amount
+			}
+		} else {
+			open, err := r.openPurchases(tx, supplierId, time.Now())
+			if err != nil {
+				return err
+			}
+			for _, p := range open {
+				if remaining == 0 {
+					break
+				}
+				outstanding, err := lockOutstanding(tx, supplierId, p.PurchaseId)
+				if err != nil {
+					return err
+				}
+				amount := min(remaining, outstanding)
+				if amount <= 0 {
+					continue
+				}
+				payment.SupplierPaymentAllocations = append(payment.SupplierPaymentAllocations, models.SupplierPaymentAllocation{
+					PurchaseId: p.PurchaseId,
+					Amount:     amount,
+				})
+				remaining -= amount
+			}
+		}
+
+		return tx.Create(&payment).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &payment, nil
+}
+
+// lockOutstanding locks the purchase row, so concurrent payments to the same
+// invoice queue up, and returns what it still owes.
+func lockOutstanding(tx *gorm.DB, supplierId uint, purchaseId string) (int64, error) {
+	var purchase models.Purchase
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&purchase, "id = ? AND supplier_id = ?", purchaseId, supplierId).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, apperr.Validation("purchase %s does not belong to supplier %d", purchaseId, supplierId)
+		}
+		return 0, err
+	}
+
+	var paid int64
+	if err := tx.Model(&models.SupplierPaymentAllocation{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("purchase_id = ?", purchase.ID).
+		Scan(&paid).Error; err != nil {
+		return 0, err
+	}
+	return purchase.GrandTotal - purchase.ReturnedTotal - paid, nil
+}
+
+// summarizeAging puts every open purchase into its supplier's age bucket.
+// Suppliers come back in the order given.
+func summarizeAging(suppliers []models.Supplier, rows []OpenPurchaseDTO) []SupplierAgingDTO {
+	aging := make([]SupplierAgingDTO, len(suppliers))
+	index := make(map[uint]int, len(suppliers))
+	for i, supplier := range suppliers {
+		aging[i] = SupplierAgingDTO{SupplierId: supplier.ID, Name: supplier.Name, Purchases: []OpenPurchaseDTO{}}
+		index[supplier.ID] = i
+	}
+
+	for _, row := range rows {
+		i, ok := index[row.SupplierId]
+		if !ok {
+			continue
+		}
+		a := &aging[i]
+		switch {
+		case row.AgeDays <= 30:
+			a.Current += row.Outstanding
+		case row.AgeDays <= 60:
+			a.Days30 += row.Outstanding
+		case row.AgeDays <= 90:
+			a.Days60 += row.Outstanding
+		default:
+			a.Days90Plus += row.Outstanding
+		}
+		a.Total += row.Outstanding
+		a.Purchases = append(a.Purchases, row)
+	}
+	return aging
+}
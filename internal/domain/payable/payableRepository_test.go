@@ -0,0 +1,38 @@
+package payable
+
+import (
+	"testing"
+
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeAging(t *testing.T) {
+	suppliers := []models.Supplier{{ID: 2, Name: "ACME"}, {ID: 1, Name: "ZENITH"}}
+	rows := []OpenPurchaseDTO{
+		{PurchaseId: "PO-1", SupplierId: 1, Outstanding: 100, AgeDays: 0},
+		{PurchaseId: "PO-2", SupplierId: 1, Outstanding: 200, AgeDays: 30},
+		{PurchaseId: "PO-3", SupplierId: 1, Outstanding: 300, AgeDays: 31},
+		{PurchaseId: "PO-4", SupplierId: 2, Outstanding: 400, AgeDays: 60},
+		{PurchaseId: "PO-5", SupplierId: 2, Outstanding: 500, AgeDays: 61},
+		{PurchaseId: "PO-6", SupplierId: 2, Outstanding: 600, AgeDays: 90},
+		{PurchaseId: "PO-7", SupplierId: 2, Outstanding: 700, AgeDays: 91},
+		{PurchaseId: "PO-8", SupplierId: 9, Outstanding: 800, AgeDays: 1},
+	}
+
+	aging := summarizeAging(suppliers, rows)
+
+	assert.Len(t, aging, 2)
+	assert.Equal(t, uint(2), aging[0].SupplierId)
+	assert.Equal(t, int64(0), aging[0].Current)
+	assert.Equal(t, int64(400), aging[0].Days30)
+	assert.Equal(t, int64(1100), aging[0].Days60)
+	assert.Equal(t, int64(700), aging[0].Days90Plus)
+	assert.Equal(t, int64(2200), aging[0].Total)
+	assert.Len(t, aging[0].Purchases, 4)
+
+	assert.Equal(t, uint(1), aging[1].SupplierId)
+	assert.Equal(t, int64(300), aging[1].Current)
+	assert.Equal(t, int64(300), aging[1].Days30)
+	assert.Equal(t, int64(600), aging[1].Total)
+}
@@ -0,0 +1,43 @@
+package payable
+
+import (
+	"log"
+	"time"
+
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+)
+
+type PayableServiceInterface interface {
+	GetAging(supplierId uint, asOf time.Time) ([]SupplierAgingDTO, error)
+	GetStatement(supplierId uint) (*SupplierStatementDTO, error)
+	GetPayments(supplierId uint) ([]models.SupplierPayment, error)
+	CreatePayment(supplierId uint, payment *models.SupplierPayment) (*models.SupplierPayment, error)
+}
+
+type PayableService struct {
+	repo PayableRepositoryInterface
+}
+
+//! constructor must be return the Interface, NOT struct, if not, google wire generate fail
+
+func NewPayableService(repo PayableRepositoryInterface) PayableServiceInterface {
+	log.Println(util.Green + "PayableService constructor is called" + util.Reset)
+	return &PayableService{repo: repo}
+}
+
+func (s *PayableService) GetAging(supplierId uint, asOf time.Time) ([]SupplierAgingDTO, error) {
+	return s.repo.GetAging(supplierId, asOf)
+}
+
+func (s *PayableService) GetStatement(supplierId uint) (*SupplierStatementDTO, error) {
+	return s.repo.GetStatement(supplierId)
+}
+
+func (s *PayableService) GetPayments(supplierId uint) ([]models.SupplierPayment, error) {
+	return s.repo.GetPayments(supplierId)
+}
+
+func (s *PayableService) CreatePayment(supplierId uint, payment *models.SupplierPayment) (*models.SupplierPayment, error) {
+	return s.repo.CreatePayment(supplierId, payment)
+}
@@ -0,0 +1,74 @@
+package pricing
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"gorm.io/gorm"
+)
+
+type PricingRepositoryInterface interface {
+	GetUnitPrice(productId string, unitName string, priceType string) (int64, error)
+	GetUnitConversion(productId string) (*models.UnitConversion, error)
+}
+
+type PricingRepository struct {
+	db *gorm.DB
+}
+
+//! constructor must be return the Interface, NOT struct, if not, google wire generate fail
+
+func NewPricingRepository(db *gorm.DB) PricingRepositoryInterface {
+	log.Println(util.Cyan + "PricingRepository constructor is called" + util.Reset)
+	return &PricingRepository{db: db}
+}
+
+// GetUnitPrice returns the BUY or SELL price of one unitName of the product.
+// ProductPrice is the price list; products that have no entry there yet fall
+// back to the price columns on the product itself.
+func (r *PricingRepository) GetUnitPrice(productId string, unitName string, priceType string) (int64, error) {
+	var price models.ProductPrice
+	err := r.db.
+		Joins("JOIN unit_of_measures ON unit_of_measures.id = product_prices.unit_id AND unit_of_measures.deleted_at IS NULL").
+		Where("product_prices.product_id = ? AND product_prices.price_type = ? AND UPPER(unit_of_measures.unit_name) = UPPER(?)", productId, priceType, unitName).
+		First(&price).Error
+	if err == nil {
+		return price.UnitPrice, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return 0, err
+	}
+
+	var product models.Product
+	if err := r.db.First(&product, "id = ?", productId).Error; err != nil {
+		return 0, fmt.Errorf("%w: product %s not found", ErrInvalidInvoice, productId)
+	}
+	isBase := unitName == "" || strings.EqualFold(unitName, product.Uom)
+	isDerived := product.DeriveUom != "" && strings.EqualFold(unitName, product.DeriveUom)
+	switch {
+	case priceType == PriceTypeSell && isBase && product.SellPriceLevel1 > 0:
+		return product.SellPriceLevel1, nil
+	case priceType == PriceTypeSell && isDerived && product.DeriveUnitPrice > 0:
+		return product.DeriveUnitPrice, nil
+	case priceType == PriceTypeBuy && isBase && product.BuyPrice > 0:
+		return product.BuyPrice, nil
+	}
+	return 0, fmt.Errorf("%w: no %s price for product %s in unit %s", ErrInvalidInvoice, priceType, productId, unitName)
+}
+
+// GetUnitConversion returns the product's base/derived unit pair, or nil when
+// the product is only sold in its base unit.
+func (r *PricingRepository) GetUnitConversion(productId string) (*models.UnitConversion, error) {
+	var unitConv models.UnitConversion
+	err := r.db.First(&unitConv, "product_id = ?", productId).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &unitConv, nil
+}
@@ -0,0 +1,202 @@
+package pricing
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/config"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+)
+
+const (
+	PriceTypeSell = "SELL"
+	PriceTypeBuy  = "BUY"
+)
+
+// ErrInvalidInvoice wraps every pricing rejection; it is an apperr validation
+// error, so the rejection is answered with 400 and its message.
+var ErrInvalidInvoice = apperr.Validation("invalid invoice")
+
+type PricingServiceInterface interface {
+	PriceSale(sale *models.Sale) error
+	PricePurchase(purchase *models.Purchase) error
+}
+
+type PricingService struct {
+	repo    PricingRepositoryInterface
+	taxRate int64 // basis points, 750 = 7.5%
+}
+
+//! constructor must be return the Interface, NOT struct, if not, google wire generate fail
+
+// NewPricingService taxes invoices at the configured TAX_RATE.
+func NewPricingService(repo PricingRepositoryInterface, cfg *config.Config) PricingServiceInterface {
+	log.Println(util.Cyan + "PricingService constructor is called" + util.Reset)
+	return &PricingService{repo: repo, taxRate: cfg.Tax.Rate}
+}
+
+// PriceSale fills in missing unit prices from the SELL price list and
+// recomputes line totals, Total, Tax and GrandTotal. Totals the client did
+// send must match the computed ones.
+func (s *PricingService) PriceSale(sale *models.Sale) error {
+	inv := invoice{Discount: sale.Discount, Total: sale.Total, Tax: sale.Tax, GrandTotal: sale.GrandTotal}
+	for i := range sale.SaleDetails {
+		sd := &sale.SaleDetails[i]
+
+		// derived-unit lines carry their quantity in DerivedQty, see saleRepository
+		qty := sd.Qty
+		unitConv, err := s.repo.GetUnitConversion(sd.ProductId)
+		if err != nil {
+			return err
+		}
+		if unitConv != nil && unitConv.DeriveUnit != "" && strings.EqualFold(sd.Uom, unitConv.DeriveUnit) {
+			qty = sd.DerivedQty
+		}
+
+		if sd.Price == 0 {
+			price, err := s.repo.GetUnitPrice(sd.ProductId, sd.Uom, PriceTypeSell)
+			if err != nil {
+				return err
+			}
+			sd.Price = price
+		}
+		inv.Lines = append(inv.Lines, line{
+			Ref:      fmt.Sprintf("line %d (%s)", i+1, sd.ProductId),
+			Qty:      qty,
+			Price:    sd.Price,
+			Discount: sd.Discount,
+			Total:    sd.Total,
+		})
+	}
+
+	if err := computeInvoice(&inv, s.taxRate); err != nil {
+		return err
+	}
+
+	for i := range sale.SaleDetails {
+		sale.SaleDetails[i].Total = inv.Lines[i].Total
+	}
+	sale.Total = inv.Total
+	sale.TaxRate = s.taxRate
+	sale.Tax = inv.Tax
+	sale.GrandTotal = inv.GrandTotal
+	return nil
+}
+
+// PricePurchase is PriceSale for supplier invoices, priced from the BUY list.
+func (s *PricingService) PricePurchase(purchase *models.Purchase) error {
+	inv := invoice{Discount: purchase.Discount, Total: purchase.Total, Tax: purchase.Tax, GrandTotal: purchase.GrandTotal}
+	for i := range purchase.PurchaseDetails {
+		pd := &purchase.PurchaseDetails[i]
+		if pd.Price == 0 {
+			price, err := s.repo.GetUnitPrice(pd.ProductId, pd.UnitName, PriceTypeBuy)
+			if err != nil {
+				return err
+			}
+			pd.Price = price
+		}
+		inv.Lines = append(inv.Lines, line{
+			Ref:      fmt.Sprintf("line %d (%s)", i+1, pd.ProductId),
+			Qty:      pd.Qty,
+			Price:    pd.Price,
+			Discount: pd.Discount,
+			Total:    pd.Total,
+		})
+	}
+
+	if err := computeInvoice(&inv, s.taxRate); err != nil {
+		return err
+	}
+
+	for i := range purchase.PurchaseDetails {
+		purchase.PurchaseDetails[i].Total = inv.Lines[i].Total
+	}
+	purchase.Total = inv.Total
+	purchase.TaxRate = s.taxRate
+	purchase.Tax = inv.Tax
+	purchase.GrandTotal = inv.GrandTotal
+	return nil
+}
+
+// RefundAmount is what returning qty of a line is worth: the line's net total
+// pro rata, scaled by the invoice-level discount and tax (grandTotal/total).
+func RefundAmount(lineTotal int64, lineQty int, qty int, invoiceTotal int64, grandTotal int64) int64 {
+	if lineQty <= 0 {
+		return 0
+	}
+	share := lineTotal * int64(qty) / int64(lineQty)
+	if invoiceTotal <= 0 {
+		return share
+	}
+	return share * grandTotal / invoiceTotal
+}
+
+type line struct {
+	Ref      string
+	Qty      int
+	Price    int64
+	Discount int64
+	Total    int64
+}
+
+// invoice holds the amounts as sent by the client; a zero total means "not
+// sent" and is filled in, anything else has to match the computation.
+type invoice struct {
+	Lines      []line
+	Discount   int64
+	Total      int64
+	Tax        int64
+	GrandTotal int64
+}
+
+func computeInvoice(inv *invoice, taxRate int64) error {
+	if len(inv.Lines) == 0 {
+		return fmt.Errorf("%w: at least one line item is required", ErrInvalidInvoice)
+	}
+
+	var total int64
+	for i := range inv.Lines {
+		l := &inv.Lines[i]
+		if l.Qty <= 0 {
+			return fmt.Errorf("%w: %s quantity must be greater than zero", ErrInvalidInvoice, l.Ref)
+		}
+		if l.Price < 0 {
+			return fmt.Errorf("%w: %s price must not be negative", ErrInvalidInvoice, l.Ref)
+		}
+		gross := int64(l.Qty) * l.Price
+		if l.Discount < 0 || l.Discount > gross {
+			return fmt.Errorf("%w: %s discount %d must be between 0 and %d", ErrInvalidInvoice, l.Ref, l.Discount, gross)
+		}
+		net := gross - l.Discount
+		if l.Total != 0 && l.Total != net {
+			return fmt.Errorf("%w: %s total %d does not match %d x %d - %d = %d", ErrInvalidInvoice, l.Ref, l.Total, l.Qty, l.Price, l.Discount, net)
+		}
+		l.Total = net
+		total += net
+	}
+
+	if inv.Total != 0 && inv.Total != total {
+		return fmt.Errorf("%w: total %d does not match the sum of line totals %d", ErrInvalidInvoice, inv.Total, total)
+	}
+	if inv.Discount < 0 || inv.Discount > total {
+		return fmt.Errorf("%w: discount %d must be between 0 and %d", ErrInvalidInvoice, inv.Discount, total)
+	}
+
+	taxable := total - inv.Discount
+	tax := (taxable*taxRate + 5000) / 10000 // round half up
+	if inv.Tax != 0 && inv.Tax != tax {
+		return fmt.Errorf("%w: tax %d does not match %d", ErrInvalidInvoice, inv.Tax, tax)
+	}
+	grandTotal := taxable + tax
+	if inv.GrandTotal != 0 && inv.GrandTotal != grandTotal {
+		return fmt.Errorf("%w: grand total %d does not match %d - %d + %d = %d", ErrInvalidInvoice, inv.GrandTotal, total, inv.Discount, tax, grandTotal)
+	}
+
+	inv.Total = total
+	inv.Tax = tax
+	inv.GrandTotal = grandTotal
+	return nil
+}
@@ -0,0 +1,75 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeInvoice(t *testing.T) {
+	tests := []struct {
+		name           string
+		inv            invoice
+		taxRate        int64
+		wantLineTotals []int64
+		wantTotal      int64
+		wantTax        int64
+		wantGrandTotal int64
+		wantErr        bool
+	}{
+		{
+			name:           "totals filled in",
+			inv:            invoice{Lines: []line{{Qty: 2, Price: 500}, {Qty: 3, Price: 100, Discount: 50}}},
+			wantLineTotals: []int64{1000, 250},
+			wantTotal:      1250, wantGrandTotal: 1250,
+		},
+		{
+			name:           "invoice discount then tax",
+			inv:            invoice{Lines: []line{{Qty: 4, Price: 250}}, Discount: 100},
+			taxRate:        750,
+			wantLineTotals: []int64{1000},
+			wantTotal:      1000, wantTax: 68, wantGrandTotal: 968,
+		},
+		{
+			name:           "matching client totals",
+			inv:            invoice{Lines: []line{{Qty: 1, Price: 1000, Total: 1000}}, Total: 1000, Tax: 50, GrandTotal: 1050},
+			taxRate:        500,
+			wantLineTotals: []int64{1000},
+			wantTotal:      1000, wantTax: 50, wantGrandTotal: 1050,
+		},
+		{name: "no lines", inv: invoice{}, wantErr: true},
+		{name: "zero qty", inv: invoice{Lines: []line{{Qty: 0, Price: 100}}}, wantErr: true},
+		{name: "line discount above gross", inv: invoice{Lines: []line{{Qty: 1, Price: 100, Discount: 101}}}, wantErr: true},
+		{name: "line total mismatch", inv: invoice{Lines: []line{{Qty: 2, Price: 100, Total: 150}}}, wantErr: true},
+		{name: "total mismatch", inv: invoice{Lines: []line{{Qty: 2, Price: 100}}, Total: 150}, wantErr: true},
+		{name: "invoice discount above total", inv: invoice{Lines: []line{{Qty: 2, Price: 100}}, Discount: 201}, wantErr: true},
+		{name: "tax mismatch", inv: invoice{Lines: []line{{Qty: 2, Price: 100}}, Tax: 1}, taxRate: 1000, wantErr: true},
+		{name: "grand total mismatch", inv: invoice{Lines: []line{{Qty: 2, Price: 100}}, GrandTotal: 200}, taxRate: 1000, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv := tt.inv
+			err := computeInvoice(&inv, tt.taxRate)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidInvoice)
+				return
+			}
+
+			assert.NoError(t, err)
+			for i, want := range tt.wantLineTotals {
+				assert.Equal(t, want, inv.Lines[i].Total)
+			}
+			assert.Equal(t, tt.wantTotal, inv.Total)
+			assert.Equal(t, tt.wantTax, inv.Tax)
+			assert.Equal(t, tt.wantGrandTotal, inv.GrandTotal)
+		})
+	}
+}
+
+func TestRefundAmount(t *testing.T) {
+	// 1000 line of 4 units on an invoice of 2000 discounted and taxed to 1900
+	assert.Equal(t, int64(475), RefundAmount(1000, 4, 2, 2000, 1900))
+	assert.Equal(t, int64(250), RefundAmount(1000, 4, 1, 0, 0))
+	assert.Equal(t, int64(0), RefundAmount(1000, 0, 1, 2000, 1900))
+}
@@ -101,3 +101,15 @@ type UpdateUnitRequstDTO struct {
 	ID       uint   `json:"id"`
 	UnitName string `json:"unitName"`
 }
+
+type ProductSearchResultDTO struct {
+	ID              string  `json:"id"`
+	ProductName     string  `json:"productName"`
+	BrandName       string  `json:"brandName"`
+	CategoryId      uint    `json:"categoryId"`
+	CategoryName    string  `json:"categoryName"`
+	BaseUnit        string  `json:"baseUnit"`
+	SellPriceLevel1 int64   `json:"sellPricelvl1"`
+	IsActive        bool    `json:"isActive"`
+	Rank            float64 `json:"rank"`
+}
@@ -5,34 +5,25 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
-	"sync"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
-	"gorm.io/gorm"
+	"github.com/sankangkin/di-rest-api/internal/query"
 )
 
 type ProductHandler struct {
 	svc ProductServiceInterface
 }
 
-// ! singleton pattern
-var (
-	hdlInstance *ProductHandler
-	hdlOnce     sync.Once
-)
-
 // func NewProductHandler(srv ProductRepositoryInterface) *ProductHandler{
 // 	return &ProductHandler{srv:srv}
 // }
 
 func NewProductHandler(svc ProductServiceInterface) *ProductHandler {
 	log.Println(util.Yellow + "ProductHandler constructor is called" + util.Reset)
-	hdlOnce.Do(func() {
-		hdlInstance = &ProductHandler{svc: svc}
-	})
-	return hdlInstance
+	return &ProductHandler{svc: svc}
 }
 
 // CreateProduct godoc
@@ -43,18 +34,15 @@ func NewProductHandler(svc ProductServiceInterface) *ProductHandler {
 // @Produce      json
 // @Param        product      body      CreateProductRequstDTO     true  "Product input data"
 // @Success      200          {object}  models.Product
-// @Failure      400          {object}  httputil.HttpError400
-// @Failure      401          {object}  httputil.HttpError401
-// @Failure      500          {object}  httputil.HttpError500
+// @Failure      400          {object}  apperr.Response
+// @Failure      401          {object}  apperr.Response
+// @Failure      500          {object}  apperr.Response
 // @Router       /api/product [post]
 // @Security     Bearer
 func (h *ProductHandler) CreateProduct(c *fiber.Ctx) error {
 	input := new(CreateProductRequstDTO)
 	if err := c.BodyParser(input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  400,
-			"message": "Invalid JSON format",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 
 	log.Println("New product input: ", input)
@@ -80,14 +68,13 @@ func (h *ProductHandler) CreateProduct(c *fiber.Ctx) error {
 		return err
 	}
 
-	errors := models.ValidateStruct(newProduct)
-	if errors != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(errors)
+	if err := models.ValidateStruct(newProduct); err != nil {
+		return err
 	}
 	log.Println("newProduct : ", newProduct)
 
 	if _, err := h.svc.CreateSerive(&newProduct); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return err
 	}
 	return c.Status(http.StatusOK).JSON(
 		&fiber.Map{
@@ -105,29 +92,75 @@ func (h *ProductHandler) CreateProduct(c *fiber.Ctx) error {
 //	@Tags			Products
 //	@Accept			json
 //	@Produce		json
+//	@Param			page	query		int		false	"page number, from 1"
+//	@Param			limit	query		int		false	"rows per page, 20 by default and 100 at most"
+//	@Param			sort	query		string	false	"comma separated fields of id, productName, brandName, buyPrice, sellPrice, createdAt; prefix - for descending"
+//	@Param			categoryId	query		int	false	"only the products of this category"
+//	@Param			isActive	query		bool	false	"only active or only inactive products"
+//	@Param			brandName	query		string	false	"only the products of this brand"
+//	@Param			uomId	query		int	false	"only the products with this base unit"
 //	@Success		200				{array}		models.Product
-//	@Failure		400				{object}	httputil.HttpError400
-//	@Failure		401				{object}	httputil.HttpError401
-//	@Failure		500				{object}	httputil.HttpError500
+//	@Failure		400				{object}	apperr.Response
+//	@Failure		401				{object}	apperr.Response
+//	@Failure		500				{object}	apperr.Response
 //	@Router			/api/products [get]
 //	@Security		Bearer
 func (h *ProductHandler) GetAllProducts(c *fiber.Ctx) error {
-	products, err := h.svc.GetAllSerive()
+	spec, err := query.Parse(c.Queries(), productList)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
+	}
+	products, page, err := h.svc.GetAllSerive(spec)
+	if err != nil {
+		return err
 	}
 	return c.Status(http.StatusOK).JSON(
 		&fiber.Map{
 			"status":  "SUCCESS",
 			"message": strconv.Itoa(len(products)) + " records found",
 			"data":    products,
+			"meta":    page,
 			"count":   len(products),
 		})
 
 }
 
+// SearchProducts godoc
+//
+//	@Summary		Search products
+//	@Description	Type-ahead lookup of products by id, name, brand or category name, Myanmar script included. Best matches come first.
+//	@Tags			Products
+//	@Accept			json
+//	@Produce		json
+//	@Param			q			query		string	true	"search term"
+//	@Param			page		query		int		false	"page number, from 1"
+//	@Param			limit		query		int		false	"rows per page, 20 by default and 100 at most"
+//	@Param			categoryId	query		int		false	"only the products of this category"
+//	@Param			isActive	query		bool	false	"only active or only inactive products"
+//	@Success		200			{array}		ProductSearchResultDTO
+//	@Failure		400			{object}	apperr.Response
+//	@Failure		401			{object}	apperr.Response
+//	@Failure		500			{object}	apperr.Response
+//	@Router			/api/products/search [get]
+//	@Security		Bearer
+func (h *ProductHandler) SearchProducts(c *fiber.Ctx) error {
+	spec, err := query.Parse(c.Queries(), productSearchList)
+	if err != nil {
+		return err
+	}
+	products, page, err := h.svc.SearchProducts(c.Query("q"), spec)
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusOK).JSON(
+		&fiber.Map{
+			"status":  "SUCCESS",
+			"message": strconv.Itoa(len(products)) + " records found",
+			"data":    products,
+			"meta":    page,
+		})
+}
+
 // GetProductById godoc
 //
 //	@Summary		Fetch individual product by Id
@@ -137,24 +170,16 @@ func (h *ProductHandler) GetAllProducts(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Param			id					path		string	true	"product Id"
 //	@Success		200					{object}	models.Product
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/products/{id} [get]
 //	@Security		Bearer
 func (h *ProductHandler) GetProductById(c *fiber.Ctx) error {
 
 	product, err := h.svc.GetByIdSerive(c.Params("id"))
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "Record not found",
-			})
-		}
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
-			"status": "FAIL", "message": err.Error(),
-		})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "SUCCESS",
@@ -172,32 +197,21 @@ func (h *ProductHandler) GetProductById(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Param			id					path		string	true	"product Id"
 //	@Success		200					{object}	models.Product
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/products/prices/{id} [get]
 //
 // @Security       Bearer
 func (h *ProductHandler) GetProductUnitPricesById(c *fiber.Ctx) error {
 	productId := c.Params("id")
 	if productId == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "FAIL",
-			"message": "Product ID is required",
-		})
+		return apperr.Validation("product ID is required")
 	}
 
 	unitPrices, err := h.svc.GetProductUnitPricesByIdSerive(c.Params("id"))
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "No unit prices found for this product",
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status": "FAIL", "message": err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -218,9 +232,9 @@ func (h *ProductHandler) GetProductUnitPricesById(c *fiber.Ctx) error {
 //	@Param			id					path		string						true	"product Id"
 //	@Param			product				body		UpdateProductRequstDTO	true	"Product Data"
 //	@Success		200					{object}	models.Product
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/products/{id}	[put]
 //
 //	@Security		Bearer
@@ -230,24 +244,13 @@ func (h *ProductHandler) UpdateProduct(c *fiber.Ctx) error {
 	// Step 1: Get the existing product
 	foundProduct, err := h.svc.GetByIdSerive(id)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "Record not found",
-			})
-		}
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
-			"status": "FAIL", "message": err.Error(),
-		})
+		return err
 	}
 
 	// Step 2: Parse incoming update fields
 	input := new(UpdateProductRequstDTO)
 	if err := c.BodyParser(input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  400,
-			"message": "Invalid JSON format",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 	log.Println("inputProduct(Handler): ", input)
 
@@ -267,10 +270,7 @@ func (h *ProductHandler) UpdateProduct(c *fiber.Ctx) error {
 	// Step 4: Update and return
 	result, err := h.svc.Update(foundProduct)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "FAIL",
-			"message": err.Error(),
-		})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "SUCCESS",
@@ -283,23 +283,12 @@ func (h *ProductHandler) UpdateProductOld(c *fiber.Ctx) error {
 
 	foundProduct, err := h.svc.GetByIdSerive(c.Params("id"))
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "Record not found",
-			})
-		}
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
-			"status": "FAIL", "message": err.Error(),
-		})
+		return err
 	}
 	input := new(UpdateProductRequstDTO)
 	log.Println("inputProduct(Handler): ", input)
 	if err := c.BodyParser(input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  400,
-			"message": "Invalid JSON format",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 	updateProduct := models.Product{
 		ID:              foundProduct.ID,
@@ -314,17 +303,11 @@ func (h *ProductHandler) UpdateProductOld(c *fiber.Ctx) error {
 	}
 	log.Println("updateProduct(Handler): ", &updateProduct)
 	if err := c.BodyParser(&updateProduct); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  400,
-			"message": "Invalid JSON format",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 	result, err := h.svc.Update(&updateProduct)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "FAIL",
-			"message": err.Error(),
-		})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "SUCCESS",
@@ -342,9 +325,9 @@ func (h *ProductHandler) UpdateProductOld(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Param			id					path		string	true	"product Id"
 //	@Success		200					{object}	models.Product
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/products/{id}	[delete]
 //	@Security		Bearer
 func (h *ProductHandler) DeleteProduct(c *fiber.Ctx) error {
@@ -352,22 +335,11 @@ func (h *ProductHandler) DeleteProduct(c *fiber.Ctx) error {
 	id := strings.ToUpper(c.Params("id"))
 	product, err := h.svc.GetByIdSerive(id)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "Record not found",
-			})
-		}
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
-			"status": "FAIL", "message": err.Error(),
-		})
+		return err
 	}
 	err = h.svc.DeleteSerive(product.ID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "FAIL",
-			"message": "Internal server error",
-		})
+		return err
 	}
 	return c.JSON(fiber.Map{
 		"code":    200,
@@ -383,17 +355,15 @@ func (h *ProductHandler) DeleteProduct(c *fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Success		200					{object}	models.Product
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/products/stocks [get]
 //	@Security		Bearer
 func (h *ProductHandler) GetAllProductStocks(c *fiber.Ctx) error {
 	products, err := h.svc.GetAllProductStocks()
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
 	}
 	return c.Status(http.StatusOK).JSON(
 		&fiber.Map{
@@ -413,32 +383,21 @@ func (h *ProductHandler) GetAllProductStocks(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Param			id					path		string						true	"product Id"
 //	@Success		200					{object}	models.Product
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //
 // @Router			/api/products/stocks/{id} [get]
 // @Security		Bearer
 func (h *ProductHandler) GetProductStocksById(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "FAIL",
-			"message": "ID is required",
-		})
+		return apperr.Validation("ID is required")
 	}
 
 	productStocks, err := h.svc.GetProductStocksById(id)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "No product stocks found for this ID",
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status": "FAIL", "message": err.Error(),
-		})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "SUCCESS",
@@ -454,25 +413,33 @@ func (h *ProductHandler) GetProductStocksById(c *fiber.Ctx) error {
 //	@Tags			Products
 //	@Accept			json
 //	@Produce		json
+//	@Param			page	query		int		false	"page number, from 1"
+//	@Param			limit	query		int		false	"rows per page, 20 by default and 100 at most"
+//	@Param			sort	query		string	false	"comma separated fields of serial, productId, productName, unitPrice; prefix - for descending"
+//	@Param			productId	query		string	false	"only the prices of this product"
+//	@Param			priceType	query		string	false	"BUY or SELL"
 //	@Success		200					{object}	models.Product
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //
 // @Router			/api/products/prices/ [get]
 // @Security		Bearer
 func (h *ProductHandler) GetAllProductPrices(c *fiber.Ctx) error {
-	products, err := h.svc.GetAllProductPrices()
+	spec, err := query.Parse(c.Queries(), productPriceList)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
+	}
+	products, page, err := h.svc.GetAllProductPrices(spec)
+	if err != nil {
+		return err
 	}
 	return c.Status(http.StatusOK).JSON(
 		&fiber.Map{
 			"status":  "SUCCESS",
 			"message": strconv.Itoa(len(products)) + " records found",
 			"data":    products,
+			"meta":    page,
 			"count":   len(products),
 		})
 }
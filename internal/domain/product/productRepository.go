@@ -1,26 +1,26 @@
 package product
 
 import (
-	"errors"
-	"fmt"
 	"log"
 	"strings"
-	"sync"
 	"time"
 
+	"github.com/sankangkin/di-rest-api/internal/apperr"
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 	"gorm.io/gorm"
 )
 
 type ProductRepositoryInterface interface {
 	Create(product *models.Product) (*models.Product, error)
 	// GetAll() ([]models.Product, error)
-	GetAll() ([]ResponseProductDTO, error)
+	GetAll(spec query.Spec) ([]ResponseProductDTO, query.Page, error)
 	GetById(id string) (*models.Product, error)
+	Search(q string, spec query.Spec) ([]ProductSearchResultDTO, query.Page, error)
 	GetAllProductStocks() ([]ResponseProductStockDTO, error)
 	GetProductStocksById(productId string) (*ResponseProductStockDTO, error)
-	GetAllProductPrices() ([]ResponseProductUnitPriceDTO, error)
+	GetAllProductPrices(spec query.Spec) ([]ResponseProductUnitPriceDTO, query.Page, error)
 	GetProductUnitPricesById(productId string) ([]ResponseProductUnitPriceDTO, error)
 	GetUnitConversionsById(id string) (models.UnitConversion, error)
 	GetAllUnitConversionsWithProductName() ([]UnitConversionWithProductDTO, error)
@@ -37,12 +37,6 @@ type ProductRepository struct {
 	db *gorm.DB
 }
 
-// ! singleton pattern
-var (
-	repoInstance *ProductRepository
-	repoOnce     sync.Once
-)
-
 // func NewProductRepository(db *gorm.DB) ProductRepositoryInterface {
 // 	return &ProductRepository{db: db}
 // }
@@ -52,28 +46,44 @@ var (
 // constructor
 func NewProductRepository(db *gorm.DB) ProductRepositoryInterface {
 	log.Println(util.Yellow + "ProductRepository constructor is called " + util.Reset)
-	repoOnce.Do(func() {
-		repoInstance = &ProductRepository{db: db}
-	})
-	return repoInstance
+	return &ProductRepository{db: db}
 }
 
 func (r *ProductRepository) Create(product *models.Product) (*models.Product, error) {
+	product.ProductName = util.NormalizeText(product.ProductName)
+	product.BrandName = util.NormalizeText(product.BrandName)
 	err := r.db.Create(&product).Error
 	return product, err
 }
 
-func (r *ProductRepository) GetAll() ([]ResponseProductDTO, error) {
+// productList is what GET /products sorts and filters on.
+var productList = query.Resource{
+	Sort: map[string]string{
+		"id":          "id",
+		"productName": "product_name",
+		"brandName":   "brand_name",
+		"buyPrice":    "buy_price",
+		"sellPrice":   "sell_price_level1",
+		"createdAt":   "created_at",
+	},
+	DefaultSort: "-id",
+	Filters: map[string]query.Filter{
+		"categoryId": {Column: "category_id", Kind: query.Uint},
+		"isActive":   {Column: "is_active", Kind: query.Bool},
+		"brandName":  {Column: "brand_name"},
+		"uomId":      {Column: "uom_id", Kind: query.Uint},
+	},
+	Key: "id",
+}
+
+func (r *ProductRepository) GetAll(spec query.Spec) ([]ResponseProductDTO, query.Page, error) {
 	var products []models.Product
-	err := r.db.Model(&models.Product{}).Order("id DESC").Find(&products).Error
+	page, err := query.Find(r.db.Model(&models.Product{}), spec, &products)
 	if err != nil {
-		return nil, err
-	}
-	if len(products) == 0 {
-		return nil, errors.New("no records found")
+		return nil, query.Page{}, err
 	}
 
-	var dtos []ResponseProductDTO
+	dtos := []ResponseProductDTO{}
 	for _, p := range products {
 		dto := ResponseProductDTO{
 			ID:          p.ID,
@@ -97,7 +107,66 @@ func (r *ProductRepository) GetAll() ([]ResponseProductDTO, error) {
 		dtos = append(dtos, dto)
 	}
 
-	return dtos, nil
+	return dtos, page, nil
+}
+
+// productSearchList is what GET /products/search filters on; results come
+// ranked, so it does not sort.
+var productSearchList = query.Resource{
+	Filters: map[string]query.Filter{
+		"categoryId": {Column: "p.category_id", Kind: query.Uint},
+		"isActive":   {Column: "p.is_active", Kind: query.Bool},
+	},
+	Key: "p.id",
+}
+
+// likeEscaper escapes the LIKE wildcards of a search term.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Search matches q, already normalized, as a substring of the id, name and
+// brand of the products and the name of their category, and as a fuzzy
+// match of the name to forgive typos. Substring matching keeps Myanmar text
+// working, whose words are not separated by spaces; the trigram indexes of
+// migration 0003 serve both kinds of match.
+//
+// Results rank an exact id first, then ids and names starting with q, then
+// by how close the best field is to q.
+func (r *ProductRepository) Search(q string, spec query.Spec) ([]ProductSearchResultDTO, query.Page, error) {
+	results := []ProductSearchResultDTO{}
+	page, err := query.Find(searchQuery(r.db, q), spec, &results)
+	if err != nil {
+		return nil, query.Page{}, err
+	}
+	return results, page, nil
+}
+
+func searchQuery(db *gorm.DB, q string) *gorm.DB {
+	args := map[string]interface{}{
+		"q":        q,
+		"id":       strings.ToUpper(q),
+		"prefix":   likeEscaper.Replace(q) + "%",
+		"contains": "%" + likeEscaper.Replace(q) + "%",
+	}
+
+	return db.
+		Table("products AS p").
+		Select(`p.id, p.product_name, p.brand_name, p.category_id, c.category_name, p.uom AS base_unit, p.sell_price_level1, p.is_active,
+			(p.id = @id)::int * 2
+			+ (p.id ILIKE @prefix OR p.product_name ILIKE @prefix)::int
+			+ GREATEST(
+				word_similarity(@q, p.id),
+				word_similarity(@q, p.product_name),
+				word_similarity(@q, COALESCE(p.brand_name, '')),
+				word_similarity(@q, COALESCE(c.category_name, ''))
+			) AS rank`, args).
+		Joins("LEFT JOIN categories AS c ON c.id = p.category_id AND c.deleted_at IS NULL").
+		Where("p.deleted_at IS NULL").
+		Where(`p.id ILIKE @contains
+			OR p.product_name ILIKE @contains
+			OR p.brand_name ILIKE @contains
+			OR c.category_name ILIKE @contains
+			OR @q <% p.product_name`, args).
+		Order("rank DESC, p.product_name")
 }
 
 func (r *ProductRepository) GetById(id string) (*models.Product, error) {
@@ -144,11 +213,11 @@ func (r *ProductRepository) Update(input *models.Product) (*models.Product, erro
 
 	log.Println("input from Repository: ", input)
 	if input.BrandName == "" || input.ProductName == "" || input.Uom == "" || input.BuyPrice == 0 || input.CategoryId == 0 || input.SellPriceLevel1 == 0 || input.DeriveUnitPrice == 0 || input.UomId == 0 {
-		return nil, fmt.Errorf("missing required fields")
+		return nil, apperr.Validation("missing required fields")
 	}
 
-	existingProduct.BrandName = input.BrandName
-	existingProduct.ProductName = input.ProductName
+	existingProduct.BrandName = util.NormalizeText(input.BrandName)
+	existingProduct.ProductName = util.NormalizeText(input.ProductName)
 	// existingProduct.Uom = input.Uom
 	existingProduct.UomId = input.UomId
 	existingProduct.BuyPrice = input.BuyPrice
@@ -232,7 +301,7 @@ func (r *ProductRepository) GetAllProductStocks() ([]ResponseProductStockDTO, er
 	// 	Scan(&results).Error
 
 	err := r.db.
-		Table("product_stocks AS p").
+		Table("product_stock_totals AS p").
 		Select(`
 			p.product_id,
 			item.product_name,
@@ -259,10 +328,10 @@ func (r *ProductRepository) GetProductStocksById(productId string) (*ResponsePro
 	var result ResponseProductStockDTO
 
 	err := r.db.
-		Table("product_stocks").
-		Select("product_stocks.product_id, products.product_name, product_stocks.base_qty as base_uom_in_stock, product_stocks.derived_qty as derived_uom_in_stock, product_stocks.reorder_lvl").
-		Joins("JOIN products ON products.id = product_stocks.product_id").
-		Where("product_stocks.product_id = ?", strings.ToUpper(productId)).
+		Table("product_stock_totals AS p").
+		Select("p.product_id, products.product_name, p.base_qty as base_uom_in_stock, p.derived_qty as derived_uom_in_stock, p.reorder_lvl").
+		Joins("JOIN products ON products.id = p.product_id").
+		Where("p.product_id = ?", strings.ToUpper(productId)).
 		Scan(&result).Error
 
 	if err != nil {
@@ -272,28 +341,43 @@ func (r *ProductRepository) GetProductStocksById(productId string) (*ResponsePro
 	return &result, nil
 }
 
-func (r *ProductRepository) GetAllProductPrices() ([]ResponseProductUnitPriceDTO, error) {
-	var results []ResponseProductUnitPriceDTO
+// productPriceList is what GET /products/prices sorts and filters on.
+var productPriceList = query.Resource{
+	Sort: map[string]string{
+		"serial":      "pp.id",
+		"productId":   "pp.product_id",
+		"productName": "p.product_name",
+		"unitPrice":   "pp.unit_price",
+	},
+	DefaultSort: "serial",
+	Filters: map[string]query.Filter{
+		"productId": {Column: "pp.product_id", Kind: query.Code},
+		"priceType": {Column: "pp.price_type", Kind: query.Code},
+	},
+	Key: "pp.id",
+}
 
-	err := r.db.
-		Raw(`
-		SELECT 
+func (r *ProductRepository) GetAllProductPrices(spec query.Spec) ([]ResponseProductUnitPriceDTO, query.Page, error) {
+	results := []ResponseProductUnitPriceDTO{}
+
+	db := r.db.
+		Table("product_prices AS pp").
+		Select(`
 			ROW_NUMBER() OVER (ORDER BY pp.id) AS serial,
 			p.id AS product_id,
 			p.product_name,
 			u.unit_name AS uom,
 			pp.unit_price
-		FROM product_prices AS pp
-		JOIN products AS p ON pp.product_id = p.id
-		JOIN unit_of_measures AS u ON pp.unit_id = u.id
-	`).
-		Scan(&results).Error
+		`).
+		Joins("JOIN products AS p ON pp.product_id = p.id").
+		Joins("JOIN unit_of_measures AS u ON pp.unit_id = u.id")
 
+	page, err := query.Find(db, spec, &results)
 	if err != nil {
-		return nil, err
+		return nil, query.Page{}, err
 	}
 
-	return results, nil
+	return results, page, nil
 }
 
 func (r *ProductRepository) GetUnitConversionsById(id string) (models.UnitConversion, error) {
@@ -301,12 +385,12 @@ func (r *ProductRepository) GetUnitConversionsById(id string) (models.UnitConver
 	err := r.db.Where("product_id = ?", strings.ToUpper(id)).Find(&unitConversions).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return models.UnitConversion{}, errors.New("no unit conversion found for this product")
+			return models.UnitConversion{}, apperr.NotFound("no unit conversion found for product %s", id)
 		}
 		return models.UnitConversion{}, err
 	}
 	if unitConversions.ProductId == "" {
-		return models.UnitConversion{}, errors.New("no unit conversion found for this product")
+		return models.UnitConversion{}, apperr.NotFound("no unit conversion found for product %s", id)
 	}
 	return unitConversions, nil
 }
@@ -350,7 +434,7 @@ func (r *ProductRepository) GetUniofMeasurementById(id string) (models.UnitOfMea
 	err := r.db.Where("id = ?", strings.ToUpper(id)).Find(&unitOfMeasure).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return models.UnitOfMeasure{}, errors.New("no unit of measurement found for this id")
+			return models.UnitOfMeasure{}, apperr.NotFound("no unit of measurement found for id %s", id)
 		}
 		return models.UnitOfMeasure{}, err
 	}
@@ -0,0 +1,26 @@
+package product
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestSearchQuery(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return searchQuery(tx, "50%_off").Find(&[]ProductSearchResultDTO{})
+	})
+
+	// wildcards in the term are matched literally
+	assert.Contains(t, sql, `p.product_name ILIKE '%50\%\_off%'`)
+	assert.Contains(t, sql, `p.id ILIKE '50\%\_off%'`)
+	assert.Contains(t, sql, `(p.id = '50%_OFF')::int * 2`)
+	assert.Contains(t, sql, `'50%_off' <% p.product_name`)
+	assert.Contains(t, sql, `ORDER BY rank DESC, p.product_name`)
+}
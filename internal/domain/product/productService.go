@@ -2,19 +2,22 @@ package product
 
 import (
 	"log"
-	"sync"
+	"unicode/utf8"
 
+	"github.com/sankangkin/di-rest-api/internal/apperr"
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 )
 
 type ProductServiceInterface interface {
 	CreateSerive(product *models.Product) (*models.Product, error)
-	GetAllSerive() ([]ResponseProductDTO, error)
+	GetAllSerive(spec query.Spec) ([]ResponseProductDTO, query.Page, error)
 	GetByIdSerive(id string) (*models.Product, error)
+	SearchProducts(q string, spec query.Spec) ([]ProductSearchResultDTO, query.Page, error)
 	GetAllProductStocks() ([]ResponseProductStockDTO, error)
 	GetProductStocksById(productId string) (*ResponseProductStockDTO, error)
-	GetAllProductPrices() ([]ResponseProductUnitPriceDTO, error)
+	GetAllProductPrices(spec query.Spec) ([]ResponseProductUnitPriceDTO, query.Page, error)
 	GetProductUnitPricesByIdSerive(productId string) ([]ResponseProductUnitPriceDTO, error)
 	GetUnitConversionsById(id string) (models.UnitConversion, error)
 	GetAllUnitConversions() ([]models.UnitConversion, error)
@@ -30,12 +33,6 @@ type ProductService struct {
 	repo ProductRepositoryInterface
 }
 
-// ! singleton pattern
-var (
-	svcInstance *ProductService
-	svcOnce     sync.Once
-)
-
 // func NewProductService(repo ProductRepositoryInterface) ProductServiceInterface{
 // 	return &ProductService{repo: repo}
 // }
@@ -45,23 +42,35 @@ func NewProductService(repo ProductRepositoryInterface) ProductServiceInterface
 
 	log.Println(util.Yellow + "ProductService constructor is called" + util.Reset)
 
-	svcOnce.Do(func() {
-		svcInstance = &ProductService{repo: repo}
-	})
-	return svcInstance
+	return &ProductService{repo: repo}
 }
 
 func (s *ProductService) CreateSerive(product *models.Product) (*models.Product, error) {
-
+	// the average cost starts at the buy price, receipts move it from there
+	product.AvgCost = product.BuyPrice
 	return s.repo.Create(product)
 }
-func (s *ProductService) GetAllSerive() ([]ResponseProductDTO, error) {
-	return s.repo.GetAll()
+func (s *ProductService) GetAllSerive(spec query.Spec) ([]ResponseProductDTO, query.Page, error) {
+	return s.repo.GetAll(spec)
 }
 func (s *ProductService) GetByIdSerive(id string) (*models.Product, error) {
 	return s.repo.GetById(id)
 }
 
+// maxSearchLen bounds the search term, in characters.
+const maxSearchLen = 100
+
+func (s *ProductService) SearchProducts(q string, spec query.Spec) ([]ProductSearchResultDTO, query.Page, error) {
+	q = util.NormalizeText(q)
+	if q == "" {
+		return nil, query.Page{}, apperr.Validation("q is required")
+	}
+	if utf8.RuneCountInString(q) > maxSearchLen {
+		return nil, query.Page{}, apperr.Validation("q must be at most %d characters", maxSearchLen)
+	}
+	return s.repo.Search(q, spec)
+}
+
 func (s *ProductService) GetProductUnitPricesByIdSerive(productId string) ([]ResponseProductUnitPriceDTO, error) {
 	return s.repo.GetProductUnitPricesById(productId)
 }
@@ -82,8 +91,8 @@ func (s *ProductService) GetProductStocksById(productId string) (*ResponseProduc
 	return s.repo.GetProductStocksById(productId)
 }
 
-func (s *ProductService) GetAllProductPrices() ([]ResponseProductUnitPriceDTO, error) {
-	return s.repo.GetAllProductPrices()
+func (s *ProductService) GetAllProductPrices(spec query.Spec) ([]ResponseProductUnitPriceDTO, query.Page, error) {
+	return s.repo.GetAllProductPrices(spec)
 }
 func (s *ProductService) GetUnitConversionsById(id string) (models.UnitConversion, error) {
 	return s.repo.GetUnitConversionsById(id)
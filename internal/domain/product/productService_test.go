@@ -0,0 +1,33 @@
+package product
+
+import (
+	"testing"
+
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type searchRepo struct {
+	ProductRepositoryInterface
+	q string
+}
+
+func (r *searchRepo) Search(q string, spec query.Spec) ([]ProductSearchResultDTO, query.Page, error) {
+	r.q = q
+	return []ProductSearchResultDTO{}, query.Page{}, nil
+}
+
+func TestSearchProducts(t *testing.T) {
+	repo := &searchRepo{}
+	svc := &ProductService{repo: repo}
+
+	// "ဦ" typed as U+1025 U+102E is searched as its composed form U+1026
+	_, _, err := svc.SearchProducts("  ဦး ", query.Spec{})
+	require.NoError(t, err)
+	assert.Equal(t, "ဦး", repo.q)
+
+	_, _, err = svc.SearchProducts("   ", query.Spec{})
+	assert.ErrorIs(t, err, apperr.ErrValidation)
+}
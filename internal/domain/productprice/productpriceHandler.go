@@ -3,29 +3,21 @@ package productprice
 import (
 	"log"
 	"strconv"
-	"sync"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
-	"gorm.io/gorm"
+	"github.com/sankangkin/di-rest-api/internal/query"
 )
 
 type ProductPriceHandler struct {
 	svc ProductPriceServiceInterface
 }
 
-var (
-	hdlInstance *ProductPriceHandler
-	hdlOnce     sync.Once
-)
-
 func NewProductPriceHandler(svc ProductPriceServiceInterface) *ProductPriceHandler {
 	log.Println(util.Yellow + "ProductPriceHandler constructor is called" + util.Reset)
-	hdlOnce.Do(func() {
-		hdlInstance = &ProductPriceHandler{svc: svc}
-	})
-	return hdlInstance
+	return &ProductPriceHandler{svc: svc}
 }
 
 // CreateProductPrice godoc
@@ -37,18 +29,15 @@ func NewProductPriceHandler(svc ProductPriceServiceInterface) *ProductPriceHandl
 //	@Produce		json
 //	@Param			productPrice		body		models.ProductPrice	true	"Product Price Input Data"
 //	@Success		200				{object}	models.ProductPrice
-//	@Failure		400				{object}	httputil.HttpError400
-//	@Failure		401				{object}	httputil.HttpError401
-//	@Failure		500				{object}	httputil.HttpError500
+//	@Failure		400				{object}	apperr.Response
+//	@Failure		401				{object}	apperr.Response
+//	@Failure		500				{object}	apperr.Response
 //	@Router			/api/productprices [post]
 //	@Security		Bearer
 func (h *ProductPriceHandler) CreateProductPrice(c *fiber.Ctx) error {
 	input := new(models.ProductPrice)
 	if err := c.BodyParser(input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  400,
-			"message": "Invalid JSON format",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 
 	log.Println("New product price input: ", input)
@@ -60,14 +49,13 @@ func (h *ProductPriceHandler) CreateProductPrice(c *fiber.Ctx) error {
 		PriceType: input.PriceType,
 	}
 
-	errors := models.ValidateStruct(newProductPrice)
-	if errors != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(errors)
+	if err := models.ValidateStruct(newProductPrice); err != nil {
+		return err
 	}
 	log.Println("newProductPrice : ", newProductPrice)
 
 	if _, err := h.svc.Create(&newProductPrice); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(
 		&fiber.Map{
@@ -85,24 +73,33 @@ func (h *ProductPriceHandler) CreateProductPrice(c *fiber.Ctx) error {
 //	@Tags			ProductPrice
 //	@Accept			json
 //	@Produce		json
+//	@Param			page	query		int		false	"page number, from 1"
+//	@Param			limit	query		int		false	"rows per page, 20 by default and 100 at most"
+//	@Param			sort	query		string	false	"comma separated fields of id, productId, productName, price; prefix - for descending"
+//	@Param			productId	query		string	false	"only the prices of this product"
+//	@Param			unitId	query		int	false	"only the prices of this unit"
+//	@Param			priceType	query		string	false	"BUY or SELL"
 //	@Success		200				{object}	[]models.ProductPrice
-//	@Failure		400				{object}	httputil.HttpError400
-//	@Failure		401				{object}	httputil.HttpError401
-//	@Failure		500				{object}	httputil.HttpError500
+//	@Failure		400				{object}	apperr.Response
+//	@Failure		401				{object}	apperr.Response
+//	@Failure		500				{object}	apperr.Response
 //	@Router			/api/productprices [get]
 //	@Security		Bearer
 func (h *ProductPriceHandler) GetAllProductPrices(c *fiber.Ctx) error {
-	productPrices, err := h.svc.GetAll()
+	spec, err := query.Parse(c.Queries(), productPriceList)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
+	}
+	productPrices, page, err := h.svc.GetAll(spec)
+	if err != nil {
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(
 		&fiber.Map{
 			"status":  "SUCCESS",
 			"message": strconv.Itoa(len(productPrices)) + " records found",
 			"data":    productPrices,
+			"meta":    page,
 			"count":   len(productPrices),
 		})
 }
@@ -116,9 +113,9 @@ func (h *ProductPriceHandler) GetAllProductPrices(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Param			id					path		string	true	"product price Id"
 //	@Success		200					{object}	models.ProductPrice
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/productprices/{id} [get]
 //	@Security		Bearer
 func (h *ProductPriceHandler) GetProductPriceById(c *fiber.Ctx) error {
@@ -126,22 +123,11 @@ func (h *ProductPriceHandler) GetProductPriceById(c *fiber.Ctx) error {
 	productPriceIdStr := c.Params("id")
 	productPriceId, err := strconv.Atoi(productPriceIdStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "FAIL",
-			"message": "Invalid product price ID",
-		})
+		return apperr.Validation("invalid product price ID").Wrap(err)
 	}
 	productPrice, err := h.svc.GetById(productPriceId)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "Record not found",
-			})
-		}
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
-			"status": "FAIL", "message": err.Error(),
-		})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "SUCCESS",
@@ -160,40 +146,26 @@ func (h *ProductPriceHandler) GetProductPriceById(c *fiber.Ctx) error {
 //	@Param			id					path		string						true	"product price Id"
 //	@Param			productPrice		body		UpdateProductPriceRequestDTO	true	"Product Price Data"
 //	@Success		200					{object}	models.ProductPrice
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/productprices/{id}	[put]
 //	@Security		Bearer
 func (h *ProductPriceHandler) UpdateProductPrice(c *fiber.Ctx) error {
 	idUint64, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "FAIL",
-			"message": "Invalid product price ID",
-		})
+		return apperr.Validation("invalid product price ID").Wrap(err)
 	}
 	id := int(idUint64)
 
 	// Step 1: Get the existing product
 	foundProductPrice, err := h.svc.GetById(id)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "Record not found",
-			})
-		}
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
-			"status": "FAIL", "message": err.Error(),
-		})
+		return err
 	}
 	input := new(UpdateProductPriceRequestDTO)
 	if err := c.BodyParser(input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  400,
-			"message": "Invalid JSON format",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 	log.Println("inputProduct(Handler): ", input)
 
@@ -215,10 +187,7 @@ func (h *ProductPriceHandler) UpdateProductPrice(c *fiber.Ctx) error {
 	// Step 4: Update and return
 	result, err := h.svc.UpdateProductPrice(updatedProductPrice)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "FAIL",
-			"message": err.Error(),
-		})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "SUCCESS",
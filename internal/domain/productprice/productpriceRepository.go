@@ -1,20 +1,20 @@
 package productprice
 
 import (
-	"errors"
 	"fmt"
 	"log"
-	"sync"
 	"time"
 
+	"github.com/sankangkin/di-rest-api/internal/apperr"
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 	"gorm.io/gorm"
 )
 
 type ProductPriceRepositoryInterface interface {
 	Create(productPrice *models.ProductPrice) (*models.ProductPrice, error)
-	GetAll() ([]ProductPriceResponseDTO, error)
+	GetAll(spec query.Spec) ([]ProductPriceResponseDTO, query.Page, error)
 	GetById(id int) (*ProductPriceResponseDTO, error)
 	UpdateProductPrice(input *models.ProductPrice) (*models.ProductPrice, error)
 	DeleteProductPrice(id int) error
@@ -24,12 +24,6 @@ type ProductPriceRepository struct {
 	db *gorm.DB
 }
 
-// ! singleton pattern
-var (
-	repoInstance *ProductPriceRepository
-	repoOnce     sync.Once
-)
-
 // func NewProductPriceRepository(db *gorm.DB) ProductPriceRepositoryInterface {
 // 	return &ProductPriceRepository{db: db}
 // }
@@ -39,10 +33,7 @@ var (
 // constructor
 func NewProductPriceRepository(db *gorm.DB) ProductPriceRepositoryInterface {
 	log.Println(util.Yellow + "ProductPriceRepository constructor is called" + util.Reset)
-	repoOnce.Do(func() {
-		repoInstance = &ProductPriceRepository{db: db}
-	})
-	return repoInstance
+	return &ProductPriceRepository{db: db}
 }
 
 func (r *ProductPriceRepository) Create(productPrice *models.ProductPrice) (*models.ProductPrice, error) {
@@ -67,26 +58,38 @@ func (r *ProductPriceRepository) Create(productPrice *models.ProductPrice) (*mod
 	return productPrice, nil
 }
 
-func (r *ProductPriceRepository) GetAll() ([]ProductPriceResponseDTO, error) {
-	var results []ProductPriceResponseDTO
+// productPriceList is what GET /productprices sorts and filters on.
+var productPriceList = query.Resource{
+	Sort: map[string]string{
+		"id":          "pp.id",
+		"productId":   "pp.product_id",
+		"productName": "p.product_name",
+		"price":       "pp.unit_price",
+	},
+	DefaultSort: "productId",
+	Filters: map[string]query.Filter{
+		"productId": {Column: "pp.product_id", Kind: query.Code},
+		"unitId":    {Column: "pp.unit_id", Kind: query.Uint},
+		"priceType": {Column: "pp.price_type", Kind: query.Code},
+	},
+	Key: "pp.id",
+}
 
-	err := r.db.
+func (r *ProductPriceRepository) GetAll(spec query.Spec) ([]ProductPriceResponseDTO, query.Page, error) {
+	results := []ProductPriceResponseDTO{}
+
+	db := r.db.
 		Table("product_prices AS pp").
 		Select(`pp.id, pp.product_id, p.product_name, u.unit_name, pp.unit_id, pp.unit_price, pp.price_type`).
 		Joins("JOIN products AS p ON pp.product_id = p.id").
-		Joins("JOIN unit_of_measures AS u ON pp.unit_id = u.id").
-		Order("pp.product_id ASC").
-		Scan(&results).Error
+		Joins("JOIN unit_of_measures AS u ON pp.unit_id = u.id")
 
+	page, err := query.Find(db, spec, &results)
 	if err != nil {
-		return nil, err
-	}
-
-	if len(results) == 0 {
-		return nil, errors.New("no records found")
+		return nil, query.Page{}, err
 	}
 
-	return results, nil
+	return results, page, nil
 }
 
 func (r *ProductPriceRepository) GetById(id int) (*ProductPriceResponseDTO, error) {
@@ -116,7 +119,7 @@ func (r *ProductPriceRepository) UpdateProductPriceOld(input *models.ProductPric
 
 	log.Println("input from Repository: ", input)
 	if input.ProductId == "" || input.UnitId == 0 || input.UnitPrice == 0 {
-		return nil, fmt.Errorf("missing required fields")
+		return nil, apperr.Validation("missing required fields")
 	}
 
 	priceChanged := existingProductPrice.UnitPrice != input.UnitPrice
@@ -162,7 +165,7 @@ func (r *ProductPriceRepository) UpdateProductPrice(input *models.ProductPrice)
 	// Validate input
 	if input.ProductId == "" || input.UnitId == 0 || input.UnitPrice == 0 {
 		tx.Rollback()
-		return nil, fmt.Errorf("missing required fields")
+		return nil, apperr.Validation("missing required fields")
 	}
 
 	priceChanged := existingProductPrice.UnitPrice != input.UnitPrice
@@ -212,7 +215,7 @@ func (r *ProductPriceRepository) UpdateProductPrice(input *models.ProductPrice)
 
 // 	log.Println("input from Repository: ", input)
 // 	if input.ProductId == "" || input.UnitId == 0 || input.UnitPrice == 0 {
-// 		return nil, fmt.Errorf("missing required fields")
+// 		return nil, apperr.Validation("missing required fields")
 // 	}
 
 // 	priceChanged := existingProductPrice.UnitPrice != input.UnitPrice
@@ -2,15 +2,15 @@ package productprice
 
 import (
 	"log"
-	"sync"
 
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 )
 
 type ProductPriceServiceInterface interface {
 	Create(productPrice *models.ProductPrice) (*models.ProductPrice, error)
-	GetAll() ([]ProductPriceResponseDTO, error)
+	GetAll(spec query.Spec) ([]ProductPriceResponseDTO, query.Page, error)
 	GetById(id int) (*ProductPriceResponseDTO, error)
 	UpdateProductPrice(input *models.ProductPrice) (*models.ProductPrice, error)
 	DeleteProductPrice(id int) error
@@ -20,12 +20,6 @@ type ProductPriceService struct {
 	repo ProductPriceRepositoryInterface
 }
 
-// ! singleton pattern
-var (
-	svcInstance *ProductPriceService
-	svcOnce     sync.Once
-)
-
 // func NewProductPriceService(repo ProductPriceRepositoryInterface) ProductPriceServiceInterface{
 // 	return &ProductPriceService{repo: repo}
 // }
@@ -35,17 +29,14 @@ func NewProductPriceService(repo ProductPriceRepositoryInterface) ProductPriceSe
 
 	log.Println(util.Yellow + "ProductPriceService constructor is called" + util.Reset)
 
-	svcOnce.Do(func() {
-		svcInstance = &ProductPriceService{repo: repo}
-	})
-	return svcInstance
+	return &ProductPriceService{repo: repo}
 }
 
 func (s *ProductPriceService) Create(productPrice *models.ProductPrice) (*models.ProductPrice, error) {
 	return s.repo.Create(productPrice)
 }
-func (s *ProductPriceService) GetAll() ([]ProductPriceResponseDTO, error) {
-	return s.repo.GetAll()
+func (s *ProductPriceService) GetAll(spec query.Spec) ([]ProductPriceResponseDTO, query.Page, error) {
+	return s.repo.GetAll(spec)
 }
 func (s *ProductPriceService) GetById(id int) (*ProductPriceResponseDTO, error) {
 	return s.repo.GetById(id)
@@ -1,19 +1,50 @@
 package productstock
 
+// ResponseProductStockDTO is the stock of a product over all locations,
+// with the stock at each location in Locations.
 type ResponseProductStockDTO struct {
-	ProductID   string `json:"productId"`
-	ProductName string `json:"productName"`
-	BaseQty     int    `json:"baseQty"`
-	DerivedQty  int    `json:"derivedQty"`
-	ReorderLvl  int    `json:"reorderlvl"`
-	Factor      int    `json:"factor"`
-	BaseUnit    string `json:"baseUnit"`
-	DeriveUnit  string `json:"deriveUnit"`
+	ProductID   string             `json:"productId"`
+	ProductName string             `json:"productName"`
+	BaseQty     int                `json:"baseQty"`
+	DerivedQty  int                `json:"derivedQty"`
+	ReorderLvl  int                `json:"reorderlvl"`
+	Factor      int                `json:"factor"`
+	BaseUnit    string             `json:"baseUnit"`
+	DeriveUnit  string             `json:"deriveUnit"`
+	Locations   []LocationStockDTO `gorm:"-" json:"locations"`
+}
+
+type LocationStockDTO struct {
+	ProductID    string `json:"-"`
+	LocationId   uint   `json:"locationId"`
+	LocationCode string `json:"locationCode"`
+	LocationName string `json:"locationName"`
+	BaseQty      int    `json:"baseQty"`
+	DerivedQty   int    `json:"derivedQty"`
+	ReorderLvl   int    `json:"reorderlvl"`
+}
+
+// LowStockDTO is the stock of a product at a location that is at or below
+// its reorder level. Shortage is how many base units it takes to get back
+// above the reorder level, loose derived units counting as fractions of one.
+type LowStockDTO struct {
+	ProductID    string `json:"productId"`
+	ProductName  string `json:"productName"`
+	LocationId   uint   `json:"locationId"`
+	LocationCode string `json:"locationCode"`
+	BaseQty      int    `json:"baseQty"`
+	DerivedQty   int    `json:"derivedQty"`
+	Factor       int    `json:"factor"`
+	BaseUnit     string `json:"baseUnit"`
+	DeriveUnit   string `json:"deriveUnit"`
+	ReorderLvl   int    `json:"reorderlvl"`
+	Shortage     int    `json:"shortage"`
 }
 
 type UpdateProductStockDTO struct {
 	ID         uint   `gorm:"primaryKey" json:"id"`
 	ProductID  string `json:"productId"`
+	LocationId uint   `json:"locationId"` // the default location when empty
 	BaseQty    int    `json:"baseQty"`
 	DerivedQty int    `json:"derivedQty"`
 	ReorderLvl int    `json:"reorderlvl"`
@@ -4,24 +4,18 @@ import (
 	"log"
 	"net/http"
 	"strconv"
-	"sync"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
-	"gorm.io/gorm"
+	"github.com/sankangkin/di-rest-api/internal/query"
 )
 
 type ProductStockHandler struct {
 	svc ProductStockRepositoryInterface
 }
 
-// ! singleton pattern
-var (
-	handlerInstance *ProductStockHandler
-	handlerOnce     sync.Once
-)
-
 // func NewProductStockHandler(repo ProductStockRepositoryInterface) ProductStockHandlerInterface{
 // 	return &ProductStockHandler{repo: repo}
 // }
@@ -31,38 +25,33 @@ func NewProductStockHandler(svc ProductStockRepositoryInterface) *ProductStockHa
 
 	log.Println(util.Yellow + "ProductStockHandler constructor is called" + util.Reset)
 
-	handlerOnce.Do(func() {
-		handlerInstance = &ProductStockHandler{svc: svc}
-	})
-	return handlerInstance
+	return &ProductStockHandler{svc: svc}
 }
 
 // CreateProductStocks godoc
 //
 //	@Summary		Create new product stock
-//	@Description	Create a new product stock with productId, baseQty, derivedQty, and reorderLvl
+//	@Description	Create the stock of a product at a location, the default location when locationId is empty, with baseQty, derivedQty and reorderLvl
 //	@Tags			ProductStocks
 //	@Accept			json
 //	@Produce		json
 //	@Param			productStock		body		models.ProductStock	true	"Product Stock Input Data"
 //	@Success		200				{object}	models.ProductStock
-//	@Failure		400				{object}	httputil.HttpError400
-//	@Failure		401				{object}	httputil.HttpError401
-//	@Failure		500				{object}	httputil.HttpError500
+//	@Failure		400				{object}	apperr.Response
+//	@Failure		401				{object}	apperr.Response
+//	@Failure		500				{object}	apperr.Response
 //	@Router			/api/productstocks [post]
 //	@Security		Bearer
 func (h *ProductStockHandler) CreateProductStocks(c *fiber.Ctx) error {
 	input := new(models.ProductStock)
 	if err := c.BodyParser(input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  400,
-			"message": "Invalid JSON format",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 	log.Println("inputProduct(Handler): ", input)
 
 	productStockToCreate := &models.ProductStock{
 		ProductId:  input.ProductId,
+		LocationId: input.LocationId,
 		BaseQty:    input.BaseQty,
 		DerivedQty: input.DerivedQty,
 		ReorderLvl: input.ReorderLvl,
@@ -72,10 +61,7 @@ func (h *ProductStockHandler) CreateProductStocks(c *fiber.Ctx) error {
 	// Step 4: Create and return
 	result, err := h.svc.CreateProductStocks(productStockToCreate)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "FAIL",
-			"message": err.Error(),
-		})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "SUCCESS",
@@ -87,66 +73,99 @@ func (h *ProductStockHandler) CreateProductStocks(c *fiber.Ctx) error {
 // GetAllProductStocks godoc
 //
 //	@Summary		Get all product stocks
-//	@Description	Get all product stocks
+//	@Description	Get the stock of every product over all locations, with the stock at each location
 //	@Tags			ProductStocks
 //	@Accept			json
 //	@Produce		json
-//	@Success		200					{object}	models.ProductStock
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Param			page	query		int		false	"page number, from 1"
+//	@Param			limit	query		int		false	"rows per page, 20 by default and 100 at most"
+//	@Param			sort	query		string	false	"comma separated fields of productId, productName, baseQty, derivedQty; prefix - for descending"
+//	@Param			productId	query		string	false	"only the stock of this product"
+//	@Success		200					{array}		ResponseProductStockDTO
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/productstocks [get]
 //	@Security		Bearer
 func (h *ProductStockHandler) GetAllProductStocks(c *fiber.Ctx) error {
-	productStocks, err := h.svc.GetAllProductStocks()
+	spec, err := query.Parse(c.Queries(), productStockList)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
+	}
+	productStocks, page, err := h.svc.GetAllProductStocks(spec)
+	if err != nil {
+		return err
 	}
 	return c.Status(http.StatusOK).JSON(
 		&fiber.Map{
 			"status":  "SUCCESS",
 			"message": strconv.Itoa(len(productStocks)) + " records found",
 			"data":    productStocks,
+			"meta":    page,
 			"count":   len(productStocks),
 		})
 }
 
+// GetLowStocks godoc
+//
+//	@Summary		Fetch the stock at or below its reorder level
+//	@Description	Fetch the stock of every product and location that is at or below its reorder level, loose derived units counting as 1/factor of a base unit
+//	@Tags			ProductStocks
+//	@Accept			json
+//	@Produce		json
+//	@Param			page		query		int		false	"page number, from 1"
+//	@Param			limit		query		int		false	"rows per page, 20 by default and 100 at most"
+//	@Param			sort		query		string	false	"comma separated fields of productId, productName, locationId, shortage; prefix - for descending"
+//	@Param			productId	query		string	false	"only the stock of this product"
+//	@Param			locationId	query		int		false	"only the stock at this location"
+//	@Param			categoryId	query		int		false	"only the products of this category"
+//	@Success		200			{array}		LowStockDTO
+//	@Failure		400			{object}	apperr.Response
+//	@Failure		401			{object}	apperr.Response
+//	@Failure		500			{object}	apperr.Response
+//	@Router			/api/productstocks/low [get]
+//	@Security		Bearer
+func (h *ProductStockHandler) GetLowStocks(c *fiber.Ctx) error {
+	spec, err := query.Parse(c.Queries(), lowStockList)
+	if err != nil {
+		return err
+	}
+	lowStocks, page, err := h.svc.GetLowStocks(spec)
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusOK).JSON(
+		&fiber.Map{
+			"status":  "SUCCESS",
+			"message": strconv.Itoa(len(lowStocks)) + " records found",
+			"data":    lowStocks,
+			"meta":    page,
+		})
+}
+
 // GetProductStocksById godoc
 //
 //	@Summary		Fetch individual productstock by Id
-//	@Description	Fetch individual productstock by Id
+//	@Description	Fetch the stock of a product over all locations, with the stock at each location
 //	@Tags			ProductStocks
 //	@Accept			json
 //	@Produce		json
 //	@Param			id					path		string	true	"product Id"
-//	@Success		200					{object}	models.ProductStock
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Success		200					{object}	ResponseProductStockDTO
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/productstocks/{id} [get]
 //	@Security		Bearer
 func (h *ProductStockHandler) GetProductStocksById(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "FAIL",
-			"message": "ID is required",
-		})
+		return apperr.Validation("ID is required")
 	}
 
 	productStocks, err := h.svc.GetProductStocksById(id)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "No product stocks found for this ID",
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status": "FAIL", "message": err.Error(),
-		})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "SUCCESS",
@@ -158,72 +177,45 @@ func (h *ProductStockHandler) GetProductStocksById(c *fiber.Ctx) error {
 // UpdateProductStocksById godoc
 //
 //	@Summary		Update individual productstock
-//	@Description	Update individual products
+//	@Description	Set the stock of a product at a location, the default location when locationId is empty; the differences are booked as adjustments
 //	@Tags			ProductStocks
 //	@Accept			json
 //	@Produce		json
 //	@Param			id					path		string						true	"product Id"
 //	@Param			product				body		UpdateProductStockDTO	true	"ProductStock Data"
 //	@Success		200					{object}	models.ProductStock
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/productstocks/{id}	[put]
 //
 //	@Security		Bearer
 func (h *ProductStockHandler) UpdateProductStocksById(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  "FAIL",
-			"message": "ID is required",
-		})
+		return apperr.Validation("ID is required")
 	}
 
 	input := new(UpdateProductStockDTO)
 	if err := c.BodyParser(input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  400,
-			"message": "Invalid JSON format",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 	log.Println("inputProduct(Handler): ", input)
 
-	// Step 3: Manually update only intended fields
-	foundProductStock, err := h.svc.GetProductStocksById(id)
-	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "Record not found",
-			})
-		}
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
-			"status": "FAIL", "message": err.Error(),
-		})
-	}
-	foundProductStock.BaseQty = input.BaseQty
-	foundProductStock.DerivedQty = input.DerivedQty
-	foundProductStock.ReorderLvl = input.ReorderLvl
-
-	log.Println("updateProduct(Handler): ", foundProductStock)
-
-	// Map foundProductStock (*ResponseProductStockDTO) to *models.ProductStock
+	// Step 3: Manually update only intended fields of the stock at the location
 	productStockToUpdate := &models.ProductStock{
-		ProductId:  foundProductStock.ProductID,
-		BaseQty:    foundProductStock.BaseQty,
-		DerivedQty: foundProductStock.DerivedQty,
-		ReorderLvl: foundProductStock.ReorderLvl,
+		ProductId:  id,
+		LocationId: input.LocationId,
+		BaseQty:    input.BaseQty,
+		DerivedQty: input.DerivedQty,
+		ReorderLvl: input.ReorderLvl,
 		// Add other fields as necessary
 	}
 
 	// Step 4: Update and return
 	result, err := h.svc.UpdateProductStocksById(productStockToUpdate)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "FAIL",
-			"message": err.Error(),
-		})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "SUCCESS",
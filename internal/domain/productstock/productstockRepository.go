@@ -3,30 +3,29 @@ package productstock
 import (
 	"log"
 	"strings"
-	"sync"
 
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/stockmovement"
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ProductStockRepositoryInterface interface {
 	CreateProductStocks(productStock *models.ProductStock) (*models.ProductStock, error)
-	GetAllProductStocks() ([]ResponseProductStockDTO, error)
+	GetAllProductStocks(spec query.Spec) ([]ResponseProductStockDTO, query.Page, error)
+	GetLowStocks(spec query.Spec) ([]LowStockDTO, query.Page, error)
 	GetProductStocksById(productId string) (*ResponseProductStockDTO, error)
 	UpdateProductStocksById(productStock *models.ProductStock) (*models.ProductStock, error)
 }
 
 type ProductStockRepository struct {
-	db *gorm.DB
+	db    *gorm.DB
+	stock stockmovement.StockMovementServiceInterface
 }
 
-// ! singleton pattern
-var (
-	repoInstance *ProductStockRepository
-	repoOnce     sync.Once
-)
-
 // func NewProductStockRepository(db *gorm.DB) ProductStockRepositoryInterface {
 // 	return &ProductStockRepository{db: db}
 // }
@@ -34,16 +33,26 @@ var (
 //! constructor must be return the Interface, NOT struct, if not, google wire generate fail
 
 // constructor
-func NewProductStockRepository(db *gorm.DB) ProductStockRepositoryInterface {
+func NewProductStockRepository(db *gorm.DB, stock stockmovement.StockMovementServiceInterface) ProductStockRepositoryInterface {
 	log.Println(util.Yellow + "ProductStockRepository constructor is called " + util.Reset)
-	repoOnce.Do(func() {
-		repoInstance = &ProductStockRepository{db: db}
-	})
-	return repoInstance
+	return &ProductStockRepository{db: db, stock: stock}
 }
 
-func (r *ProductStockRepository) GetAllProductStocks() ([]ResponseProductStockDTO, error) {
-	var results []ResponseProductStockDTO
+// productStockList is what GET /productstocks sorts and filters on.
+var productStockList = query.Resource{
+	Sort: map[string]string{
+		"productId":   "p.product_id",
+		"productName": "item.product_name",
+		"baseQty":     "p.base_qty",
+		"derivedQty":  "p.derived_qty",
+	},
+	DefaultSort: "productId",
+	Filters:     map[string]query.Filter{"productId": {Column: "p.product_id", Kind: query.Code}},
+	Key:         "p.id",
+}
+
+func (r *ProductStockRepository) GetAllProductStocks(spec query.Spec) ([]ResponseProductStockDTO, query.Page, error) {
+	results := []ResponseProductStockDTO{}
 
 	// Perform the join and select necessary fields
 	// err := r.db.
@@ -52,8 +61,8 @@ func (r *ProductStockRepository) GetAllProductStocks() ([]ResponseProductStockDT
 	// 	Joins("JOIN products ON products.id = product_stocks.product_id").
 	// 	Scan(&results).Error
 
-	err := r.db.
-		Table("product_stocks AS p").
+	db := r.db.
+		Table("product_stock_totals AS p").
 		Select(`
 			p.product_id,
 			item.product_name,
@@ -65,60 +74,234 @@ func (r *ProductStockRepository) GetAllProductStocks() ([]ResponseProductStockDT
 			uc.factor
 		`).
 		Joins("JOIN unit_conversions uc ON p.product_id = uc.product_id").
-		Joins("JOIN products item ON p.product_id = item.id").
-		Order("p.product_id").
-		Scan(&results).Error
+		Joins("JOIN products item ON p.product_id = item.id")
 
+	page, err := query.Find(db, spec, &results)
 	if err != nil {
-		return nil, err
+		return nil, query.Page{}, err
+	}
+	if err := r.attachLocations(results); err != nil {
+		return nil, query.Page{}, err
+	}
+
+	return results, page, nil
+}
+
+// lowStockList is what GET /productstocks/low sorts and filters on.
+var lowStockList = query.Resource{
+	Sort: map[string]string{
+		"productId":   "s.product_id",
+		"productName": "item.product_name",
+		"locationId":  "s.location_id",
+		"shortage":    "shortage",
+	},
+	DefaultSort: "-shortage",
+	Filters: map[string]query.Filter{
+		"productId":  {Column: "s.product_id", Kind: query.Code},
+		"locationId": {Column: "s.location_id", Kind: query.Uint},
+		"categoryId": {Column: "item.category_id", Kind: query.Uint},
+	},
+	Key: "s.id",
+}
+
+// GetLowStocks lists the stock rows at or below their reorder level, loose
+// derived units counting as 1/factor of a base unit. A reorder level of zero
+// never alerts.
+func (r *ProductStockRepository) GetLowStocks(spec query.Spec) ([]LowStockDTO, query.Page, error) {
+	results := []LowStockDTO{}
+
+	// stock and reorder level in derived units, so nothing is rounded
+	const factor = "COALESCE(uc.factor, 1)"
+	const stock = "(s.base_qty * " + factor + " + s.derived_qty)"
+	const level = "(s.reorder_lvl * " + factor + ")"
+
+	db := r.db.
+		Table("product_stocks AS s").
+		Select(`
+			s.product_id,
+			item.product_name,
+			s.location_id,
+			l.code AS location_code,
+			s.base_qty,
+			s.derived_qty,
+			` + factor + ` AS factor,
+			COALESCE(uc.base_unit, item.uom) AS base_unit,
+			uc.derive_unit,
+			s.reorder_lvl,
+			(` + level + ` - ` + stock + ` + ` + factor + `) / ` + factor + ` AS shortage
+		`).
+		Joins("JOIN products item ON item.id = s.product_id AND item.deleted_at IS NULL").
+		Joins("JOIN locations l ON l.id = s.location_id").
+		Joins("LEFT JOIN unit_conversions uc ON uc.product_id = s.product_id AND uc.deleted_at IS NULL").
+		Where("s.deleted_at IS NULL AND s.reorder_lvl > 0 AND " + stock + " <= " + level)
+
+	page, err := query.Find(db, spec, &results)
+	if err != nil {
+		return nil, query.Page{}, err
+	}
+	return results, page, nil
+}
+
+// attachLocations fills in the stock at each location of the products.
+func (r *ProductStockRepository) attachLocations(products []ResponseProductStockDTO) error {
+	if len(products) == 0 {
+		return nil
+	}
+	ids := make([]string, len(products))
+	for i, p := range products {
+		ids[i] = p.ProductID
 	}
 
-	return results, nil
+	var rows []LocationStockDTO
+	err := r.db.
+		Table("product_stocks AS s").
+		Select("s.product_id, s.location_id, l.code AS location_code, l.name AS location_name, s.base_qty, s.derived_qty, s.reorder_lvl").
+		Joins("JOIN locations l ON l.id = s.location_id").
+		Where("s.product_id IN ? AND s.deleted_at IS NULL", ids).
+		Order("s.product_id, l.id").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	byProduct := map[string][]LocationStockDTO{}
+	for _, row := range rows {
+		byProduct[row.ProductID] = append(byProduct[row.ProductID], row)
+	}
+	for i := range products {
+		products[i].Locations = byProduct[products[i].ProductID]
+		if products[i].Locations == nil {
+			products[i].Locations = []LocationStockDTO{}
+		}
+	}
+	return nil
 }
 
+// CreateProductStocks creates an empty stock row at the location and books
+// the requested quantities as opening-balance movements, so the ledger
+// explains them.
 func (r *ProductStockRepository) CreateProductStocks(productStock *models.ProductStock) (*models.ProductStock, error) {
-	err := r.db.Create(&productStock).Error
+	opening := *productStock
+	productStock.BaseQty = 0
+	productStock.DerivedQty = 0
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		location, err := r.stock.ResolveLocation(tx, productStock.LocationId)
+		if err != nil {
+			return err
+		}
+		productStock.LocationId = location.ID
+		if err := tx.Create(&productStock).Error; err != nil {
+			return err
+		}
+		return r.applyStockDelta(tx, productStock.ProductId, location.ID, opening.BaseQty, opening.DerivedQty, "OPENING", "opening balance")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.First(productStock, "id = ?", productStock.ID).Error
 	return productStock, err
 }
 
+// applyStockDelta posts base and derived quantity differences as ADJUSTMENT
+// movements through the stock-movement engine.
+func (r *ProductStockRepository) applyStockDelta(tx *gorm.DB, productId string, locationId uint, baseDelta int, derivedDelta int, referenceNo string, remark string) error {
+	var unitConv models.UnitConversion
+	if derivedDelta != 0 {
+		if err := tx.First(&unitConv, "product_id = ?", productId).Error; err != nil {
+			return apperr.NotFound("unit conversion not found for product %s", productId)
+		}
+	}
+
+	if baseDelta != 0 {
+		if _, err := r.stock.Apply(tx, stockmovement.Movement{
+			ProductId:   productId,
+			LocationId:  locationId,
+			Qty:         baseDelta,
+			TranType:    "ADJUSTMENT",
+			ReferenceNo: referenceNo,
+			Remark:      remark,
+		}); err != nil {
+			return err
+		}
+	}
+	if derivedDelta != 0 {
+		if _, err := r.stock.Apply(tx, stockmovement.Movement{
+			ProductId:   productId,
+			LocationId:  locationId,
+			Uom:         unitConv.DeriveUnit,
+			Qty:         derivedDelta,
+			TranType:    "ADJUSTMENT",
+			ReferenceNo: referenceNo,
+			Remark:      remark,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *ProductStockRepository) GetProductStocksById(productId string) (*ResponseProductStockDTO, error) {
 	var result ResponseProductStockDTO
 
 	err := r.db.
-		Table("product_stocks").
-		Select("product_stocks.product_id, products.product_name, product_stocks.base_qty , product_stocks.derived_qty, product_stocks.reorder_lvl").
-		Joins("JOIN products ON products.id = product_stocks.product_id").
-		Where("product_stocks.product_id = ?", strings.ToUpper(productId)).
+		Table("product_stock_totals AS p").
+		Select("p.product_id, products.product_name, p.base_qty, p.derived_qty, p.reorder_lvl").
+		Joins("JOIN products ON products.id = p.product_id").
+		Where("p.product_id = ?", strings.ToUpper(productId)).
 		Scan(&result).Error
 
 	if err != nil {
 		return nil, err
 	}
 
-	return &result, nil
+	results := []ResponseProductStockDTO{result}
+	if err := r.attachLocations(results); err != nil {
+		return nil, err
+	}
+	return &results[0], nil
 }
 
+// UpdateProductStocksById moves the stock of the product at one location to
+// the requested quantities by posting the differences as adjustments; it
+// never overwrites quantities directly.
 func (r *ProductStockRepository) UpdateProductStocksById(productStock *models.ProductStock) (*models.ProductStock, error) {
 	var existingProductStock models.ProductStock
-	err := r.db.Where("product_id = ?", strings.ToUpper(productStock.ProductId)).First(&existingProductStock).Error
+	productId := strings.ToUpper(productStock.ProductId)
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		location, err := r.stock.ResolveLocation(tx, productStock.LocationId)
+		if err != nil {
+			return err
+		}
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("product_id = ? AND location_id = ?", productId, location.ID).
+			First(&existingProductStock).Error
+		if err == gorm.ErrRecordNotFound {
+			return apperr.NotFound("no stock of product %s at location %s", productId, location.Code)
+		}
+		if err != nil {
+			return err
+		}
+
+		baseDelta := productStock.BaseQty - existingProductStock.BaseQty
+		derivedDelta := productStock.DerivedQty - existingProductStock.DerivedQty
+		if err := r.applyStockDelta(tx, existingProductStock.ProductId, location.ID, baseDelta, derivedDelta, "STOCK-UPDATE", "manual stock update"); err != nil {
+			return err
+		}
+
+		return tx.Model(&existingProductStock).Update("reorder_lvl", productStock.ReorderLvl).Error
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// log.Println("input from Repository: ", productStock)
-	// if productStock.BaseQty == 0 || productStock.DerivedQty == 0 || productStock.ReorderLvl == 0 {
-	// 	return nil, fmt.Errorf("missing required fields")
-	// }
-
-	existingProductStock.BaseQty = productStock.BaseQty
-	existingProductStock.DerivedQty = productStock.DerivedQty
-	existingProductStock.ReorderLvl = productStock.ReorderLvl
-
-	log.Println("existingProductStock to update: ", existingProductStock)
-	err = r.db.Save(&existingProductStock).Error
+	err = r.db.First(&existingProductStock, "id = ?", existingProductStock.ID).Error
 	if err != nil {
 		return nil, err
 	}
+	log.Println("existingProductStock updated: ", existingProductStock)
 
 	return &existingProductStock, nil
 }
@@ -2,15 +2,15 @@ package productstock
 
 import (
 	"log"
-	"sync"
 
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 )
 
 type ProductStockServiceInterface interface {
 	CreateProductStocks(productStock *models.ProductStock) (*models.ProductStock, error)
-	GetAllProductStocks() ([]ResponseProductStockDTO, error)
+	GetAllProductStocks(spec query.Spec) ([]ResponseProductStockDTO, query.Page, error)
 	GetProductStocksById(productId string) (*ResponseProductStockDTO, error)
 	UpdateProductStocksById(productStock *models.ProductStock) (*models.ProductStock, error)
 }
@@ -19,12 +19,6 @@ type ProductStockService struct {
 	repo ProductStockRepositoryInterface
 }
 
-// ! singleton pattern
-var (
-	svcInstance *ProductStockService
-	svcOnce     sync.Once
-)
-
 // func NewProductStockService(repo ProductStockRepositoryInterface) ProductStockServiceInterface{
 // 	return &ProductStockService{repo: repo}
 // }
@@ -34,18 +28,15 @@ func NewProductStockService(repo ProductStockRepositoryInterface) ProductStockSe
 
 	log.Println(util.Yellow + "ProductStockService constructor is called" + util.Reset)
 
-	svcOnce.Do(func() {
-		svcInstance = &ProductStockService{repo: repo}
-	})
-	return svcInstance
+	return &ProductStockService{repo: repo}
 }
 
 func (s *ProductStockService) CreateProductStocks(productStock *models.ProductStock) (*models.ProductStock, error) {
 	return s.repo.CreateProductStocks(productStock)
 }
 
-func (s *ProductStockService) GetAllProductStocks() ([]ResponseProductStockDTO, error) {
-	return s.repo.GetAllProductStocks()
+func (s *ProductStockService) GetAllProductStocks(spec query.Spec) ([]ResponseProductStockDTO, query.Page, error) {
+	return s.repo.GetAllProductStocks(spec)
 }
 
 func (s *ProductStockService) GetProductStocksById(productId string) (*ResponseProductStockDTO, error) {
@@ -0,0 +1,23 @@
+package profit
+
+// ProfitRowDTO is the gross profit of one group of sale lines: a sale, a
+// product, a category or a period, named by Key and Name. Revenue is net of
+// line and invoice discounts and of returns, without tax; Cost is what the
+// goods cost at the time of the sale.
+type ProfitRowDTO struct {
+	Key     string  `json:"key"`  // sale id, product id, category id or first day of the period
+	Name    string  `json:"name"` // sale date, product or category name
+	Lines   int     `json:"lines"`
+	Revenue int64   `json:"revenue"`
+	Cost    int64   `json:"cost"`
+	Profit  int64   `json:"profit"`
+	Margin  float64 `json:"margin"` // profit in percent of revenue
+}
+
+type ProfitReportDTO struct {
+	GroupBy string         `json:"groupBy"`
+	From    string         `json:"from,omitempty"`
+	To      string         `json:"to,omitempty"`
+	Rows    []ProfitRowDTO `json:"rows"`
+	Total   ProfitRowDTO   `json:"total"`
+}
@@ -0,0 +1,116 @@
+package profit
+
+import (
+	"encoding/csv"
+	"io"
+	"log"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/query"
+)
+
+type ProfitHandler struct {
+	svc ProfitServiceInterface
+}
+
+func NewProfitHandler(svc ProfitServiceInterface) *ProfitHandler {
+	log.Println(util.Green + "ProfitHandler constructor is called" + util.Reset)
+	return &ProfitHandler{svc: svc}
+}
+
+// GetProfitReport godoc
+//
+//	@Summary		Gross profit and margin of the sales
+//	@Description	Compare what the sale lines brought in, net of discounts and returns and without tax, with what their goods cost at the time of the sale, per sale, product, category, day, week or month. format=csv downloads the report as CSV.
+//	@Tags			Profits
+//	@Accept			json
+//	@Produce		json
+//	@Produce		text/csv
+//	@Param			groupBy		query		string	false	"sale (default), product, category, day, week or month"
+//	@Param			from		query		string	false	"first sale date, yyyy-mm-dd"
+//	@Param			to			query		string	false	"last sale date, yyyy-mm-dd"
+//	@Param			locationId	query		int		false	"only the sales from this location"
+//	@Param			customerId	query		int		false	"only the sales to this customer"
+//	@Param			categoryId	query		int		false	"only the lines of products in this category"
+//	@Param			productId	query		string	false	"only the lines of this product"
+//	@Param			format		query		string	false	"json (default) or csv"
+//	@Success		200			{object}	ProfitReportDTO
+//	@Failure		400			{object}	apperr.Response
+//	@Failure		401			{object}	apperr.Response
+//	@Failure		500			{object}	apperr.Response
+//	@Router			/api/profits [get]
+//	@Security		Bearer
+func (h *ProfitHandler) GetProfitReport(c *fiber.Ctx) error {
+	format := c.Query("format", "json")
+	if format != "json" && format != "csv" {
+		return apperr.Validation("format must be json or csv, got %q", format)
+	}
+	spec, err := query.Parse(c.Queries(), profitLines)
+	if err != nil {
+		return err
+	}
+
+	report, err := h.svc.GetReport(spec, c.Query("groupBy"))
+	if err != nil {
+		return err
+	}
+	report.From = c.Query("from")
+	report.To = c.Query("to")
+
+	if format == "csv" {
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		c.Set(fiber.HeaderContentDisposition, `attachment; filename="profit-by-`+report.GroupBy+`.csv"`)
+		return writeCSV(c, report)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": strconv.Itoa(len(report.Rows)) + " records found",
+		"data":    report,
+	})
+}
+
+// csvKeys names the key and name columns of each grouping.
+var csvKeys = map[string][2]string{
+	GroupBySale:     {"saleId", "saleDate"},
+	GroupByProduct:  {"productId", "productName"},
+	GroupByCategory: {"categoryId", "categoryName"},
+	GroupByDay:      {"day", ""},
+	GroupByWeek:     {"week", ""},
+	GroupByMonth:    {"month", ""},
+}
+
+// writeCSV writes one record per row and the total last.
+func writeCSV(w io.Writer, report *ProfitReportDTO) error {
+	keys := csvKeys[report.GroupBy]
+	header := []string{keys[0]}
+	if keys[1] != "" {
+		header = append(header, keys[1])
+	}
+	header = append(header, "lines", "revenue", "cost", "profit", "margin")
+
+	out := csv.NewWriter(w)
+	if err := out.Write(header); err != nil {
+		return err
+	}
+	for _, r := range append(report.Rows, report.Total) {
+		record := []string{r.Key}
+		if keys[1] != "" {
+			record = append(record, r.Name)
+		}
+		record = append(record,
+			strconv.Itoa(r.Lines),
+			strconv.FormatInt(r.Revenue, 10),
+			strconv.FormatInt(r.Cost, 10),
+			strconv.FormatInt(r.Profit, 10),
+			strconv.FormatFloat(r.Margin, 'f', 2, 64),
+		)
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
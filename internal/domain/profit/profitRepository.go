@@ -0,0 +1,91 @@
+package profit
+
+import (
+	"log"
+
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
+	"gorm.io/gorm"
+)
+
+type ProfitRepositoryInterface interface {
+	GetLines(spec query.Spec) ([]lineRow, error)
+}
+
+type ProfitRepository struct {
+	db *gorm.DB
+}
+
+func NewProfitRepository(db *gorm.DB) ProfitRepositoryInterface {
+	log.Println(util.Green + "ProfitRepository constructor is called" + util.Reset)
+	return &ProfitRepository{db: db}
+}
+
+// lineRow is a sale line with its sale and the cost of one base unit the
+// ledger booked it out at. Qty and ReturnedQty are in the line's own unit,
+// Derived tells whether that is the derived unit, Factor derived units in
+// a base unit.
+type lineRow struct {
+	SaleId       string
+	SaleDate     string
+	CreatedAt    int64
+	SaleTotal    int64
+	SaleDiscount int64
+	ProductId    string
+	ProductName  string
+	CategoryId   uint
+	CategoryName string
+	Qty          int64
+	ReturnedQty  int64
+	LineTotal    int64
+	Derived      bool
+	Factor       int64
+	UnitCost     int64
+}
+
+// profitLines is what GET /profits filters on, the sale date by from and to.
+var profitLines = query.Resource{
+	Filters: map[string]query.Filter{
+		"locationId": {Column: "s.location_id", Kind: query.Uint},
+		"customerId": {Column: "s.customer_id", Kind: query.Uint},
+		"categoryId": {Column: "p.category_id", Kind: query.Uint},
+		"productId":  {Column: "sd.product_id", Kind: query.Code},
+	},
+	Dates: query.Filter{Column: "s.sale_date", Kind: query.DateText},
+}
+
+// GetLines loads the lines of the sales that are not voided. A line whose
+// ledger row predates the costing falls back to the product's average cost.
+func (r *ProfitRepository) GetLines(spec query.Spec) ([]lineRow, error) {
+	var rows []lineRow
+	db := r.db.
+		Table("sale_details AS sd").
+		Select(`
+			s.id AS sale_id,
+			s.sale_date,
+			s.created_at,
+			s.total AS sale_total,
+			s.discount AS sale_discount,
+			sd.product_id,
+			p.product_name,
+			p.category_id,
+			COALESCE(c.category_name, '') AS category_name,
+			CASE WHEN d.derived THEN sd.derived_qty ELSE sd.qty END AS qty,
+			sd.returned_qty,
+			sd.total AS line_total,
+			d.derived,
+			COALESCE(uc.factor, 1) AS factor,
+			COALESCE(it.unit_cost, p.avg_cost) AS unit_cost
+		`).
+		Joins("JOIN sales s ON s.id = sd.sale_id AND s.deleted_at IS NULL").
+		Joins("JOIN products p ON p.id = sd.product_id").
+		Joins("LEFT JOIN categories c ON c.id = p.category_id").
+		Joins("LEFT JOIN unit_conversions uc ON uc.product_id = sd.product_id AND uc.deleted_at IS NULL").
+		Joins("CROSS JOIN LATERAL (SELECT COALESCE(UPPER(sd.uom) = UPPER(NULLIF(uc.derive_unit, '')), false) AS derived) d").
+		Joins("LEFT JOIN item_transactions it ON it.reference_no = s.id || '-' || sd.id AND it.tran_type = ? AND it.deleted_at IS NULL", "CREDIT").
+		Where("sd.deleted_at IS NULL AND s.status <> ?", models.SaleVoided)
+
+	err := spec.Filter(db).Order("s.sale_date, s.id, sd.id").Scan(&rows).Error
+	return rows, err
+}
@@ -0,0 +1,162 @@
+package profit
+
+import (
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/query"
+)
+
+const (
+	GroupBySale     = "sale"
+	GroupByProduct  = "product"
+	GroupByCategory = "category"
+	GroupByDay      = "day"
+	GroupByWeek     = "week"
+	GroupByMonth    = "month"
+)
+
+type ProfitServiceInterface interface {
+	GetReport(spec query.Spec, groupBy string) (*ProfitReportDTO, error)
+}
+
+type ProfitService struct {
+	repo ProfitRepositoryInterface
+}
+
+func NewProfitService(repo ProfitRepositoryInterface) ProfitServiceInterface {
+	log.Println(util.Green + "ProfitService constructor is called" + util.Reset)
+	return &ProfitService{repo: repo}
+}
+
+// GetReport adds up the gross profit of the sale lines the spec selects per
+// sale, product, category, day, week or month. Returns count against the
+// sale they came from, whenever they were made.
+func (s *ProfitService) GetReport(spec query.Spec, groupBy string) (*ProfitReportDTO, error) {
+	if groupBy == "" {
+		groupBy = GroupBySale
+	}
+	switch groupBy {
+	case GroupBySale, GroupByProduct, GroupByCategory, GroupByDay, GroupByWeek, GroupByMonth:
+	default:
+		return nil, apperr.Validation("groupBy must be sale, product, category, day, week or month, got %q", groupBy)
+	}
+
+	lines, err := s.repo.GetLines(spec)
+	if err != nil {
+		return nil, err
+	}
+	rows, total := report(lines, groupBy)
+	return &ProfitReportDTO{GroupBy: groupBy, Rows: rows, Total: total}, nil
+}
+
+// lineProfit is what a sale line brought in and what its goods cost, for
+// the part of it that was not returned. The revenue carries its share of
+// the invoice discount; a derived-unit line costs a factor-th of a base
+// unit per unit.
+func lineProfit(l lineRow) (revenue int64, cost int64) {
+	kept := l.Qty - l.ReturnedQty
+	if l.Qty <= 0 || kept <= 0 {
+		return 0, 0
+	}
+
+	revenue = util.DivRound(l.LineTotal*kept, l.Qty)
+	if l.SaleTotal > 0 {
+		revenue = util.DivRound(revenue*(l.SaleTotal-l.SaleDiscount), l.SaleTotal)
+	}
+
+	cost = kept * l.UnitCost
+	if l.Derived && l.Factor > 1 {
+		cost = util.DivRound(cost, l.Factor)
+	}
+	return revenue, cost
+}
+
+// saleDay is the day of the sale, its sale date when that is a yyyy-mm-dd
+// date and the booking time otherwise.
+func saleDay(l lineRow) time.Time {
+	if len(l.SaleDate) >= 10 {
+		if day, err := time.Parse(time.DateOnly, l.SaleDate[:10]); err == nil {
+			return day
+		}
+	}
+	return time.Unix(l.CreatedAt, 0).UTC()
+}
+
+// periodOf is the first day of the day, week (from Monday) or month the
+// sale falls in.
+func periodOf(groupBy string, day time.Time) string {
+	switch groupBy {
+	case GroupByWeek:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset).Format(time.DateOnly)
+	case GroupByMonth:
+		return day.Format("2006-01") + "-01"
+	}
+	return day.Format(time.DateOnly)
+}
+
+// report groups the lines and adds up every group and the total. Sales keep
+// the order of the lines, periods run from the earliest, products and
+// categories go from the most profitable down.
+func report(lines []lineRow, groupBy string) ([]ProfitRowDTO, ProfitRowDTO) {
+	rows := []ProfitRowDTO{}
+	index := map[string]int{}
+	total := ProfitRowDTO{Key: "TOTAL"}
+
+	for _, l := range lines {
+		var key, name string
+		switch groupBy {
+		case GroupBySale:
+			key, name = l.SaleId, saleDay(l).Format(time.DateOnly)
+		case GroupByProduct:
+			key, name = l.ProductId, l.ProductName
+		case GroupByCategory:
+			key, name = strconv.FormatUint(uint64(l.CategoryId), 10), l.CategoryName
+		default:
+			key = periodOf(groupBy, saleDay(l))
+		}
+
+		i, ok := index[key]
+		if !ok {
+			i = len(rows)
+			index[key] = i
+			rows = append(rows, ProfitRowDTO{Key: key, Name: name})
+		}
+
+		revenue, cost := lineProfit(l)
+		for _, r := range []*ProfitRowDTO{&rows[i], &total} {
+			r.Lines++
+			r.Revenue += revenue
+			r.Cost += cost
+		}
+	}
+
+	for i := range rows {
+		finish(&rows[i])
+	}
+	finish(&total)
+
+	switch groupBy {
+	case GroupByProduct, GroupByCategory:
+		sort.SliceStable(rows, func(a, b int) bool { return rows[a].Profit > rows[b].Profit })
+	case GroupByDay, GroupByWeek, GroupByMonth:
+		sort.SliceStable(rows, func(a, b int) bool { return rows[a].Key < rows[b].Key })
+	}
+	return rows, total
+}
+
+// finish works out the profit and the margin, in percent of the revenue to
+// two decimals.
+func finish(r *ProfitRowDTO) {
+	r.Profit = r.Revenue - r.Cost
+	r.Margin = 0
+	if r.Revenue != 0 {
+		r.Margin = math.Round(float64(r.Profit)*10000/float64(r.Revenue)) / 100
+	}
+}
@@ -0,0 +1,57 @@
+package profit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineProfit(t *testing.T) {
+	// 4 at 250 less 100 off the line, 2 returned, and 10% off the invoice
+	revenue, cost := lineProfit(lineRow{Qty: 4, ReturnedQty: 2, LineTotal: 900, SaleTotal: 2000, SaleDiscount: 200, Factor: 1, UnitCost: 150})
+	assert.Equal(t, int64(405), revenue)
+	assert.Equal(t, int64(300), cost)
+
+	// 6 FEET of an EACH of 12 FEET costing 1300
+	revenue, cost = lineProfit(lineRow{Qty: 6, LineTotal: 720, SaleTotal: 720, Derived: true, Factor: 12, UnitCost: 1300})
+	assert.Equal(t, int64(720), revenue)
+	assert.Equal(t, int64(650), cost)
+
+	revenue, cost = lineProfit(lineRow{Qty: 3, ReturnedQty: 3, LineTotal: 300, SaleTotal: 300, Factor: 1, UnitCost: 80})
+	assert.Zero(t, revenue)
+	assert.Zero(t, cost)
+}
+
+func TestPeriodOf(t *testing.T) {
+	day := saleDay(lineRow{SaleDate: "2026-10-18 14:05"}) // a Sunday
+	assert.Equal(t, "2026-10-18", periodOf(GroupByDay, day))
+	assert.Equal(t, "2026-10-12", periodOf(GroupByWeek, day))
+	assert.Equal(t, "2026-10-01", periodOf(GroupByMonth, day))
+
+	// no date on the sale, its booking time
+	assert.Equal(t, "2026-10-19", periodOf(GroupByDay, saleDay(lineRow{SaleDate: "today", CreatedAt: 1792411200})))
+}
+
+func TestReport(t *testing.T) {
+	lines := []lineRow{
+		{SaleId: "INV-1", SaleDate: "2026-10-01", ProductId: "P001", ProductName: "Pipe", CategoryId: 1, CategoryName: "Pipes", Qty: 2, LineTotal: 200, SaleTotal: 500, Factor: 1, UnitCost: 60},
+		{SaleId: "INV-1", SaleDate: "2026-10-01", ProductId: "P002", ProductName: "Hammer", CategoryId: 2, CategoryName: "Tools", Qty: 1, LineTotal: 300, SaleTotal: 500, Factor: 1, UnitCost: 100},
+		{SaleId: "INV-2", SaleDate: "2026-09-30", ProductId: "P001", ProductName: "Pipe", CategoryId: 1, CategoryName: "Pipes", Qty: 1, LineTotal: 100, SaleTotal: 100, Factor: 1, UnitCost: 60},
+	}
+
+	rows, total := report(lines, GroupBySale)
+	assert.Equal(t, []ProfitRowDTO{
+		{Key: "INV-1", Name: "2026-10-01", Lines: 2, Revenue: 500, Cost: 220, Profit: 280, Margin: 56},
+		{Key: "INV-2", Name: "2026-09-30", Lines: 1, Revenue: 100, Cost: 60, Profit: 40, Margin: 40},
+	}, rows)
+	assert.Equal(t, ProfitRowDTO{Key: "TOTAL", Lines: 3, Revenue: 600, Cost: 280, Profit: 320, Margin: 53.33}, total)
+
+	rows, _ = report(lines, GroupByCategory)
+	assert.Equal(t, "2", rows[0].Key)
+	assert.Equal(t, int64(200), rows[0].Profit)
+	assert.Equal(t, "Pipes", rows[1].Name)
+	assert.Equal(t, int64(120), rows[1].Profit)
+
+	rows, _ = report(lines, GroupByMonth)
+	assert.Equal(t, []string{"2026-09-01", "2026-10-01"}, []string{rows[0].Key, rows[1].Key})
+}
@@ -3,12 +3,25 @@ package purchase
 import "github.com/sankangkin/di-rest-api/internal/models"
 
 type PurchaseInvoiceRequestDTO struct {
-	ID              string                  `gorm:"primaryKey" json:"id"`
 	SupplierId      uint                    `json:"supplierId"`
+	LocationId      uint                    `json:"locationId"` // the default location when empty
 	PurchaseDetails []models.PurchaseDetail `gorm:"foreignKey:purchaseId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"purchaseDetails"`
+	GoodsReceiptIds []string                `json:"goodsReceiptIds"` // the receipts of the goods this invoice bills
 	Discount        int64                   `json:"discount"`
 	Total           int64                   `json:"total"`
+	Tax             int64                   `json:"tax"`
 	GrandTotal      int64                   `json:"grandTotal"`
 	Remark          string                  `json:"remark"`
 	PurchaseDate    string                  `json:"purchaseDate"`
 }
+
+type PurchaseReturnRequestDTO struct {
+	Remark     string                         `json:"remark"`
+	ReturnDate string                         `json:"returnDate"`
+	Items      []PurchaseReturnItemRequestDTO `json:"items"`
+}
+
+type PurchaseReturnItemRequestDTO struct {
+	PurchaseDetailId uint `json:"purchaseDetailId"`
+	Qty              int  `json:"qty"`
+}
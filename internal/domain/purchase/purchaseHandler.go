@@ -4,81 +4,69 @@ import (
 	"log"
 	"net/http"
 	"strconv"
-	"sync"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
-	"gorm.io/gorm"
+	"github.com/sankangkin/di-rest-api/internal/query"
 )
 
 type PurchaseHandler struct {
 	svc PurchaseServiceInterface
 }
 
-var (
-	hdlInstance *PurchaseHandler
-	hdlOnce     sync.Once
-)
-
 func NewSaleHandler(svc PurchaseServiceInterface) *PurchaseHandler {
 	log.Println(util.Magenta + "SaleHandler constructor is called" + util.Reset)
-	hdlOnce.Do(func() {
-		hdlInstance = &PurchaseHandler{svc: svc}
-	})
-	return hdlInstance
+	return &PurchaseHandler{svc: svc}
 }
 
 // CreatePurchase 	godoc
 //
 //	@Summary		Create new purchase based on parameters
-//	@Description	Create new purchase based on parameters
+//	@Description	Book a supplier invoice and match it against the goods receipts it bills; it moves no stock. Lines whose quantity or price differs from the receipts are flagged and the purchase is MISMATCHED
 //	@Tags			Purchases
 //	@Accept			json
 //	@Param			purchase	body		PurchaseInvoiceRequestDTO	true	"Product Data"
 //	@Success		200		{object}	models.Purchase
-//	@Failure		400		{object}	httputil.HttpError400
-//	@Failure		401		{object}	httputil.HttpError401
-//	@Failure		500		{object}	httputil.HttpError500
-//	@Failure		401		{object}	httputil.HttpError401
+//	@Failure		400		{object}	apperr.Response
+//	@Failure		401		{object}	apperr.Response
+//	@Failure		404		{object}	apperr.Response
+//	@Failure		409		{object}	apperr.Response
+//	@Failure		500		{object}	apperr.Response
+//	@Failure		401		{object}	apperr.Response
 //	@Router			/api/purchases [post]
 //	@Security		Bearer
 func (h *PurchaseHandler) CreatePurchase(c *fiber.Ctx) error {
 
 	input := new(PurchaseInvoiceRequestDTO)
 	if err := c.BodyParser(input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  400,
-			"message": "Invalid JSON format",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 	newPurchase := models.Purchase{
-		ID:              input.ID,
 		SupplierId:      input.SupplierId,
+		LocationId:      input.LocationId,
 		Discount:        input.Discount,
 		GrandTotal:      input.GrandTotal,
 		Remark:          input.Remark,
 		PurchaseDate:    input.PurchaseDate,
 		PurchaseDetails: input.PurchaseDetails,
 		Total:           input.Total,
+		Tax:             input.Tax,
 	}
-	errors := models.ValidateStruct(newPurchase)
-	if errors != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "operation failed",
-		})
+	if err := models.ValidateStruct(newPurchase); err != nil {
+		return err
 	}
 
-	if _, err := h.svc.CreateService(&newPurchase); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+	created, err := h.svc.CreateService(&newPurchase, input.GoodsReceiptIds)
+	if err != nil {
+		return err
 	}
 	return c.Status(http.StatusOK).JSON(
 		&fiber.Map{
 			"status":  "SUCCESS",
 			"message": "purchase operation has been created successfully",
-			"data":    newPurchase,
+			"data":    created,
 		})
 
 }
@@ -90,25 +78,36 @@ func (h *PurchaseHandler) CreatePurchase(c *fiber.Ctx) error {
 //	@Tags			Purchases
 //	@Accept			json
 //	@Produce		json
+//	@Param			page	query		int		false	"page number, from 1"
+//	@Param			limit	query		int		false	"rows per page, 20 by default and 100 at most"
+//	@Param			sort	query		string	false	"comma separated fields of id, purchaseDate, grandTotal, createdAt; prefix - for descending"
+//	@Param			supplierId	query		int	false	"only the purchases of this supplier"
+//	@Param			locationId	query		int	false	"only the purchases received at this location"
+//	@Param			matchStatus	query		string	false	"only the purchases matched against their goods receipts as MATCHED or MISMATCHED"
+//	@Param			from	query		string	false	"first purchase date, yyyy-mm-dd"
+//	@Param			to	query		string	false	"last purchase date, yyyy-mm-dd"
 //	@Success		200				{array}		models.Purchase
-//	@Failure		400				{object}	httputil.HttpError400
-//	@Failure		401				{object}	httputil.HttpError401
-//	@Failure		500				{object}	httputil.HttpError500
+//	@Failure		400				{object}	apperr.Response
+//	@Failure		401				{object}	apperr.Response
+//	@Failure		500				{object}	apperr.Response
 //	@Router			/api/purchases	[get]
 //	@Security		Bearer
 func (h *PurchaseHandler) GetAllPurchases(c *fiber.Ctx) error {
 
-	purchases, err := h.svc.GetAllService()
+	spec, err := query.Parse(c.Queries(), purchaseList)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
+	}
+	purchases, page, err := h.svc.GetAllService(spec)
+	if err != nil {
+		return err
 	}
 	return c.Status(http.StatusOK).JSON(
 		&fiber.Map{
 			"status":  "SUCCESS",
 			"message": strconv.Itoa(len(purchases)) + " records found",
 			"data":    purchases,
+			"meta":    page,
 			"count":   len(purchases),
 		})
 }
@@ -122,24 +121,16 @@ func (h *PurchaseHandler) GetAllPurchases(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Param			id					path		string	true	"purchase Id"
 //	@Success		200					{object}	models.Purchase
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/purchases/{id}	[get]
 //	@Security		Bearer
 func (h *PurchaseHandler) GetById(c *fiber.Ctx) error {
 
 	purchase, err := h.svc.GetById(c.Params("id"))
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "Record not found",
-			})
-		}
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
-			"status": "FAIL", "message": err.Error(),
-		})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "SUCCESS",
@@ -148,3 +139,47 @@ func (h *PurchaseHandler) GetById(c *fiber.Ctx) error {
 	})
 
 }
+
+// CreatePurchaseReturn godoc
+//
+//	@Summary		Return purchased goods to the supplier
+//	@Description	Send goods of a purchase back to its supplier, take them out of stock at the locations they were received at and reduce the supplier's payable balance. Only goods its goods receipts brought in can go back
+//	@Tags			Purchases
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"purchase Id"
+//	@Param			body	body		PurchaseReturnRequestDTO	true	"Return Data"
+//	@Success		200		{object}	models.PurchaseReturn
+//	@Failure		400		{object}	apperr.Response
+//	@Failure		401		{object}	apperr.Response
+//	@Failure		500		{object}	apperr.Response
+//	@Router			/api/purchases/{id}/returns [post]
+//	@Security		Bearer
+func (h *PurchaseHandler) CreatePurchaseReturn(c *fiber.Ctx) error {
+
+	input := new(PurchaseReturnRequestDTO)
+	if err := c.BodyParser(input); err != nil {
+		return apperr.Validation("invalid JSON format")
+	}
+
+	purchaseReturn := models.PurchaseReturn{
+		Remark:     input.Remark,
+		ReturnDate: input.ReturnDate,
+	}
+	for _, item := range input.Items {
+		purchaseReturn.PurchaseReturnDetails = append(purchaseReturn.PurchaseReturnDetails, models.PurchaseReturnDetail{
+			PurchaseDetailId: item.PurchaseDetailId,
+			Qty:              item.Qty,
+		})
+	}
+
+	created, err := h.svc.ReturnService(c.Params("id"), &purchaseReturn)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "purchase return has been created successfully",
+		"data":    created,
+	})
+}
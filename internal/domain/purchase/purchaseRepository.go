@@ -1,57 +1,61 @@
 package purchase
 
 import (
-	"errors"
 	"fmt"
 	"log"
 	"strconv"
 	"strings"
-	"sync"
 
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/docnumber"
+	"github.com/sankangkin/di-rest-api/internal/domain/pricing"
+	"github.com/sankangkin/di-rest-api/internal/domain/stockmovement"
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
 type PurchaseRepositoryInterface interface {
-	Create(sale *models.Purchase) (*models.Purchase, error)
-	GetAll() ([]models.Purchase, error)
+	Create(purchase *models.Purchase, receiptIds []string) (*models.Purchase, error)
+	GetAll(spec query.Spec) ([]models.Purchase, query.Page, error)
 	GetById(id string) (*models.Purchase, error)
+	CreateReturn(id string, input *models.PurchaseReturn) (*models.PurchaseReturn, error)
 }
 
 type PurchaseRepository struct {
-	db *gorm.DB
+	db    *gorm.DB
+	stock stockmovement.StockMovementServiceInterface
+	docNo docnumber.DocNumberServiceInterface
 }
 
-var (
-	repoInstance *PurchaseRepository
-	repoOnce     sync.Once
-)
-
-func NewSaleRepository(db *gorm.DB) PurchaseRepositoryInterface {
+func NewSaleRepository(db *gorm.DB, stock stockmovement.StockMovementServiceInterface, docNo docnumber.DocNumberServiceInterface) PurchaseRepositoryInterface {
 	log.Println(util.Magenta + "SaleRepository constructor is called" + util.Reset)
-	repoOnce.Do(func() {
-		repoInstance = &PurchaseRepository{db: db}
-	})
-	return repoInstance
+	return &PurchaseRepository{db: db, stock: stock, docNo: docNo}
 }
 
-func (r *PurchaseRepository) Create(input *models.Purchase) (*models.Purchase, error) {
+// Create books the supplier's invoice and matches it against the goods
+// receipts it bills, which must come from the same supplier and not be billed
+// yet. It moves no stock, the receipts did; lines whose quantity or price
+// differs from what was received are flagged, not refused.
+func (r *PurchaseRepository) Create(input *models.Purchase, receiptIds []string) (*models.Purchase, error) {
 
 	newPurchase := models.Purchase{
-		ID:              input.ID,
 		SupplierId:      input.SupplierId,
+		LocationId:      input.LocationId,
 		Discount:        input.Discount,
 		GrandTotal:      input.GrandTotal,
 		Remark:          input.Remark,
 		PurchaseDate:    input.PurchaseDate,
 		PurchaseDetails: input.PurchaseDetails,
 		Total:           input.Total,
+		TaxRate:         input.TaxRate,
+		Tax:             input.Tax,
 	}
 	err := models.ValidateStruct(newPurchase)
 	if err != nil {
-		return nil, gorm.ErrCheckConstraintViolated
+		return nil, err
 	}
 
 	tx := r.db.Begin()
@@ -66,51 +70,148 @@ func (r *PurchaseRepository) Create(input *models.Purchase) (*models.Purchase, e
 		return nil, err
 	}
 
-	if err := tx.Create(&newPurchase).Error; err != nil {
+	// the purchase number is allocated on tx, a failed purchase gives it back
+	purchaseId, idErr := r.docNo.Next(tx, docnumber.DocTypePurchase)
+	if idErr != nil {
+		tx.Rollback()
+		return nil, idErr
+	}
+	newPurchase.ID = purchaseId
+
+	location, err := r.stock.ResolveLocation(tx, newPurchase.LocationId)
+	if err != nil {
 		tx.Rollback()
 		return nil, err
 	}
-	for i := range newPurchase.PurchaseDetails {
+	newPurchase.LocationId = location.ID
 
-		// increase productStock qtyonhand
-		var productStock models.ProductStock
-		result := tx.First(&productStock, "product_id = ?", newPurchase.PurchaseDetails[i].ProductId)
-		if err := result.Error; err != nil {
-			return nil, err
-		}
-		productStock.BaseQty += int(newPurchase.PurchaseDetails[i].Qty)
-		tx.Save(&productStock)
+	receipts, err := lockReceipts(tx, newPurchase.SupplierId, receiptIds)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := r.resolveUnits(tx, newPurchase.PurchaseDetails); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	matchInvoice(&newPurchase, receipts)
 
-		newItemTransaction := models.ItemTransaction{
-			InQty:       newPurchase.PurchaseDetails[i].Qty,
-			OutQty:      0,
-			ProductId:   newPurchase.PurchaseDetails[i].ProductId,
-			TranType:    "DEBIT",
-			ReferenceNo: newPurchase.ID + "-" + strconv.Itoa(int(newPurchase.PurchaseDetails[i].ID)),
-			Uom:         newPurchase.PurchaseDetails[i].UnitName,
-			// Remark:      "PurchaseID:" + newPurchase.ID + ", line items id:" + strconv.Itoa(int(newPurchase.PurchaseDetails[i].ID)) + ", increase quantity: " + strconv.Itoa(newPurchase.PurchaseDetails[i].Qty) + " " + newPurchase.PurchaseDetails[i].Uom,
-			Remark: fmt.Sprintf(
-				"PurchaseID:%s, line item id:%d, decrease %d %s ",
-				newPurchase.ID, newPurchase.PurchaseDetails[i].ID, newPurchase.PurchaseDetails[i].Qty, newPurchase.PurchaseDetails[i].UnitName,
-			),
+	if err := tx.Create(&newPurchase).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if len(receipts) > 0 {
+		if err := tx.Model(&models.GoodsReceipt{}).Where("id IN ?", receiptIds).Update("purchase_id", newPurchase.ID).Error; err != nil {
+			tx.Rollback()
+			return nil, err
 		}
-		tx.Save(&newItemTransaction)
+	}
 
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+	for i := range receipts {
+		receipts[i].PurchaseId = &newPurchase.ID
 	}
-	tx.Commit()
+	newPurchase.GoodsReceipts = receipts
 	return &newPurchase, nil
 
 }
 
-func (r *PurchaseRepository) GetAll() ([]models.Purchase, error) {
+// lockReceipts loads the goods receipts an invoice bills and locks them, so
+// two invoices cannot bill the same receipt.
+func lockReceipts(tx *gorm.DB, supplierId uint, ids []string) ([]models.GoodsReceipt, error) {
+	receipts := []models.GoodsReceipt{}
+	if len(ids) == 0 {
+		return receipts, nil
+	}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("GoodsReceiptLines").
+		Where("id IN ?", ids).
+		Order("id").
+		Find(&receipts).Error; err != nil {
+		return nil, err
+	}
 
-	purchases := []models.Purchase{}
-	r.db.Preload(clause.Associations).Model(&models.Purchase{}).Order("created_at DESC").Find(&purchases)
-	if len(purchases) == 0 {
-		return nil, errors.New("NO records found")
+	for _, id := range ids {
+		var receipt *models.GoodsReceipt
+		for i := range receipts {
+			if receipts[i].ID == id {
+				receipt = &receipts[i]
+				break
+			}
+		}
+		if receipt == nil {
+			return nil, apperr.NotFound("goods receipt %s not found", id)
+		}
+		if receipt.SupplierId != supplierId {
+			return nil, apperr.Validation("goods receipt %s is from supplier %d, not %d", receipt.ID, receipt.SupplierId, supplierId)
+		}
+		if receipt.PurchaseId != nil {
+			return nil, apperr.Conflict("goods receipt %s is already billed by purchase %s", receipt.ID, *receipt.PurchaseId)
+		}
 	}
+	return receipts, nil
+}
 
-	return purchases, nil
+// resolveUnits checks every invoice line's unit against its product the way
+// the stock engine will on a return, and names the base unit on lines that
+// leave it empty; the receipts always name the unit the stock was booked in.
+func (r *PurchaseRepository) resolveUnits(tx *gorm.DB, details []models.PurchaseDetail) error {
+	for i := range details {
+		unit, err := r.stock.ResolveUnit(tx, details[i].ProductId, details[i].UnitName)
+		if err != nil {
+			return err
+		}
+		details[i].UnitName = unit
+	}
+	return nil
+}
+
+// returnProductStock takes qty of a returned purchase line back out of the
+// stock at locationId, where it came in, in the line's unit and refuses to go
+// below zero.
+func (r *PurchaseRepository) returnProductStock(tx *gorm.DB, purchase *models.Purchase, pd *models.PurchaseDetail, locationId uint, qty int) error {
+	_, err := r.stock.Apply(tx, stockmovement.Movement{
+		ProductId:   pd.ProductId,
+		LocationId:  locationId,
+		Uom:         pd.UnitName,
+		Qty:         -qty,
+		TranType:    "CREDIT",
+		ReferenceNo: purchase.ID + "-" + strconv.Itoa(int(pd.ID)),
+		Remark: fmt.Sprintf(
+			"PurchaseID:%s, line item id:%d, returned %d %s to supplier %d",
+			purchase.ID, pd.ID, qty, pd.UnitName, purchase.SupplierId,
+		),
+	})
+	return err
+}
+
+// purchaseList is what GET /purchases sorts and filters on.
+var purchaseList = query.Resource{
+	Sort: map[string]string{
+		"id":           "id",
+		"purchaseDate": "purchase_date",
+		"grandTotal":   "grand_total",
+		"createdAt":    "created_at",
+	},
+	DefaultSort: "-createdAt",
+	Filters: map[string]query.Filter{
+		"supplierId":  {Column: "supplier_id", Kind: query.Uint},
+		"locationId":  {Column: "location_id", Kind: query.Uint},
+		"matchStatus": {Column: "match_status", Kind: query.Code},
+	},
+	Dates: query.Filter{Column: "purchase_date", Kind: query.DateText},
+	Key:   "id",
+}
+
+// GetAll loads a page of purchases with their supplier and lines; the
+// returns are left to GetById.
+func (r *PurchaseRepository) GetAll(spec query.Spec) ([]models.Purchase, query.Page, error) {
+
+	purchases := []models.Purchase{}
+	page, err := query.Find(r.db.Model(&models.Purchase{}), spec, &purchases, "Supplier", "PurchaseDetails")
+	return purchases, page, err
 }
 
 func (r *PurchaseRepository) GetById(id string) (*models.Purchase, error) {
@@ -119,6 +220,8 @@ func (r *PurchaseRepository) GetById(id string) (*models.Purchase, error) {
 	err := r.db.
 		Preload("Supplier").
 		Preload("PurchaseDetails").
+		Preload("PurchaseReturns.PurchaseReturnDetails").
+		Preload("GoodsReceipts.GoodsReceiptLines").
 		First(&purchase, "id = ?", strings.ToUpper(id)).Error
 
 	if err != nil {
@@ -130,3 +233,255 @@ func (r *PurchaseRepository) GetById(id string) (*models.Purchase, error) {
 
 	return &purchase, nil
 }
+
+func (r *PurchaseRepository) CreateReturn(id string, input *models.PurchaseReturn) (*models.PurchaseReturn, error) {
+	if len(input.PurchaseReturnDetails) == 0 {
+		return nil, apperr.Validation("a purchase return needs at least one line item")
+	}
+
+	purchaseReturn := models.PurchaseReturn{
+		Remark:     input.Remark,
+		ReturnDate: input.ReturnDate,
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var purchase models.Purchase
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Preload("PurchaseDetails").
+			Preload("GoodsReceipts", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+			Preload("GoodsReceipts.GoodsReceiptLines").
+			First(&purchase, "id = ?", strings.ToUpper(id)).Error; err != nil {
+			return err
+		}
+		purchaseReturn.PurchaseId = purchase.ID
+		purchaseReturn.SupplierId = purchase.SupplierId
+
+		// an invoice books no stock, only what its goods receipts brought in
+		// can go back
+		if purchase.MatchStatus != "" && len(purchase.GoodsReceipts) == 0 {
+			return apperr.Conflict("purchase %s bills no goods receipts, nothing was received to return", purchase.ID)
+		}
+		left := returnable(&purchase)
+		// where each return line's goods come out, by location
+		var taken [][]lot
+
+		for _, item := range input.PurchaseReturnDetails {
+			pd := findPurchaseDetail(purchase.PurchaseDetails, item.PurchaseDetailId)
+			if pd == nil {
+				return apperr.Validation("purchase detail %d does not belong to purchase %s", item.PurchaseDetailId, purchase.ID)
+			}
+			if item.Qty <= 0 {
+				return apperr.Validation("return quantity for purchase detail %d must be greater than zero", pd.ID)
+			}
+			if remaining := pd.Qty - pd.ReturnedQty; item.Qty > remaining {
+				return apperr.Conflict("cannot return %d %s of purchase detail %d: only %d left to return", item.Qty, pd.UnitName, pd.ID, remaining)
+			}
+			k := matchKey{productId: pd.ProductId, unit: strings.ToUpper(pd.UnitName)}
+			if received := total(left[k]); item.Qty > received {
+				return apperr.Conflict("cannot return %d %s of purchase detail %d: only %d received and not yet returned", item.Qty, pd.UnitName, pd.ID, received)
+			}
+			var lots []lot
+			left[k], lots = take(left[k], item.Qty)
+			taken = append(taken, lots)
+			pd.ReturnedQty += item.Qty
+
+			purchaseReturn.PurchaseReturnDetails = append(purchaseReturn.PurchaseReturnDetails, models.PurchaseReturnDetail{
+				PurchaseDetailId: pd.ID,
+				ProductId:        pd.ProductId,
+				Qty:              item.Qty,
+				UnitName:         pd.UnitName,
+				Price:            pd.Price,
+				Total:            pricing.RefundAmount(pd.Total, pd.Qty, item.Qty, 0, 0),
+			})
+			// the credit carries its share of the invoice discount and tax
+			purchaseReturn.Total += pricing.RefundAmount(pd.Total, pd.Qty, item.Qty, purchase.Total, purchase.GrandTotal)
+		}
+
+		fullyReturned := true
+		for _, pd := range purchase.PurchaseDetails {
+			if pd.ReturnedQty < pd.Qty {
+				fullyReturned = false
+				break
+			}
+		}
+		if fullyReturned {
+			// last return settles whatever rounding left over
+			purchaseReturn.Total = purchase.GrandTotal - purchase.ReturnedTotal
+		}
+
+		if err := tx.Create(&purchaseReturn).Error; err != nil {
+			return err
+		}
+
+		for i, rd := range purchaseReturn.PurchaseReturnDetails {
+			pd := findPurchaseDetail(purchase.PurchaseDetails, rd.PurchaseDetailId)
+
+			for _, l := range taken[i] {
+				if err := r.returnProductStock(tx, &purchase, pd, l.locationId, l.qty); err != nil {
+					return err
+				}
+			}
+
+			if err := tx.Model(pd).Update("returned_qty", pd.ReturnedQty).Error; err != nil {
+				return err
+			}
+		}
+
+		// the supplier's payable is grand_total - returned_total over its purchases
+		return tx.Model(&purchase).Update("returned_total", purchase.ReturnedTotal+purchaseReturn.Total).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &purchaseReturn, nil
+}
+
+type matchKey struct {
+	productId string
+	unit      string
+}
+
+// lot is a quantity of one product and unit at one location.
+type lot struct {
+	locationId uint
+	qty        int
+}
+
+// returnable is what can still go back to the supplier per product and unit,
+// by the location it came in at: what the purchase's goods receipts brought
+// in less what was returned already, returns taking from the receipts in
+// order. Purchases booked before goods receipts, which have no match status,
+// put their invoiced quantities into stock themselves, at their own location.
+func returnable(purchase *models.Purchase) map[matchKey][]lot {
+	left := map[matchKey][]lot{}
+	add := func(k matchKey, locationId uint, qty int) {
+		for i := range left[k] {
+			if left[k][i].locationId == locationId {
+				left[k][i].qty += qty
+				return
+			}
+		}
+		left[k] = append(left[k], lot{locationId: locationId, qty: qty})
+	}
+
+	returned := map[matchKey]int{}
+	for _, pd := range purchase.PurchaseDetails {
+		k := matchKey{productId: pd.ProductId, unit: strings.ToUpper(pd.UnitName)}
+		if purchase.MatchStatus == "" {
+			add(k, purchase.LocationId, pd.Qty)
+		}
+		returned[k] += pd.ReturnedQty
+	}
+	for _, receipt := range purchase.GoodsReceipts {
+		for _, rl := range receipt.GoodsReceiptLines {
+			add(matchKey{productId: rl.ProductId, unit: strings.ToUpper(rl.UnitName)}, receipt.LocationId, rl.Qty)
+		}
+	}
+	for k, qty := range returned {
+		left[k], _ = take(left[k], qty)
+	}
+	return left
+}
+
+// take takes qty out of lots, the first lot first, and returns the lots left
+// and what was taken at each location.
+func take(lots []lot, qty int) ([]lot, []lot) {
+	var left, taken []lot
+	for _, l := range lots {
+		n := min(l.qty, qty)
+		if n > 0 {
+			taken = append(taken, lot{locationId: l.locationId, qty: n})
+			qty -= n
+		}
+		if l.qty > n {
+			left = append(left, lot{locationId: l.locationId, qty: l.qty - n})
+		}
+	}
+	return left, taken
+}
+
+// total is the quantity of lots.
+func total(lots []lot) int {
+	qty := 0
+	for _, l := range lots {
+		qty += l.qty
+	}
+	return qty
+}
+
+type receivedGoods struct {
+	qty   int
+	value int64 // qty x order price
+}
+
+// matchInvoice compares the invoice lines with the goods receipts it bills,
+// product by product and unit by unit; lines of the same product and unit
+// are compared together. A line is flagged when the invoiced quantity is not
+// what was received or its price is not the order price. Goods received but
+// not invoiced are listed in the match note.
+func matchInvoice(purchase *models.Purchase, receipts []models.GoodsReceipt) {
+	received := map[matchKey]receivedGoods{}
+	var keys []matchKey
+	for _, receipt := range receipts {
+		for _, rl := range receipt.GoodsReceiptLines {
+			k := matchKey{productId: rl.ProductId, unit: strings.ToUpper(rl.UnitName)}
+			g, seen := received[k]
+			if !seen {
+				keys = append(keys, k)
+			}
+			g.qty += rl.Qty
+			g.value += int64(rl.Qty) * rl.Price
+			received[k] = g
+		}
+	}
+
+	invoiced := map[matchKey]int{}
+	for _, pd := range purchase.PurchaseDetails {
+		invoiced[matchKey{productId: pd.ProductId, unit: strings.ToUpper(pd.UnitName)}] += pd.Qty
+	}
+
+	matched := true
+	for i := range purchase.PurchaseDetails {
+		pd := &purchase.PurchaseDetails[i]
+		k := matchKey{productId: pd.ProductId, unit: strings.ToUpper(pd.UnitName)}
+		g := received[k]
+		pd.ReceivedQty = g.qty
+		pd.ReceivedPrice = 0
+		pd.PriceMismatch = false
+		if g.qty > 0 {
+			pd.ReceivedPrice = g.value / int64(g.qty)
+			pd.PriceMismatch = pd.Price*int64(g.qty) != g.value
+		}
+		pd.QtyMismatch = invoiced[k] != g.qty
+		if pd.QtyMismatch || pd.PriceMismatch {
+			matched = false
+		}
+	}
+
+	var notInvoiced []string
+	for _, k := range keys {
+		if _, ok := invoiced[k]; !ok {
+			notInvoiced = append(notInvoiced, fmt.Sprintf("%s %d %s", k.productId, received[k].qty, k.unit))
+		}
+	}
+	purchase.MatchNote = ""
+	if len(notInvoiced) > 0 {
+		matched = false
+		purchase.MatchNote = "received but not invoiced: " + strings.Join(notInvoiced, ", ")
+	}
+
+	purchase.MatchStatus = models.PurchaseMatched
+	if !matched {
+		purchase.MatchStatus = models.PurchaseMismatched
+	}
+}
+
+func findPurchaseDetail(details []models.PurchaseDetail, id uint) *models.PurchaseDetail {
+	for i := range details {
+		if details[i].ID == id {
+			return &details[i]
+		}
+	}
+	return nil
+}
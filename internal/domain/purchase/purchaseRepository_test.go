@@ -0,0 +1,148 @@
+package purchase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/stockmovement"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestMatchInvoice(t *testing.T) {
+	receipts := []models.GoodsReceipt{
+		{ID: "GRN-1", GoodsReceiptLines: []models.GoodsReceiptLine{
+			{ProductId: "P001", Qty: 6, UnitName: "PCS", Price: 100},
+			{ProductId: "P002", Qty: 2, UnitName: "BOX", Price: 900},
+		}},
+		{ID: "GRN-2", GoodsReceiptLines: []models.GoodsReceiptLine{
+			{ProductId: "P001", Qty: 4, UnitName: "PCS", Price: 100},
+			{ProductId: "P003", Qty: 5, UnitName: "FEET", Price: 30},
+		}},
+	}
+
+	purchase := &models.Purchase{PurchaseDetails: []models.PurchaseDetail{
+		{ProductId: "P001", Qty: 10, UnitName: "pcs", Price: 100},
+		{ProductId: "P002", Qty: 3, UnitName: "BOX", Price: 950},
+	}}
+	matchInvoice(purchase, receipts)
+
+	assert.Equal(t, models.PurchaseMismatched, purchase.MatchStatus)
+	assert.Equal(t, "received but not invoiced: P003 5 FEET", purchase.MatchNote)
+
+	p001 := purchase.PurchaseDetails[0]
+	assert.Equal(t, 10, p001.ReceivedQty)
+	assert.Equal(t, int64(100), p001.ReceivedPrice)
+	assert.False(t, p001.QtyMismatch)
+	assert.False(t, p001.PriceMismatch)
+
+	p002 := purchase.PurchaseDetails[1]
+	assert.Equal(t, 2, p002.ReceivedQty)
+	assert.Equal(t, int64(900), p002.ReceivedPrice)
+	assert.True(t, p002.QtyMismatch)
+	assert.True(t, p002.PriceMismatch)
+
+	// billed in full at the order price
+	purchase = &models.Purchase{PurchaseDetails: []models.PurchaseDetail{
+		{ProductId: "P001", Qty: 7, UnitName: "PCS", Price: 100},
+		{ProductId: "P001", Qty: 3, UnitName: "PCS", Price: 100},
+	}}
+	matchInvoice(purchase, []models.GoodsReceipt{{GoodsReceiptLines: []models.GoodsReceiptLine{
+		{ProductId: "P001", Qty: 10, UnitName: "PCS", Price: 100},
+	}}})
+	assert.Equal(t, models.PurchaseMatched, purchase.MatchStatus)
+	assert.Empty(t, purchase.MatchNote)
+
+	// nothing received yet
+	purchase = &models.Purchase{PurchaseDetails: []models.PurchaseDetail{{ProductId: "P001", Qty: 1, UnitName: "PCS", Price: 100}}}
+	matchInvoice(purchase, nil)
+	assert.Equal(t, models.PurchaseMismatched, purchase.MatchStatus)
+	assert.True(t, purchase.PurchaseDetails[0].QtyMismatch)
+	assert.False(t, purchase.PurchaseDetails[0].PriceMismatch)
+}
+
+func TestReturnable(t *testing.T) {
+	purchase := &models.Purchase{
+		MatchStatus: models.PurchaseMismatched,
+		LocationId:  1,
+		PurchaseDetails: []models.PurchaseDetail{
+			{ProductId: "P001", Qty: 10, UnitName: "PCS", ReturnedQty: 2},
+			{ProductId: "P002", Qty: 3, UnitName: "BOX"},
+		},
+		GoodsReceipts: []models.GoodsReceipt{
+			{ID: "GRN-1", LocationId: 1, GoodsReceiptLines: []models.GoodsReceiptLine{{ProductId: "P001", Qty: 6, UnitName: "pcs"}}},
+		},
+	}
+	left := returnable(purchase)
+	// 6 received, 2 of them returned; the invoiced boxes never came in
+	assert.Equal(t, []lot{{locationId: 1, qty: 4}}, left[matchKey{productId: "P001", unit: "PCS"}])
+	assert.Equal(t, 0, total(left[matchKey{productId: "P002", unit: "BOX"}]))
+
+	// booked before goods receipts: the invoice itself put the stock in
+	purchase.MatchStatus = ""
+	purchase.GoodsReceipts = nil
+	left = returnable(purchase)
+	assert.Equal(t, []lot{{locationId: 1, qty: 8}}, left[matchKey{productId: "P001", unit: "PCS"}])
+	assert.Equal(t, []lot{{locationId: 1, qty: 3}}, left[matchKey{productId: "P002", unit: "BOX"}])
+}
+
+func TestReturnableAtReceiptLocations(t *testing.T) {
+	// the invoice defaulted to the main location (1), the goods came in at
+	// the branches 2 and 3
+	purchase := &models.Purchase{
+		MatchStatus: models.PurchaseMatched,
+		LocationId:  1,
+		PurchaseDetails: []models.PurchaseDetail{
+			{ProductId: "P001", Qty: 10, UnitName: "PCS", ReturnedQty: 3},
+		},
+		GoodsReceipts: []models.GoodsReceipt{
+			{ID: "GRN-1", LocationId: 2, GoodsReceiptLines: []models.GoodsReceiptLine{{ProductId: "P001", Qty: 4, UnitName: "PCS"}}},
+			{ID: "GRN-2", LocationId: 3, GoodsReceiptLines: []models.GoodsReceiptLine{{ProductId: "P001", Qty: 6, UnitName: "PCS"}}},
+		},
+	}
+	left := returnable(purchase)
+	k := matchKey{productId: "P001", unit: "PCS"}
+	// the 3 returned already came out of the first receipt
+	assert.Equal(t, []lot{{locationId: 2, qty: 1}, {locationId: 3, qty: 6}}, left[k])
+
+	rest, taken := take(left[k], 4)
+	assert.Equal(t, []lot{{locationId: 2, qty: 1}, {locationId: 3, qty: 3}}, taken)
+	assert.Equal(t, []lot{{locationId: 3, qty: 3}}, rest)
+	assert.Equal(t, 3, total(rest))
+}
+
+// pipeUnits knows one product, P001, sold by the PCS and cut to FEET.
+type pipeUnits struct {
+	stockmovement.StockMovementServiceInterface
+}
+
+func (pipeUnits) ResolveUnit(tx *gorm.DB, productId string, uom string) (string, error) {
+	if productId != "P001" {
+		return "", apperr.NotFound("unit conversion not found for product %s", productId)
+	}
+	switch strings.ToUpper(uom) {
+	case "", "PCS":
+		return "PCS", nil
+	case "FEET":
+		return "FEET", nil
+	}
+	return "", apperr.Validation("invalid unit %s for product %s (expected PCS or FEET)", uom, productId)
+}
+
+func TestResolveUnits(t *testing.T) {
+	r := &PurchaseRepository{stock: pipeUnits{}}
+
+	details := []models.PurchaseDetail{{ProductId: "P001"}, {ProductId: "P001", UnitName: "feet"}}
+	require.NoError(t, r.resolveUnits(nil, details))
+	assert.Equal(t, "PCS", details[0].UnitName)
+	assert.Equal(t, "FEET", details[1].UnitName)
+
+	err := r.resolveUnits(nil, []models.PurchaseDetail{{ProductId: "P001", UnitName: "BOX"}})
+	assert.ErrorContains(t, err, "invalid unit BOX")
+
+	err = r.resolveUnits(nil, []models.PurchaseDetail{{ProductId: "P999"}})
+	assert.ErrorContains(t, err, "not found")
+}
@@ -2,45 +2,65 @@ package purchase
 
 import (
 	"log"
-	"sync"
+	"strings"
 
+	"github.com/sankangkin/di-rest-api/internal/domain/pricing"
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 )
 
 
 type PurchaseServiceInterface interface{
-	CreateService(purchase *models.Purchase) (*models.Purchase, error)
-	GetAllService() ([]models.Purchase, error)
+	CreateService(purchase *models.Purchase, receiptIds []string) (*models.Purchase, error)
+	GetAllService(spec query.Spec) ([]models.Purchase, query.Page, error)
 	GetById(id string) (*models.Purchase, error)
+	ReturnService(id string, purchaseReturn *models.PurchaseReturn) (*models.PurchaseReturn, error)
 }
 
 type PurchaseService struct{
 	repo PurchaseRepositoryInterface
+	pricing pricing.PricingServiceInterface
 }
 
-var (
-	svcInstance *PurchaseService
-	svcOnce sync.Once
-)
-
-func NewSaleService(repo PurchaseRepositoryInterface) PurchaseServiceInterface{
+func NewSaleService(repo PurchaseRepositoryInterface, pricing pricing.PricingServiceInterface) PurchaseServiceInterface{
 	log.Println(util.Magenta + "SaleService constructor is called" + util.Reset)
 
-	svcOnce.Do(func() {
-		svcInstance = &PurchaseService{repo: repo}
-	})
-	return svcInstance
+	return &PurchaseService{repo: repo, pricing: pricing}
+}
+
+func (s *PurchaseService)CreateService(purchase *models.Purchase, receiptIds []string) (*models.Purchase, error){
+	// totals are computed here, never taken from the client as-is
+	if err := s.pricing.PricePurchase(purchase); err != nil {
+		return nil, err
+	}
+	return s.repo.Create(purchase, normalizeIds(receiptIds))
 }
 
-func (s *PurchaseService)CreateService(purchase *models.Purchase) (*models.Purchase, error){
-	return s.repo.Create(purchase)
+// normalizeIds upper-cases and trims the goods receipt ids and drops blanks
+// and repeats.
+func normalizeIds(ids []string) []string{
+	seen := map[string]bool{}
+	out := []string{}
+	for _, id := range ids {
+		id = strings.ToUpper(strings.TrimSpace(id))
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		out = append(out, id)
+	}
+	return out
 }
 
-func (s *PurchaseService)GetAllService() ([]models.Purchase, error){
-	return s.repo.GetAll()
+func (s *PurchaseService)GetAllService(spec query.Spec) ([]models.Purchase, query.Page, error){
+	return s.repo.GetAll(spec)
 }
 
 func (s *PurchaseService)GetById(id string) (*models.Purchase, error){
 	return s.repo.GetById(id)
-}
\ No newline at end of file
+}
+
+func (s *PurchaseService)ReturnService(id string, purchaseReturn *models.PurchaseReturn) (*models.PurchaseReturn, error){
+	return s.repo.CreateReturn(id, purchaseReturn)
+}
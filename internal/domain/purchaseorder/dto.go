@@ -0,0 +1,17 @@
+package purchaseorder
+
+type PurchaseOrderRequestDTO struct {
+	SupplierId uint                          `json:"supplierId"`
+	LocationId uint                          `json:"locationId"` // the default location when empty
+	Lines      []PurchaseOrderLineRequestDTO `json:"lines"`
+	Remark     string                        `json:"remark"`
+	OrderDate  string                        `json:"orderDate"`
+}
+
+type PurchaseOrderLineRequestDTO struct {
+	ProductId   string `json:"productId"`
+	ProductName string `json:"productName"`
+	Qty         int    `json:"qty"`
+	UnitName    string `json:"unitName"`
+	Price       int64  `json:"price"`
+}
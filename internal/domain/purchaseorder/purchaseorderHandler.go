@@ -0,0 +1,188 @@
+package purchaseorder
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
+)
+
+type PurchaseOrderHandler struct {
+	svc PurchaseOrderServiceInterface
+}
+
+func NewPurchaseOrderHandler(svc PurchaseOrderServiceInterface) *PurchaseOrderHandler {
+	log.Println(util.Magenta + "PurchaseOrderHandler constructor is called" + util.Reset)
+	return &PurchaseOrderHandler{svc: svc}
+}
+
+// CreatePurchaseOrder godoc
+//
+//	@Summary		Create a draft purchase order
+//	@Description	Create a draft purchase order; each line must be an active product in its base or derived unit (empty is the base unit); line totals are quantity times price
+//	@Tags			PurchaseOrders
+//	@Accept			json
+//	@Produce		json
+//	@Param			order	body		PurchaseOrderRequestDTO	true	"Purchase order"
+//	@Success		201		{object}	models.PurchaseOrder
+//	@Failure		400		{object}	apperr.Response
+//	@Failure		401		{object}	apperr.Response
+//	@Failure		404		{object}	apperr.Response
+//	@Failure		500		{object}	apperr.Response
+//	@Router			/api/purchaseorders [post]
+//	@Security		Bearer
+func (h *PurchaseOrderHandler) CreatePurchaseOrder(c *fiber.Ctx) error {
+	input := new(PurchaseOrderRequestDTO)
+	if err := c.BodyParser(input); err != nil {
+		return apperr.Validation("invalid JSON format")
+	}
+
+	order := models.PurchaseOrder{
+		SupplierId: input.SupplierId,
+		LocationId: input.LocationId,
+		Remark:     input.Remark,
+		OrderDate:  input.OrderDate,
+	}
+	for _, l := range input.Lines {
+		order.PurchaseOrderLines = append(order.PurchaseOrderLines, models.PurchaseOrderLine{
+			ProductId:   l.ProductId,
+			ProductName: l.ProductName,
+			Qty:         l.Qty,
+			UnitName:    l.UnitName,
+			Price:       l.Price,
+		})
+	}
+
+	created, err := h.svc.Create(&order)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "draft purchase order " + created.ID + " has been created successfully",
+		"data":    created,
+	})
+}
+
+// GetAllPurchaseOrders godoc
+//
+//	@Summary		Fetch all purchase orders
+//	@Description	Fetch all purchase orders with their supplier and lines
+//	@Tags			PurchaseOrders
+//	@Accept			json
+//	@Produce		json
+//	@Param			page		query		int		false	"page number, from 1"
+//	@Param			limit		query		int		false	"rows per page, 20 by default and 100 at most"
+//	@Param			sort		query		string	false	"comma separated fields of id, orderDate, total, createdAt; prefix - for descending"
+//	@Param			status		query		string	false	"only the orders in this status: DRAFT, SENT, PARTIALLY_RECEIVED, RECEIVED or CLOSED"
+//	@Param			supplierId	query		int		false	"only the orders of this supplier"
+//	@Param			locationId	query		int		false	"only the orders to be received at this location"
+//	@Param			from		query		string	false	"first order date, yyyy-mm-dd"
+//	@Param			to			query		string	false	"last order date, yyyy-mm-dd"
+//	@Success		200			{array}		models.PurchaseOrder
+//	@Failure		400			{object}	apperr.Response
+//	@Failure		401			{object}	apperr.Response
+//	@Failure		500			{object}	apperr.Response
+//	@Router			/api/purchaseorders [get]
+//	@Security		Bearer
+func (h *PurchaseOrderHandler) GetAllPurchaseOrders(c *fiber.Ctx) error {
+	spec, err := query.Parse(c.Queries(), purchaseOrderList)
+	if err != nil {
+		return err
+	}
+	orders, page, err := h.svc.GetAll(spec)
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": strconv.Itoa(len(orders)) + " records found",
+		"data":    orders,
+		"meta":    page,
+	})
+}
+
+// GetPurchaseOrderById godoc
+//
+//	@Summary		Fetch individual purchase order by Id
+//	@Description	Fetch individual purchase order by Id
+//	@Tags			PurchaseOrders
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"purchase order Id"
+//	@Success		200	{object}	models.PurchaseOrder
+//	@Failure		401	{object}	apperr.Response
+//	@Failure		404	{object}	apperr.Response
+//	@Failure		500	{object}	apperr.Response
+//	@Router			/api/purchaseorders/{id} [get]
+//	@Security		Bearer
+func (h *PurchaseOrderHandler) GetPurchaseOrderById(c *fiber.Ctx) error {
+	order, err := h.svc.GetById(c.Params("id"))
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "Record found",
+		"data":    order,
+	})
+}
+
+// SendPurchaseOrder godoc
+//
+//	@Summary		Send a draft purchase order
+//	@Description	Mark a draft purchase order as sent to its supplier; only a sent order can be received
+//	@Tags			PurchaseOrders
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"purchase order Id"
+//	@Success		200	{object}	models.PurchaseOrder
+//	@Failure		401	{object}	apperr.Response
+//	@Failure		404	{object}	apperr.Response
+//	@Failure		409	{object}	apperr.Response
+//	@Failure		500	{object}	apperr.Response
+//	@Router			/api/purchaseorders/{id}/send [post]
+//	@Security		Bearer
+func (h *PurchaseOrderHandler) SendPurchaseOrder(c *fiber.Ctx) error {
+	order, err := h.svc.Send(c.Params("id"))
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "purchase order " + order.ID + " has been sent",
+		"data":    order,
+	})
+}
+
+// ClosePurchaseOrder godoc
+//
+//	@Summary		Close a purchase order
+//	@Description	Close a draft, sent or partially received purchase order; nothing more can be received for it
+//	@Tags			PurchaseOrders
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"purchase order Id"
+//	@Success		200	{object}	models.PurchaseOrder
+//	@Failure		401	{object}	apperr.Response
+//	@Failure		404	{object}	apperr.Response
+//	@Failure		409	{object}	apperr.Response
+//	@Failure		500	{object}	apperr.Response
+//	@Router			/api/purchaseorders/{id}/close [post]
+//	@Security		Bearer
+func (h *PurchaseOrderHandler) ClosePurchaseOrder(c *fiber.Ctx) error {
+	order, err := h.svc.Close(c.Params("id"))
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "purchase order " + order.ID + " has been closed",
+		"data":    order,
+	})
+}
@@ -0,0 +1,189 @@
+package purchaseorder
+
+import (
+	"log"
+	"strings"
+
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/docnumber"
+	"github.com/sankangkin/di-rest-api/internal/domain/stockmovement"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PurchaseOrderRepositoryInterface interface {
+	Create(order *models.PurchaseOrder) (*models.PurchaseOrder, error)
+	GetAll(spec query.Spec) ([]models.PurchaseOrder, query.Page, error)
+	GetById(id string) (*models.PurchaseOrder, error)
+	Send(id string) (*models.PurchaseOrder, error)
+	Close(id string) (*models.PurchaseOrder, error)
+}
+
+type PurchaseOrderRepository struct {
+	db    *gorm.DB
+	stock stockmovement.StockMovementServiceInterface
+	docNo docnumber.DocNumberServiceInterface
+}
+
+func NewPurchaseOrderRepository(db *gorm.DB, stock stockmovement.StockMovementServiceInterface, docNo docnumber.DocNumberServiceInterface) PurchaseOrderRepositoryInterface {
+	log.Println(util.Magenta + "PurchaseOrderRepository constructor is called" + util.Reset)
+	return &PurchaseOrderRepository{db: db, stock: stock, docNo: docNo}
+}
+
+// Create saves the order as a draft; no stock moves until goods arrive.
+func (r *PurchaseOrderRepository) Create(input *models.PurchaseOrder) (*models.PurchaseOrder, error) {
+	order := models.PurchaseOrder{
+		SupplierId:         input.SupplierId,
+		LocationId:         input.LocationId,
+		Status:             models.PurchaseOrderDraft,
+		PurchaseOrderLines: input.PurchaseOrderLines,
+		Total:              input.Total,
+		Remark:             input.Remark,
+		OrderDate:          input.OrderDate,
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		location, err := r.stock.ResolveLocation(tx, order.LocationId)
+		if err != nil {
+			return err
+		}
+		order.LocationId = location.ID
+
+		// goods receipts book the lines into stock as ordered, so the
+		// units are checked the way the stock engine will check them
+		for i := range order.PurchaseOrderLines {
+			if err := r.checkLine(tx, &order.PurchaseOrderLines[i]); err != nil {
+				return err
+			}
+		}
+
+		// the order number is allocated on tx, a failed order gives it back
+		id, err := r.docNo.Next(tx, docnumber.DocTypeOrder)
+		if err != nil {
+			return err
+		}
+		order.ID = id
+		return tx.Create(&order).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetById(order.ID)
+}
+
+// checkLine makes sure the line orders an active product in one of its
+// units, and fills in the product name and the unit as the product names it.
+func (r *PurchaseOrderRepository) checkLine(tx *gorm.DB, l *models.PurchaseOrderLine) error {
+	var product models.Product
+	if err := tx.Select("id", "product_name", "is_active").First(&product, "id = ?", l.ProductId).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return apperr.Validation("product %s not found", l.ProductId)
+		}
+		return err
+	}
+	if !product.IsActive {
+		return apperr.Validation("product %s is inactive and cannot be ordered", l.ProductId)
+	}
+	unit, err := r.stock.ResolveUnit(tx, l.ProductId, l.UnitName)
+	if err != nil {
+		return err
+	}
+	l.UnitName = unit
+	if l.ProductName == "" {
+		l.ProductName = product.ProductName
+	}
+	return nil
+}
+
+// purchaseOrderList is what GET /purchaseorders sorts and filters on.
+var purchaseOrderList = query.Resource{
+	Sort: map[string]string{
+		"id":        "id",
+		"orderDate": "order_date",
+		"total":     "total",
+		"createdAt": "created_at",
+	},
+	DefaultSort: "-createdAt",
+	Filters: map[string]query.Filter{
+		"status":     {Column: "status", Kind: query.Code},
+		"supplierId": {Column: "supplier_id", Kind: query.Uint},
+		"locationId": {Column: "location_id", Kind: query.Uint},
+	},
+	Dates: query.Filter{Column: "order_date", Kind: query.DateText},
+	Key:   "id",
+}
+
+func (r *PurchaseOrderRepository) GetAll(spec query.Spec) ([]models.PurchaseOrder, query.Page, error) {
+	orders := []models.PurchaseOrder{}
+	page, err := query.Find(r.db.Model(&models.PurchaseOrder{}), spec, &orders, "Supplier", "PurchaseOrderLines")
+	return orders, page, err
+}
+
+func (r *PurchaseOrderRepository) GetById(id string) (*models.PurchaseOrder, error) {
+	var order models.PurchaseOrder
+	err := r.db.
+		Preload("Supplier").
+		Preload("PurchaseOrderLines").
+		Preload("GoodsReceipts.GoodsReceiptLines").
+		First(&order, "id = ?", strings.ToUpper(id)).Error
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// Send marks a draft as sent to its supplier; from then on goods can be
+// received for it.
+func (r *PurchaseOrderRepository) Send(id string) (*models.PurchaseOrder, error) {
+	return r.transition(id, models.PurchaseOrderSent)
+}
+
+// Close ends an order before everything on it has arrived, or a draft that
+// is not going to be sent. What was received stays in stock.
+func (r *PurchaseOrderRepository) Close(id string) (*models.PurchaseOrder, error) {
+	return r.transition(id, models.PurchaseOrderClosed)
+}
+
+// transitions lists, per status an order is moved to by hand, the statuses
+// it can be moved from. Goods receipts move it to PARTIALLY_RECEIVED and
+// RECEIVED.
+var transitions = map[string][]string{
+	models.PurchaseOrderSent: {models.PurchaseOrderDraft},
+	models.PurchaseOrderClosed: {
+		models.PurchaseOrderDraft, models.PurchaseOrderSent, models.PurchaseOrderPartiallyReceived,
+	},
+}
+
+// checkTransition refuses to move the order to status to from where it is.
+func checkTransition(order *models.PurchaseOrder, to string) error {
+	for _, from := range transitions[to] {
+		if order.Status == from {
+			return nil
+		}
+	}
+	return apperr.Conflict("purchase order %s is %s and cannot become %s", order.ID, order.Status, to)
+}
+
+// transition moves the order to status to. The row is locked so a goods
+// receipt cannot change the status meanwhile.
+func (r *PurchaseOrderRepository) transition(id string, to string) (*models.PurchaseOrder, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var order models.PurchaseOrder
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&order, "id = ?", strings.ToUpper(id)).Error; err != nil {
+			return err
+		}
+		if err := checkTransition(&order, to); err != nil {
+			return err
+		}
+		return tx.Model(&order).Update("status", to).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetById(id)
+}
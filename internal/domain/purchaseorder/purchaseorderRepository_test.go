@@ -0,0 +1,42 @@
+package purchaseorder
+
+import (
+	"testing"
+
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckTransition(t *testing.T) {
+	tests := []struct {
+		from string
+		to   string
+		ok   bool
+	}{
+		{from: models.PurchaseOrderDraft, to: models.PurchaseOrderSent, ok: true},
+		{from: models.PurchaseOrderDraft, to: models.PurchaseOrderClosed, ok: true},
+		{from: models.PurchaseOrderSent, to: models.PurchaseOrderSent},
+		{from: models.PurchaseOrderSent, to: models.PurchaseOrderClosed, ok: true},
+		{from: models.PurchaseOrderPartiallyReceived, to: models.PurchaseOrderSent},
+		{from: models.PurchaseOrderPartiallyReceived, to: models.PurchaseOrderClosed, ok: true},
+		{from: models.PurchaseOrderReceived, to: models.PurchaseOrderSent},
+		{from: models.PurchaseOrderReceived, to: models.PurchaseOrderClosed},
+		{from: models.PurchaseOrderClosed, to: models.PurchaseOrderSent},
+		{from: models.PurchaseOrderClosed, to: models.PurchaseOrderClosed},
+		// receipts set these, never a request
+		{from: models.PurchaseOrderSent, to: models.PurchaseOrderPartiallyReceived},
+		{from: models.PurchaseOrderPartiallyReceived, to: models.PurchaseOrderReceived},
+		{from: models.PurchaseOrderSent, to: models.PurchaseOrderDraft},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			err := checkTransition(&models.PurchaseOrder{ID: "ORD-1", Status: tt.from}, tt.to)
+			if tt.ok {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, apperr.ErrConflict)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package purchaseorder
+
+import (
+	"log"
+	"strings"
+
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
+)
+
+type PurchaseOrderServiceInterface interface {
+	Create(order *models.PurchaseOrder) (*models.PurchaseOrder, error)
+	GetAll(spec query.Spec) ([]models.PurchaseOrder, query.Page, error)
+	GetById(id string) (*models.PurchaseOrder, error)
+	Send(id string) (*models.PurchaseOrder, error)
+	Close(id string) (*models.PurchaseOrder, error)
+}
+
+type PurchaseOrderService struct {
+	repo PurchaseOrderRepositoryInterface
+}
+
+func NewPurchaseOrderService(repo PurchaseOrderRepositoryInterface) PurchaseOrderServiceInterface {
+	log.Println(util.Magenta + "PurchaseOrderService constructor is called" + util.Reset)
+	return &PurchaseOrderService{repo: repo}
+}
+
+// Create prices the lines at quantity times price, the order at the sum of
+// its lines, and saves it as a draft.
+func (s *PurchaseOrderService) Create(order *models.PurchaseOrder) (*models.PurchaseOrder, error) {
+	order.Total = 0
+	for i := range order.PurchaseOrderLines {
+		l := &order.PurchaseOrderLines[i]
+		l.ProductId = strings.ToUpper(strings.TrimSpace(l.ProductId))
+		l.Total = int64(l.Qty) * l.Price
+		order.Total += l.Total
+	}
+	if err := models.ValidateStruct(order); err != nil {
+		return nil, err
+	}
+	return s.repo.Create(order)
+}
+
+func (s *PurchaseOrderService) GetAll(spec query.Spec) ([]models.PurchaseOrder, query.Page, error) {
+	return s.repo.GetAll(spec)
+}
+
+func (s *PurchaseOrderService) GetById(id string) (*models.PurchaseOrder, error) {
+	return s.repo.GetById(id)
+}
+
+func (s *PurchaseOrderService) Send(id string) (*models.PurchaseOrder, error) {
+	return s.repo.Send(id)
+}
+
+func (s *PurchaseOrderService) Close(id string) (*models.PurchaseOrder, error) {
+	return s.repo.Close(id)
+}
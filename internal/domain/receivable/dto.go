@@ -0,0 +1,52 @@
+package receivable
+
+type PaymentRequestDTO struct {
+	Amount      int64                         `json:"amount"`
+	Method      string                        `json:"method"` // CASH (default), BANK, MOBILE, CHEQUE
+	PaymentDate string                        `json:"paymentDate"`
+	ReferenceNo string                        `json:"referenceNo"`
+	Remark      string                        `json:"remark"`
+	Allocations []PaymentAllocationRequestDTO `json:"allocations"` // empty: oldest open sales first
+}
+
+type PaymentAllocationRequestDTO struct {
+	SaleId string `json:"saleId"`
+	Amount int64  `json:"amount"`
+}
+
+type OpenSaleDTO struct {
+	SaleId        string `json:"saleId"`
+	SaleDate      string `json:"saleDate"`
+	GrandTotal    int64  `json:"grandTotal"`
+	ReturnedTotal int64  `json:"returnedTotal"`
+	PaidAmount    int64  `json:"paidAmount"`
+	Outstanding   int64  `json:"outstanding"`
+}
+
+type CustomerBalanceDTO struct {
+	CustomerId  uint          `json:"customerId"`
+	Name        string        `json:"name"`
+	Sales       int64         `json:"sales"`
+	Returned    int64         `json:"returned"`
+	Paid        int64         `json:"paid"`
+	Unallocated int64         `json:"unallocated"` // paid but not applied to any sale yet
+	Balance     int64         `json:"balance"`     // negative: the customer is in credit
+	OpenSales   []OpenSaleDTO `json:"openSales"`
+}
+
+type StatementEntryDTO struct {
+	Date        string `json:"date"`
+	Type        string `json:"type"` // SALE, RETURN or PAYMENT
+	ReferenceNo string `json:"referenceNo"`
+	Debit       int64  `json:"debit"`
+	Credit      int64  `json:"credit"`
+	Balance     int64  `json:"balance"`
+	createdAt   int64
+}
+
+type CustomerStatementDTO struct {
+	CustomerId uint                `json:"customerId"`
+	Name       string              `json:"name"`
+	Entries    []StatementEntryDTO `json:"entries"`
+	Balance    int64               `json:"balance"`
+}
@@ -0,0 +1,164 @@
+package receivable
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+)
+
+type ReceivableHandler struct {
+	svc ReceivableServiceInterface
+}
+
+func NewReceivableHandler(svc ReceivableServiceInterface) *ReceivableHandler {
+	log.Println(util.Gray + "ReceivableHandler constructor is called" + util.Reset)
+	return &ReceivableHandler{svc: svc}
+}
+
+// GetCustomerBalance godoc
+//
+//	@Summary		Fetch the outstanding balance of a customer
+//	@Description	Fetch what a customer owes: sales less returns and payments, with the sales still open
+//	@Tags			Customers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id					path		string	true	"customer Id"
+//	@Success		200					{object}	CustomerBalanceDTO
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
+//	@Router			/api/customers/{id}/balance	[get]
+//	@Security		Bearer
+func (h *ReceivableHandler) GetCustomerBalance(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return apperr.Validation("invalid ID parameter").Wrap(err)
+	}
+
+	balance, err := h.svc.GetBalance(uint(id))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "Record found",
+		"data":    balance,
+	})
+}
+
+// GetCustomerStatement godoc
+//
+//	@Summary		Fetch the account statement of a customer
+//	@Description	Fetch sales, returns and payments of a customer in booking order with a running balance
+//	@Tags			Customers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id						path		string	true	"customer Id"
+//	@Success		200						{object}	CustomerStatementDTO
+//	@Failure		400						{object}	apperr.Response
+//	@Failure		401						{object}	apperr.Response
+//	@Failure		500						{object}	apperr.Response
+//	@Router			/api/customers/{id}/statement	[get]
+//	@Security		Bearer
+func (h *ReceivableHandler) GetCustomerStatement(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return apperr.Validation("invalid ID parameter").Wrap(err)
+	}
+
+	statement, err := h.svc.GetStatement(uint(id))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "Record found",
+		"data":    statement,
+	})
+}
+
+// GetCustomerPayments godoc
+//
+//	@Summary		Fetch the payments of a customer
+//	@Description	Fetch the payments of a customer with the sales each one was applied to
+//	@Tags			Customers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id						path		string	true	"customer Id"
+//	@Success		200						{array}		models.Payment
+//	@Failure		400						{object}	apperr.Response
+//	@Failure		401						{object}	apperr.Response
+//	@Failure		500						{object}	apperr.Response
+//	@Router			/api/customers/{id}/payments	[get]
+//	@Security		Bearer
+func (h *ReceivableHandler) GetCustomerPayments(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return apperr.Validation("invalid ID parameter").Wrap(err)
+	}
+
+	payments, err := h.svc.GetPayments(uint(id))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "Total " + strconv.Itoa(len(payments)) + " records found",
+		"data":    payments,
+	})
+}
+
+// CreateCustomerPayment godoc
+//
+//	@Summary		Record a payment received from a customer
+//	@Description	Record a payment and apply it to the given sales, or to the oldest open sales when none are given
+//	@Tags			Customers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id						path		string				true	"customer Id"
+//	@Param			payment					body		PaymentRequestDTO	true	"Payment Data"
+//	@Success		200						{object}	models.Payment
+//	@Failure		400						{object}	apperr.Response
+//	@Failure		401						{object}	apperr.Response
+//	@Failure		500						{object}	apperr.Response
+//	@Router			/api/customers/{id}/payments	[post]
+//	@Security		Bearer
+func (h *ReceivableHandler) CreateCustomerPayment(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return apperr.Validation("invalid ID parameter").Wrap(err)
+	}
+
+	input := new(PaymentRequestDTO)
+	if err := c.BodyParser(input); err != nil {
+		return apperr.Validation("invalid JSON format")
+	}
+
+	payment := models.Payment{
+		Amount:      input.Amount,
+		Method:      input.Method,
+		PaymentDate: input.PaymentDate,
+		ReferenceNo: input.ReferenceNo,
+		Remark:      input.Remark,
+	}
+	for _, a := range input.Allocations {
+		payment.PaymentAllocations = append(payment.PaymentAllocations, models.PaymentAllocation{
+			SaleId: a.SaleId,
+			Amount: a.Amount,
+		})
+	}
+
+	created, err := h.svc.CreatePayment(uint(id), &payment)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "payment has been recorded successfully",
+		"data":    created,
+	})
+}
@@ -0,0 +1,292 @@
+package receivable
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReceivableRepositoryInterface interface {
+	GetBalance(customerId uint) (*CustomerBalanceDTO, error)
+	GetStatement(customerId uint) (*CustomerStatementDTO, error)
+	GetPayments(customerId uint) ([]models.Payment, error)
+	CreatePayment(customerId uint, input *models.Payment) (*models.Payment, error)
+}
+
+type ReceivableRepository struct {
+	db *gorm.DB
+}
+
+//! constructor must be return the Interface, NOT struct, if not, google wire generate fail
+
+func NewReceivableRepository(db *gorm.DB) ReceivableRepositoryInterface {
+	log.Println(util.Gray + "ReceivableRepository constructor is called" + util.Reset)
+	return &ReceivableRepository{db: db}
+}
+
+// paidSql sums the payment allocations to a sale, the one record of what
+// was paid; sales.paid_amount is a copy kept for the sale screens.
+const paidSql = "COALESCE((SELECT SUM(a.amount) FROM payment_allocations a WHERE a.sale_id = sales.id), 0)"
+
+// outstandingSql is what is still owed on a sale
+const outstandingSql = "sales.grand_total - sales.returned_total - " + paidSql
+
+func (r *ReceivableRepository) GetBalance(customerId uint) (*CustomerBalanceDTO, error) {
+	var customer models.Customer
+	if err := r.db.First(&customer, "id = ?", customerId).Error; err != nil {
+		return nil, err
+	}
+
+	result := CustomerBalanceDTO{CustomerId: customer.ID, Name: customer.Name, OpenSales: []OpenSaleDTO{}}
+	err := r.db.
+		Model(&models.Sale{}).
+		Select("COALESCE(SUM(grand_total), 0) AS sales, COALESCE(SUM(returned_total), 0) AS returned").
+		Where("customer_id = ? AND status <> ?", customerId, models.SaleVoided).
+		Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var paid struct {
+		Paid      int64
+		Allocated int64
+	}
+	err = r.db.Raw(`
+		SELECT COALESCE(SUM(p.amount), 0) AS paid,
+		       COALESCE((SELECT SUM(a.amount) FROM payment_allocations a
+		                 JOIN payments ap ON ap.id = a.payment_id
+		                 WHERE ap.customer_id = ?), 0) AS allocated
+		FROM payments p WHERE p.customer_id = ?`, customerId, customerId).
+		Scan(&paid).Error
+	if err != nil {
+		return nil, err
+	}
+	result.Paid = paid.Paid
+	result.Unallocated = paid.Paid - paid.Allocated
+	result.Balance = result.Sales - result.Returned - result.Paid
+
+	var openSales []models.Sale
+	if err := r.openSales(r.db, customerId).Find(&openSales).Error; err != nil {
+		return nil, err
+	}
+	for _, sale := range openSales {
+		result.OpenSales = append(result.OpenSales, OpenSaleDTO{
+			SaleId:        sale.ID,
+			SaleDate:      sale.SaleDate,
+			GrandTotal:    sale.GrandTotal,
+			ReturnedTotal: sale.ReturnedTotal,
+			PaidAmount:    sale.PaidAmount,
+			Outstanding:   sale.GrandTotal - sale.ReturnedTotal - sale.PaidAmount,
+		})
+	}
+
+	return &result, nil
+}
+
+// GetStatement lists the customer's sales, returns and payments in the order
+// they were booked, with a running balance. Voided sales and everything
+// returned against them are left out, their payments stay in as credit.
+func (r *ReceivableRepository) GetStatement(customerId uint) (*CustomerStatementDTO, error) {
+	var customer models.Customer
+	if err := r.db.First(&customer, "id = ?", customerId).Error; err != nil {
+		return nil, err
+	}
+
+	var sales []models.Sale
+	if err := r.db.
+		Where("customer_id = ? AND status <> ?", customerId, models.SaleVoided).
+		Find(&sales).Error; err != nil {
+		return nil, err
+	}
+
+	var returns []models.SaleReturn
+	if err := r.db.
+		Joins("JOIN sales ON sales.id = sale_returns.sale_id").
+		Where("sales.customer_id = ? AND sales.status <> ? AND sale_returns.is_void = ?", customerId, models.SaleVoided, false).
+		Find(&returns).Error; err != nil {
+		return nil, err
+	}
+
+	var payments []models.Payment
+	if err := r.db.Where("customer_id = ?", customerId).Find(&payments).Error; err != nil {
+		return nil, err
+	}
+
+	entries := []StatementEntryDTO{}
+	for _, sale := range sales {
+		entries = append(entries, StatementEntryDTO{
+			Date: sale.SaleDate, Type: "SALE", ReferenceNo: sale.ID, Debit: sale.GrandTotal, createdAt: sale.CreatedAt,
+		})
+	}
+	for _, ret := range returns {
+		entries = append(entries, StatementEntryDTO{
+			Date: ret.ReturnDate, Type: "RETURN", ReferenceNo: fmt.Sprintf("%s/R%d", ret.SaleId, ret.ID), Credit: ret.Total, createdAt: ret.CreatedAt,
+		})
+	}
+	for _, payment := range payments {
+		entries = append(entries, StatementEntryDTO{
+			Date: payment.PaymentDate, Type: "PAYMENT", ReferenceNo: payment.ReferenceNo, Credit: payment.Amount, createdAt: payment.CreatedAt,
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].createdAt < entries[j].createdAt })
+
+	var balance int64
+	for i := range entries {
+		balance += entries[i].Debit - entries[i].Credit
+		entries[i].Balance = balance
+	}
+
+	return &CustomerStatementDTO{
+		CustomerId: customer.ID,
+		Name:       customer.Name,
+		Entries:    entries,
+		Balance:    balance,
+	}, nil
+}
+
+func (r *ReceivableRepository) GetPayments(customerId uint) ([]models.Payment, error) {
+	if err := r.db.First(&models.Customer{}, "id = ?", customerId).Error; err != nil {
+		return nil, err
+	}
+
+	payments := []models.Payment{}
+	err := r.db.
+		Preload("PaymentAllocations").
+		Where("customer_id = ?", customerId).
+		Order("created_at DESC").
+		Find(&payments).Error
+	return payments, err
+}
+
+// CreatePayment records money received from the customer and applies it to
+// the given sales, or to the oldest open sales when none are given. Anything
+// left over stays on the account as credit.
+func (r *ReceivableRepository) CreatePayment(customerId uint, input *models.Payment) (*models.Payment, error) {
+	if input.Amount <= 0 {
+		return nil, apperr.Validation("payment amount must be greater than zero")
+	}
+
+	payment := models.Payment{
+		CustomerId:  customerId,
+		Amount:      input.Amount,
+		Method:      strings.ToUpper(input.Method),
+		PaymentDate: input.PaymentDate,
+		ReferenceNo: input.ReferenceNo,
+		Remark:      input.Remark,
+	}
+	if payment.Method == "" {
+		payment.Method = "CASH"
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&models.Customer{}, "id = ?", customerId).Error; err != nil {
+			return err
+		}
+
+		remaining := payment.Amount
+		if len(input.PaymentAllocations) > 0 {
+			for _, a := range input.PaymentAllocations {
+				if a.Amount <= 0 {
+					return apperr.Validation("allocation to sale %s must be greater than zero", a.SaleId)
+				}
+				if a.Amount > remaining {
+					return apperr.Validation("allocations exceed the payment amount %d", payment.Amount)
+				}
+				var sale models.Sale
+				if err := withPaid(tx.Clauses(clause.Locking{Strength: "UPDATE"})).
+					First(&sale, "id = ? AND customer_id = ?", strings.ToUpper(a.SaleId), customerId).Error; err != nil {
+					if err == gorm.ErrRecordNotFound {
+						return apperr.Validation("sale %s does not belong to customer %d", a.SaleId, customerId)
+					}
+					return err
+				}
+				if err := allocate(tx, &payment, &sale, a.Amount); err != nil {
+					return err
+				}
+				remaining -= a.Amount
+			}
+		} else {
+			var openSales []models.Sale
+			if err := r.openSales(tx.Clauses(clause.Locking{Strength: "UPDATE"}), customerId).Find(&openSales).Error; err != nil {
+				return err
+			}
+			for i := range openSales {
+				if remaining == 0 {
+					break
+				}
+				sale := &openSales[i]
+				amount := min(remaining, sale.GrandTotal-sale.ReturnedTotal-sale.PaidAmount)
+				if err := allocate(tx, &payment, sale, amount); err != nil {
+					return err
+				}
+				remaining -= amount
+			}
+		}
+
+		if err := tx.Create(&payment).Error; err != nil {
+			return err
+		}
+		return syncPaidAmounts(tx, payment.PaymentAllocations)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &payment, nil
+}
+
+// allocate applies amount of the payment to the (locked) sale, whose
+// PaidAmount was read from the allocations already saved.
+func allocate(tx *gorm.DB, payment *models.Payment, sale *models.Sale, amount int64) error {
+	if sale.Status == models.SaleVoided {
+		return apperr.Conflict("sale %s is voided and cannot take payments", sale.ID)
+	}
+	// allocations of this payment are only saved with it
+	paid := sale.PaidAmount
+	for _, a := range payment.PaymentAllocations {
+		if a.SaleId == sale.ID {
+			paid += a.Amount
+		}
+	}
+	if outstanding := sale.GrandTotal - sale.ReturnedTotal - paid; amount > outstanding {
+		return apperr.Conflict("cannot apply %d to sale %s: only %d outstanding", amount, sale.ID, outstanding)
+	}
+
+	payment.PaymentAllocations = append(payment.PaymentAllocations, models.PaymentAllocation{
+		SaleId: sale.ID,
+		Amount: amount,
+	})
+	return nil
+}
+
+// syncPaidAmounts copies the allocation totals onto the sales allocated to.
+func syncPaidAmounts(tx *gorm.DB, allocations []models.PaymentAllocation) error {
+	if len(allocations) == 0 {
+		return nil
+	}
+	saleIds := make([]string, 0, len(allocations))
+	for _, a := range allocations {
+		saleIds = append(saleIds, a.SaleId)
+	}
+	return tx.Model(&models.Sale{}).
+		Where("id IN ?", saleIds).
+		Update("paid_amount", gorm.Expr(paidSql)).Error
+}
+
+// withPaid selects sales with PaidAmount summed from the allocations.
+func withPaid(db *gorm.DB) *gorm.DB {
+	return db.Select("sales.*, " + paidSql + " AS paid_amount")
+}
+
+func (r *ReceivableRepository) openSales(db *gorm.DB, customerId uint) *gorm.DB {
+	return withPaid(db).
+		Where("customer_id = ? AND status <> ? AND "+outstandingSql+" > 0", customerId, models.SaleVoided).
+		Order("created_at, id")
+}
@@ -0,0 +1,42 @@
+package receivable
+
+import (
+	"log"
+
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+)
+
+type ReceivableServiceInterface interface {
+	GetBalance(customerId uint) (*CustomerBalanceDTO, error)
+	GetStatement(customerId uint) (*CustomerStatementDTO, error)
+	GetPayments(customerId uint) ([]models.Payment, error)
+	CreatePayment(customerId uint, payment *models.Payment) (*models.Payment, error)
+}
+
+type ReceivableService struct {
+	repo ReceivableRepositoryInterface
+}
+
+//! constructor must be return the Interface, NOT struct, if not, google wire generate fail
+
+func NewReceivableService(repo ReceivableRepositoryInterface) ReceivableServiceInterface {
+	log.Println(util.Gray + "ReceivableService constructor is called" + util.Reset)
+	return &ReceivableService{repo: repo}
+}
+
+func (s *ReceivableService) GetBalance(customerId uint) (*CustomerBalanceDTO, error) {
+	return s.repo.GetBalance(customerId)
+}
+
+func (s *ReceivableService) GetStatement(customerId uint) (*CustomerStatementDTO, error) {
+	return s.repo.GetStatement(customerId)
+}
+
+func (s *ReceivableService) GetPayments(customerId uint) ([]models.Payment, error) {
+	return s.repo.GetPayments(customerId)
+}
+
+func (s *ReceivableService) CreatePayment(customerId uint, payment *models.Payment) (*models.Payment, error) {
+	return s.repo.CreatePayment(customerId, payment)
+}
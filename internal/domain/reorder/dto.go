@@ -0,0 +1,44 @@
+package reorder
+
+// CreateOrderRequestDTO turns the suggestion for one supplier into a draft
+// purchase order.
+type CreateOrderRequestDTO struct {
+	SupplierId uint     `json:"supplierId"`
+	LocationId uint     `json:"locationId"` // the default location when empty
+	Days       int      `json:"days"`       // 30 when empty
+	CoverDays  int      `json:"coverDays"`  // 30 when empty
+	ProductIds []string `json:"productIds"` // every suggested product of the supplier when empty
+	Remark     string   `json:"remark"`
+	OrderDate  string   `json:"orderDate"`
+}
+
+// SuggestionLineDTO is a product to reorder. Quantities and price are in the
+// base unit; DailySales are the base units sold per day over the window.
+type SuggestionLineDTO struct {
+	ProductId    string  `json:"productId"`
+	ProductName  string  `json:"productName"`
+	BaseUnit     string  `json:"baseUnit"`
+	BaseQty      int     `json:"baseQty"`
+	DerivedQty   int     `json:"derivedQty"`
+	ReorderLvl   int     `json:"reorderlvl"`
+	DailySales   float64 `json:"dailySales"`
+	SuggestedQty int     `json:"suggestedQty"`
+	Price        int64   `json:"price"`
+	Total        int64   `json:"total"`
+}
+
+// SupplierSuggestionDTO groups the products last bought from one supplier;
+// SupplierId 0 holds the products never bought before.
+type SupplierSuggestionDTO struct {
+	SupplierId   uint                `json:"supplierId"`
+	SupplierName string              `json:"supplierName"`
+	Lines        []SuggestionLineDTO `json:"lines"`
+	Total        int64               `json:"total"`
+}
+
+type SuggestionReportDTO struct {
+	LocationId uint                    `json:"locationId"`
+	Days       int                     `json:"days"`
+	CoverDays  int                     `json:"coverDays"`
+	Suppliers  []SupplierSuggestionDTO `json:"suppliers"`
+}
@@ -0,0 +1,120 @@
+package reorder
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+)
+
+type ReorderHandler struct {
+	svc ReorderServiceInterface
+}
+
+func NewReorderHandler(svc ReorderServiceInterface) *ReorderHandler {
+	log.Println(util.Magenta + "ReorderHandler constructor is called" + util.Reset)
+	return &ReorderHandler{svc: svc}
+}
+
+// queryNumber reads an optional non-negative number parameter, zero when absent.
+func queryNumber(c *fiber.Ctx, name string) (int, error) {
+	v := c.Query(name)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, apperr.Validation("invalid %s %q", name, v)
+	}
+	return n, nil
+}
+
+// GetSuggestions godoc
+//
+//	@Summary		Suggest what to reorder
+//	@Description	Propose reorder quantities for a location from the average daily sales of the last days: enough for coverDays more of them on top of the reorder level. The products are grouped by the supplier they were last bought from, supplierId 0 holding those never bought.
+//	@Tags			Reorders
+//	@Accept			json
+//	@Produce		json
+//	@Param			locationId	query		int		false	"the location to restock, the default location when empty"
+//	@Param			days		query		int		false	"days of sales to average, 30 by default"
+//	@Param			coverDays	query		int		false	"days of sales the order should cover, 30 by default"
+//	@Param			productIds	query		string	false	"comma separated product ids to limit the suggestion to"
+//	@Success		200			{object}	SuggestionReportDTO
+//	@Failure		400			{object}	apperr.Response
+//	@Failure		401			{object}	apperr.Response
+//	@Failure		404			{object}	apperr.Response
+//	@Failure		500			{object}	apperr.Response
+//	@Router			/api/reorders/suggestions [get]
+//	@Security		Bearer
+func (h *ReorderHandler) GetSuggestions(c *fiber.Ctx) error {
+	locationId, err := queryNumber(c, "locationId")
+	if err != nil {
+		return err
+	}
+	days, err := queryNumber(c, "days")
+	if err != nil {
+		return err
+	}
+	coverDays, err := queryNumber(c, "coverDays")
+	if err != nil {
+		return err
+	}
+	var productIds []string
+	if v := c.Query("productIds"); v != "" {
+		productIds = strings.Split(v, ",")
+	}
+
+	report, err := h.svc.Suggest(uint(locationId), days, coverDays, productIds)
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": strconv.Itoa(len(report.Suppliers)) + " suppliers to reorder from",
+		"data":    report,
+	})
+}
+
+// CreateSuggestedOrder godoc
+//
+//	@Summary		Turn a reorder suggestion into a draft purchase order
+//	@Description	Work out the suggestion with the same parameters and save what it proposes to buy from the supplier as a draft purchase order
+//	@Tags			Reorders
+//	@Accept			json
+//	@Produce		json
+//	@Param			order	body		CreateOrderRequestDTO	true	"Suggestion parameters"
+//	@Success		201		{object}	models.PurchaseOrder
+//	@Failure		400		{object}	apperr.Response
+//	@Failure		401		{object}	apperr.Response
+//	@Failure		403		{object}	apperr.Response
+//	@Failure		404		{object}	apperr.Response
+//	@Failure		500		{object}	apperr.Response
+//	@Router			/api/reorders/purchaseorders [post]
+//	@Security		Bearer
+func (h *ReorderHandler) CreateSuggestedOrder(c *fiber.Ctx) error {
+	input := new(CreateOrderRequestDTO)
+	if err := c.BodyParser(input); err != nil {
+		return apperr.Validation("invalid JSON format")
+	}
+
+	order, err := h.svc.CreateOrder(&models.PurchaseOrder{
+		SupplierId: input.SupplierId,
+		LocationId: input.LocationId,
+		Remark:     input.Remark,
+		OrderDate:  input.OrderDate,
+	}, input.Days, input.CoverDays, input.ProductIds)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "draft purchase order " + order.ID + " has been created successfully",
+		"data":    order,
+	})
+}
@@ -0,0 +1,121 @@
+package reorder
+
+import (
+	"log"
+	"time"
+
+	"github.com/sankangkin/di-rest-api/internal/domain/stockmovement"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"gorm.io/gorm"
+)
+
+type ReorderRepositoryInterface interface {
+	ResolveLocation(locationId uint) (*models.Location, error)
+	GetStocks(locationId uint, productIds []string) ([]stockRow, error)
+	GetSales(locationId uint, since time.Time) ([]saleRow, error)
+	GetLastPurchases(productIds []string) ([]purchaseRow, error)
+}
+
+type ReorderRepository struct {
+	db    *gorm.DB
+	stock stockmovement.StockMovementServiceInterface
+}
+
+func NewReorderRepository(db *gorm.DB, stock stockmovement.StockMovementServiceInterface) ReorderRepositoryInterface {
+	log.Println(util.Magenta + "ReorderRepository constructor is called" + util.Reset)
+	return &ReorderRepository{db: db, stock: stock}
+}
+
+// stockRow is the stock of a product at the location with what it takes to
+// price and convert it.
+type stockRow struct {
+	ProductId   string
+	ProductName string
+	Factor      int
+	BaseUnit    string
+	DeriveUnit  string
+	BaseQty     int
+	DerivedQty  int
+	ReorderLvl  int
+	BuyPrice    int64
+}
+
+// saleRow is what left the location through sales in one unit.
+type saleRow struct {
+	ProductId string
+	Uom       string
+	OutQty    int
+}
+
+// purchaseRow is the latest purchase line of a product.
+type purchaseRow struct {
+	ProductId    string
+	SupplierId   uint
+	SupplierName string
+	Price        int64
+	UnitName     string
+}
+
+func (r *ReorderRepository) ResolveLocation(locationId uint) (*models.Location, error) {
+	return r.stock.ResolveLocation(r.db, locationId)
+}
+
+func (r *ReorderRepository) GetStocks(locationId uint, productIds []string) ([]stockRow, error) {
+	var rows []stockRow
+	db := r.db.
+		Table("product_stocks AS s").
+		Select(`
+			s.product_id,
+			item.product_name,
+			COALESCE(uc.factor, 1) AS factor,
+			COALESCE(uc.base_unit, item.uom) AS base_unit,
+			uc.derive_unit,
+			s.base_qty,
+			s.derived_qty,
+			s.reorder_lvl,
+			item.buy_price
+		`).
+		Joins("JOIN products item ON item.id = s.product_id AND item.deleted_at IS NULL").
+		Joins("LEFT JOIN unit_conversions uc ON uc.product_id = s.product_id AND uc.deleted_at IS NULL").
+		Where("s.location_id = ? AND s.deleted_at IS NULL AND item.is_active", locationId)
+	if len(productIds) > 0 {
+		db = db.Where("s.product_id IN ?", productIds)
+	}
+	err := db.Order("s.product_id").Scan(&rows).Error
+	return rows, err
+}
+
+// GetSales sums what the sale lines booked at the location since the given
+// time took out of stock, per product and unit. Only CREDIT rows of sale
+// lines count, less what voids and returns of those lines put back.
+func (r *ReorderRepository) GetSales(locationId uint, since time.Time) ([]saleRow, error) {
+	var rows []saleRow
+	err := r.db.
+		Table("item_transactions AS t").
+		Select(`t.product_id, t.uom, SUM(t.out_qty - COALESCE((
+			SELECT SUM(back.in_qty) FROM item_transactions back
+			WHERE back.reference_no = t.reference_no AND back.tran_type = 'DEBIT' AND back.deleted_at IS NULL
+		), 0)) AS out_qty`).
+		Joins("JOIN sale_details sd ON t.reference_no = sd.sale_id || '-' || sd.id AND sd.deleted_at IS NULL").
+		Where("t.tran_type = ? AND t.location_id = ? AND t.created_at >= ? AND t.deleted_at IS NULL", "CREDIT", locationId, since).
+		Group("t.product_id, t.uom").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *ReorderRepository) GetLastPurchases(productIds []string) ([]purchaseRow, error) {
+	var rows []purchaseRow
+	if len(productIds) == 0 {
+		return rows, nil
+	}
+	err := r.db.
+		Table("purchase_details AS pd").
+		Select("DISTINCT ON (pd.product_id) pd.product_id, p.supplier_id, sup.name AS supplier_name, pd.price, pd.unit_name").
+		Joins("JOIN purchases p ON p.id = pd.purchase_id AND p.deleted_at IS NULL").
+		Joins("JOIN suppliers sup ON sup.id = p.supplier_id").
+		Where("pd.deleted_at IS NULL AND pd.product_id IN ?", productIds).
+		Order("pd.product_id, p.created_at DESC, pd.id DESC").
+		Scan(&rows).Error
+	return rows, err
+}
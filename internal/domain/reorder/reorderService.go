@@ -0,0 +1,220 @@
+package reorder
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/purchaseorder"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+)
+
+const (
+	DefaultDays = 30
+	MaxDays     = 365
+)
+
+type ReorderServiceInterface interface {
+	Suggest(locationId uint, days int, coverDays int, productIds []string) (*SuggestionReportDTO, error)
+	CreateOrder(order *models.PurchaseOrder, days int, coverDays int, productIds []string) (*models.PurchaseOrder, error)
+}
+
+type ReorderService struct {
+	repo   ReorderRepositoryInterface
+	orders purchaseorder.PurchaseOrderServiceInterface
+}
+
+func NewReorderService(repo ReorderRepositoryInterface, orders purchaseorder.PurchaseOrderServiceInterface) ReorderServiceInterface {
+	log.Println(util.Magenta + "ReorderService constructor is called" + util.Reset)
+	return &ReorderService{repo: repo, orders: orders}
+}
+
+// window checks the sales window and the days of stock to cover, zero
+// meaning the default.
+func window(days int, coverDays int) (int, int, error) {
+	if days == 0 {
+		days = DefaultDays
+	}
+	if coverDays == 0 {
+		coverDays = DefaultDays
+	}
+	if days < 1 || days > MaxDays {
+		return 0, 0, apperr.Validation("days must be between 1 and %d, got %d", MaxDays, days)
+	}
+	if coverDays < 1 || coverDays > MaxDays {
+		return 0, 0, apperr.Validation("coverDays must be between 1 and %d, got %d", MaxDays, coverDays)
+	}
+	return days, coverDays, nil
+}
+
+// normalizeIds returns the product ids upper-cased and trimmed, leaving the
+// caller's slice as it was.
+func normalizeIds(productIds []string) []string {
+	ids := make([]string, len(productIds))
+	for i, id := range productIds {
+		ids[i] = strings.ToUpper(strings.TrimSpace(id))
+	}
+	return ids
+}
+
+// Suggest proposes what to reorder for the location from the sales of the
+// last days, enough to cover coverDays more of them and stay above the
+// reorder level, grouped by the supplier each product was last bought from.
+func (s *ReorderService) Suggest(locationId uint, days int, coverDays int, productIds []string) (*SuggestionReportDTO, error) {
+	days, coverDays, err := window(days, coverDays)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := s.repo.ResolveLocation(locationId)
+	if err != nil {
+		return nil, err
+	}
+	stocks, err := s.repo.GetStocks(location.ID, normalizeIds(productIds))
+	if err != nil {
+		return nil, err
+	}
+	sales, err := s.repo.GetSales(location.ID, time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(stocks))
+	for i, st := range stocks {
+		ids[i] = st.ProductId
+	}
+	purchases, err := s.repo.GetLastPurchases(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	report := suggest(stocks, sales, purchases, days, coverDays)
+	report.LocationId = location.ID
+	return report, nil
+}
+
+// suggest works out the reorder quantity of each product, in base units:
+// enough to end coverDays of average daily sales above the reorder level,
+// like the shortage of the low-stock list. Products that need nothing are
+// left out.
+func suggest(stocks []stockRow, sales []saleRow, purchases []purchaseRow, days int, coverDays int) *SuggestionReportDTO {
+	deriveUnits := make(map[string]string, len(stocks))
+	factors := make(map[string]int, len(stocks))
+	for _, st := range stocks {
+		deriveUnits[st.ProductId] = st.DeriveUnit
+		factors[st.ProductId] = max(st.Factor, 1)
+	}
+	sold := map[string]float64{}
+	for _, sr := range sales {
+		qty := float64(sr.OutQty)
+		if du := deriveUnits[sr.ProductId]; du != "" && strings.EqualFold(sr.Uom, du) {
+			qty /= float64(factors[sr.ProductId])
+		}
+		sold[sr.ProductId] += qty
+	}
+	lastPurchase := make(map[string]purchaseRow, len(purchases))
+	for _, pr := range purchases {
+		lastPurchase[pr.ProductId] = pr
+	}
+
+	groups := map[uint]*SupplierSuggestionDTO{}
+	for _, st := range stocks {
+		factor := factors[st.ProductId]
+		daily := sold[st.ProductId] / float64(days)
+		onHand := float64(st.BaseQty) + float64(st.DerivedQty)/float64(factor)
+		if st.ReorderLvl == 0 && daily == 0 {
+			// neither a level to keep nor sales to cover
+			continue
+		}
+		need := float64(st.ReorderLvl) + daily*float64(coverDays) - onHand
+		if need < 0 {
+			continue
+		}
+		// the hair keeps float noise from ordering a whole unit more
+		qty := int(math.Floor(need+1e-9)) + 1
+
+		price := st.BuyPrice
+		pr, bought := lastPurchase[st.ProductId]
+		if bought {
+			price = pr.Price
+			if st.DeriveUnit != "" && strings.EqualFold(pr.UnitName, st.DeriveUnit) {
+				price *= int64(factor)
+			}
+		}
+
+		group, ok := groups[pr.SupplierId]
+		if !ok {
+			group = &SupplierSuggestionDTO{SupplierId: pr.SupplierId, SupplierName: pr.SupplierName}
+			groups[pr.SupplierId] = group
+		}
+		line := SuggestionLineDTO{
+			ProductId:    st.ProductId,
+			ProductName:  st.ProductName,
+			BaseUnit:     st.BaseUnit,
+			BaseQty:      st.BaseQty,
+			DerivedQty:   st.DerivedQty,
+			ReorderLvl:   st.ReorderLvl,
+			DailySales:   math.Round(daily*100) / 100,
+			SuggestedQty: qty,
+			Price:        price,
+			Total:        int64(qty) * price,
+		}
+		group.Lines = append(group.Lines, line)
+		group.Total += line.Total
+	}
+
+	report := &SuggestionReportDTO{Days: days, CoverDays: coverDays, Suppliers: []SupplierSuggestionDTO{}}
+	for _, g := range groups {
+		report.Suppliers = append(report.Suppliers, *g)
+	}
+	// suppliers by id, the products never bought before last
+	sort.Slice(report.Suppliers, func(i, j int) bool {
+		a, b := report.Suppliers[i].SupplierId, report.Suppliers[j].SupplierId
+		if a == 0 || b == 0 {
+			return b == 0 && a != 0
+		}
+		return a < b
+	})
+	return report
+}
+
+// CreateOrder fills the lines of a draft purchase order, whose supplier,
+// location and remark are given, with what the suggestion proposes to buy
+// from the supplier.
+func (s *ReorderService) CreateOrder(order *models.PurchaseOrder, days int, coverDays int, productIds []string) (*models.PurchaseOrder, error) {
+	if order.SupplierId == 0 {
+		return nil, apperr.Validation("supplierId is required")
+	}
+	report, err := s.Suggest(order.LocationId, days, coverDays, productIds)
+	if err != nil {
+		return nil, err
+	}
+
+	order.LocationId = report.LocationId
+	if order.Remark == "" {
+		order.Remark = fmt.Sprintf("Reorder suggestion for %d days over %d days of sales", report.CoverDays, report.Days)
+	}
+	order.PurchaseOrderLines = nil
+	for _, g := range report.Suppliers {
+		if g.SupplierId != order.SupplierId {
+			continue
+		}
+		for _, l := range g.Lines {
+			order.PurchaseOrderLines = append(order.PurchaseOrderLines, models.PurchaseOrderLine{
+				ProductId:   l.ProductId,
+				ProductName: l.ProductName,
+				Qty:         l.SuggestedQty,
+				UnitName:    l.BaseUnit,
+				Price:       l.Price,
+			})
+		}
+	}
+	if len(order.PurchaseOrderLines) == 0 {
+		return nil, apperr.Validation("nothing to reorder from supplier %d", order.SupplierId)
+	}
+	return s.orders.Create(order)
+}
@@ -0,0 +1,74 @@
+package reorder
+
+import (
+	"testing"
+
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggest(t *testing.T) {
+	stocks := []stockRow{
+		// 2 PCS and 5 FEET on hand, 10 FEET per PCS
+		{ProductId: "P001", ProductName: "PVC PIPE", Factor: 10, BaseUnit: "PCS", DeriveUnit: "FEET", BaseQty: 2, DerivedQty: 5, ReorderLvl: 5, BuyPrice: 100},
+		// plenty left for the sales it has
+		{ProductId: "P002", ProductName: "ELBOW", Factor: 1, BaseUnit: "PCS", BaseQty: 100, ReorderLvl: 5, BuyPrice: 20},
+		// never bought, nothing sold, at its reorder level
+		{ProductId: "P003", ProductName: "GLUE", Factor: 1, BaseUnit: "TUBE", BaseQty: 1, ReorderLvl: 1, BuyPrice: 30},
+		// out of stock, but no reorder level and no sales: nothing to order
+		{ProductId: "P004", ProductName: "TAPE", Factor: 1, BaseUnit: "ROLL", BuyPrice: 10},
+	}
+	sales := []saleRow{
+		{ProductId: "P001", Uom: "PCS", OutQty: 20},
+		{ProductId: "P001", Uom: "feet", OutQty: 100},
+		{ProductId: "P002", Uom: "PCS", OutQty: 30},
+	}
+	purchases := []purchaseRow{
+		// last bought by the FEET at 12, 120 a PCS
+		{ProductId: "P001", SupplierId: 7, SupplierName: "PIPES LTD", Price: 12, UnitName: "FEET"},
+		{ProductId: "P002", SupplierId: 7, SupplierName: "PIPES LTD", Price: 18, UnitName: "PCS"},
+	}
+
+	report := suggest(stocks, sales, purchases, 30, 15)
+
+	assert.Equal(t, 30, report.Days)
+	assert.Equal(t, 15, report.CoverDays)
+	require.Len(t, report.Suppliers, 2)
+
+	pipes := report.Suppliers[0]
+	assert.Equal(t, uint(7), pipes.SupplierId)
+	require.Len(t, pipes.Lines, 1)
+	// 30 PCS in 30 days is 1 a day: 5 + 15 - 2.5 = 17.5, so 18
+	assert.Equal(t, 1.0, pipes.Lines[0].DailySales)
+	assert.Equal(t, 18, pipes.Lines[0].SuggestedQty)
+	assert.Equal(t, int64(120), pipes.Lines[0].Price)
+	assert.Equal(t, int64(18*120), pipes.Total)
+
+	unknown := report.Suppliers[1]
+	assert.Equal(t, uint(0), unknown.SupplierId)
+	require.Len(t, unknown.Lines, 1)
+	// at its reorder level, one more gets it above; priced at the buy price
+	assert.Equal(t, "P003", unknown.Lines[0].ProductId)
+	assert.Equal(t, 1, unknown.Lines[0].SuggestedQty)
+	assert.Equal(t, int64(30), unknown.Lines[0].Price)
+}
+
+func TestNormalizeIds(t *testing.T) {
+	productIds := []string{" p001", "P002 "}
+	assert.Equal(t, []string{"P001", "P002"}, normalizeIds(productIds))
+	// the handler's query values are left alone
+	assert.Equal(t, []string{" p001", "P002 "}, productIds)
+}
+
+func TestWindow(t *testing.T) {
+	days, coverDays, err := window(0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultDays, days)
+	assert.Equal(t, DefaultDays, coverDays)
+
+	_, _, err = window(400, 10)
+	assert.ErrorIs(t, err, apperr.ErrValidation)
+	_, _, err = window(10, -1)
+	assert.ErrorIs(t, err, apperr.ErrValidation)
+}
@@ -3,12 +3,30 @@ package sale
 import "github.com/sankangkin/di-rest-api/internal/models"
 
 type SaleInvoiceRequestDTO struct {
-	ID          string              `gorm:"primaryKey" json:"id"`
 	CustomerId  uint                `json:"customerId"`
+	LocationId  uint                `json:"locationId"` // the default location when empty
 	SaleDetails []models.SaleDetail `gorm:"foreignKey:SaleId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"saleDetails"`
 	Discount    int64               `json:"discount"`
 	Total       int64               `json:"total"`
+	Tax         int64               `json:"tax"`
 	GrandTotal  int64               `json:"grandTotal"`
+	PaymentType models.PaymentType  `json:"paymentType"` // CASH (default), CREDIT or PARTIAL
+	PaidAmount  int64               `json:"paidAmount"`  // only for PARTIAL
 	Remark      string              `json:"remark"`
 	SaleDate    string              `json:"saleDate"`
-}
\ No newline at end of file
+}
+
+type SaleVoidRequestDTO struct {
+	Remark string `json:"remark"`
+}
+
+type SaleReturnRequestDTO struct {
+	Remark     string                     `json:"remark"`
+	ReturnDate string                     `json:"returnDate"`
+	Items      []SaleReturnItemRequestDTO `json:"items"`
+}
+
+type SaleReturnItemRequestDTO struct {
+	SaleDetailId uint `json:"saleDetailId"`
+	Qty          int  `json:"qty"`
+}
@@ -4,29 +4,21 @@ import (
 	"log"
 	"net/http"
 	"strconv"
-	"sync"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
-	"gorm.io/gorm"
+	"github.com/sankangkin/di-rest-api/internal/query"
 )
 
 type SaleHandler struct {
 	svc SaleServiceInterface
 }
 
-var (
-	hdlInstance *SaleHandler
-	hdlOnce     sync.Once
-)
-
 func NewSaleHandler(svc SaleServiceInterface) *SaleHandler {
 	log.Println(util.Blue + "SaleHandler constructor is called" + util.Reset)
-	hdlOnce.Do(func() {
-		hdlInstance = &SaleHandler{svc: svc}
-	})
-	return hdlInstance
+	return &SaleHandler{svc: svc}
 }
 
 // CreateSale 	godoc
@@ -37,10 +29,10 @@ func NewSaleHandler(svc SaleServiceInterface) *SaleHandler {
 //	@Accept			json
 //	@Param			sale	body		SaleInvoiceRequestDTO	true	"Product Data"
 //	@Success		200		{object}	models.Sale
-//	@Failure		400		{object}	httputil.HttpError400
-//	@Failure		401		{object}	httputil.HttpError401
-//	@Failure		500		{object}	httputil.HttpError500
-//	@Failure		401		{object}	httputil.HttpError401
+//	@Failure		400		{object}	apperr.Response
+//	@Failure		401		{object}	apperr.Response
+//	@Failure		500		{object}	apperr.Response
+//	@Failure		401		{object}	apperr.Response
 //	@Router			/api/sales [post]
 //	@Security		Bearer
 func (h *SaleHandler) CreateSale(c *fiber.Ctx) error {
@@ -48,38 +40,34 @@ func (h *SaleHandler) CreateSale(c *fiber.Ctx) error {
 	input := new(SaleInvoiceRequestDTO)
 	log.Println("input", input)
 	if err := c.BodyParser(input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  400,
-			"message": "Invalid JSON format",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 	newSale := models.Sale{
-		ID:          input.ID,
 		CustomerId:  input.CustomerId,
+		LocationId:  input.LocationId,
 		Discount:    input.Discount,
 		GrandTotal:  input.GrandTotal,
 		Remark:      input.Remark,
 		SaleDate:    input.SaleDate,
 		SaleDetails: input.SaleDetails,
 		Total:       input.Total,
+		Tax:         input.Tax,
+		PaymentType: input.PaymentType,
+		PaidAmount:  input.PaidAmount,
 	}
-	errors := models.ValidateStruct(newSale)
-	if errors != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "operation failed",
-		})
+	if err := models.ValidateStruct(newSale); err != nil {
+		return err
 	}
 
-	if _, err := h.svc.CreateService(&newSale); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+	created, err := h.svc.CreateService(&newSale)
+	if err != nil {
+		return err
 	}
 	return c.Status(http.StatusOK).JSON(
 		&fiber.Map{
 			"status":  "SUCCESS",
 			"message": "Sale has been created successfully",
-			"data":    newSale,
+			"data":    created,
 		})
 
 }
@@ -91,25 +79,37 @@ func (h *SaleHandler) CreateSale(c *fiber.Ctx) error {
 //	@Tags			Sales
 //	@Accept			json
 //	@Produce		json
+//	@Param			page	query		int		false	"page number, from 1"
+//	@Param			limit	query		int		false	"rows per page, 20 by default and 100 at most"
+//	@Param			sort	query		string	false	"comma separated fields of id, saleDate, grandTotal, createdAt; prefix - for descending"
+//	@Param			customerId	query		int	false	"only the sales of this customer"
+//	@Param			locationId	query		int	false	"only the sales from this location"
+//	@Param			status	query		string	false	"ACTIVE, PARTIALLY_RETURNED, RETURNED or VOIDED"
+//	@Param			paymentType	query		string	false	"CASH, CREDIT or PARTIAL"
+//	@Param			from	query		string	false	"first sale date, yyyy-mm-dd"
+//	@Param			to	query		string	false	"last sale date, yyyy-mm-dd"
 //	@Success		200				{array}		models.Sale
-//	@Failure		400				{object}	httputil.HttpError400
-//	@Failure		401				{object}	httputil.HttpError401
-//	@Failure		500				{object}	httputil.HttpError500
+//	@Failure		400				{object}	apperr.Response
+//	@Failure		401				{object}	apperr.Response
+//	@Failure		500				{object}	apperr.Response
 //	@Router			/api/sales	[get]
 //	@Security		Bearer
 func (h *SaleHandler) GetAllSales(c *fiber.Ctx) error {
 
-	sales, err := h.svc.GetAllService()
+	spec, err := query.Parse(c.Queries(), saleList)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
+	}
+	sales, page, err := h.svc.GetAllService(spec)
+	if err != nil {
+		return err
 	}
 	return c.Status(http.StatusOK).JSON(
 		&fiber.Map{
 			"status":  "SUCCESS",
 			"message": strconv.Itoa(len(sales)) + " records found",
 			"data":    sales,
+			"meta":    page,
 			"count":   len(sales),
 		})
 }
@@ -123,24 +123,16 @@ func (h *SaleHandler) GetAllSales(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Param			id					path		string	true	"sale Id"
 //	@Success		200					{object}	models.Sale
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/sales/{id}	[get]
 //	@Security		Bearer
 func (h *SaleHandler) GetById(c *fiber.Ctx) error {
 
 	sale, err := h.svc.GetById(c.Params("id"))
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "Record not found",
-			})
-		}
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
-			"status": "FAIL", "message": err.Error(),
-		})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "SUCCESS",
@@ -149,3 +141,82 @@ func (h *SaleHandler) GetById(c *fiber.Ctx) error {
 	})
 
 }
+
+// VoidSale godoc
+//
+//	@Summary		Void a sale
+//	@Description	Void a sale, put every unreturned quantity back into stock and keep the invoice marked as VOIDED
+//	@Tags			Sales
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string				true	"sale Id"
+//	@Param			body	body		SaleVoidRequestDTO	false	"Void Data"
+//	@Success		200		{object}	models.Sale
+//	@Failure		400		{object}	apperr.Response
+//	@Failure		401		{object}	apperr.Response
+//	@Failure		500		{object}	apperr.Response
+//	@Router			/api/sales/{id}/void [post]
+//	@Security		Bearer
+func (h *SaleHandler) VoidSale(c *fiber.Ctx) error {
+
+	input := new(SaleVoidRequestDTO)
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(input); err != nil {
+			return apperr.Validation("invalid JSON format")
+		}
+	}
+
+	sale, err := h.svc.VoidService(c.Params("id"), input.Remark)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "Sale has been voided successfully",
+		"data":    sale,
+	})
+}
+
+// CreateSaleReturn godoc
+//
+//	@Summary		Return items of a sale
+//	@Description	Book a customer return against the lines of a sale and put the returned quantities back into stock
+//	@Tags			Sales
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"sale Id"
+//	@Param			body	body		SaleReturnRequestDTO	true	"Return Data"
+//	@Success		200		{object}	models.SaleReturn
+//	@Failure		400		{object}	apperr.Response
+//	@Failure		401		{object}	apperr.Response
+//	@Failure		500		{object}	apperr.Response
+//	@Router			/api/sales/{id}/returns [post]
+//	@Security		Bearer
+func (h *SaleHandler) CreateSaleReturn(c *fiber.Ctx) error {
+
+	input := new(SaleReturnRequestDTO)
+	if err := c.BodyParser(input); err != nil {
+		return apperr.Validation("invalid JSON format")
+	}
+
+	saleReturn := models.SaleReturn{
+		Remark:     input.Remark,
+		ReturnDate: input.ReturnDate,
+	}
+	for _, item := range input.Items {
+		saleReturn.SaleReturnDetails = append(saleReturn.SaleReturnDetails, models.SaleReturnDetail{
+			SaleDetailId: item.SaleDetailId,
+			Qty:          item.Qty,
+		})
+	}
+
+	created, err := h.svc.ReturnService(c.Params("id"), &saleReturn)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "Sale return has been created successfully",
+		"data":    created,
+	})
+}
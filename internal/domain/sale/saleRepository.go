@@ -1,56 +1,60 @@
 package sale
 
 import (
-	"errors"
 	"fmt"
 	"log"
 	"strconv"
 	"strings"
-	"sync"
 
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/docnumber"
+	"github.com/sankangkin/di-rest-api/internal/domain/pricing"
+	"github.com/sankangkin/di-rest-api/internal/domain/stockmovement"
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
 type SaleRepositoryInterface interface {
 	Create(sale *models.Sale) (*models.Sale, error)
-	GetAll() ([]models.Sale, error)
+	GetAll(spec query.Spec) ([]models.Sale, query.Page, error)
 	GetById(id string) (*models.Sale, error)
+	Void(id string, remark string) (*models.Sale, error)
+	CreateReturn(id string, input *models.SaleReturn) (*models.SaleReturn, error)
 }
 
 type SaleRepository struct {
-	db *gorm.DB
+	db    *gorm.DB
+	stock stockmovement.StockMovementServiceInterface
+	docNo docnumber.DocNumberServiceInterface
 }
 
-var (
-	repoInstance *SaleRepository
-	repoOnce     sync.Once
-)
-
-func NewSaleRepository(db *gorm.DB) SaleRepositoryInterface {
+func NewSaleRepository(db *gorm.DB, stock stockmovement.StockMovementServiceInterface, docNo docnumber.DocNumberServiceInterface) SaleRepositoryInterface {
 	log.Println(util.Blue + "SaleRepository constructor is called" + util.Reset)
-	repoOnce.Do(func() {
-		repoInstance = &SaleRepository{db: db}
-	})
-	return repoInstance
+	return &SaleRepository{db: db, stock: stock, docNo: docNo}
 }
 
 func (r *SaleRepository) Create(input *models.Sale) (*models.Sale, error) {
 	newSale := models.Sale{
-		ID:          input.ID,
 		CustomerId:  input.CustomerId,
+		LocationId:  input.LocationId,
 		Discount:    input.Discount,
 		GrandTotal:  input.GrandTotal,
 		Remark:      input.Remark,
 		SaleDate:    input.SaleDate,
 		SaleDetails: input.SaleDetails,
 		Total:       input.Total,
+		TaxRate:     input.TaxRate,
+		Tax:         input.Tax,
+		PaymentType: input.PaymentType,
+		PaidAmount:  input.PaidAmount,
+		Status:      models.SaleActive,
 	}
 
 	if err := models.ValidateStruct(newSale); err != nil {
-		return nil, gorm.ErrCheckConstraintViolated
+		return nil, err
 	}
 
 	tx := r.db.Begin()
@@ -63,6 +67,21 @@ func (r *SaleRepository) Create(input *models.Sale) (*models.Sale, error) {
 		return nil, err
 	}
 
+	// the invoice number is allocated on tx, a failed sale gives it back
+	id, err := r.docNo.Next(tx, docnumber.DocTypeSale)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	newSale.ID = id
+
+	location, err := r.stock.ResolveLocation(tx, newSale.LocationId)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	newSale.LocationId = location.ID
+
 	if err := tx.Create(&newSale).Error; err != nil {
 		tx.Rollback()
 		return nil, err
@@ -76,7 +95,26 @@ func (r *SaleRepository) Create(input *models.Sale) (*models.Sale, error) {
 	for i := range newSale.SaleDetails {
 		sd := &newSale.SaleDetails[i]
 
-		if err := adjustProductStock(tx, newSale.ID, sd); err != nil {
+		if err := r.adjustProductStock(tx, &newSale, sd); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	// money taken at the counter is recorded as a payment applied to this sale
+	if newSale.PaidAmount > 0 {
+		payment := models.Payment{
+			CustomerId:  newSale.CustomerId,
+			Amount:      newSale.PaidAmount,
+			Method:      "CASH",
+			PaymentDate: newSale.SaleDate,
+			ReferenceNo: newSale.ID,
+			Remark:      fmt.Sprintf("Paid at sale %s", newSale.ID),
+			PaymentAllocations: []models.PaymentAllocation{
+				{SaleId: newSale.ID, Amount: newSale.PaidAmount},
+			},
+		}
+		if err := tx.Create(&payment).Error; err != nil {
 			tx.Rollback()
 			return nil, err
 		}
@@ -89,88 +127,286 @@ func (r *SaleRepository) Create(input *models.Sale) (*models.Sale, error) {
 	return &newSale, nil
 }
 
-func adjustProductStock(tx *gorm.DB, saleId string, sd *models.SaleDetail) error {
-	var productStock models.ProductStock
-	if err := tx.First(&productStock, "product_id = ?", sd.ProductId).Error; err != nil {
+// adjustProductStock takes a sale line out of the stock of the sale's
+// location through the shared stock-movement engine, in the line's own unit.
+func (r *SaleRepository) adjustProductStock(tx *gorm.DB, sale *models.Sale, sd *models.SaleDetail) error {
+	saleId := sale.ID
+	unitConv, err := stockmovement.FindUnitConversion(tx, sd.ProductId)
+	if err != nil {
 		return err
 	}
 
-	var unitConv models.UnitConversion
-	if err := tx.First(&unitConv, "product_id = ?", sd.ProductId).Error; err != nil {
-		return fmt.Errorf("unit conversion not found for product %s", sd.ProductId)
+	unitKind := "base unit"
+	if isDerivedUnit(sd, unitConv) {
+		unitKind = "derived unit"
 	}
+	qty := soldQty(sd, unitConv)
+
+	_, err = r.stock.Apply(tx, stockmovement.Movement{
+		ProductId:   sd.ProductId,
+		LocationId:  sale.LocationId,
+		Uom:         sd.Uom,
+		Qty:         -qty,
+		TranType:    "CREDIT",
+		ReferenceNo: saleId + "-" + strconv.Itoa(int(sd.ID)),
+		Remark:      fmt.Sprintf("SaleId %s, SaleDetailId %d, ProductId %s, Sold %d %s (%s)", saleId, sd.ID, sd.ProductId, qty, sd.Uom, unitKind),
+	})
+	return err
+}
 
-	factor := int(unitConv.Factor)
+func (r *SaleRepository) Void(id string, remark string) (*models.Sale, error) {
+	var sale models.Sale
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockSale(tx, id, &sale); err != nil {
+			return err
+		}
+		if sale.Status == models.SaleVoided {
+			return apperr.Conflict("sale %s is already voided", sale.ID)
+		}
+
+		voidDoc := models.SaleReturn{
+			SaleId: sale.ID,
+			IsVoid: true,
+			Remark: remark,
+		}
+		for i := range sale.SaleDetails {
+			sd := &sale.SaleDetails[i]
+
+			unitConv, err := stockmovement.FindUnitConversion(tx, sd.ProductId)
+			if err != nil {
+				return err
+			}
+			lineQty := soldQty(sd, unitConv)
+			remaining := lineQty - sd.ReturnedQty
+			if remaining <= 0 {
+				continue
+			}
+			voidDoc.SaleReturnDetails = append(voidDoc.SaleReturnDetails, models.SaleReturnDetail{
+				SaleDetailId: sd.ID,
+				ProductId:    sd.ProductId,
+				Qty:          remaining,
+				Uom:          sd.Uom,
+				Price:        sd.Price,
+				Total:        pricing.RefundAmount(sd.Total, lineQty, remaining, 0, 0),
+			})
+		}
+		voidDoc.Total = sale.GrandTotal - sale.ReturnedTotal
 
-	switch {
-	case strings.EqualFold(sd.Uom, unitConv.BaseUnit):
-		if sd.Qty > productStock.BaseQty {
-			return fmt.Errorf("not enough stock: base unit of %s. requested %d, available %d", sd.ProductId, sd.Qty, productStock.BaseQty)
+		if err := tx.Create(&voidDoc).Error; err != nil {
+			return err
 		}
-		productStock.BaseQty -= sd.Qty
+		for i := range voidDoc.SaleReturnDetails {
+			rd := &voidDoc.SaleReturnDetails[i]
+			sd := findSaleDetail(sale.SaleDetails, rd.SaleDetailId)
 
-		// Log base unit transaction
-		trx := models.ItemTransaction{
-			ProductId:   sd.ProductId,
-			ReferenceNo: saleId + "-" + strconv.Itoa(int(sd.ID)),
-			OutQty:      sd.Qty,
-			Uom:         sd.Uom,
-			TranType:    "CREDIT",
-			Remark:      fmt.Sprintf("SaleId %s, SaleDetailId %d, ProductId %s, Sold %d %s (base unit)", sd.SaleId, sd.ID, sd.ProductId, sd.Qty, sd.Uom),
+			if err := r.restoreProductStock(tx, &sale, sd, rd.Qty, "Voided"); err != nil {
+				return err
+			}
+			sd.ReturnedQty += rd.Qty
+			if err := tx.Model(sd).Update("returned_qty", sd.ReturnedQty).Error; err != nil {
+				return err
+			}
 		}
-		if err := tx.Create(&trx).Error; err != nil {
+
+		// payments applied to the sale go back on the customer's account as credit
+		if err := tx.Where("sale_id = ?", sale.ID).Delete(&models.PaymentAllocation{}).Error; err != nil {
 			return err
 		}
 
-	case strings.EqualFold(sd.Uom, unitConv.DeriveUnit):
-		totalNeeded := sd.DerivedQty
+		// paid_amount is a copy of the allocation total, taken again now
+		// that the allocations are gone
+		sale.Status = models.SaleVoided
+		return tx.Model(&sale).Updates(map[string]interface{}{
+			"status":      sale.Status,
+			"paid_amount": gorm.Expr("(SELECT COALESCE(SUM(amount), 0) FROM payment_allocations WHERE sale_id = ?)", sale.ID),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		if totalNeeded <= productStock.DerivedQty {
-			productStock.DerivedQty -= totalNeeded
-		} else {
-			shortage := totalNeeded - productStock.DerivedQty
-			productStock.DerivedQty = 0
+	return r.GetById(sale.ID)
+}
+
+func (r *SaleRepository) CreateReturn(id string, input *models.SaleReturn) (*models.SaleReturn, error) {
+	if len(input.SaleReturnDetails) == 0 {
+		return nil, apperr.Validation("a return needs at least one line item")
+	}
 
-			baseToConvert := (shortage + factor - 1) / factor // round up
-			if baseToConvert > productStock.BaseQty {
-				return fmt.Errorf("not enough stock for derived sale of product %s: need %d %s → convert %d base units, only %d available",
-					sd.ProductId, totalNeeded, unitConv.DeriveUnit, baseToConvert, productStock.BaseQty)
+	var sale models.Sale
+	saleReturn := models.SaleReturn{
+		Remark:     input.Remark,
+		ReturnDate: input.ReturnDate,
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockSale(tx, id, &sale); err != nil {
+			return err
+		}
+		if sale.Status == models.SaleVoided {
+			return apperr.Conflict("sale %s is voided and cannot take returns", sale.ID)
+		}
+		saleReturn.SaleId = sale.ID
+
+		for _, item := range input.SaleReturnDetails {
+			sd := findSaleDetail(sale.SaleDetails, item.SaleDetailId)
+			if sd == nil {
+				return apperr.Validation("sale detail %d does not belong to sale %s", item.SaleDetailId, sale.ID)
+			}
+			if item.Qty <= 0 {
+				return apperr.Validation("return quantity for sale detail %d must be greater than zero", sd.ID)
+			}
+
+			unitConv, err := stockmovement.FindUnitConversion(tx, sd.ProductId)
+			if err != nil {
+				return err
+			}
+			lineQty := soldQty(sd, unitConv)
+			remaining := lineQty - sd.ReturnedQty
+			if item.Qty > remaining {
+				return apperr.Conflict("cannot return %d %s of sale detail %d: only %d left to return", item.Qty, sd.Uom, sd.ID, remaining)
 			}
 
-			productStock.BaseQty -= baseToConvert
-			convertedDerived := baseToConvert * factor
-			productStock.DerivedQty = convertedDerived - shortage
+			saleReturn.SaleReturnDetails = append(saleReturn.SaleReturnDetails, models.SaleReturnDetail{
+				SaleDetailId: sd.ID,
+				ProductId:    sd.ProductId,
+				Qty:          item.Qty,
+				Uom:          sd.Uom,
+				Price:        sd.Price,
+				Total:        pricing.RefundAmount(sd.Total, lineQty, item.Qty, 0, 0),
+			})
+			// the refund carries its share of the invoice discount and tax
+			saleReturn.Total += pricing.RefundAmount(sd.Total, lineQty, item.Qty, sale.Total, sale.GrandTotal)
+			sd.ReturnedQty += item.Qty
 		}
 
-		// Log derived unit transaction
-		trx := models.ItemTransaction{
-			ProductId:   sd.ProductId,
-			ReferenceNo: saleId + "-" + strconv.Itoa(int(sd.ID)),
-			OutQty:      sd.DerivedQty,
-			Uom:         sd.Uom,
-			TranType:    "CREDIT",
-			Remark:      fmt.Sprintf("SaleId %s, SaleDetailId %d, ProductId %s, Sold %d %s (derived unit)", sd.SaleId, sd.ID, sd.ProductId, sd.DerivedQty, sd.Uom),
+		status := models.SaleReturned
+		for i := range sale.SaleDetails {
+			sd := &sale.SaleDetails[i]
+			unitConv, err := stockmovement.FindUnitConversion(tx, sd.ProductId)
+			if err != nil {
+				return err
+			}
+			if sd.ReturnedQty < soldQty(sd, unitConv) {
+				status = models.SalePartiallyReturned
+				break
+			}
+		}
+		if status == models.SaleReturned {
+			// last return settles whatever rounding left over
+			saleReturn.Total = sale.GrandTotal - sale.ReturnedTotal
 		}
-		if err := tx.Create(&trx).Error; err != nil {
+
+		if err := tx.Create(&saleReturn).Error; err != nil {
 			return err
 		}
+		for _, rd := range saleReturn.SaleReturnDetails {
+			sd := findSaleDetail(sale.SaleDetails, rd.SaleDetailId)
+			if err := r.restoreProductStock(tx, &sale, sd, rd.Qty, "Returned"); err != nil {
+				return err
+			}
+			if err := tx.Model(sd).Update("returned_qty", sd.ReturnedQty).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&sale).Updates(map[string]interface{}{
+			"status":         status,
+			"returned_total": sale.ReturnedTotal + saleReturn.Total,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &saleReturn, nil
+}
+
+// lockSale loads the sale with its details and holds a row lock on it until
+// the surrounding transaction ends, so two reversals cannot race each other.
+func lockSale(tx *gorm.DB, id string, sale *models.Sale) error {
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("SaleDetails").
+		First(sale, "id = ?", strings.ToUpper(id)).Error
+}
 
-	default:
-		return fmt.Errorf("invalid unit %s for product %s (expected %s or %s)", sd.Uom, sd.ProductId, unitConv.BaseUnit, unitConv.DeriveUnit)
+func findSaleDetail(details []models.SaleDetail, id uint) *models.SaleDetail {
+	for i := range details {
+		if details[i].ID == id {
+			return &details[i]
+		}
 	}
+	return nil
+}
 
-	return tx.Save(&productStock).Error
+func isDerivedUnit(sd *models.SaleDetail, unitConv *models.UnitConversion) bool {
+	return unitConv.DeriveUnit != "" && strings.EqualFold(sd.Uom, unitConv.DeriveUnit)
 }
 
-func (r *SaleRepository) GetAll() ([]models.Sale, error) {
+// soldQty is the quantity of a sale line in its own unit, matching what
+// adjustProductStock took out of stock.
+func soldQty(sd *models.SaleDetail, unitConv *models.UnitConversion) int {
+	if isDerivedUnit(sd, unitConv) {
+		return sd.DerivedQty
+	}
+	return sd.Qty
+}
 
-	sales := []models.Sale{}
-	r.db.Preload(clause.Associations).Model(&models.Sale{}).Order("sale_date DESC").Find(&sales)
-	if len(sales) == 0 {
-		return nil, errors.New("NO records found")
+// restoreProductStock is the inverse of adjustProductStock: it puts qty of the
+// sale line's unit back into the stock of the sale's location and writes a
+// compensating DEBIT row that carries the same reference number as the
+// original sale line.
+func (r *SaleRepository) restoreProductStock(tx *gorm.DB, sale *models.Sale, sd *models.SaleDetail, qty int, reason string) error {
+	saleId := sale.ID
+	unitConv, err := stockmovement.FindUnitConversion(tx, sd.ProductId)
+	if err != nil {
+		return err
+	}
+
+	unitKind := "base unit"
+	if isDerivedUnit(sd, unitConv) {
+		unitKind = "derived unit"
 	}
 
-	return sales, nil
+	_, err = r.stock.Apply(tx, stockmovement.Movement{
+		ProductId:   sd.ProductId,
+		LocationId:  sale.LocationId,
+		Uom:         sd.Uom,
+		Qty:         qty,
+		TranType:    "DEBIT",
+		ReferenceNo: saleId + "-" + strconv.Itoa(int(sd.ID)),
+		Remark:      fmt.Sprintf("SaleId %s, SaleDetailId %d, ProductId %s, %s %d %s (%s)", saleId, sd.ID, sd.ProductId, reason, qty, sd.Uom, unitKind),
+	})
+	return err
+}
+
+// saleList is what GET /sales sorts and filters on.
+var saleList = query.Resource{
+	Sort: map[string]string{
+		"id":         "id",
+		"saleDate":   "sale_date",
+		"grandTotal": "grand_total",
+		"createdAt":  "created_at",
+	},
+	DefaultSort: "-saleDate",
+	Filters: map[string]query.Filter{
+		"customerId":  {Column: "customer_id", Kind: query.Uint},
+		"status":      {Column: "status", Kind: query.Code},
+		"paymentType": {Column: "payment_type", Kind: query.Code},
+		"locationId":  {Column: "location_id", Kind: query.Uint},
+	},
+	Dates: query.Filter{Column: "sale_date", Kind: query.DateText},
+	Key:   "id",
+}
+
+// GetAll loads a page of sales with their customer and lines; the returns
+// are left to GetById.
+func (r *SaleRepository) GetAll(spec query.Spec) ([]models.Sale, query.Page, error) {
+
+	sales := []models.Sale{}
+	page, err := query.Find(r.db.Model(&models.Sale{}), spec, &sales, "Customer", "SaleDetails")
+	return sales, page, err
 }
 
 func (r *SaleRepository) GetById(id string) (*models.Sale, error) {
@@ -178,6 +414,7 @@ func (r *SaleRepository) GetById(id string) (*models.Sale, error) {
 	err := r.db.
 		Preload("Customer").
 		Preload("SaleDetails").
+		Preload("SaleReturns.SaleReturnDetails").
 		First(&sale, "id = ?", strings.ToUpper(id)).Error
 
 	if err != nil {
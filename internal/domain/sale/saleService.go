@@ -1,45 +1,116 @@
 package sale
 
 import (
+	"fmt"
 	"log"
-	"sync"
+	"strings"
 
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/barcode"
+	"github.com/sankangkin/di-rest-api/internal/domain/pricing"
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 )
 
 type SaleServiceInterface interface{
 	CreateService(sale *models.Sale) (*models.Sale, error)
-	GetAllService() ([]models.Sale, error)
+	GetAllService(spec query.Spec) ([]models.Sale, query.Page, error)
 	GetById(id string) (*models.Sale, error)
+	VoidService(id string, remark string) (*models.Sale, error)
+	ReturnService(id string, saleReturn *models.SaleReturn) (*models.SaleReturn, error)
 }
 
 type SaleService struct{
 	repo SaleRepositoryInterface
+	pricing pricing.PricingServiceInterface
+	barcodes barcode.BarcodeServiceInterface
 }
 
-var (
-	svcInstance *SaleService
-	svcOnce sync.Once
-)
-
-func NewSaleService(repo SaleRepositoryInterface) SaleServiceInterface{
+func NewSaleService(repo SaleRepositoryInterface, pricing pricing.PricingServiceInterface, barcodes barcode.BarcodeServiceInterface) SaleServiceInterface{
 	log.Println(util.Blue + "SaleService constructor is called" + util.Reset)
 
-	svcOnce.Do(func() {
-		svcInstance = &SaleService{repo: repo}
-	})
-	return svcInstance
+	return &SaleService{repo: repo, pricing: pricing, barcodes: barcodes}
 }
 
 func (s *SaleService)CreateService(sale *models.Sale) (*models.Sale, error){
+	if err := s.resolveBarcodes(sale); err != nil {
+		return nil, err
+	}
+	// totals are computed here, never taken from the client as-is
+	if err := s.pricing.PriceSale(sale); err != nil {
+		return nil, err
+	}
+	if err := settlePayment(sale); err != nil {
+		return nil, err
+	}
 	return s.repo.Create(sale)
 }
 
-func (s *SaleService)GetAllService() ([]models.Sale, error){
-	return s.repo.GetAll()
+// resolveBarcodes fills in the product and unit of the lines given by a
+// scanned barcode. Qty of such a line is in the scanned unit, so for a derived
+// unit it moves to DerivedQty.
+func (s *SaleService) resolveBarcodes(sale *models.Sale) error {
+	for i := range sale.SaleDetails {
+		sd := &sale.SaleDetails[i]
+		if sd.Barcode == "" {
+			continue
+		}
+		bc, err := s.barcodes.Resolve(sd.Barcode)
+		if err != nil {
+			return err
+		}
+		if sd.ProductId != "" && !strings.EqualFold(sd.ProductId, bc.ProductId) {
+			return apperr.Validation("line %d: barcode %s is product %s, not %s", i+1, bc.Code, bc.ProductId, sd.ProductId)
+		}
+		sd.Barcode = bc.Code
+		sd.ProductId = bc.ProductId
+		sd.ProductName = bc.Product.ProductName
+		sd.Uom = bc.Unit.UnitName
+		if bc.UnitId != bc.Product.UomId && sd.DerivedQty == 0 {
+			sd.DerivedQty, sd.Qty = sd.Qty, 0
+		}
+	}
+	return nil
+}
+
+// settlePayment works out how much of the priced sale is paid at the counter;
+// the rest is owed on the customer's account.
+func settlePayment(sale *models.Sale) error {
+	sale.PaymentType = models.PaymentType(strings.ToUpper(string(sale.PaymentType)))
+	switch sale.PaymentType {
+	case "", models.PaymentCash:
+		if sale.PaidAmount != 0 && sale.PaidAmount != sale.GrandTotal {
+			return fmt.Errorf("%w: a cash sale is paid in full, paid amount %d does not match grand total %d", pricing.ErrInvalidInvoice, sale.PaidAmount, sale.GrandTotal)
+		}
+		sale.PaymentType = models.PaymentCash
+		sale.PaidAmount = sale.GrandTotal
+	case models.PaymentCredit:
+		if sale.PaidAmount != 0 {
+			return fmt.Errorf("%w: a credit sale takes no payment, use PARTIAL instead", pricing.ErrInvalidInvoice)
+		}
+	case models.PaymentPartial:
+		if sale.PaidAmount <= 0 || sale.PaidAmount >= sale.GrandTotal {
+			return fmt.Errorf("%w: paid amount of a partially paid sale must be between 0 and %d", pricing.ErrInvalidInvoice, sale.GrandTotal)
+		}
+	default:
+		return fmt.Errorf("%w: unknown payment type %s", pricing.ErrInvalidInvoice, sale.PaymentType)
+	}
+	return nil
+}
+
+func (s *SaleService)GetAllService(spec query.Spec) ([]models.Sale, query.Page, error){
+	return s.repo.GetAll(spec)
 }
 
 func (s *SaleService)GetById(id string) (*models.Sale, error){
 	return s.repo.GetById(id)
-}
\ No newline at end of file
+}
+
+func (s *SaleService)VoidService(id string, remark string) (*models.Sale, error){
+	return s.repo.Void(id, remark)
+}
+
+func (s *SaleService)ReturnService(id string, saleReturn *models.SaleReturn) (*models.SaleReturn, error){
+	return s.repo.CreateReturn(id, saleReturn)
+}
@@ -0,0 +1,319 @@
+package stockmovement
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Movement is one signed change to a product's stock caused by a source
+// document (sale line, purchase line, inventory record, adjustment ...).
+type Movement struct {
+	ProductId   string
+	LocationId  uint   // where the stock is, zero means the default location
+	Uom         string // unit name, empty means the product's base unit
+	Qty         int    // positive puts stock in, negative takes it out
+	TranType    string // DEBIT / CREDIT / ADJUSTMENT / TRANSFER, derived from the sign when empty
+	ReferenceNo string // source document reference, e.g. "<saleId>-<saleDetailId>"
+	Remark      string
+	// UnitCost is what one Uom of stock coming in cost, set by goods
+	// receipts only. It moves the product's average cost; every other
+	// movement is booked at the average as it is.
+	UnitCost int64
+}
+
+type StockMovementServiceInterface interface {
+	Apply(tx *gorm.DB, movement Movement) (*models.ItemTransaction, error)
+	ResolveLocation(tx *gorm.DB, locationId uint) (*models.Location, error)
+	ResolveUnit(tx *gorm.DB, productId string, uom string) (string, error)
+}
+
+type StockMovementService struct{}
+
+//! constructor must be return the Interface, NOT struct, if not, google wire generate fail
+
+func NewStockMovementService() StockMovementServiceInterface {
+	log.Println(util.White + "StockMovementService constructor is called" + util.Reset)
+	return &StockMovementService{}
+}
+
+// ResolveLocation returns the location a document names, or the default
+// location when it names none (zero).
+func (s *StockMovementService) ResolveLocation(tx *gorm.DB, locationId uint) (*models.Location, error) {
+	var location models.Location
+	var err error
+	if locationId == 0 {
+		err = tx.First(&location, "is_default").Error
+	} else {
+		err = tx.First(&location, "id = ?", locationId).Error
+	}
+	if err == gorm.ErrRecordNotFound {
+		if locationId == 0 {
+			return nil, apperr.NotFound("no default location, name one with locationId")
+		}
+		return nil, apperr.NotFound("location %d not found", locationId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &location, nil
+}
+
+// ResolveUnit returns the product's base or derived unit that uom names, the
+// base unit when uom is empty. It is the unit check Apply makes, for
+// documents whose stock moves later.
+func (s *StockMovementService) ResolveUnit(tx *gorm.DB, productId string, uom string) (string, error) {
+	unitConv, err := FindUnitConversion(tx, productId)
+	if err != nil {
+		return "", err
+	}
+	return unitOf(unitConv, productId, uom)
+}
+
+// Apply converts the movement to the product's base/derived unit, refuses to
+// take stock below zero, updates the ProductStock of the location and writes
+// the ItemTransaction at the product's moving-average cost. Everything runs
+// on tx, so the caller's document and the stock change commit or roll back
+// together.
+func (s *StockMovementService) Apply(tx *gorm.DB, movement Movement) (*models.ItemTransaction, error) {
+	if movement.Qty == 0 {
+		return nil, apperr.Validation("movement quantity for product %s must not be zero", movement.ProductId)
+	}
+
+	location, err := s.ResolveLocation(tx, movement.LocationId)
+	if err != nil {
+		return nil, err
+	}
+
+	unitConv, err := FindUnitConversion(tx, movement.ProductId)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt := movement.Qty > 0 && movement.UnitCost > 0
+	cost, err := averageCost(tx, movement.ProductId, receipt)
+	if err != nil {
+		return nil, err
+	}
+
+	productStock, err := lockStock(tx, movement.ProductId, location, unitConv, movement.Qty > 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// the stock on hand before the receipt, read before productStock is saved
+	var onHand int64
+	if receipt {
+		if onHand, err = derivedOnHand(tx, movement.ProductId, unitConv); err != nil {
+			return nil, err
+		}
+	}
+
+	unit, err := applyToStock(productStock, unitConv, movement.ProductId, movement.Uom, movement.Qty)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Save(productStock).Error; err != nil {
+		return nil, err
+	}
+
+	if receipt {
+		// counted in derived units so a receipt of FEET and the stock on hand
+		// add up, priced per base unit
+		factor := int64(unitConv.Factor)
+		in, inCost := int64(movement.Qty)*factor, movement.UnitCost
+		if unitConv.DeriveUnit != "" && strings.EqualFold(unit, unitConv.DeriveUnit) {
+			in, inCost = int64(movement.Qty), movement.UnitCost*factor
+		}
+		cost = movingAverage(onHand, cost, in, inCost)
+		if err := tx.Model(&models.Product{}).Where("id = ?", movement.ProductId).Update("avg_cost", cost).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	trx := models.ItemTransaction{
+		ProductId:   movement.ProductId,
+		LocationId:  location.ID,
+		ReferenceNo: movement.ReferenceNo,
+		Uom:         unit,
+		TranType:    movement.TranType,
+		Remark:      movement.Remark,
+		UnitCost:    cost,
+	}
+	if movement.Qty > 0 {
+		trx.InQty = movement.Qty
+	} else {
+		trx.OutQty = -movement.Qty
+	}
+	if trx.TranType == "" {
+		trx.TranType = "DEBIT"
+		if movement.Qty < 0 {
+			trx.TranType = "CREDIT"
+		}
+	}
+	if err := tx.Create(&trx).Error; err != nil {
+		return nil, err
+	}
+
+	return &trx, nil
+}
+
+// averageCost returns the moving-average cost of one base unit of the
+// product, its buy price until a receipt sets one. A receipt locks the
+// product, so two receipts of the same product average one after the other.
+func averageCost(tx *gorm.DB, productId string, lock bool) (int64, error) {
+	var product models.Product
+	db := tx.Select("id", "avg_cost", "buy_price")
+	if lock {
+		db = db.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	if err := db.First(&product, "id = ?", productId).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, apperr.NotFound("product %s not found", productId)
+		}
+		return 0, err
+	}
+	if product.AvgCost > 0 {
+		return product.AvgCost, nil
+	}
+	return product.BuyPrice, nil
+}
+
+// derivedOnHand is the product's stock over all locations in derived units,
+// base units times the factor plus loose derived units.
+func derivedOnHand(tx *gorm.DB, productId string, unitConv *models.UnitConversion) (int64, error) {
+	var total struct {
+		BaseQty    int64
+		DerivedQty int64
+	}
+	err := tx.Model(&models.ProductStock{}).
+		Select("COALESCE(SUM(base_qty), 0) AS base_qty, COALESCE(SUM(derived_qty), 0) AS derived_qty").
+		Where("product_id = ?", productId).
+		Scan(&total).Error
+	return total.BaseQty*int64(unitConv.Factor) + total.DerivedQty, err
+}
+
+// movingAverage is the cost of one base unit after in units costing inCost
+// each base unit come in on top of onHand units at cost; both quantities are
+// in the same unit. Stock below zero carries no cost of its own.
+func movingAverage(onHand int64, cost int64, in int64, inCost int64) int64 {
+	if onHand < 0 {
+		onHand = 0
+	}
+	total := onHand + in
+	if total <= 0 {
+		return cost
+	}
+	return (onHand*cost + in*inCost + total/2) / total
+}
+
+// lockStock returns the stock row of the product at the location and holds
+// a row lock on it. SELECT ... FOR UPDATE: concurrent movements of the same
+// product and location queue up behind this row until the caller's
+// transaction ends, so two cashiers selling the last unit cannot both see it
+// in stock. Stock coming in to a location the product has never been at
+// starts a row there.
+func lockStock(tx *gorm.DB, productId string, location *models.Location, unitConv *models.UnitConversion, incoming bool) (*models.ProductStock, error) {
+	var productStock models.ProductStock
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&productStock, "product_id = ? AND location_id = ?", productId, location.ID).Error
+	if err != gorm.ErrRecordNotFound {
+		return &productStock, err
+	}
+	if !incoming {
+		return nil, apperr.InsufficientStock("no stock of product %s at location %s", productId, location.Code)
+	}
+
+	// a concurrent movement may start the same row, whoever comes second
+	// waits for it and then locks it below
+	err = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.ProductStock{
+		ProductId:    productId,
+		LocationId:   location.ID,
+		BaseUnitId:   unitConv.BaseUnitId,
+		DeriveUnitId: unitConv.DeriveUnitId,
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&productStock, "product_id = ? AND location_id = ?", productId, location.ID).Error
+	return &productStock, err
+}
+
+// FindUnitConversion returns the product's unit conversion, the one every
+// movement is booked by. Products without one only have a base unit, which is
+// taken from the product itself.
+func FindUnitConversion(tx *gorm.DB, productId string) (*models.UnitConversion, error) {
+	var unitConv models.UnitConversion
+	err := tx.First(&unitConv, "product_id = ?", productId).Error
+	if err == nil {
+		return &unitConv, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	var product models.Product
+	if err := tx.First(&product, "id = ?", productId).Error; err != nil {
+		return nil, apperr.NotFound("unit conversion not found for product %s", productId)
+	}
+	return &models.UnitConversion{ProductId: productId, BaseUnit: product.Uom, BaseUnitId: int(product.UomId), Factor: 1}, nil
+}
+
+// unitOf returns the unit of the product uom names, the base unit when uom
+// is empty.
+func unitOf(unitConv *models.UnitConversion, productId string, uom string) (string, error) {
+	switch {
+	case uom == "" || strings.EqualFold(uom, unitConv.BaseUnit):
+		return unitConv.BaseUnit, nil
+	case unitConv.DeriveUnit != "" && strings.EqualFold(uom, unitConv.DeriveUnit):
+		return unitConv.DeriveUnit, nil
+	}
+	return "", apperr.Validation("invalid unit %s for product %s (expected %s or %s)", uom, productId, unitConv.BaseUnit, unitConv.DeriveUnit)
+}
+
+// applyToStock changes stock in place and returns the unit name the movement
+// was booked in. Taking out derived units breaks whole base units when loose
+// derived stock runs short.
+func applyToStock(productStock *models.ProductStock, unitConv *models.UnitConversion, productId string, uom string, qty int) (string, error) {
+	unit, err := unitOf(unitConv, productId, uom)
+	if err != nil {
+		return "", err
+	}
+
+	switch unit {
+	case unitConv.BaseUnit:
+		if qty < 0 && -qty > productStock.BaseQty {
+			return "", apperr.InsufficientStock("not enough stock: base unit of %s. requested %d, available %d", productId, -qty, productStock.BaseQty)
+		}
+		productStock.BaseQty += qty
+		return unitConv.BaseUnit, nil
+
+	default:
+		if qty > 0 || -qty <= productStock.DerivedQty {
+			productStock.DerivedQty += qty
+			return unitConv.DeriveUnit, nil
+		}
+
+		factor := unitConv.Factor
+		if factor < 1 {
+			return "", fmt.Errorf("invalid conversion factor %d for product %s", factor, productId)
+		}
+		shortage := -qty - productStock.DerivedQty
+		baseToConvert := (shortage + factor - 1) / factor // round up
+		if baseToConvert > productStock.BaseQty {
+			return "", apperr.InsufficientStock("not enough stock for derived unit of product %s: need %d %s → convert %d base units, only %d available",
+				productId, -qty, unitConv.DeriveUnit, baseToConvert, productStock.BaseQty)
+		}
+		productStock.BaseQty -= baseToConvert
+		productStock.DerivedQty = baseToConvert*factor - shortage
+		return unitConv.DeriveUnit, nil
+	}
+}
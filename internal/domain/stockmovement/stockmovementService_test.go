@@ -0,0 +1,81 @@
+package stockmovement
+
+import (
+	"testing"
+
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyToStock(t *testing.T) {
+	unitConv := &models.UnitConversion{ProductId: "P001", BaseUnit: "EACH", DeriveUnit: "FEET", Factor: 10}
+
+	tests := []struct {
+		name        string
+		uom         string
+		qty         int
+		wantUnit    string
+		wantBase    int
+		wantDerived int
+		wantErr     bool
+	}{
+		{name: "receive base", uom: "EACH", qty: 3, wantUnit: "EACH", wantBase: 8, wantDerived: 4},
+		{name: "empty unit is base", uom: "", qty: -2, wantUnit: "EACH", wantBase: 3, wantDerived: 4},
+		{name: "unit match ignores case", uom: "feet", qty: 6, wantUnit: "FEET", wantBase: 5, wantDerived: 10},
+		{name: "sell loose derived", uom: "FEET", qty: -4, wantUnit: "FEET", wantBase: 5, wantDerived: 0},
+		{name: "sell derived breaks base", uom: "FEET", qty: -15, wantUnit: "FEET", wantBase: 3, wantDerived: 9},
+		{name: "base below zero", uom: "EACH", qty: -6, wantErr: true},
+		{name: "derived below zero", uom: "FEET", qty: -55, wantErr: true},
+		{name: "unknown unit", uom: "PACK", qty: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stock := &models.ProductStock{ProductId: "P001", BaseQty: 5, DerivedQty: 4}
+
+			unit, err := applyToStock(stock, unitConv, "P001", tt.uom, tt.qty)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, 5, stock.BaseQty)
+				assert.Equal(t, 4, stock.DerivedQty)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantUnit, unit)
+			assert.Equal(t, tt.wantBase, stock.BaseQty)
+			assert.Equal(t, tt.wantDerived, stock.DerivedQty)
+		})
+	}
+}
+
+func TestUnitOf(t *testing.T) {
+	unitConv := &models.UnitConversion{ProductId: "P001", BaseUnit: "EACH", DeriveUnit: "FEET", Factor: 10}
+
+	unit, err := unitOf(unitConv, "P001", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "EACH", unit)
+	unit, err = unitOf(unitConv, "P001", "feet")
+	assert.NoError(t, err)
+	assert.Equal(t, "FEET", unit)
+	_, err = unitOf(unitConv, "P001", "PACK")
+	assert.Error(t, err)
+
+	// no derived unit, an empty name must not match it
+	_, err = unitOf(&models.UnitConversion{ProductId: "P002", BaseUnit: "EACH"}, "P002", "BOX")
+	assert.Error(t, err)
+}
+
+func TestMovingAverage(t *testing.T) {
+	// 10 on hand at 100, 10 more at 130
+	assert.Equal(t, int64(115), movingAverage(10, 100, 10, 130))
+	// 2 at 100 and 1 at 101 is 100.33
+	assert.Equal(t, int64(100), movingAverage(2, 100, 1, 101))
+	// 1 at 100 and 1 at 101 rounds half up
+	assert.Equal(t, int64(101), movingAverage(1, 100, 1, 101))
+	// nothing or less than nothing on hand takes the receipt's cost
+	assert.Equal(t, int64(130), movingAverage(0, 100, 5, 130))
+	assert.Equal(t, int64(130), movingAverage(-3, 100, 5, 130))
+	// 5 EACH of 12 FEET at 1200 and 6 FEET at 110 a foot, 1320 an EACH
+	assert.Equal(t, int64(1211), movingAverage(60, 1200, 6, 1320))
+}
@@ -0,0 +1,47 @@
+package stocktake
+
+type OpenStockTakeRequestDTO struct {
+	LocationId uint     `json:"locationId"` // the default location when empty
+	ProductIds []string `json:"productIds"` // every product stocked at the location when empty
+	Remark     string   `json:"remark"`
+}
+
+type EnterCountsRequestDTO struct {
+	Counts []CountItemDTO `json:"counts"`
+}
+
+// CountItemDTO is what a counter found of one product, in base and derived
+// units.
+type CountItemDTO struct {
+	ProductId  string `json:"productId"`
+	BaseQty    int    `json:"baseQty"`
+	DerivedQty int    `json:"derivedQty"`
+}
+
+// VarianceDTO is the difference between the counted and expected stock of a
+// line; positive quantities were found over, negative ones are missing.
+type VarianceDTO struct {
+	LineId             uint   `json:"lineId"`
+	ProductId          string `json:"productId"`
+	ExpectedBaseQty    int    `json:"expectedBaseQty"`
+	ExpectedDerivedQty int    `json:"expectedDerivedQty"`
+	CountedBaseQty     int    `json:"countedBaseQty"`
+	CountedDerivedQty  int    `json:"countedDerivedQty"`
+	BaseQty            int    `json:"baseQty"`
+	DerivedQty         int    `json:"derivedQty"`
+	BuyPrice           int64  `json:"buyPrice"`
+	Value              int64  `json:"value"` // at BuyPrice, negative is a loss
+}
+
+type VarianceReportDTO struct {
+	StockTakeId string        `json:"stockTakeId"`
+	LocationId  uint          `json:"locationId"`
+	Status      string        `json:"status"`
+	Lines       int           `json:"lines"`
+	Counted     int           `json:"counted"`
+	Uncounted   int           `json:"uncounted"` // left alone when posting
+	Gain        int64         `json:"gain"`
+	Loss        int64         `json:"loss"`
+	Net         int64         `json:"net"`
+	Variances   []VarianceDTO `json:"variances"`
+}
@@ -0,0 +1,209 @@
+package stocktake
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/middleware"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
+)
+
+type StockTakeHandler struct {
+	svc StockTakeServiceInterface
+}
+
+func NewStockTakeHandler(svc StockTakeServiceInterface) *StockTakeHandler {
+	log.Println(util.Yellow + "StockTakeHandler constructor is called" + util.Reset)
+	return &StockTakeHandler{svc: svc}
+}
+
+// OpenStockTake godoc
+//
+//	@Summary		Open a stock take
+//	@Description	Start a physical count of a location. The expected stock of every product to count is snapshotted; only one count can be open per location.
+//	@Tags			StockTakes
+//	@Accept			json
+//	@Produce		json
+//	@Param			stockTake	body		OpenStockTakeRequestDTO	true	"Stock take"
+//	@Success		201			{object}	models.StockTake
+//	@Failure		400			{object}	apperr.Response
+//	@Failure		401			{object}	apperr.Response
+//	@Failure		403			{object}	apperr.Response
+//	@Failure		404			{object}	apperr.Response
+//	@Failure		409			{object}	apperr.Response
+//	@Failure		500			{object}	apperr.Response
+//	@Router			/api/stocktakes [post]
+//	@Security		Bearer
+func (h *StockTakeHandler) OpenStockTake(c *fiber.Ctx) error {
+	input := new(OpenStockTakeRequestDTO)
+	if err := c.BodyParser(input); err != nil {
+		return apperr.Validation("invalid JSON format")
+	}
+
+	stockTake, err := h.svc.Open(&models.StockTake{
+		LocationId: input.LocationId,
+		Remark:     input.Remark,
+	}, input.ProductIds)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "stock take " + stockTake.ID + " is open with " + strconv.Itoa(len(stockTake.StockTakeLines)) + " products to count",
+		"data":    stockTake,
+	})
+}
+
+// GetAllStockTakes godoc
+//
+//	@Summary		Fetch all stock takes
+//	@Description	Fetch all stock takes, without their lines
+//	@Tags			StockTakes
+//	@Accept			json
+//	@Produce		json
+//	@Param			page		query		int		false	"page number, from 1"
+//	@Param			limit		query		int		false	"rows per page, 20 by default and 100 at most"
+//	@Param			sort		query		string	false	"comma separated fields of id, createdAt; prefix - for descending"
+//	@Param			status		query		string	false	"OPEN or POSTED"
+//	@Param			locationId	query		int		false	"only the stock takes of this location"
+//	@Success		200			{array}		models.StockTake
+//	@Failure		400			{object}	apperr.Response
+//	@Failure		401			{object}	apperr.Response
+//	@Failure		500			{object}	apperr.Response
+//	@Router			/api/stocktakes [get]
+//	@Security		Bearer
+func (h *StockTakeHandler) GetAllStockTakes(c *fiber.Ctx) error {
+	spec, err := query.Parse(c.Queries(), stockTakeList)
+	if err != nil {
+		return err
+	}
+	stockTakes, page, err := h.svc.GetAll(spec)
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": strconv.Itoa(len(stockTakes)) + " records found",
+		"data":    stockTakes,
+		"meta":    page,
+	})
+}
+
+// GetStockTakeById godoc
+//
+//	@Summary		Fetch individual stock take by Id
+//	@Description	Fetch a stock take with its lines and the counts of every counter
+//	@Tags			StockTakes
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"stock take Id"
+//	@Success		200	{object}	models.StockTake
+//	@Failure		401	{object}	apperr.Response
+//	@Failure		404	{object}	apperr.Response
+//	@Failure		500	{object}	apperr.Response
+//	@Router			/api/stocktakes/{id} [get]
+//	@Security		Bearer
+func (h *StockTakeHandler) GetStockTakeById(c *fiber.Ctx) error {
+	stockTake, err := h.svc.GetById(c.Params("id"))
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "Record found",
+		"data":    stockTake,
+	})
+}
+
+// EnterCounts godoc
+//
+//	@Summary		Enter counted quantities
+//	@Description	Record what the signed-in user counted. Counting a product again replaces the user's earlier count of it; the counts of all users are added up.
+//	@Tags			StockTakes
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"stock take Id"
+//	@Param			counts	body		EnterCountsRequestDTO	true	"Counts"
+//	@Success		200		{object}	models.StockTake
+//	@Failure		400		{object}	apperr.Response
+//	@Failure		401		{object}	apperr.Response
+//	@Failure		404		{object}	apperr.Response
+//	@Failure		409		{object}	apperr.Response
+//	@Failure		500		{object}	apperr.Response
+//	@Router			/api/stocktakes/{id}/counts [post]
+//	@Security		Bearer
+func (h *StockTakeHandler) EnterCounts(c *fiber.Ctx) error {
+	input := new(EnterCountsRequestDTO)
+	if err := c.BodyParser(input); err != nil {
+		return apperr.Validation("invalid JSON format")
+	}
+
+	stockTake, err := h.svc.EnterCounts(c.Params("id"), middleware.UserName(c), input.Counts)
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": strconv.Itoa(len(input.Counts)) + " counts recorded",
+		"data":    stockTake,
+	})
+}
+
+// GetStockTakeVariances godoc
+//
+//	@Summary		Review the variances of a stock take
+//	@Description	List the counted products whose stock differs from the snapshot, valued at the buy price
+//	@Tags			StockTakes
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"stock take Id"
+//	@Success		200	{object}	VarianceReportDTO
+//	@Failure		401	{object}	apperr.Response
+//	@Failure		404	{object}	apperr.Response
+//	@Failure		500	{object}	apperr.Response
+//	@Router			/api/stocktakes/{id}/variances [get]
+//	@Security		Bearer
+func (h *StockTakeHandler) GetStockTakeVariances(c *fiber.Ctx) error {
+	report, err := h.svc.Variances(c.Params("id"))
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": strconv.Itoa(len(report.Variances)) + " variances found",
+		"data":    report,
+	})
+}
+
+// PostStockTake godoc
+//
+//	@Summary		Post a stock take
+//	@Description	Book every variance of the counted products as an ADJUSTMENT and lock the stock take. Products nobody counted keep their stock.
+//	@Tags			StockTakes
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"stock take Id"
+//	@Success		200	{object}	models.StockTake
+//	@Failure		401	{object}	apperr.Response
+//	@Failure		403	{object}	apperr.Response
+//	@Failure		404	{object}	apperr.Response
+//	@Failure		409	{object}	apperr.Response
+//	@Failure		500	{object}	apperr.Response
+//	@Router			/api/stocktakes/{id}/post [post]
+//	@Security		Bearer
+func (h *StockTakeHandler) PostStockTake(c *fiber.Ctx) error {
+	stockTake, err := h.svc.Post(c.Params("id"))
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "stock take " + stockTake.ID + " has been posted",
+		"data":    stockTake,
+	})
+}
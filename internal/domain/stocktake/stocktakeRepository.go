@@ -0,0 +1,337 @@
+package stocktake
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/docnumber"
+	"github.com/sankangkin/di-rest-api/internal/domain/stockmovement"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockTakeRepositoryInterface interface {
+	Open(stockTake *models.StockTake, productIds []string) (*models.StockTake, error)
+	GetAll(spec query.Spec) ([]models.StockTake, query.Page, error)
+	GetById(id string) (*models.StockTake, error)
+	EnterCounts(id string, countedBy string, counts []CountItemDTO) (*models.StockTake, error)
+	Post(id string) (*models.StockTake, error)
+}
+
+type StockTakeRepository struct {
+	db    *gorm.DB
+	stock stockmovement.StockMovementServiceInterface
+	docNo docnumber.DocNumberServiceInterface
+}
+
+func NewStockTakeRepository(db *gorm.DB, stock stockmovement.StockMovementServiceInterface, docNo docnumber.DocNumberServiceInterface) StockTakeRepositoryInterface {
+	log.Println(util.Yellow + "StockTakeRepository constructor is called" + util.Reset)
+	return &StockTakeRepository{db: db, stock: stock, docNo: docNo}
+}
+
+// snapshotSelect reads the expected stock of products at a location together
+// with their conversion factor and buy price; products never stocked there
+// expect nothing.
+const snapshotSelect = `p.id AS product_id,
+	COALESCE(s.base_qty, 0) AS expected_base_qty,
+	COALESCE(s.derived_qty, 0) AS expected_derived_qty,
+	COALESCE((SELECT uc.factor FROM unit_conversions uc WHERE uc.product_id = p.id AND uc.deleted_at IS NULL ORDER BY uc.id LIMIT 1), 1) AS factor,
+	p.buy_price`
+
+func snapshot(tx *gorm.DB, locationId uint) *gorm.DB {
+	return tx.Table("products AS p").
+		Select(snapshotSelect).
+		Joins("LEFT JOIN product_stocks s ON s.product_id = p.id AND s.location_id = ? AND s.deleted_at IS NULL", locationId).
+		Where("p.deleted_at IS NULL")
+}
+
+// Open starts a count at the location and snapshots the expected stock of
+// the products to count, all of the location's when productIds is empty.
+func (r *StockTakeRepository) Open(input *models.StockTake, productIds []string) (*models.StockTake, error) {
+	stockTake := models.StockTake{
+		LocationId: input.LocationId,
+		Remark:     input.Remark,
+		Status:     models.StockTakeOpen,
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		location, err := r.stock.ResolveLocation(tx, stockTake.LocationId)
+		if err != nil {
+			return err
+		}
+		stockTake.LocationId = location.ID
+
+		var open models.StockTake
+		err = tx.Where("location_id = ? AND status = ?", location.ID, models.StockTakeOpen).First(&open).Error
+		if err == nil {
+			return apperr.Conflict("stock take %s is still open at location %s", open.ID, location.Code)
+		}
+		if err != gorm.ErrRecordNotFound {
+			return err
+		}
+
+		var lines []models.StockTakeLine
+		q := snapshot(tx, location.ID)
+		if len(productIds) > 0 {
+			q = q.Where("p.id IN ?", productIds)
+		} else {
+			q = q.Where("s.id IS NOT NULL")
+		}
+		if err := q.Order("p.id").Scan(&lines).Error; err != nil {
+			return err
+		}
+		if missing := missingProducts(productIds, lines); len(missing) > 0 {
+			return apperr.NotFound("product %s not found", strings.Join(missing, ", "))
+		}
+		if len(lines) == 0 {
+			return apperr.Validation("there is no stock to count at location %s", location.Code)
+		}
+
+		// the stock take number is allocated on tx, a failed open gives it back
+		id, err := r.docNo.Next(tx, docnumber.DocTypeStockTake)
+		if err != nil {
+			return err
+		}
+		stockTake.ID = id
+		if err := tx.Create(&stockTake).Error; err != nil {
+			return err
+		}
+		for i := range lines {
+			lines[i].StockTakeId = stockTake.ID
+		}
+		return tx.CreateInBatches(&lines, 500).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetById(stockTake.ID)
+}
+
+func missingProducts(productIds []string, lines []models.StockTakeLine) []string {
+	found := make(map[string]bool, len(lines))
+	for _, l := range lines {
+		found[l.ProductId] = true
+	}
+	var missing []string
+	for _, id := range productIds {
+		if !found[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+// stockTakeList is what GET /stocktakes sorts and filters on.
+var stockTakeList = query.Resource{
+	Sort: map[string]string{
+		"id":        "id",
+		"createdAt": "created_at",
+	},
+	DefaultSort: "-createdAt",
+	Filters: map[string]query.Filter{
+		"status":     {Column: "status", Kind: query.Code},
+		"locationId": {Column: "location_id", Kind: query.Uint},
+	},
+	Key: "id",
+}
+
+// GetAll loads a page of stock takes; the lines are left to GetById.
+func (r *StockTakeRepository) GetAll(spec query.Spec) ([]models.StockTake, query.Page, error) {
+	stockTakes := []models.StockTake{}
+	page, err := query.Find(r.db.Model(&models.StockTake{}), spec, &stockTakes)
+	return stockTakes, page, err
+}
+
+func (r *StockTakeRepository) GetById(id string) (*models.StockTake, error) {
+	var stockTake models.StockTake
+	err := r.db.
+		Preload("StockTakeLines", func(db *gorm.DB) *gorm.DB { return db.Order("product_id") }).
+		Preload("StockTakeLines.StockTakeCounts").
+		First(&stockTake, "id = ?", strings.ToUpper(id)).Error
+	if err != nil {
+		return nil, err
+	}
+	return &stockTake, nil
+}
+
+// lockOpen loads the stock take with its lines and holds a row lock on it, so
+// counts and posting of one session queue up; a posted session is locked for
+// good.
+func lockOpen(tx *gorm.DB, id string, stockTake *models.StockTake) error {
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(stockTake, "id = ?", strings.ToUpper(id)).Error
+	if err != nil {
+		return err
+	}
+	if stockTake.Status != models.StockTakeOpen {
+		return apperr.Conflict("stock take %s is %s and can no longer change", stockTake.ID, strings.ToLower(stockTake.Status))
+	}
+	return tx.Where("stock_take_id = ?", stockTake.ID).Find(&stockTake.StockTakeLines).Error
+}
+
+// EnterCounts records what countedBy found. A product counted again by the
+// same counter replaces the earlier count; the line's counted quantities are
+// the sum over all counters. A product that turns up without being expected
+// joins the count with nothing expected.
+func (r *StockTakeRepository) EnterCounts(id string, countedBy string, counts []CountItemDTO) (*models.StockTake, error) {
+	var stockTake models.StockTake
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockOpen(tx, id, &stockTake); err != nil {
+			return err
+		}
+		lines := make(map[string]*models.StockTakeLine, len(stockTake.StockTakeLines))
+		for i := range stockTake.StockTakeLines {
+			lines[stockTake.StockTakeLines[i].ProductId] = &stockTake.StockTakeLines[i]
+		}
+
+		for _, item := range counts {
+			line, ok := lines[item.ProductId]
+			if !ok {
+				var err error
+				if line, err = addLine(tx, &stockTake, item.ProductId); err != nil {
+					return err
+				}
+				lines[item.ProductId] = line
+			}
+			if item.DerivedQty != 0 && line.Factor == 1 {
+				return apperr.Validation("product %s has no derived unit to count", item.ProductId)
+			}
+
+			count := models.StockTakeCount{
+				StockTakeLineId: line.ID,
+				CountedBy:       countedBy,
+				BaseQty:         item.BaseQty,
+				DerivedQty:      item.DerivedQty,
+			}
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "stock_take_line_id"}, {Name: "counted_by"}},
+				DoUpdates: clause.AssignmentColumns([]string{"base_qty", "derived_qty", "updated_at"}),
+			}).Create(&count).Error
+			if err != nil {
+				return err
+			}
+
+			var total struct{ BaseQty, DerivedQty int }
+			err = tx.Model(&models.StockTakeCount{}).
+				Select("COALESCE(SUM(base_qty), 0) AS base_qty, COALESCE(SUM(derived_qty), 0) AS derived_qty").
+				Where("stock_take_line_id = ?", line.ID).
+				Scan(&total).Error
+			if err != nil {
+				return err
+			}
+			line.CountedBaseQty = total.BaseQty
+			line.CountedDerivedQty = total.DerivedQty
+			line.Counted = true
+			err = tx.Model(line).Updates(map[string]interface{}{
+				"counted_base_qty":    line.CountedBaseQty,
+				"counted_derived_qty": line.CountedDerivedQty,
+				"counted":             true,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetById(stockTake.ID)
+}
+
+func addLine(tx *gorm.DB, stockTake *models.StockTake, productId string) (*models.StockTakeLine, error) {
+	var line models.StockTakeLine
+	err := snapshot(tx, stockTake.LocationId).Where("p.id = ?", productId).Scan(&line).Error
+	if err != nil {
+		return nil, err
+	}
+	if line.ProductId == "" {
+		return nil, apperr.NotFound("product %s not found", productId)
+	}
+	line.StockTakeId = stockTake.ID
+	if err := tx.Create(&line).Error; err != nil {
+		return nil, err
+	}
+	return &line, nil
+}
+
+// Post books the variance of every counted line as an ADJUSTMENT movement
+// and locks the session. Lines nobody counted are left alone.
+func (r *StockTakeRepository) Post(id string) (*models.StockTake, error) {
+	var stockTake models.StockTake
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockOpen(tx, id, &stockTake); err != nil {
+			return err
+		}
+
+		for i := range stockTake.StockTakeLines {
+			line := &stockTake.StockTakeLines[i]
+			if !line.Counted {
+				continue
+			}
+			if err := r.postVariance(tx, &stockTake, line); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		return tx.Model(&stockTake).Updates(map[string]interface{}{
+			"status":    models.StockTakePosted,
+			"posted_at": now,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetById(stockTake.ID)
+}
+
+// postVariance books what was counted less what the books said as a single
+// signed ADJUSTMENT movement. Lines that match the books move nothing.
+func (r *StockTakeRepository) postVariance(tx *gorm.DB, stockTake *models.StockTake, line *models.StockTakeLine) error {
+	unitConv, err := stockmovement.FindUnitConversion(tx, line.ProductId)
+	if err != nil {
+		return err
+	}
+	qty, uom := variance(line, unitConv)
+	if qty == 0 {
+		return nil
+	}
+
+	_, err = r.stock.Apply(tx, stockmovement.Movement{
+		ProductId:   line.ProductId,
+		LocationId:  stockTake.LocationId,
+		Uom:         uom,
+		Qty:         qty,
+		TranType:    "ADJUSTMENT",
+		ReferenceNo: stockTake.ID + "-" + strconv.Itoa(int(line.ID)),
+		Remark: fmt.Sprintf("Stock take %s, ProductId %s, counted %d/%d, expected %d/%d (base/derived unit)",
+			stockTake.ID, line.ProductId, line.CountedBaseQty, line.CountedDerivedQty, line.ExpectedBaseQty, line.ExpectedDerivedQty),
+	})
+	return err
+}
+
+// variance is the counted less the expected stock of a line, in base units
+// when it comes to whole base units and in derived units otherwise.
+func variance(line *models.StockTakeLine, unitConv *models.UnitConversion) (int, string) {
+	factor := unitConv.Factor
+	if factor < 1 {
+		factor = 1
+	}
+	qty := (line.CountedBaseQty-line.ExpectedBaseQty)*factor + line.CountedDerivedQty - line.ExpectedDerivedQty
+	if qty%factor == 0 {
+		return qty / factor, unitConv.BaseUnit
+	}
+	return qty, unitConv.DeriveUnit
+}
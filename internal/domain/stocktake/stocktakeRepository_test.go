@@ -0,0 +1,36 @@
+package stocktake
+
+import (
+	"testing"
+
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVariance(t *testing.T) {
+	pipe := &models.UnitConversion{BaseUnit: "PCS", DeriveUnit: "FEET", Factor: 10}
+	elbow := &models.UnitConversion{BaseUnit: "PCS", Factor: 1}
+
+	tests := []struct {
+		name     string
+		unitConv *models.UnitConversion
+		line     models.StockTakeLine
+		qty      int
+		uom      string
+	}{
+		{name: "as booked", unitConv: pipe, line: models.StockTakeLine{ExpectedBaseQty: 4, ExpectedDerivedQty: 3, CountedBaseQty: 4, CountedDerivedQty: 3}, qty: 0, uom: "PCS"},
+		{name: "a piece missing", unitConv: pipe, line: models.StockTakeLine{ExpectedBaseQty: 4, ExpectedDerivedQty: 3, CountedBaseQty: 3, CountedDerivedQty: 3}, qty: -1, uom: "PCS"},
+		{name: "feet missing", unitConv: pipe, line: models.StockTakeLine{ExpectedBaseQty: 4, ExpectedDerivedQty: 3, CountedBaseQty: 3, CountedDerivedQty: 8}, qty: -5, uom: "FEET"},
+		{name: "feet found", unitConv: pipe, line: models.StockTakeLine{ExpectedBaseQty: 4, CountedBaseQty: 4, CountedDerivedQty: 7}, qty: 7, uom: "FEET"},
+		// a piece cut into feet is the same stock
+		{name: "cut, not lost", unitConv: pipe, line: models.StockTakeLine{ExpectedBaseQty: 5, CountedBaseQty: 4, CountedDerivedQty: 10}, qty: 0, uom: "PCS"},
+		{name: "no derived unit", unitConv: elbow, line: models.StockTakeLine{ExpectedBaseQty: 2, CountedBaseQty: 5}, qty: 3, uom: "PCS"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qty, uom := variance(&tt.line, tt.unitConv)
+			assert.Equal(t, tt.qty, qty)
+			assert.Equal(t, tt.uom, uom)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package stocktake
+
+import (
+	"log"
+	"strings"
+
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
+)
+
+type StockTakeServiceInterface interface {
+	Open(stockTake *models.StockTake, productIds []string) (*models.StockTake, error)
+	GetAll(spec query.Spec) ([]models.StockTake, query.Page, error)
+	GetById(id string) (*models.StockTake, error)
+	EnterCounts(id string, countedBy string, counts []CountItemDTO) (*models.StockTake, error)
+	Variances(id string) (*VarianceReportDTO, error)
+	Post(id string) (*models.StockTake, error)
+}
+
+type StockTakeService struct {
+	repo StockTakeRepositoryInterface
+}
+
+func NewStockTakeService(repo StockTakeRepositoryInterface) StockTakeServiceInterface {
+	log.Println(util.Yellow + "StockTakeService constructor is called" + util.Reset)
+	return &StockTakeService{repo: repo}
+}
+
+func normalizeProductId(id string) string {
+	return strings.ToUpper(strings.TrimSpace(id))
+}
+
+func (s *StockTakeService) Open(stockTake *models.StockTake, productIds []string) (*models.StockTake, error) {
+	seen := make(map[string]bool, len(productIds))
+	ids := make([]string, 0, len(productIds))
+	for _, id := range productIds {
+		id = normalizeProductId(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return s.repo.Open(stockTake, ids)
+}
+
+func (s *StockTakeService) GetAll(spec query.Spec) ([]models.StockTake, query.Page, error) {
+	return s.repo.GetAll(spec)
+}
+
+func (s *StockTakeService) GetById(id string) (*models.StockTake, error) {
+	return s.repo.GetById(id)
+}
+
+// validateCounts checks the counts of one counter before the session is
+// touched; a product may appear once per request.
+func validateCounts(countedBy string, counts []CountItemDTO) error {
+	if countedBy == "" {
+		return apperr.Validation("counts need the name of the counter")
+	}
+	if len(counts) == 0 {
+		return apperr.Validation("at least one count is required")
+	}
+	seen := make(map[string]bool, len(counts))
+	for i := range counts {
+		c := &counts[i]
+		c.ProductId = normalizeProductId(c.ProductId)
+		if c.ProductId == "" {
+			return apperr.Validation("count %d needs a productId", i+1)
+		}
+		if seen[c.ProductId] {
+			return apperr.Validation("product %s is counted twice", c.ProductId)
+		}
+		seen[c.ProductId] = true
+		if c.BaseQty < 0 || c.DerivedQty < 0 {
+			return apperr.Validation("counted quantities of product %s must not be negative", c.ProductId)
+		}
+	}
+	return nil
+}
+
+func (s *StockTakeService) EnterCounts(id string, countedBy string, counts []CountItemDTO) (*models.StockTake, error) {
+	countedBy = strings.TrimSpace(countedBy)
+	if err := validateCounts(countedBy, counts); err != nil {
+		return nil, err
+	}
+	return s.repo.EnterCounts(id, countedBy, counts)
+}
+
+func (s *StockTakeService) Variances(id string) (*VarianceReportDTO, error) {
+	stockTake, err := s.repo.GetById(id)
+	if err != nil {
+		return nil, err
+	}
+	return varianceReport(stockTake), nil
+}
+
+func (s *StockTakeService) Post(id string) (*models.StockTake, error) {
+	return s.repo.Post(id)
+}
+
+// varianceReport lists the counted lines whose stock differs from what was
+// expected, with the value of the difference at the buy price.
+func varianceReport(stockTake *models.StockTake) *VarianceReportDTO {
+	report := &VarianceReportDTO{
+		StockTakeId: stockTake.ID,
+		LocationId:  stockTake.LocationId,
+		Status:      stockTake.Status,
+		Lines:       len(stockTake.StockTakeLines),
+		Variances:   []VarianceDTO{},
+	}
+	for _, line := range stockTake.StockTakeLines {
+		if !line.Counted {
+			report.Uncounted++
+			continue
+		}
+		report.Counted++
+
+		v := VarianceDTO{
+			LineId:             line.ID,
+			ProductId:          line.ProductId,
+			ExpectedBaseQty:    line.ExpectedBaseQty,
+			ExpectedDerivedQty: line.ExpectedDerivedQty,
+			CountedBaseQty:     line.CountedBaseQty,
+			CountedDerivedQty:  line.CountedDerivedQty,
+			BaseQty:            line.CountedBaseQty - line.ExpectedBaseQty,
+			DerivedQty:         line.CountedDerivedQty - line.ExpectedDerivedQty,
+			BuyPrice:           line.BuyPrice,
+		}
+		if v.BaseQty == 0 && v.DerivedQty == 0 {
+			continue
+		}
+		v.Value = varianceValue(v.BaseQty, v.DerivedQty, line.Factor, line.BuyPrice)
+
+		if v.Value > 0 {
+			report.Gain += v.Value
+		} else {
+			report.Loss -= v.Value
+		}
+		report.Variances = append(report.Variances, v)
+	}
+	report.Net = report.Gain - report.Loss
+	return report
+}
+
+// varianceValue prices base and derived units at the buy price of a base
+// unit, a derived unit being worth 1/factor of it, rounded half away from
+// zero.
+func varianceValue(baseQty int, derivedQty int, factor int, buyPrice int64) int64 {
+	if factor < 1 {
+		factor = 1
+	}
+	units := int64(baseQty)*int64(factor) + int64(derivedQty)
+	return util.DivRound(units*buyPrice, int64(factor))
+}
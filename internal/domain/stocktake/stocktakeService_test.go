@@ -0,0 +1,65 @@
+package stocktake
+
+import (
+	"testing"
+
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVarianceValue(t *testing.T) {
+	assert.Equal(t, int64(200), varianceValue(2, 0, 1, 100))
+	assert.Equal(t, int64(-100), varianceValue(-1, 0, 10, 100))
+	// 15 FEET at 10 FEET per PCS of 100 is 150
+	assert.Equal(t, int64(150), varianceValue(1, 5, 10, 100))
+	assert.Equal(t, int64(-150), varianceValue(-1, -5, 10, 100))
+	// a third of 100 rounds to 33, two thirds to 67
+	assert.Equal(t, int64(33), varianceValue(0, 1, 3, 100))
+	assert.Equal(t, int64(-67), varianceValue(0, -2, 3, 100))
+}
+
+func TestVarianceReport(t *testing.T) {
+	report := varianceReport(&models.StockTake{
+		ID:     "STK-2026-10-000001",
+		Status: models.StockTakeOpen,
+		StockTakeLines: []models.StockTakeLine{
+			{ID: 1, ProductId: "P001", Factor: 10, BuyPrice: 100, ExpectedBaseQty: 5, CountedBaseQty: 4, CountedDerivedQty: 5, Counted: true},
+			{ID: 2, ProductId: "P002", Factor: 1, BuyPrice: 50, ExpectedBaseQty: 3, CountedBaseQty: 5, Counted: true},
+			{ID: 3, ProductId: "P003", Factor: 1, BuyPrice: 70, ExpectedBaseQty: 2, CountedBaseQty: 2, Counted: true},
+			{ID: 4, ProductId: "P004", Factor: 1, BuyPrice: 70, ExpectedBaseQty: 9},
+		},
+	})
+
+	assert.Equal(t, 4, report.Lines)
+	assert.Equal(t, 3, report.Counted)
+	assert.Equal(t, 1, report.Uncounted)
+	require.Len(t, report.Variances, 2)
+	assert.Equal(t, VarianceDTO{
+		LineId: 1, ProductId: "P001", ExpectedBaseQty: 5, CountedBaseQty: 4, CountedDerivedQty: 5,
+		BaseQty: -1, DerivedQty: 5, BuyPrice: 100, Value: -50,
+	}, report.Variances[0])
+	assert.Equal(t, int64(100), report.Gain)
+	assert.Equal(t, int64(50), report.Loss)
+	assert.Equal(t, int64(50), report.Net)
+}
+
+func TestValidateCounts(t *testing.T) {
+	counts := []CountItemDTO{{ProductId: " p001 ", BaseQty: 3}}
+	require.NoError(t, validateCounts("alice", counts))
+	assert.Equal(t, "P001", counts[0].ProductId)
+
+	for name, tc := range map[string]struct {
+		countedBy string
+		counts    []CountItemDTO
+	}{
+		"no counter":    {"", []CountItemDTO{{ProductId: "P001"}}},
+		"no counts":     {"alice", nil},
+		"no product":    {"alice", []CountItemDTO{{BaseQty: 1}}},
+		"counted twice": {"alice", []CountItemDTO{{ProductId: "P001"}, {ProductId: "p001"}}},
+		"negative":      {"alice", []CountItemDTO{{ProductId: "P001", DerivedQty: -1}}},
+	} {
+		assert.ErrorIs(t, validateCounts(tc.countedBy, tc.counts), apperr.ErrValidation, name)
+	}
+}
@@ -0,0 +1,15 @@
+package stocktransfer
+
+type TransferRequestDTO struct {
+	FromLocationId uint                     `json:"fromLocationId"`
+	ToLocationId   uint                     `json:"toLocationId"`
+	Items          []TransferItemRequestDTO `json:"items"`
+	Remark         string                   `json:"remark"`
+	TransferDate   string                   `json:"transferDate"`
+}
+
+type TransferItemRequestDTO struct {
+	ProductId string `json:"productId"`
+	Qty       int    `json:"qty"`
+	Uom       string `json:"uom"` // the base unit when empty
+}
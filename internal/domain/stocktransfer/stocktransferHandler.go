@@ -0,0 +1,133 @@
+package stocktransfer
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
+)
+
+type StockTransferHandler struct {
+	svc StockTransferServiceInterface
+}
+
+func NewStockTransferHandler(svc StockTransferServiceInterface) *StockTransferHandler {
+	log.Println(util.Cyan + "StockTransferHandler constructor is called" + util.Reset)
+	return &StockTransferHandler{svc: svc}
+}
+
+// CreateTransfer godoc
+//
+//	@Summary		Move stock between locations
+//	@Description	Take every line out of the from location and put it into the to location in one go, booking a TRANSFER ledger row at each side
+//	@Tags			Transfers
+//	@Accept			json
+//	@Produce		json
+//	@Param			transfer	body		TransferRequestDTO	true	"Transfer Data"
+//	@Success		201			{object}	models.StockTransfer
+//	@Failure		400			{object}	apperr.Response
+//	@Failure		401			{object}	apperr.Response
+//	@Failure		403			{object}	apperr.Response
+//	@Failure		404			{object}	apperr.Response
+//	@Failure		409			{object}	apperr.Response
+//	@Failure		500			{object}	apperr.Response
+//	@Router			/api/transfers [post]
+//	@Security		Bearer
+func (h *StockTransferHandler) CreateTransfer(c *fiber.Ctx) error {
+	input := new(TransferRequestDTO)
+	if err := c.BodyParser(input); err != nil {
+		return apperr.Validation("invalid JSON format")
+	}
+
+	transfer := models.StockTransfer{
+		FromLocationId: input.FromLocationId,
+		ToLocationId:   input.ToLocationId,
+		Remark:         input.Remark,
+		TransferDate:   input.TransferDate,
+	}
+	for _, item := range input.Items {
+		transfer.StockTransferDetails = append(transfer.StockTransferDetails, models.StockTransferDetail{
+			ProductId: item.ProductId,
+			Qty:       item.Qty,
+			Uom:       item.Uom,
+		})
+	}
+
+	created, err := h.svc.Create(&transfer)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "stock transfer " + created.ID + " has been created successfully",
+		"data":    created,
+	})
+}
+
+// GetAllTransfers godoc
+//
+//	@Summary		Fetch all stock transfers
+//	@Description	Fetch all stock transfers with their lines
+//	@Tags			Transfers
+//	@Accept			json
+//	@Produce		json
+//	@Param			page			query		int		false	"page number, from 1"
+//	@Param			limit			query		int		false	"rows per page, 20 by default and 100 at most"
+//	@Param			sort			query		string	false	"comma separated fields of id, transferDate, createdAt; prefix - for descending"
+//	@Param			fromLocationId	query		int		false	"only the transfers out of this location"
+//	@Param			toLocationId	query		int		false	"only the transfers into this location"
+//	@Param			from			query		string	false	"first transfer date, yyyy-mm-dd"
+//	@Param			to				query		string	false	"last transfer date, yyyy-mm-dd"
+//	@Success		200				{array}		models.StockTransfer
+//	@Failure		400				{object}	apperr.Response
+//	@Failure		401				{object}	apperr.Response
+//	@Failure		500				{object}	apperr.Response
+//	@Router			/api/transfers [get]
+//	@Security		Bearer
+func (h *StockTransferHandler) GetAllTransfers(c *fiber.Ctx) error {
+	spec, err := query.Parse(c.Queries(), transferList)
+	if err != nil {
+		return err
+	}
+	transfers, page, err := h.svc.GetAll(spec)
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": strconv.Itoa(len(transfers)) + " records found",
+		"data":    transfers,
+		"meta":    page,
+	})
+}
+
+// GetTransferById godoc
+//
+//	@Summary		Fetch individual stock transfer by Id
+//	@Description	Fetch individual stock transfer by Id
+//	@Tags			Transfers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"transfer Id"
+//	@Success		200	{object}	models.StockTransfer
+//	@Failure		401	{object}	apperr.Response
+//	@Failure		404	{object}	apperr.Response
+//	@Failure		500	{object}	apperr.Response
+//	@Router			/api/transfers/{id} [get]
+//	@Security		Bearer
+func (h *StockTransferHandler) GetTransferById(c *fiber.Ctx) error {
+	transfer, err := h.svc.GetById(c.Params("id"))
+	if err != nil {
+		return err
+	}
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "Record found",
+		"data":    transfer,
+	})
+}
@@ -0,0 +1,139 @@
+package stocktransfer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/sankangkin/di-rest-api/internal/domain/docnumber"
+	"github.com/sankangkin/di-rest-api/internal/domain/stockmovement"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
+	"gorm.io/gorm"
+)
+
+type StockTransferRepositoryInterface interface {
+	Create(transfer *models.StockTransfer) (*models.StockTransfer, error)
+	GetAll(spec query.Spec) ([]models.StockTransfer, query.Page, error)
+	GetById(id string) (*models.StockTransfer, error)
+}
+
+type StockTransferRepository struct {
+	db    *gorm.DB
+	stock stockmovement.StockMovementServiceInterface
+	docNo docnumber.DocNumberServiceInterface
+}
+
+func NewStockTransferRepository(db *gorm.DB, stock stockmovement.StockMovementServiceInterface, docNo docnumber.DocNumberServiceInterface) StockTransferRepositoryInterface {
+	log.Println(util.Cyan + "StockTransferRepository constructor is called" + util.Reset)
+	return &StockTransferRepository{db: db, stock: stock, docNo: docNo}
+}
+
+// Create books the transfer document and moves every line out of the from
+// location and into the to location in one transaction, so stock is never
+// seen at both places or at neither.
+func (r *StockTransferRepository) Create(input *models.StockTransfer) (*models.StockTransfer, error) {
+	transfer := models.StockTransfer{
+		FromLocationId:       input.FromLocationId,
+		ToLocationId:         input.ToLocationId,
+		StockTransferDetails: input.StockTransferDetails,
+		Remark:               input.Remark,
+		TransferDate:         input.TransferDate,
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		from, err := r.stock.ResolveLocation(tx, transfer.FromLocationId)
+		if err != nil {
+			return err
+		}
+		to, err := r.stock.ResolveLocation(tx, transfer.ToLocationId)
+		if err != nil {
+			return err
+		}
+
+		// the transfer number is allocated on tx, a failed transfer gives it back
+		id, err := r.docNo.Next(tx, docnumber.DocTypeTransfer)
+		if err != nil {
+			return err
+		}
+		transfer.ID = id
+
+		if err := tx.Create(&transfer).Error; err != nil {
+			return err
+		}
+
+		for i := range transfer.StockTransferDetails {
+			td := &transfer.StockTransferDetails[i]
+			ref := transfer.ID + "-" + strconv.Itoa(int(td.ID))
+			remark := fmt.Sprintf("TransferId %s, ProductId %s, %d %s from %s to %s", transfer.ID, td.ProductId, td.Qty, td.Uom, from.Code, to.Code)
+
+			out, err := r.stock.Apply(tx, stockmovement.Movement{
+				ProductId:   td.ProductId,
+				LocationId:  from.ID,
+				Uom:         td.Uom,
+				Qty:         -td.Qty,
+				TranType:    "TRANSFER",
+				ReferenceNo: ref,
+				Remark:      remark,
+			})
+			if err != nil {
+				return err
+			}
+			// the line keeps the unit name the stock was actually booked in
+			td.Uom = out.Uom
+
+			if _, err := r.stock.Apply(tx, stockmovement.Movement{
+				ProductId:   td.ProductId,
+				LocationId:  to.ID,
+				Uom:         td.Uom,
+				Qty:         td.Qty,
+				TranType:    "TRANSFER",
+				ReferenceNo: ref,
+				Remark:      remark,
+			}); err != nil {
+				return err
+			}
+			if err := tx.Model(td).Update("uom", td.Uom).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetById(transfer.ID)
+}
+
+// transferList is what GET /transfers sorts and filters on.
+var transferList = query.Resource{
+	Sort: map[string]string{
+		"id":           "id",
+		"transferDate": "transfer_date",
+		"createdAt":    "created_at",
+	},
+	DefaultSort: "-createdAt",
+	Filters: map[string]query.Filter{
+		"fromLocationId": {Column: "from_location_id", Kind: query.Uint},
+		"toLocationId":   {Column: "to_location_id", Kind: query.Uint},
+	},
+	Dates: query.Filter{Column: "transfer_date", Kind: query.DateText},
+	Key:   "id",
+}
+
+func (r *StockTransferRepository) GetAll(spec query.Spec) ([]models.StockTransfer, query.Page, error) {
+	transfers := []models.StockTransfer{}
+	page, err := query.Find(r.db.Model(&models.StockTransfer{}), spec, &transfers, "StockTransferDetails")
+	return transfers, page, err
+}
+
+func (r *StockTransferRepository) GetById(id string) (*models.StockTransfer, error) {
+	var transfer models.StockTransfer
+	if err := r.db.Preload("StockTransferDetails").First(&transfer, "id = ?", strings.ToUpper(id)).Error; err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
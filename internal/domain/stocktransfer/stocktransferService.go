@@ -0,0 +1,58 @@
+package stocktransfer
+
+import (
+	"log"
+	"strings"
+
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
+)
+
+type StockTransferServiceInterface interface {
+	Create(transfer *models.StockTransfer) (*models.StockTransfer, error)
+	GetAll(spec query.Spec) ([]models.StockTransfer, query.Page, error)
+	GetById(id string) (*models.StockTransfer, error)
+}
+
+type StockTransferService struct {
+	repo StockTransferRepositoryInterface
+}
+
+func NewStockTransferService(repo StockTransferRepositoryInterface) StockTransferServiceInterface {
+	log.Println(util.Cyan + "StockTransferService constructor is called" + util.Reset)
+	return &StockTransferService{repo: repo}
+}
+
+// validate checks a transfer before any stock is touched: both locations are
+// named and differ, and every line moves a positive quantity.
+func validate(transfer *models.StockTransfer) error {
+	for i := range transfer.StockTransferDetails {
+		td := &transfer.StockTransferDetails[i]
+		td.ProductId = strings.ToUpper(strings.TrimSpace(td.ProductId))
+		td.Uom = strings.TrimSpace(td.Uom)
+	}
+	if err := models.ValidateStruct(transfer); err != nil {
+		return err
+	}
+	if transfer.FromLocationId == transfer.ToLocationId {
+		return apperr.Validation("a transfer needs two different locations, got %d twice", transfer.FromLocationId)
+	}
+	return nil
+}
+
+func (s *StockTransferService) Create(transfer *models.StockTransfer) (*models.StockTransfer, error) {
+	if err := validate(transfer); err != nil {
+		return nil, err
+	}
+	return s.repo.Create(transfer)
+}
+
+func (s *StockTransferService) GetAll(spec query.Spec) ([]models.StockTransfer, query.Page, error) {
+	return s.repo.GetAll(spec)
+}
+
+func (s *StockTransferService) GetById(id string) (*models.StockTransfer, error) {
+	return s.repo.GetById(id)
+}
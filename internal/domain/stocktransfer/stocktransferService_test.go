@@ -0,0 +1,31 @@
+package stocktransfer
+
+import (
+	"testing"
+
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	transfer := &models.StockTransfer{
+		FromLocationId:       1,
+		ToLocationId:         2,
+		StockTransferDetails: []models.StockTransferDetail{{ProductId: " p001 ", Qty: 3, Uom: " box "}},
+	}
+	require.NoError(t, validate(transfer))
+	assert.Equal(t, "P001", transfer.StockTransferDetails[0].ProductId)
+	assert.Equal(t, "box", transfer.StockTransferDetails[0].Uom)
+
+	for name, transfer := range map[string]*models.StockTransfer{
+		"same location": {FromLocationId: 1, ToLocationId: 1, StockTransferDetails: []models.StockTransferDetail{{ProductId: "P001", Qty: 1}}},
+		"no from":       {ToLocationId: 2, StockTransferDetails: []models.StockTransferDetail{{ProductId: "P001", Qty: 1}}},
+		"no lines":      {FromLocationId: 1, ToLocationId: 2},
+		"zero qty":      {FromLocationId: 1, ToLocationId: 2, StockTransferDetails: []models.StockTransferDetail{{ProductId: "P001"}}},
+		"negative qty":  {FromLocationId: 1, ToLocationId: 2, StockTransferDetails: []models.StockTransferDetail{{ProductId: "P001", Qty: -2}}},
+	} {
+		assert.ErrorIs(t, validate(transfer), apperr.ErrValidation, name)
+	}
+}
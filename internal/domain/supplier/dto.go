@@ -12,4 +12,13 @@ type UpdateSupplierRequstDTO struct{
 	Name string
 	Address string
 	Phone string
-}
\ No newline at end of file
+}
+
+type SupplierBalanceDTO struct {
+	SupplierId uint   `json:"supplierId"`
+	Name       string `json:"name"`
+	Purchased  int64  `json:"purchased"`
+	Returned   int64  `json:"returned"`
+	Paid       int64  `json:"paid"`
+	Balance    int64  `json:"balance"`
+}
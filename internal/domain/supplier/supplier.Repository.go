@@ -1,81 +1,50 @@
 package supplier
 
 import (
-	"errors"
 	"log"
-	"sync"
 
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 	"gorm.io/gorm"
 )
 
 type SupplierRepositoryInterface interface {
 	Create(supplier *models.Supplier) (*models.Supplier, error)
-	GetAll() ([]models.Supplier, error)
+	GetAll(spec query.Spec) ([]models.Supplier, query.Page, error)
 	GetById(id uint) (*models.Supplier, error)
 	Update(Supplier *models.Supplier) (*models.Supplier, error)
 	Delete(id uint) error
+	GetPayableBalance(id uint) (*SupplierBalanceDTO, error)
 }
 
 type SupplierRepository struct {
 	db *gorm.DB
 }
 
-var (
-	repoInstance *SupplierRepository
-	repoOnce     sync.Once
-)
-
 func NewSupplierRepository(db *gorm.DB) SupplierRepositoryInterface {
 	log.Println(util.Green + "SupplierRepository constructor is called" + util.Reset)
-	repoOnce.Do(func() {
-		repoInstance = &SupplierRepository{db: db}
-	})
-
-	return repoInstance
+	return &SupplierRepository{db: db}
 }
 
-// Create godoc
-//
-//	@Summary		Create new supplier
-//	@Description	Create a new supplier with name, address, and phone
-//	@Tags			Suppliers
-//	@Accept			json
-//	@Produce		json
-//	@Param			supplier		body		CreateSupplierRequestDTO	true	"Supplier Input Data"
-//	@Success		200			{object}	models.Supplier
-//	@Failure		400			{object}	httputil.HttpError400
-//	@Failure		401			{object}	httputil.HttpError401
-//	@Failure		500			{object}	httputil.HttpError500
-//	@Router			/api/suppliers [post]
-//	@Security		Bearer
 func (r *SupplierRepository) Create(supplier *models.Supplier) (*models.Supplier, error) {
 	err := r.db.Create(&supplier).Error
 
 	return supplier, err
 }
 
-// GetAll godoc
-//
-//	@Summary		Fetch all suppliers
-//	@Description	Fetch all suppliers
-//	@Tags			Suppliers
-//	@Accept			json
-//	@Produce		json
-//	@Success		200				{array}		models.Supplier
-//	@Failure		400				{object}	httputil.HttpError400
-//	@Failure		401				{object}	httputil.HttpError401
-//	@Failure		500				{object}	httputil.HttpError500
-//	@Router			/api/suppliers	[get]
-//	@Security		Bearer
-func (r *SupplierRepository) GetAll() ([]models.Supplier, error) {
+// supplierList is what GET /suppliers sorts and filters on.
+var supplierList = query.Resource{
+	Sort:        map[string]string{"id": "id", "name": "name"},
+	DefaultSort: "id",
+	Filters:     map[string]query.Filter{"phone": {Column: "phone"}},
+	Key:         "id",
+}
+
+func (r *SupplierRepository) GetAll(spec query.Spec) ([]models.Supplier, query.Page, error) {
 	suppliers := []models.Supplier{}
-	r.db.Model(&models.Supplier{}).Order("ID asc").Find(&suppliers)
-	if len(suppliers) == 0 {
-		return nil, errors.New("NO records found")
-	}
-	return suppliers, nil
+	page, err := query.Find(r.db.Model(&models.Supplier{}), spec, &suppliers)
+	return suppliers, page, err
 }
 
 func (r *SupplierRepository) GetById(id uint) (*models.Supplier, error) {
@@ -89,21 +58,6 @@ func (r *SupplierRepository) GetById(id uint) (*models.Supplier, error) {
 	return &supplier, nil
 }
 
-// Update godoc
-//
-//	@Summary		Update individual supplier
-//	@Description	Update individual supplier
-//	@Tags			Suppliers
-//	@Accept			json
-//	@Produce		json
-//	@Param			id					path		string						true	"supplier Id"
-//	@Param			supplier				body		models.Supplier	true	"Supplier Data"
-//	@Success		200					{object}	models.Supplier
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
-//	@Router			/api/suppliers/{id}	[put]
-//	@Security		Bearer
 func (r *SupplierRepository) Update(input *models.Supplier) (*models.Supplier, error) {
 	var existingSupplier *models.Supplier
 	err := r.db.Where("id = ?", input.ID).First(&existingSupplier).Error
@@ -131,22 +85,36 @@ func (r *SupplierRepository) Update(input *models.Supplier) (*models.Supplier, e
 	return existingSupplier, nil
 }
 
-// Delete godoc
-//
-//	@Summary		Delete individual supplier
-//	@Description	Delete individual supplier
-//	@Tags			Suppliers
-//	@Accept			json
-//	@Produce		json
-//	@Param			id					path		string	true	"supplier Id"
-//	@Success		200					{object}	models.Supplier
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
-//	@Router			/api/suppliers/{id}	[delete]
-//	@Security		Bearer
 func (r *SupplierRepository) Delete(id uint) error {
 
 	return r.db.Delete(&models.Supplier{}, id).Error
 
 }
+
+func (r *SupplierRepository) GetPayableBalance(id uint) (*SupplierBalanceDTO, error) {
+	var supplier models.Supplier
+	if err := r.db.First(&supplier, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+
+	result := SupplierBalanceDTO{SupplierId: supplier.ID, Name: supplier.Name}
+	err := r.db.
+		Model(&models.Purchase{}).
+		Select("COALESCE(SUM(grand_total), 0) AS purchased, COALESCE(SUM(returned_total), 0) AS returned").
+		Where("supplier_id = ?", id).
+		Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+	err = r.db.
+		Model(&models.SupplierPayment{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("supplier_id = ?", id).
+		Scan(&result.Paid).Error
+	if err != nil {
+		return nil, err
+	}
+	result.Balance = result.Purchased - result.Returned - result.Paid
+
+	return &result, nil
+}
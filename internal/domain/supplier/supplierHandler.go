@@ -4,29 +4,21 @@ import (
 	"log"
 	"net/http"
 	"strconv"
-	"sync"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
-	"gorm.io/gorm"
+	"github.com/sankangkin/di-rest-api/internal/query"
 )
 
 type SupplierHandler struct {
 	svc SupplierServiceInterface
 }
 
-var (
-	hdlInstance *SupplierHandler
-	hdlOnce     sync.Once
-)
-
 func NewSupplierHandler(svc SupplierServiceInterface) *SupplierHandler {
 	log.Println(util.Green + "SupplierHandler constructor is called" + util.Reset)
-	hdlOnce.Do(func() {
-		hdlInstance = &SupplierHandler{svc: svc}
-	})
-	return hdlInstance
+	return &SupplierHandler{svc: svc}
 }
 
 // CreateSupplier 	godoc
@@ -37,27 +29,23 @@ func NewSupplierHandler(svc SupplierServiceInterface) *SupplierHandler {
 //	@Accept			json
 //	@Param			supplier	body		CreateSupplierRequestDTO	true	"Supplier Data"
 //	@Success		200		{object}	models.Supplier
-//	@Failure		400		{object}	httputil.HttpError400
-//	@Failure		401		{object}	httputil.HttpError401
-//	@Failure		500		{object}	httputil.HttpError500
-//	@Failure		401		{object}	httputil.HttpError401
+//	@Failure		400		{object}	apperr.Response
+//	@Failure		401		{object}	apperr.Response
+//	@Failure		500		{object}	apperr.Response
+//	@Failure		401		{object}	apperr.Response
 //	@Router			/api/suppliers [post]
 //	@Security		Bearer
 func (h *SupplierHandler) CreateSupplier(c *fiber.Ctx) error {
 	newSupplier := new(models.Supplier)
 	if err := c.BodyParser(newSupplier); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  400,
-			"message": "Invalid JSON format",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 
-	errors := models.ValidateStruct(newSupplier)
-	if errors != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(errors)
+	if err := models.ValidateStruct(newSupplier); err != nil {
+		return err
 	}
 	if _, err := h.svc.CreateSupplier(newSupplier); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return err
 	}
 	return c.Status(http.StatusOK).JSON(
 		&fiber.Map{
@@ -74,22 +62,31 @@ func (h *SupplierHandler) CreateSupplier(c *fiber.Ctx) error {
 //	@Tags			Suppliers
 //	@Accept			json
 //	@Produce		json
+//	@Param			page	query		int		false	"page number, from 1"
+//	@Param			limit	query		int		false	"rows per page, 20 by default and 100 at most"
+//	@Param			sort	query		string	false	"comma separated fields of id, name; prefix - for descending"
+//	@Param			phone	query		string	false	"only the supplier with this phone number"
 //	@Success		200				{array}		models.Supplier
-//	@Failure		400				{object}	httputil.HttpError400
-//	@Failure		401				{object}	httputil.HttpError401
-//	@Failure		500				{object}	httputil.HttpError500
+//	@Failure		400				{object}	apperr.Response
+//	@Failure		401				{object}	apperr.Response
+//	@Failure		500				{object}	apperr.Response
 //	@Router			/api/suppliers	[get]
 //	@Security		Bearer
 func (h *SupplierHandler) GetAllSuppliers(c *fiber.Ctx) error {
-	Suppliers, err := h.svc.GetAllSuppliers()
+	spec, err := query.Parse(c.Queries(), supplierList)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return err
+	}
+	Suppliers, page, err := h.svc.GetAllSuppliers(spec)
+	if err != nil {
+		return err
 	}
 	return c.Status(http.StatusOK).JSON(
 		&fiber.Map{
 			"status":  "SUCCESS",
 			"message": strconv.Itoa(len(Suppliers)) + " records found",
 			"data":    Suppliers,
+			"meta":    page,
 		})
 }
 
@@ -102,32 +99,20 @@ func (h *SupplierHandler) GetAllSuppliers(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Param			id					path		string	true	"supplier Id"
 //	@Success		200					{object}	models.Supplier
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/suppliers/{id}	[get]
 //	@Security		Bearer
 func (h *SupplierHandler) GetSupplierById(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status": "fail",
-			"error":  "invalid ID parameter",
-			"detail": err.Error(),
-		})
+		return apperr.Validation("invalid ID parameter").Wrap(err)
 	}
 
 	Supplier, err := h.svc.GetSupplierById(uint(id))
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "Record not found",
-			})
-		}
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
-			"status": "FAIL", "message": err.Error(),
-		})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "SUCCESS",
@@ -146,40 +131,25 @@ func (h *SupplierHandler) GetSupplierById(c *fiber.Ctx) error {
 //	@Param			id					path		string						true	"Supplier Id"
 //	@Param			product				body		UpdateSupplierRequstDTO	true	"Supplier Data"
 //	@Success		200					{object}	models.Supplier
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/suppliers/{id}	[put]
 //	@Security		Bearer
 func (h *SupplierHandler) UpdateSupplier(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status": "fail",
-			"error":  "invalid ID parameter",
-			"detail": err.Error(),
-		})
+		return apperr.Validation("invalid ID parameter").Wrap(err)
 	}
 	foundSupplier, err := h.svc.GetSupplierById(uint(id))
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "Record not found",
-			})
-		}
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
-			"status": "FAIL", "message": err.Error(),
-		})
+		return err
 	}
 
 	input := new(UpdateSupplierRequstDTO)
 	log.Println("input: ", input)
 	if err := c.BodyParser(input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  400,
-			"message": "Invalid JSON format",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 
 	updateSupplier := models.Supplier{
@@ -190,15 +160,12 @@ func (h *SupplierHandler) UpdateSupplier(c *fiber.Ctx) error {
 	}
 	log.Println("updateCustomer: ", &updateSupplier)
 	if err := c.BodyParser(&updateSupplier); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  400,
-			"message": "Invalid JSON format",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 
 	result, err := h.svc.UpdateSupplier(&updateSupplier)
 	if err != nil {
-		log.Fatalf(err.Error())
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "SUCCESS",
@@ -216,41 +183,57 @@ func (h *SupplierHandler) UpdateSupplier(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Param			id					path		string	true	"supplier Id"
 //	@Success		200					{object}	models.Supplier
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/suppliers/{id}	[delete]
 //	@Security		Bearer
 func (h *SupplierHandler) DeleteSupplier(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status": "fail",
-			"error":  "invalid ID parameter",
-			"detail": err.Error(),
-		})
+		return apperr.Validation("invalid ID parameter").Wrap(err)
 	}
 	Supplier, err := h.svc.GetSupplierById(uint(id))
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "Record not found",
-			})
-		}
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
-			"status": "FAIL", "message": err.Error(),
-		})
+		return err
 	}
 	h.svc.DeleteSupplier(uint(Supplier.ID))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "FAIL",
-			"message": "Internal server error",
-		})
+		return err
 	}
 	return c.JSON(fiber.Map{
 		"code":    200,
 		"message": "Delete successfully",
 	})
 }
+
+// GetSupplierBalance godoc
+//
+//	@Summary		Fetch the payable balance of a supplier
+//	@Description	Fetch what is owed to a supplier: purchases less goods returned and payments made
+//	@Tags			Suppliers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id					path		string	true	"supplier Id"
+//	@Success		200					{object}	SupplierBalanceDTO
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
+//	@Router			/api/suppliers/{id}/balance	[get]
+//	@Security		Bearer
+func (h *SupplierHandler) GetSupplierBalance(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return apperr.Validation("invalid ID parameter").Wrap(err)
+	}
+
+	balance, err := h.svc.GetPayableBalance(uint(id))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": "Record found",
+		"data":    balance,
+	})
+}
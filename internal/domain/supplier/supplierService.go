@@ -2,36 +2,29 @@ package supplier
 
 import (
 	"log"
-	"sync"
 
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 )
 
 type SupplierServiceInterface interface {
 	CreateSupplier(Supplier *models.Supplier) (*models.Supplier, error)
-	GetAllSuppliers() ([]models.Supplier, error)
+	GetAllSuppliers(spec query.Spec) ([]models.Supplier, query.Page, error)
 	GetSupplierById(id uint) (*models.Supplier, error)
 	UpdateSupplier(Supplier *models.Supplier) (*models.Supplier, error)
 	DeleteSupplier(id uint) error
+	GetPayableBalance(id uint) (*SupplierBalanceDTO, error)
 }
 
 type SupplierService struct {
 	repo SupplierRepositoryInterface
 }
 
-var (
-	svcInstance *SupplierService
-	svcOnce sync.Once
-)
 func NewSupplierService(repo SupplierRepositoryInterface) SupplierServiceInterface{
 
 	log.Println(util.Green +"SupplierService constructor is called" + util.Reset)
-	svcOnce.Do(func() {
-
-		svcInstance = &SupplierService{repo: repo}
-	})
-	return svcInstance
+	return &SupplierService{repo: repo}
 }
 
 func (s *SupplierService)CreateSupplier(Supplier *models.Supplier) (*models.Supplier, error){
@@ -40,8 +33,8 @@ func (s *SupplierService)CreateSupplier(Supplier *models.Supplier) (*models.Supp
 
 
 
-func (s *SupplierService)GetAllSuppliers() ([]models.Supplier, error){
-	return s.repo.GetAll()
+func (s *SupplierService)GetAllSuppliers(spec query.Spec) ([]models.Supplier, query.Page, error){
+	return s.repo.GetAll(spec)
 }
 
 func (s *SupplierService)GetSupplierById(id uint) (*models.Supplier, error){
@@ -56,3 +49,7 @@ func (s *SupplierService)UpdateSupplier(Supplier *models.Supplier) (*models.Supp
 func (s *SupplierService)DeleteSupplier(id uint) error{
 	return s.repo.Delete(id)
 }
+
+func (s *SupplierService)GetPayableBalance(id uint) (*SupplierBalanceDTO, error){
+	return s.repo.GetPayableBalance(id)
+}
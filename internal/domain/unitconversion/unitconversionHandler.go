@@ -4,29 +4,21 @@ import (
 	"log"
 	"net/http"
 	"strconv"
-	"sync"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
-	"gorm.io/gorm"
+	"github.com/sankangkin/di-rest-api/internal/query"
 )
 
 type UnitConversionHandler struct {
 	svc UnitConversionServiceInterface
 }
 
-var (
-	handlerInstance *UnitConversionHandler
-	handlerOnce     sync.Once
-)
-
 func NewUnitConversionHandler(svc UnitConversionServiceInterface) *UnitConversionHandler {
 	log.Println(util.Gray + "UnitConversionHandler constructor is called " + util.Reset)
-	handlerOnce.Do(func() {
-		handlerInstance = &UnitConversionHandler{svc: svc}
-	})
-	return handlerInstance
+	return &UnitConversionHandler{svc: svc}
 }
 
 // CreateUnitConversion godoc
@@ -37,20 +29,17 @@ func NewUnitConversionHandler(svc UnitConversionServiceInterface) *UnitConversio
 //	@Accept			json
 //	@Param			product	body		CreateUnitConversionDTO	true	"Product Data"
 //	@Success		200		{object}	models.UnitConversion
-//	@Failure		400		{object}	httputil.HttpError400
-//	@Failure		401		{object}	httputil.HttpError401
-//	@Failure		500		{object}	httputil.HttpError500
-//	@Failure		401		{object}	httputil.HttpError401
+//	@Failure		400		{object}	apperr.Response
+//	@Failure		401		{object}	apperr.Response
+//	@Failure		500		{object}	apperr.Response
+//	@Failure		401		{object}	apperr.Response
 //	@Router			/api/unitconversions [post]
 //	@Security		Bearer
 func (h *UnitConversionHandler) CreateUnitConversion(c *fiber.Ctx) error {
 
 	input := new(CreateUnitConversionDTO)
 	if err := c.BodyParser(input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  400,
-			"message": "Invalid JSON format",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 
 	newUnitConversion := models.UnitConversion{
@@ -61,13 +50,12 @@ func (h *UnitConversionHandler) CreateUnitConversion(c *fiber.Ctx) error {
 		DeriveUnitId: input.DeriveUnitId,
 	}
 
-	errors := models.ValidateStruct(newUnitConversion)
-	if errors != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(errors)
+	if err := models.ValidateStruct(newUnitConversion); err != nil {
+		return err
 	}
 
 	if _, err := h.svc.CreateUnitConversion(&newUnitConversion); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return err
 	}
 	return c.Status(http.StatusOK).JSON(
 		&fiber.Map{
@@ -84,22 +72,31 @@ func (h *UnitConversionHandler) CreateUnitConversion(c *fiber.Ctx) error {
 // @Tags			UnitConversions
 // @Accept			json
 // @Produce		json
+// @Param			page		query		int		false	"page number, from 1"
+// @Param			limit		query		int		false	"rows per page, 20 by default and 100 at most"
+// @Param			sort		query		string	false	"comma separated fields of id, productId; prefix - for descending"
+// @Param			productId	query		string	false	"only the conversions of this product"
 // @Success		200				{array}		models.UnitConversion
-// @Failure		400				{object}	httputil.HttpError400
-// @Failure		401				{object}	httputil.HttpError401
-// @Failure		500				{object}	httputil.HttpError500
+// @Failure		400				{object}	apperr.Response
+// @Failure		401				{object}	apperr.Response
+// @Failure		500				{object}	apperr.Response
 // @Router			/api/unitconversions	[get]
 // @Security		Bearer
 func (h *UnitConversionHandler) GetAllUnitConversions(c *fiber.Ctx) error {
-	unitConversions, err := h.svc.GetAllUnitConversions()
+	spec, err := query.Parse(c.Queries(), unitConversionList)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return err
+	}
+	unitConversions, page, err := h.svc.GetAllUnitConversions(spec)
+	if err != nil {
+		return err
 	}
 	return c.Status(http.StatusOK).JSON(
 		&fiber.Map{
 			"status":  "SUCCESS",
 			"message": strconv.Itoa(len(unitConversions)) + " records found",
 			"data":    unitConversions,
+			"meta":    page,
 		})
 }
 
@@ -112,33 +109,21 @@ func (h *UnitConversionHandler) GetAllUnitConversions(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Param			id					path		string	true	"unit conversion Id"
 //	@Success		200					{object}	models.UnitConversion
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/unitconversions/{id}	[get]
 //	@Security		Bearer
 func (h *UnitConversionHandler) GetUnitConversionById(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status": "fail",
-			"error":  "invalid ID parameter",
-			"detail": err.Error(),
-		})
+		return apperr.Validation("invalid ID parameter").Wrap(err)
 	}
 
 	unitConversion, err := h.svc.GetUnitConversionById(int(id))
 	log.Println("id : ", id)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "Record not found",
-			})
-		}
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
-			"status": "FAIL", "message": err.Error(),
-		})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "SUCCESS",
@@ -157,43 +142,28 @@ func (h *UnitConversionHandler) GetUnitConversionById(c *fiber.Ctx) error {
 //	@Param			id					path		string						true	"unit conversion Id"
 //	@Param			product				body		UpdateUnitConversionRequestDTO	true	"Product Data"
 //	@Success		200					{object}	models.UnitConversion
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/unitconversions/{id}	[put]
 //	@Security		Bearer
 func (h *UnitConversionHandler) UpdateUnitConversion(c *fiber.Ctx) error {
 
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status": "fail",
-			"error":  "invalid ID parameter",
-			"detail": err.Error(),
-		})
+		return apperr.Validation("invalid ID parameter").Wrap(err)
 	}
 
 	foundUnitConversion, err := h.svc.GetUnitConversionById(int(id))
 	log.Println("id : ", id)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "Record not found",
-			})
-		}
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
-			"status": "FAIL", "message": err.Error(),
-		})
+		return err
 	}
 
 	input := new(UpdateUnitConversionRequestDTO)
 	log.Println("input: ", input)
 	if err := c.BodyParser(input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  400,
-			"message": "Invalid JSON format",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 
 	updateUnitConversion := models.UnitConversion{
@@ -206,15 +176,12 @@ func (h *UnitConversionHandler) UpdateUnitConversion(c *fiber.Ctx) error {
 	}
 	log.Println("updateUnitConversion: ", &updateUnitConversion)
 	if err := c.BodyParser(&updateUnitConversion); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  400,
-			"message": "Invalid JSON format",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 
 	result, err := h.svc.UpdateUnitConversion(&updateUnitConversion)
 	if err != nil {
-		log.Fatal(err.Error())
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "SUCCESS",
@@ -233,38 +200,23 @@ func (h *UnitConversionHandler) UpdateUnitConversion(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Param			id					path		string	true	"unit conversion Id"
 //	@Success		200					{object}	models.UnitConversion
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/unitconversions/{id}	[delete]
 //	@Security		Bearer
 func (h *UnitConversionHandler) DeleteUnitConversion(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status": "fail",
-			"error":  "invalid ID parameter",
-			"detail": err.Error(),
-		})
+		return apperr.Validation("invalid ID parameter").Wrap(err)
 	}
 	conversion, err := h.svc.GetUnitConversionById(int(id))
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "Record not found",
-			})
-		}
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
-			"status": "FAIL", "message": err.Error(),
-		})
+		return err
 	}
 	err = h.svc.DeleteUnitConversion(int(conversion.ID))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "FAIL",
-			"message": "Internal server error",
-		})
+		return err
 	}
 	return c.JSON(fiber.Map{
 		"code":    200,
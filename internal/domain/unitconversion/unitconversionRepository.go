@@ -1,19 +1,18 @@
 package unitconversion
 
 import (
-	"errors"
-	"fmt"
 	"log"
-	"sync"
 
+	"github.com/sankangkin/di-rest-api/internal/apperr"
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 	"gorm.io/gorm"
 )
 
 type UnitConversionRepositoryInterface interface {
 	Create(unitConversion *models.UnitConversion) (*models.UnitConversion, error)
-	GetAll() ([]models.UnitConversion, error)
+	GetAll(spec query.Spec) ([]models.UnitConversion, query.Page, error)
 	GetById(id int) (*models.UnitConversion, error)
 	Update(unitConversion *models.UnitConversion) (*models.UnitConversion, error)
 	Delete(id int) error
@@ -23,12 +22,6 @@ type UnitConversionRepository struct {
 	db *gorm.DB
 }
 
-// ! singleton pattern
-var (
-	repoInstance *UnitConversionRepository
-	repoOnce     sync.Once
-)
-
 // func NewUnitConversionRepository(db *gorm.DB) UnitConversionRepositoryInterface {
 // 	return &UnitConversionRepository{db: db}
 // }
@@ -38,10 +31,7 @@ var (
 // constructor
 func NewUnitConversionRepository(db *gorm.DB) UnitConversionRepositoryInterface {
 	log.Println(util.Yellow + "UnitConversionRepository constructor is called " + util.Reset)
-	repoOnce.Do(func() {
-		repoInstance = &UnitConversionRepository{db: db}
-	})
-	return repoInstance
+	return &UnitConversionRepository{db: db}
 }
 
 func (r *UnitConversionRepository) Create(unitConversion *models.UnitConversion) (*models.UnitConversion, error) {
@@ -49,17 +39,22 @@ func (r *UnitConversionRepository) Create(unitConversion *models.UnitConversion)
 	return unitConversion, err
 }
 
-func (r *UnitConversionRepository) GetAll() ([]models.UnitConversion, error) {
-	var unitConversions []models.UnitConversion
-	err := r.db.Model(&models.UnitConversion{}).Order("id DESC").Find(&unitConversions).Error
+// unitConversionList is what GET /unitconversions sorts and filters on.
+var unitConversionList = query.Resource{
+	Sort:        map[string]string{"id": "id", "productId": "product_id"},
+	DefaultSort: "-id",
+	Filters:     map[string]query.Filter{"productId": {Column: "product_id", Kind: query.Code}},
+	Key:         "id",
+}
+
+func (r *UnitConversionRepository) GetAll(spec query.Spec) ([]models.UnitConversion, query.Page, error) {
+	unitConversions := []models.UnitConversion{}
+	page, err := query.Find(r.db.Model(&models.UnitConversion{}), spec, &unitConversions)
 	if err != nil {
-		return nil, err
-	}
-	if len(unitConversions) == 0 {
-		return nil, errors.New("no records found")
+		return nil, query.Page{}, err
 	}
 
-	return unitConversions, nil
+	return unitConversions, page, nil
 }
 
 func (r *UnitConversionRepository) GetById(id int) (*models.UnitConversion, error) {
@@ -82,7 +77,7 @@ func (r *UnitConversionRepository) Update(unitConversion *models.UnitConversion)
 	}
 
 	if unitConversion.BaseUnitId == 0 || unitConversion.DeriveUnitId == 0 || unitConversion.Factor == 0 {
-		return nil, fmt.Errorf("missing required fields")
+		return nil, apperr.Validation("missing required fields")
 	}
 
 	// existingUnitConversion.BaseUnit = unitConversion.BaseUnit
@@ -2,15 +2,15 @@ package unitconversion
 
 import (
 	"log"
-	"sync"
 
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 )
 
 type UnitConversionServiceInterface interface {
 	CreateUnitConversion(unitConversion *models.UnitConversion) (*models.UnitConversion, error)
-	GetAllUnitConversions() ([]models.UnitConversion, error)
+	GetAllUnitConversions(spec query.Spec) ([]models.UnitConversion, query.Page, error)
 	GetUnitConversionById(id int) (*models.UnitConversion, error)
 	UpdateUnitConversion(unitConversion *models.UnitConversion) (*models.UnitConversion, error)
 	DeleteUnitConversion(id int) error
@@ -21,11 +21,6 @@ type UnitConversionService struct {
 }
 
 // ! for singletone pattern
-var (
-	svcInstance *UnitConversionService
-	svcOnce     sync.Once
-)
-
 // func NewUnitConversionService(repo UnitConversionRepositoryInterface) UnitConversionServiceInterface{
 // 	return &UnitConversionService{repo:repo}
 // }
@@ -33,18 +28,15 @@ var (
 // ! constructor must be return the Interface, NOT struct, if not, google wire generate fail
 func NewUnitConversionService(repo UnitConversionRepositoryInterface) UnitConversionServiceInterface {
 	log.Println(util.Gray + "UnitConversionService constructor is called " + util.Reset)
-	svcOnce.Do(func() {
-		svcInstance = &UnitConversionService{repo: repo}
-	})
-	return svcInstance
+	return &UnitConversionService{repo: repo}
 }
 
 func (s *UnitConversionService) CreateUnitConversion(unitConversion *models.UnitConversion) (*models.UnitConversion, error) {
 	return s.repo.Create(unitConversion)
 }
 
-func (s *UnitConversionService) GetAllUnitConversions() ([]models.UnitConversion, error) {
-	return s.repo.GetAll()
+func (s *UnitConversionService) GetAllUnitConversions(spec query.Spec) ([]models.UnitConversion, query.Page, error) {
+	return s.repo.GetAll(spec)
 }
 
 func (s *UnitConversionService) GetUnitConversionById(id int) (*models.UnitConversion, error) {
@@ -4,29 +4,21 @@ import (
 	"log"
 	"net/http"
 	"strconv"
-	"sync"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
-	"gorm.io/gorm"
+	"github.com/sankangkin/di-rest-api/internal/query"
 )
 
 type UnitOfMeasurementHandler struct {
 	svc UnitOfMeasurementServiceInterface
 }
 
-var (
-	handlerInstance *UnitOfMeasurementHandler
-	handlerOnce     sync.Once
-)
-
 func NewUnitOfMeasurementHandler(svc UnitOfMeasurementServiceInterface) *UnitOfMeasurementHandler {
 	log.Println(util.Gray + "UnitOfMeasurementHandler constructor is called " + util.Reset)
-	handlerOnce.Do(func() {
-		handlerInstance = &UnitOfMeasurementHandler{svc: svc}
-	})
-	return handlerInstance
+	return &UnitOfMeasurementHandler{svc: svc}
 }
 
 // CreateUnitOfMeasurement godoc
@@ -37,20 +29,17 @@ func NewUnitOfMeasurementHandler(svc UnitOfMeasurementServiceInterface) *UnitOfM
 //	@Accept			json
 //	@Param			product	body		models.UnitOfMeasure	true	"Product Data"
 //	@Success		200		{object}	models.UnitOfMeasure
-//	@Failure		400		{object}	httputil.HttpError400
-//	@Failure		401		{object}	httputil.HttpError401
-//	@Failure		500		{object}	httputil.HttpError500
-//	@Failure		401		{object}	httputil.HttpError401
+//	@Failure		400		{object}	apperr.Response
+//	@Failure		401		{object}	apperr.Response
+//	@Failure		500		{object}	apperr.Response
+//	@Failure		401		{object}	apperr.Response
 //	@Router			/api/unitofmeasurements [post]
 //	@Security		Bearer
 func (h *UnitOfMeasurementHandler) CreateUnitOfMeasurement(c *fiber.Ctx) error {
 
 	input := new(models.UnitOfMeasure)
 	if err := c.BodyParser(input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  400,
-			"message": "Invalid JSON format",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 
 	newUnitOfMeasurement := models.UnitOfMeasure{
@@ -58,13 +47,12 @@ func (h *UnitOfMeasurementHandler) CreateUnitOfMeasurement(c *fiber.Ctx) error {
 		UnitName: input.UnitName,
 	}
 
-	errors := models.ValidateStruct(newUnitOfMeasurement)
-	if errors != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(errors)
+	if err := models.ValidateStruct(newUnitOfMeasurement); err != nil {
+		return err
 	}
 
 	if _, err := h.svc.CreateUnitOfMeasurement(&newUnitOfMeasurement); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return err
 	}
 	return c.Status(http.StatusOK).JSON(
 		&fiber.Map{
@@ -82,21 +70,26 @@ func (h *UnitOfMeasurementHandler) CreateUnitOfMeasurement(c *fiber.Ctx) error {
 // @Accept			json
 // @Produce		json
 // @Success		200				{array}		models.UnitOfMeasure
-// @Failure		400				{object}	httputil.HttpError400
-// @Failure		401				{object}	httputil.HttpError401
-// @Failure		500				{object}	httputil.HttpError500
+// @Failure		400				{object}	apperr.Response
+// @Failure		401				{object}	apperr.Response
+// @Failure		500				{object}	apperr.Response
 // @Router			/api/unitofmeasurements	[get]
 // @Security		Bearer
 func (h *UnitOfMeasurementHandler) GetAllUnitOfMeasurement(c *fiber.Ctx) error {
-	unitOfMeasurements, err := h.svc.GetAllUnitOfMeasurement()
+	spec, err := query.Parse(c.Queries(), uomList)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return err
+	}
+	unitOfMeasurements, page, err := h.svc.GetAllUnitOfMeasurement(spec)
+	if err != nil {
+		return err
 	}
 	return c.Status(http.StatusOK).JSON(
 		&fiber.Map{
 			"status":  "SUCCESS",
 			"message": strconv.Itoa(len(unitOfMeasurements)) + " records found",
 			"data":    unitOfMeasurements,
+			"meta":    page,
 		})
 }
 
@@ -108,35 +101,26 @@ func (h *UnitOfMeasurementHandler) GetAllUnitOfMeasurement(c *fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Param			id					path		string	true	"unit of measurement Id"
+//	@Param			page	query		int		false	"page number, from 1"
+//	@Param			limit	query		int		false	"rows per page, 20 by default and 100 at most"
+//	@Param			sort	query		string	false	"comma separated fields of id, unitName; prefix - for descending"
 //	@Success		200					{object}	models.UnitOfMeasure
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/unitofmeasurements/{id}	[get]
 //
 // @Security       Bearer
 func (h *UnitOfMeasurementHandler) GetUnitOfMeasurementById(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status": "fail",
-			"error":  "invalid ID parameter",
-			"detail": err.Error(),
-		})
+		return apperr.Validation("invalid ID parameter").Wrap(err)
 	}
 
 	unitOfMeasurement, err := h.svc.GetUnitOfMeasurementById(int(id))
 	log.Println("id : ", id)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "Record not found",
-			})
-		}
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
-			"status": "FAIL", "message": err.Error(),
-		})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "SUCCESS",
@@ -155,42 +139,27 @@ func (h *UnitOfMeasurementHandler) GetUnitOfMeasurementById(c *fiber.Ctx) error
 //	@Param			id					path		string						true	"unit of measurement Id"
 //	@Param			product				body		models.UnitOfMeasure	true	"Product Data"
 //	@Success		200					{object}	models.UnitOfMeasure
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/unitofmeasurements/{id}	[put]
 //	@Security		Bearer
 func (h *UnitOfMeasurementHandler) UpdateUnitOfMeasurement(c *fiber.Ctx) error {
 
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status": "fail",
-			"error":  "invalid ID parameter",
-			"detail": err.Error(),
-		})
+		return apperr.Validation("invalid ID parameter").Wrap(err)
 	}
 
 	foundUnitOfMeasurement, err := h.svc.GetUnitOfMeasurementById(int(id))
 	log.Println("id : ", id)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "Record not found",
-			})
-		}
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
-			"status": "FAIL", "message": err.Error(),
-		})
+		return err
 	}
 
 	input := new(models.UnitOfMeasure)
 	if err := c.BodyParser(input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  400,
-			"message": "Invalid JSON format",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 	updateUnitOfMeasurement := models.UnitOfMeasure{
 		ID:       foundUnitOfMeasurement.ID,
@@ -198,15 +167,12 @@ func (h *UnitOfMeasurementHandler) UpdateUnitOfMeasurement(c *fiber.Ctx) error {
 	}
 	log.Println("updateUnitOfMeasurement: ", &updateUnitOfMeasurement)
 	if err := c.BodyParser(&updateUnitOfMeasurement); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  400,
-			"message": "Invalid JSON format",
-		})
+		return apperr.Validation("invalid JSON format")
 	}
 
 	result, err := h.svc.UpdateUnitOfMeasurement(&updateUnitOfMeasurement)
 	if err != nil {
-		log.Fatal(err.Error())
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "SUCCESS",
@@ -224,38 +190,23 @@ func (h *UnitOfMeasurementHandler) UpdateUnitOfMeasurement(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Param			id					path		string	true	"unit of measurement Id"
 //	@Success		200					{object}	models.UnitOfMeasure
-//	@Failure		400					{object}	httputil.HttpError400
-//	@Failure		401					{object}	httputil.HttpError401
-//	@Failure		500					{object}	httputil.HttpError500
+//	@Failure		400					{object}	apperr.Response
+//	@Failure		401					{object}	apperr.Response
+//	@Failure		500					{object}	apperr.Response
 //	@Router			/api/unitofmeasurements/{id}	[delete]
 //	@Security		Bearer
 func (h *UnitOfMeasurementHandler) DeleteUnitOfMeasurement(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status": "fail",
-			"error":  "invalid ID parameter",
-			"detail": err.Error(),
-		})
+		return apperr.Validation("invalid ID parameter").Wrap(err)
 	}
 	unitOfMeasurement, err := h.svc.GetUnitOfMeasurementById(int(id))
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"status":  "FAIL",
-				"message": "Record not found",
-			})
-		}
-		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
-			"status": "FAIL", "message": err.Error(),
-		})
+		return err
 	}
 	err = h.svc.DeleteUnitOfMeasurement(int(unitOfMeasurement.ID))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  "FAIL",
-			"message": "Internal server error",
-		})
+		return err
 	}
 	return c.JSON(fiber.Map{
 		"code":    200,
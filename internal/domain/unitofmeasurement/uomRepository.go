@@ -1,19 +1,18 @@
 package unitofmeasurement
 
 import (
-	"errors"
-	"fmt"
 	"log"
-	"sync"
 
+	"github.com/sankangkin/di-rest-api/internal/apperr"
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 	"gorm.io/gorm"
 )
 
 type UnitOfMeasurementRepositoryInterface interface {
 	Create(unitOfMeasurement *models.UnitOfMeasure) (*models.UnitOfMeasure, error)
-	GetAll() ([]models.UnitOfMeasure, error)
+	GetAll(spec query.Spec) ([]models.UnitOfMeasure, query.Page, error)
 	GetById(id int) (*models.UnitOfMeasure, error)
 	Update(unitOfMeasurement *models.UnitOfMeasure) (*models.UnitOfMeasure, error)
 	Delete(id int) error
@@ -23,12 +22,6 @@ type UnitOfMeasurementRepository struct {
 	db *gorm.DB
 }
 
-// ! singleton pattern
-var (
-	repoInstance *UnitOfMeasurementRepository
-	repoOnce     sync.Once
-)
-
 // func NewUnitOfMeasurementRepository(db *gorm.DB) UnitOfMeasurementRepositoryInterface {
 // 	return &UnitOfMeasurementRepository{db: db}
 // }
@@ -38,10 +31,7 @@ var (
 // constructor
 func NewUnitOfMeasurementRepository(db *gorm.DB) UnitOfMeasurementRepositoryInterface {
 	log.Println(util.Yellow + "UnitOfMeasurementRepository constructor is called " + util.Reset)
-	repoOnce.Do(func() {
-		repoInstance = &UnitOfMeasurementRepository{db: db}
-	})
-	return repoInstance
+	return &UnitOfMeasurementRepository{db: db}
 }
 
 func (r *UnitOfMeasurementRepository) Create(unitOfMeasurement *models.UnitOfMeasure) (*models.UnitOfMeasure, error) {
@@ -49,17 +39,21 @@ func (r *UnitOfMeasurementRepository) Create(unitOfMeasurement *models.UnitOfMea
 	return unitOfMeasurement, err
 }
 
-func (r *UnitOfMeasurementRepository) GetAll() ([]models.UnitOfMeasure, error) {
-	var unitOfMeasurements []models.UnitOfMeasure
-	err := r.db.Model(&models.UnitOfMeasure{}).Order("id DESC").Find(&unitOfMeasurements).Error
+// uomList is what GET /uoms sorts on.
+var uomList = query.Resource{
+	Sort:        map[string]string{"id": "id", "unitName": "unit_name"},
+	DefaultSort: "-id",
+	Key:         "id",
+}
+
+func (r *UnitOfMeasurementRepository) GetAll(spec query.Spec) ([]models.UnitOfMeasure, query.Page, error) {
+	unitOfMeasurements := []models.UnitOfMeasure{}
+	page, err := query.Find(r.db.Model(&models.UnitOfMeasure{}), spec, &unitOfMeasurements)
 	if err != nil {
-		return nil, err
-	}
-	if len(unitOfMeasurements) == 0 {
-		return nil, errors.New("no records found")
+		return nil, query.Page{}, err
 	}
 
-	return unitOfMeasurements, nil
+	return unitOfMeasurements, page, nil
 }
 
 func (r *UnitOfMeasurementRepository) GetById(id int) (*models.UnitOfMeasure, error) {
@@ -83,7 +77,7 @@ func (r *UnitOfMeasurementRepository) Update(input *models.UnitOfMeasure) (*mode
 
 	log.Println("input from Repository: ", input)
 	if input.UnitName == "" {
-		return nil, fmt.Errorf("missing required fields")
+		return nil, apperr.Validation("missing required fields")
 	}
 
 	existingUnit.UnitName = input.UnitName
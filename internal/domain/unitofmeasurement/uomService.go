@@ -2,15 +2,15 @@ package unitofmeasurement
 
 import (
 	"log"
-	"sync"
 
 	"github.com/sankangkin/di-rest-api/internal/domain/util"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 )
 
 type UnitOfMeasurementServiceInterface interface {
 	CreateUnitOfMeasurement(unitOfMeasurement *models.UnitOfMeasure) (*models.UnitOfMeasure, error)
-	GetAllUnitOfMeasurement() ([]models.UnitOfMeasure, error)
+	GetAllUnitOfMeasurement(spec query.Spec) ([]models.UnitOfMeasure, query.Page, error)
 	GetUnitOfMeasurementById(id int) (*models.UnitOfMeasure, error)
 	UpdateUnitOfMeasurement(unitOfMeasurement *models.UnitOfMeasure) (*models.UnitOfMeasure, error)
 	DeleteUnitOfMeasurement(id int) error
@@ -20,12 +20,6 @@ type UnitOfMeasurementService struct {
 	repo UnitOfMeasurementRepositoryInterface
 }
 
-// ! singleton pattern
-var (
-	svcInstance *UnitOfMeasurementService
-	svcOnce     sync.Once
-)
-
 // func NewUnitOfMeasurementService(repo UnitOfMeasurementRepositoryInterface) UnitOfMeasurementServiceInterface{
 // 	return &UnitOfMeasurementService{repo: repo}
 // }
@@ -35,18 +29,15 @@ func NewUnitOfMeasurementService(repo UnitOfMeasurementRepositoryInterface) Unit
 
 	log.Println(util.Yellow + "UnitOfMeasurementService constructor is called" + util.Reset)
 
-	svcOnce.Do(func() {
-		svcInstance = &UnitOfMeasurementService{repo: repo}
-	})
-	return svcInstance
+	return &UnitOfMeasurementService{repo: repo}
 }
 
 func (s *UnitOfMeasurementService) CreateUnitOfMeasurement(unitOfMeasurement *models.UnitOfMeasure) (*models.UnitOfMeasure, error) {
 
 	return s.repo.Create(unitOfMeasurement)
 }
-func (s *UnitOfMeasurementService) GetAllUnitOfMeasurement() ([]models.UnitOfMeasure, error) {
-	return s.repo.GetAll()
+func (s *UnitOfMeasurementService) GetAllUnitOfMeasurement(spec query.Spec) ([]models.UnitOfMeasure, query.Page, error) {
+	return s.repo.GetAll(spec)
 }
 func (s *UnitOfMeasurementService) GetUnitOfMeasurementById(id int) (*models.UnitOfMeasure, error) {
 	return s.repo.GetById(id)
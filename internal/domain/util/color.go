@@ -8,6 +8,4 @@ var Blue = "\033[34m"
 var Magenta = "\033[35m" 
 var Cyan = "\033[36m" 
 var Gray = "\033[37m" 
-var White = "\033[97m"
-
-var SecreteKey = "superSecreateKey"
\ No newline at end of file
+var White = "\033[97m"
\ No newline at end of file
@@ -0,0 +1,10 @@
+package util
+
+// DivRound divides n by d, d > 0, rounding half away from zero, the way
+// money amounts are rounded.
+func DivRound(n int64, d int64) int64 {
+	if n < 0 {
+		return -((-n + d/2) / d)
+	}
+	return (n + d/2) / d
+}
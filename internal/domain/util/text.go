@@ -0,0 +1,14 @@
+package util
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeText trims s and puts it in Unicode NFC, so names typed on
+// different keyboards, e.g. Myanmar vowels entered as one code point or as
+// two, are stored and searched the same way.
+func NormalizeText(s string) string {
+	return norm.NFC.String(strings.TrimSpace(s))
+}
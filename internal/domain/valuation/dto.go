@@ -0,0 +1,31 @@
+package valuation
+
+// ProductValuationDTO is the stock of one product as of the valuation date
+// and what it is worth at the product's moving-average cost then. The stock
+// is BaseQty base units and DerivedQty loose derived units.
+type ProductValuationDTO struct {
+	ProductId    string `json:"productId"`
+	ProductName  string `json:"productName"`
+	CategoryId   uint   `json:"categoryId"`
+	CategoryName string `json:"categoryName"`
+	BaseUnit     string `json:"baseUnit"`
+	BaseQty      int64  `json:"baseQty"`
+	DerivedQty   int64  `json:"derivedQty"`
+	UnitCost     int64  `json:"unitCost"` // of one base unit
+	Value        int64  `json:"value"`
+}
+
+type CategoryValuationDTO struct {
+	CategoryId   uint   `json:"categoryId"`
+	CategoryName string `json:"categoryName"`
+	Products     int    `json:"products"`
+	Value        int64  `json:"value"`
+}
+
+type ValuationDTO struct {
+	AsOf       string                 `json:"asOf"`
+	LocationId uint                   `json:"locationId"` // zero is every location
+	Total      int64                  `json:"total"`
+	Categories []CategoryValuationDTO `json:"categories"`
+	Products   []ProductValuationDTO  `json:"products"`
+}
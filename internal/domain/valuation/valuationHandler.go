@@ -0,0 +1,78 @@
+package valuation
+
+import (
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+)
+
+type ValuationHandler struct {
+	svc ValuationServiceInterface
+}
+
+func NewValuationHandler(svc ValuationServiceInterface) *ValuationHandler {
+	log.Println(util.Green + "ValuationHandler constructor is called" + util.Reset)
+	return &ValuationHandler{svc: svc}
+}
+
+// queryId reads an optional id parameter, zero when absent.
+func queryId(c *fiber.Ctx, name string) (uint, error) {
+	v := c.Query(name)
+	if v == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return 0, apperr.Validation("invalid %s %q", name, v)
+	}
+	return uint(id), nil
+}
+
+// GetValuation godoc
+//
+//	@Summary		Value the stock as of a date
+//	@Description	Replay the ledger to the end of the asOf day, local midnight in SHOP_TIME_ZONE, and value every product's stock at quantity times its moving-average cost then, per product and per category
+//	@Tags			Valuation
+//	@Accept			json
+//	@Produce		json
+//	@Param			asOf		query		string	false	"valuation date, yyyy-mm-dd in the shop's time zone (default today)"
+//	@Param			locationId	query		int		false	"only the stock at this location, every location when empty"
+//	@Param			categoryId	query		int		false	"only the products of this category"
+//	@Success		200			{object}	ValuationDTO
+//	@Failure		400			{object}	apperr.Response
+//	@Failure		401			{object}	apperr.Response
+//	@Failure		500			{object}	apperr.Response
+//	@Router			/api/valuation [get]
+//	@Security		Bearer
+func (h *ValuationHandler) GetValuation(c *fiber.Ctx) error {
+	var asOf time.Time
+	if v := c.Query("asOf"); v != "" {
+		day, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return apperr.Validation("asOf must be a yyyy-mm-dd date, got %q", v)
+		}
+		asOf = day
+	}
+	locationId, err := queryId(c, "locationId")
+	if err != nil {
+		return err
+	}
+	categoryId, err := queryId(c, "categoryId")
+	if err != nil {
+		return err
+	}
+
+	valuation, err := h.svc.GetValuation(asOf, locationId, categoryId)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "SUCCESS",
+		"message": strconv.Itoa(len(valuation.Products)) + " products valued",
+		"data":    valuation,
+	})
+}
@@ -0,0 +1,89 @@
+package valuation
+
+import (
+	"log"
+	"time"
+
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"gorm.io/gorm"
+)
+
+type ValuationRepositoryInterface interface {
+	GetStocks(before time.Time, locationId uint, categoryId uint) ([]stockRow, error)
+}
+
+type ValuationRepository struct {
+	db *gorm.DB
+}
+
+func NewValuationRepository(db *gorm.DB) ValuationRepositoryInterface {
+	log.Println(util.Green + "ValuationRepository constructor is called" + util.Reset)
+	return &ValuationRepository{db: db}
+}
+
+// stockRow is the stock of a product in derived units, base units times
+// Factor, with the cost of one base unit.
+type stockRow struct {
+	ProductId    string
+	ProductName  string
+	CategoryId   uint
+	CategoryName string
+	BaseUnit     string
+	Factor       int64
+	Qty          int64
+	UnitCost     int64
+}
+
+// ledgerQtySql sums the ledger per product in derived units: rows booked in
+// the derived unit count as they are, rows in the base unit times the factor.
+const ledgerQtySql = `
+	SELECT it.product_id,
+	       SUM(CASE WHEN uc.derive_unit <> '' AND UPPER(it.uom) = UPPER(uc.derive_unit)
+	                THEN it.in_qty - it.out_qty
+	                ELSE (it.in_qty - it.out_qty) * COALESCE(uc.factor, 1)
+	           END)::bigint AS qty
+	FROM item_transactions it
+	LEFT JOIN unit_conversions uc ON uc.product_id = it.product_id AND uc.deleted_at IS NULL
+	WHERE it.deleted_at IS NULL AND it.created_at < @before`
+
+// lastCostSql is the cost the latest ledger row of each product was booked
+// at, the product's average cost at that time.
+const lastCostSql = `
+	SELECT DISTINCT ON (product_id) product_id, unit_cost
+	FROM item_transactions
+	WHERE deleted_at IS NULL AND created_at < @before
+	ORDER BY product_id, id DESC`
+
+// GetStocks replays the ledger up to before, at one location or at all of
+// them when locationId is 0, and leaves out products with no stock.
+func (r *ValuationRepository) GetStocks(before time.Time, locationId uint, categoryId uint) ([]stockRow, error) {
+	qty := ledgerQtySql
+	if locationId != 0 {
+		qty += " AND it.location_id = @location"
+	}
+	qty += " GROUP BY it.product_id"
+
+	var rows []stockRow
+	db := r.db.
+		Table("(?) AS m", r.db.Raw(qty, map[string]interface{}{"before": before, "location": locationId})).
+		Select(`
+			p.id AS product_id,
+			p.product_name,
+			p.category_id,
+			COALESCE(c.category_name, '') AS category_name,
+			COALESCE(uc.base_unit, p.uom) AS base_unit,
+			COALESCE(uc.factor, 1) AS factor,
+			m.qty,
+			COALESCE(k.unit_cost, p.avg_cost) AS unit_cost
+		`).
+		Joins("JOIN products p ON p.id = m.product_id").
+		Joins("LEFT JOIN unit_conversions uc ON uc.product_id = p.id AND uc.deleted_at IS NULL").
+		Joins("LEFT JOIN categories c ON c.id = p.category_id").
+		Joins("LEFT JOIN (?) AS k ON k.product_id = p.id", r.db.Raw(lastCostSql, map[string]interface{}{"before": before})).
+		Where("m.qty <> 0")
+	if categoryId != 0 {
+		db = db.Where("p.category_id = ?", categoryId)
+	}
+	err := db.Order("category_name, p.id").Scan(&rows).Error
+	return rows, err
+}
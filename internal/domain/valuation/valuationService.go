@@ -0,0 +1,91 @@
+package valuation
+
+import (
+	"log"
+	"time"
+
+	"github.com/sankangkin/di-rest-api/internal/config"
+	"github.com/sankangkin/di-rest-api/internal/domain/util"
+)
+
+type ValuationServiceInterface interface {
+	GetValuation(asOf time.Time, locationId uint, categoryId uint) (*ValuationDTO, error)
+}
+
+type ValuationService struct {
+	repo ValuationRepositoryInterface
+	zone *time.Location
+}
+
+func NewValuationService(repo ValuationRepositoryInterface, cfg *config.Config) ValuationServiceInterface {
+	log.Println(util.Green + "ValuationService constructor is called" + util.Reset)
+	return &ValuationService{repo: repo, zone: cfg.Shop.TimeZone}
+}
+
+// GetValuation values the stock at the end of the asOf day, a calendar date,
+// in the shop's time zone; the zero asOf is today there.
+func (s *ValuationService) GetValuation(asOf time.Time, locationId uint, categoryId uint) (*ValuationDTO, error) {
+	if asOf.IsZero() {
+		asOf = time.Now().In(s.zone)
+	}
+	day, end := dayBounds(asOf, s.zone)
+	rows, err := s.repo.GetStocks(end, locationId, categoryId)
+	if err != nil {
+		return nil, err
+	}
+
+	valuation := valuate(rows)
+	valuation.AsOf = day.Format(time.DateOnly)
+	valuation.LocationId = locationId
+	return valuation, nil
+}
+
+// dayBounds returns the local midnights that start and end the calendar date
+// of day in zone, so that a sale rung up late in the evening counts on its
+// own day whatever the server's clock is set to.
+func dayBounds(day time.Time, zone *time.Location) (time.Time, time.Time) {
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, zone)
+	return start, start.AddDate(0, 0, 1)
+}
+
+// valuate prices every product at quantity times cost and adds them up per
+// category, in the order of the rows.
+func valuate(rows []stockRow) *ValuationDTO {
+	valuation := &ValuationDTO{
+		Categories: []CategoryValuationDTO{},
+		Products:   []ProductValuationDTO{},
+	}
+	index := map[uint]int{}
+	for _, row := range rows {
+		factor := row.Factor
+		if factor < 1 {
+			factor = 1
+		}
+		p := ProductValuationDTO{
+			ProductId:    row.ProductId,
+			ProductName:  row.ProductName,
+			CategoryId:   row.CategoryId,
+			CategoryName: row.CategoryName,
+			BaseUnit:     row.BaseUnit,
+			BaseQty:      row.Qty / factor,
+			DerivedQty:   row.Qty % factor,
+			UnitCost:     row.UnitCost,
+			Value:        util.DivRound(row.Qty*row.UnitCost, factor),
+		}
+		valuation.Products = append(valuation.Products, p)
+		valuation.Total += p.Value
+
+		i, ok := index[p.CategoryId]
+		if !ok {
+			i = len(valuation.Categories)
+			index[p.CategoryId] = i
+			valuation.Categories = append(valuation.Categories, CategoryValuationDTO{
+				CategoryId:   p.CategoryId,
+				CategoryName: p.CategoryName,
+			})
+		}
+		valuation.Categories[i].Products++
+		valuation.Categories[i].Value += p.Value
+	}
+	return valuation
+}
@@ -0,0 +1,79 @@
+package valuation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sankangkin/di-rest-api/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValuate(t *testing.T) {
+	valuation := valuate([]stockRow{
+		{ProductId: "P001", CategoryId: 1, CategoryName: "Pipes", Factor: 12, Qty: 5*12 + 6, UnitCost: 1200},
+		{ProductId: "P002", CategoryId: 1, CategoryName: "Pipes", Factor: 1, Qty: 3, UnitCost: 250},
+		{ProductId: "P003", CategoryId: 2, CategoryName: "Tools", Factor: 10, Qty: 5, UnitCost: 333},
+		{ProductId: "P004", CategoryId: 2, CategoryName: "Tools", Qty: -2, UnitCost: 100},
+	})
+
+	p001 := valuation.Products[0]
+	assert.Equal(t, int64(5), p001.BaseQty)
+	assert.Equal(t, int64(6), p001.DerivedQty)
+	assert.Equal(t, int64(6600), p001.Value)
+
+	// half a unit at 333 is 166.5
+	assert.Equal(t, int64(167), valuation.Products[2].Value)
+	// stock below zero counts against the total
+	assert.Equal(t, int64(-200), valuation.Products[3].Value)
+
+	assert.Equal(t, []CategoryValuationDTO{
+		{CategoryId: 1, CategoryName: "Pipes", Products: 2, Value: 7350},
+		{CategoryId: 2, CategoryName: "Tools", Products: 2, Value: -33},
+	}, valuation.Categories)
+	assert.Equal(t, int64(7317), valuation.Total)
+}
+
+type stocksAt struct {
+	before time.Time
+}
+
+func (r *stocksAt) GetStocks(before time.Time, locationId uint, categoryId uint) ([]stockRow, error) {
+	r.before = before
+	return nil, nil
+}
+
+func TestGetValuationEndsAtLocalMidnight(t *testing.T) {
+	for _, name := range []string{"Asia/Yangon", "America/New_York", "UTC"} {
+		t.Run(name, func(t *testing.T) {
+			zone, err := time.LoadLocation(name)
+			require.NoError(t, err)
+			repo := &stocksAt{}
+			svc := NewValuationService(repo, &config.Config{Shop: config.ShopConfig{TimeZone: zone}})
+
+			asOf, _ := time.Parse(time.DateOnly, "2025-03-10")
+			valuation, err := svc.GetValuation(asOf, 0, 0)
+			require.NoError(t, err)
+
+			assert.Equal(t, "2025-03-10", valuation.AsOf)
+			assert.True(t, repo.before.Equal(time.Date(2025, time.March, 11, 0, 0, 0, 0, zone)), repo.before)
+			// a sale rung up at 11pm local is still on the 10th
+			lateSale := time.Date(2025, time.March, 10, 23, 0, 0, 0, zone)
+			assert.True(t, lateSale.Before(repo.before))
+		})
+	}
+}
+
+func TestGetValuationDefaultsToLocalToday(t *testing.T) {
+	zone, err := time.LoadLocation("Pacific/Kiritimati") // UTC+14, a day ahead of UTC most of the time
+	require.NoError(t, err)
+	repo := &stocksAt{}
+	svc := NewValuationService(repo, &config.Config{Shop: config.ShopConfig{TimeZone: zone}})
+
+	valuation, err := svc.GetValuation(time.Time{}, 0, 0)
+	require.NoError(t, err)
+
+	today := time.Now().In(zone)
+	assert.Equal(t, today.Format(time.DateOnly), valuation.AsOf)
+	assert.Equal(t, zone, repo.before.Location())
+}
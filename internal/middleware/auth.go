@@ -1,59 +1,43 @@
 package middleware
 
 import (
+	"errors"
+
 	jwtware "github.com/gofiber/contrib/jwt"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
-	"github.com/sankangkin/di-rest-api/internal/domain/util"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/config"
 )
 
+// SessionChecker tells whether the login session an access token was issued
+// for ("sid" claim) is still open, i.e. not logged out or revoked.
+type SessionChecker interface {
+	IsSessionActive(sessionId string) bool
+}
 
-func Protected() fiber.Handler {
+func Protected(cfg *config.Config, sessions SessionChecker) fiber.Handler {
 	return jwtware.New(jwtware.Config{
 		SigningKey: jwtware.SigningKey{
-			Key: []byte(util.SecreteKey),
+			JWTAlg: jwtware.HS256,
+			Key:    []byte(cfg.JWT.Secret),
+		},
+		SuccessHandler: func(c *fiber.Ctx) error {
+			token := c.Locals("user").(*jwt.Token)
+			claims, _ := token.Claims.(jwt.MapClaims)
+			sessionId, _ := claims["sid"].(string)
+			if !sessions.IsSessionActive(sessionId) {
+				return apperr.Unauthorized("session revoked")
+			}
+			return c.Next()
 		},
 		ErrorHandler: jwtError,
 	})
 }
 
 func jwtError(c *fiber.Ctx, err error) error {
-	if err.Error() == "Missing or malformed JWT" {
-		return c.Status(fiber.StatusBadRequest).
-			JSON(fiber.Map{
-				"status":  "error",
-				"message": "Missing or malformed JWT",
-				"data":    nil,
-			})
+	if errors.Is(err, jwtware.ErrJWTMissingOrMalformed) {
+		return apperr.Unauthorized("missing or malformed JWT")
 	}
-	return c.Status(fiber.StatusUnauthorized).
-		JSON(fiber.Map{
-			"status":  "error",
-			"message": "Unauthorized",
-			"data":    nil,
-		})
+	return apperr.Unauthorized("invalid or expired JWT").Wrap(err)
 }
-
-func Authorize(c *fiber.Ctx) error {
-
-	tokenString := c.Cookies("refresh_token")
-	claims := jwt.MapClaims{}
-
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
-		return []byte(util.SecreteKey), nil
-	})
-
-	if err != nil {
-		c.JSON(err.Error())
-	}
-
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		role := claims["role"].(string)
-		if role == "ADMIN" {
-			c.Next()
-		}
-
-	}
-	return nil
-
-}
\ No newline at end of file
@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/models"
+)
+
+// Rule limits one route to a set of roles. Path is matched segment by
+// segment against the request path: ":name" matches any single segment and a
+// trailing "*" matches the rest. An empty Method matches every method.
+type Rule struct {
+	Method string
+	Path   string
+	Roles  []models.Role
+}
+
+// Policy is a route-by-route permission table. The first matching rule wins;
+// routes without a rule are open to every authenticated role.
+type Policy []Rule
+
+// Allows reports whether role may call method on path.
+func (p Policy) Allows(method, path string, role models.Role) bool {
+	if !knownRole(role) {
+		return false
+	}
+	for _, rule := range p {
+		if rule.Method != "" && !strings.EqualFold(rule.Method, method) {
+			continue
+		}
+		if !matchPath(rule.Path, path) {
+			continue
+		}
+		for _, allowed := range rule.Roles {
+			if allowed == role {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// Authorize checks the role claim of the token Protected() validated and put
+// in the context against the policy, and fails with apperr.Forbidden (403)
+// when it is not allowed.
+// It must run after Protected().
+func Authorize(policy Policy) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		token, ok := c.Locals("user").(*jwt.Token)
+		if !ok || token == nil {
+			return apperr.Unauthorized("missing or malformed JWT")
+		}
+
+		role := RoleOf(token)
+		if !policy.Allows(c.Method(), c.Path(), role) {
+			return apperr.Forbidden("role %q may not %s %s", role, c.Method(), c.Path())
+		}
+		return c.Next()
+	}
+}
+
+// RoleOf returns the role claim of the token, normalised to the stored form
+// ("admin", "user"); tokens minted with "ADMIN" still work.
+func RoleOf(token *jwt.Token) models.Role {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return ""
+	}
+	role, _ := claims["role"].(string)
+	return models.Role(strings.ToLower(role))
+}
+
+// UserName returns the userName claim of the token Protected() put in the
+// context, empty when there is none.
+func UserName(c *fiber.Ctx) string {
+	token, ok := c.Locals("user").(*jwt.Token)
+	if !ok || token == nil {
+		return ""
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return ""
+	}
+	name, _ := claims["userName"].(string)
+	return name
+}
+
+func knownRole(role models.Role) bool {
+	return role == models.ADMIN || role == models.USER
+}
+
+func matchPath(pattern, path string) bool {
+	patternSegs := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathSegs := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i, seg := range patternSegs {
+		if seg == "*" {
+			return true
+		}
+		if i >= len(pathSegs) {
+			return false
+		}
+		if strings.HasPrefix(seg, ":") {
+			if pathSegs[i] == "" {
+				return false
+			}
+			continue
+		}
+		if !strings.EqualFold(seg, pathSegs[i]) {
+			return false
+		}
+	}
+	return len(patternSegs) == len(pathSegs)
+}
@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/sankangkin/di-rest-api/internal/config"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchPath(t *testing.T) {
+	tests := []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"/api/products", "/api/products", true},
+		{"/api/products", "/api/products/", true},
+		{"/api/products", "/API/Products", true},
+		{"/api/products/:id", "/api/products/P001", true},
+		{"/api/products/:id", "/api/products", false},
+		{"/api/products/:id", "/api/products/P001/prices", false},
+		{"/api/inventories/*", "/api/inventories/increase", true},
+		{"/api/inventories/*", "/api/inventories/a/b", true},
+		{"/api/inventories/*", "/api/products", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, matchPath(tt.pattern, tt.path), "%s ~ %s", tt.pattern, tt.path)
+	}
+}
+
+func TestPolicyAllows(t *testing.T) {
+	policy := Policy{
+		{Method: fiber.MethodDelete, Path: "/api/products/:id", Roles: []models.Role{models.ADMIN}},
+		{Path: "/api/reports/*", Roles: []models.Role{models.ADMIN}},
+		{Method: fiber.MethodGet, Path: "/api/reports/daily", Roles: []models.Role{models.USER}},
+	}
+
+	tests := []struct {
+		method, path string
+		role         models.Role
+		want         bool
+	}{
+		{fiber.MethodDelete, "/api/products/P001", models.ADMIN, true},
+		{fiber.MethodDelete, "/api/products/P001", models.USER, false},
+		{fiber.MethodGet, "/api/products/P001", models.USER, true},
+		{fiber.MethodGet, "/api/reports/daily", models.USER, false}, // first rule wins
+		{fiber.MethodPost, "/api/reports/daily", models.ADMIN, true},
+		{fiber.MethodGet, "/api/customers", models.USER, true},
+		{fiber.MethodGet, "/api/customers", "guest", false},
+		{fiber.MethodGet, "/api/customers", "", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, policy.Allows(tt.method, tt.path, tt.role), "%s %s as %q", tt.method, tt.path, tt.role)
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	policy := Policy{{Method: fiber.MethodDelete, Path: "/api/products/:id", Roles: []models.Role{models.ADMIN}}}
+
+	tests := []struct {
+		name       string
+		token      *jwt.Token
+		wantStatus int
+	}{
+		{name: "no token", wantStatus: fiber.StatusUnauthorized},
+		{name: "user", token: &jwt.Token{Claims: jwt.MapClaims{"role": "user"}}, wantStatus: fiber.StatusForbidden},
+		{name: "admin", token: &jwt.Token{Claims: jwt.MapClaims{"role": "admin"}}, wantStatus: fiber.StatusOK},
+		{name: "upper case admin", token: &jwt.Token{Claims: jwt.MapClaims{"role": "ADMIN"}}, wantStatus: fiber.StatusOK},
+		{name: "no role claim", token: &jwt.Token{Claims: jwt.MapClaims{}}, wantStatus: fiber.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New(fiber.Config{ErrorHandler: apperr.Handler})
+			app.Use(func(c *fiber.Ctx) error {
+				if tt.token != nil {
+					c.Locals("user", tt.token)
+				}
+				return c.Next()
+			}, Authorize(policy))
+			app.Delete("/api/products/:id", func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodDelete, "/api/products/P001", nil))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}
+
+type sessions map[string]bool
+
+func (s sessions) IsSessionActive(sessionId string) bool { return s[sessionId] }
+
+func TestProtectedRejectsClosedSessions(t *testing.T) {
+	cfg := &config.Config{JWT: config.JWTConfig{Secret: "0123456789abcdef0123456789abcdef"}}
+	open := sessions{"open": true}
+	sign := func(claims jwt.MapClaims) string {
+		claims["exp"] = time.Now().Add(time.Minute).Unix()
+		signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(cfg.JWT.Secret))
+		assert.NoError(t, err)
+		return signed
+	}
+
+	tests := []struct {
+		name       string
+		token      string
+		wantStatus int
+	}{
+		{name: "open session", token: sign(jwt.MapClaims{"sid": "open"}), wantStatus: fiber.StatusOK},
+		{name: "revoked session", token: sign(jwt.MapClaims{"sid": "revoked"}), wantStatus: fiber.StatusUnauthorized},
+		{name: "no session", token: sign(jwt.MapClaims{}), wantStatus: fiber.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New(fiber.Config{ErrorHandler: apperr.Handler})
+			app.Get("/", Protected(cfg, open), func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}
@@ -1,9 +1,10 @@
 package models
 
 import (
-	"fmt"
+	"errors"
 	"time"
 
+	"github.com/sankangkin/di-rest-api/internal/apperr"
 	"gorm.io/gorm"
 
 	"github.com/go-playground/locales/en"
@@ -36,6 +37,7 @@ type Product struct {
 	UomId            uint              `json:"uomId" validate:"required"`
 	DeriveUomId      uint              `json:"deriveUomId" validate:"required"`
 	BuyPrice         int64             `json:"buyPrice" validate:"required,min=1"`
+	AvgCost          int64             `json:"avgCost"` // moving-average cost of one base unit, kept by the stock engine
 	SellPriceLevel1  int64             `json:"sellPricelvl1" validate:"required,min=1"`
 	DeriveUnitPrice  int64             `json:"deriveUnitPrice" validate:"required,min=1"`
 	BrandName        string            `json:"brandName"`
@@ -52,6 +54,28 @@ type UnitOfMeasure struct {
 	UnitConversion []UnitConversion `gorm:"foreignKey:BaseUnitId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
 }
 
+// BarcodeKind is the numbering a ProductBarcode follows.
+type BarcodeKind string
+
+const (
+	BarcodeEAN      BarcodeKind = "EAN" // EAN-8 or EAN-13, with check digit
+	BarcodeUPC      BarcodeKind = "UPC" // UPC-A, with check digit
+	BarcodeInternal BarcodeKind = "INTERNAL"
+)
+
+// ProductBarcode is one scannable code of a product in one of its units, so
+// a PACK and an EACH of the same product can carry different codes.
+type ProductBarcode struct {
+	gorm.Model
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	Code      string         `gorm:"type:varchar(64);uniqueIndex" json:"code" validate:"required,max=64"`
+	Kind      BarcodeKind    `gorm:"type:varchar(20);default:INTERNAL" json:"kind"`
+	ProductId string         `gorm:"index" json:"productId" validate:"required"`
+	Product   *Product       `json:"-"`
+	UnitId    uint           `json:"unitId" validate:"required"`
+	Unit      *UnitOfMeasure `gorm:"foreignKey:UnitId" json:"-"`
+}
+
 type ProductPrice struct {
 	gorm.Model
 	ID        uint   `gorm:"primaryKey" json:"id"`
@@ -72,10 +96,24 @@ type ProductPriceHistory struct {
 	CreatedAt     time.Time
 }
 
+// Location is a place stock is kept, e.g. the shop or the yard. Documents
+// that name no location use the default one.
+type Location struct {
+	gorm.Model
+	ID        uint   `gorm:"primaryKey:autoIncrement" json:"id"`
+	Code      string `gorm:"type:varchar(20);uniqueIndex" json:"code" validate:"required,max=20"`
+	Name      string `json:"name" validate:"required,min=3"`
+	IsDefault bool   `json:"isDefault"`
+	CreatedAt int64  `gorm:"autoCreateTime" json:"-"`
+	UpdatedAt int64  `gorm:"autoUpdateTime" json:"-"` // seconds, like CreatedAt
+}
+
+// ProductStock is the stock of one product at one location.
 type ProductStock struct {
 	gorm.Model
 	ID           uint   `gorm:"primaryKey" json:"id"`
-	ProductId    string `gorm:"type:varchar(20)" json:"productId"`
+	ProductId    string `gorm:"type:varchar(20);uniqueIndex:idx_product_stocks_product_location" json:"productId"`
+	LocationId   uint   `gorm:"uniqueIndex:idx_product_stocks_product_location" json:"locationId"`
 	BaseUnitId   int    `json:"baseUnitId" validate:"required"`
 	DeriveUnitId int    `json:"deriveUnitId" validate:"required"`
 	BaseQty      int    `json:"baseQty" validate:"required,min=1"`
@@ -97,14 +135,15 @@ type UnitConversion struct {
 
 type Inventory struct {
 	gorm.Model
-	ID        uint      `gorm:"primaryKey:autoIncrement" json:"id"`
-	OutQty    int       `json:"inQty"`
-	InQty     int       `json:"outQty"`
-	ProductId string    `json:"productId"`
-	Product   Product   `gorm:"foreignKey:ProductId;" json:"product"`
-	Remark    string    `json:"remark"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"createdTime"`
-	UpdatedAt time.Time `gorm:"autoCreateTime" json:"updatedTime"`
+	ID         uint      `gorm:"primaryKey:autoIncrement" json:"id"`
+	OutQty     int       `json:"inQty"`
+	InQty      int       `json:"outQty"`
+	ProductId  string    `json:"productId"`
+	Product    Product   `gorm:"foreignKey:ProductId;" json:"product"`
+	LocationId uint      `json:"locationId"`
+	Remark     string    `json:"remark"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"createdTime"`
+	UpdatedAt  time.Time `gorm:"autoCreateTime" json:"updatedTime"`
 }
 
 type ItemTransaction struct {
@@ -112,15 +151,91 @@ type ItemTransaction struct {
 	// TODO to enhance with UUID
 	ID          uint      `gorm:"primaryKey:autoIncrement" json:"id"`
 	ProductId   string    `json:"productId"`
+	LocationId  uint      `gorm:"index" json:"locationId"`
 	ReferenceNo string    `json:"referenceNo"`
 	InQty       int       `json:"inQty"`
 	OutQty      int       `json:"outQty"`
 	Uom         string    `json:"uom"`
 	TranType    string    `json:"tranType"`
 	Remark      string    `json:"remark"`
+	UnitCost    int64     `json:"unitCost"` // the product's average cost of one base unit after this movement
 	CreatedAt   time.Time `gorm:"autoCreateTime" json:"createdTime"`
 }
 
+// StockTransfer moves stock from one location to another. Each line is
+// booked as a TRANSFER out of FromLocationId and a TRANSFER into
+// ToLocationId, both with the reference "<transferId>-<lineId>".
+type StockTransfer struct {
+	gorm.Model
+	ID                   string                `gorm:"primaryKey" json:"id"`
+	FromLocationId       uint                  `json:"fromLocationId" validate:"required"`
+	ToLocationId         uint                  `json:"toLocationId" validate:"required"`
+	StockTransferDetails []StockTransferDetail `gorm:"foreignKey:StockTransferId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"stockTransferDetails" validate:"required,min=1,dive"`
+	Remark               string                `json:"remark"`
+	TransferDate         string                `json:"transferDate"`
+	CreatedAt            int64                 `gorm:"autoCreateTime" json:"-"`
+	UpdatedAt            int64                 `gorm:"autoUpdateTime:milli" json:"-"`
+}
+
+type StockTransferDetail struct {
+	gorm.Model
+	ID              uint   `gorm:"primaryKey:autoIncrement" json:"id"`
+	StockTransferId string `json:"stockTransferId"`
+	ProductId       string `gorm:"type:varchar(20)" json:"productId" validate:"required"`
+	Qty             int    `json:"qty" validate:"required,min=1"`
+	Uom             string `json:"uom"` // empty is the base unit
+}
+
+const (
+	StockTakeOpen   = "OPEN"
+	StockTakePosted = "POSTED"
+)
+
+// StockTake is a physical count of one location. Opening it snapshots the
+// expected stock of each product into its lines; counters then enter what
+// they found, and posting books every variance as an ADJUSTMENT and locks the
+// session.
+type StockTake struct {
+	gorm.Model
+	ID             string          `gorm:"primaryKey" json:"id"`
+	LocationId     uint            `gorm:"index" json:"locationId"`
+	Status         string          `gorm:"type:varchar(10);default:OPEN;index" json:"status"`
+	Remark         string          `json:"remark"`
+	PostedAt       *time.Time      `json:"postedAt,omitempty"`
+	StockTakeLines []StockTakeLine `gorm:"foreignKey:StockTakeId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"lines"`
+	CreatedAt      int64           `gorm:"autoCreateTime" json:"-"`
+	UpdatedAt      int64           `gorm:"autoUpdateTime:milli" json:"-"`
+}
+
+// StockTakeLine is one product of a stock take. The expected quantities,
+// conversion factor and buy price are snapshots taken when the count was
+// opened; the counted quantities are the sum of the counts entered so far.
+type StockTakeLine struct {
+	gorm.Model
+	ID                 uint             `gorm:"primaryKey:autoIncrement" json:"id"`
+	StockTakeId        string           `gorm:"uniqueIndex:idx_stock_take_lines_product" json:"stockTakeId"`
+	ProductId          string           `gorm:"type:varchar(20);uniqueIndex:idx_stock_take_lines_product" json:"productId"`
+	Factor             int              `json:"factor"` // derived units per base unit, 1 without a derived unit
+	BuyPrice           int64            `json:"buyPrice"`
+	ExpectedBaseQty    int              `json:"expectedBaseQty"`
+	ExpectedDerivedQty int              `json:"expectedDerivedQty"`
+	CountedBaseQty     int              `json:"countedBaseQty"`
+	CountedDerivedQty  int              `json:"countedDerivedQty"`
+	Counted            bool             `json:"counted"` // lines nobody counted are left alone when posting
+	StockTakeCounts    []StockTakeCount `gorm:"foreignKey:StockTakeLineId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"counts,omitempty"`
+}
+
+// StockTakeCount is what one counter found of a product. A counter entering
+// the product again replaces their earlier count.
+type StockTakeCount struct {
+	gorm.Model
+	ID              uint   `gorm:"primaryKey:autoIncrement" json:"id"`
+	StockTakeLineId uint   `gorm:"uniqueIndex:idx_stock_take_counts_counter" json:"stockTakeLineId"`
+	CountedBy       string `gorm:"uniqueIndex:idx_stock_take_counts_counter" json:"countedBy"`
+	BaseQty         int    `json:"baseQty"`
+	DerivedQty      int    `json:"derivedQty"`
+}
+
 type Role string
 
 const (
@@ -140,26 +255,42 @@ type User struct {
 	UpdatedAt int64  `gorm:"autoUpdateTime:milli" json:"-"`
 }
 
+// RefreshToken is one issued refresh token, stored as its SHA-256 hash. All
+// tokens rotated out of one login share a FamilyId, which is also the session
+// id ("sid") carried by the access tokens issued alongside them.
+type RefreshToken struct {
+	ID        uint   `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserId    uint   `gorm:"index" json:"userId"`
+	FamilyId  string `gorm:"type:varchar(32);index" json:"familyId"`
+	TokenHash string `gorm:"type:varchar(64);uniqueIndex" json:"-"`
+	ExpiresAt int64  `json:"expiresAt"`
+	UsedAt    int64  `json:"usedAt"`
+	RevokedAt int64  `json:"revokedAt"`
+	CreatedAt int64  `gorm:"autoCreateTime" json:"createdAt"`
+}
+
 type Customer struct {
 	gorm.Model
-	ID        uint   `gorm:"primaryKey:autoIncrement" json:"id"`
-	Name      string `json:"name" validate:"required,min=3"`
-	Address   string `json:"address" validate:"required,min=3"`
-	Phone     string `json:"phone" validate:"required,min=3"`
-	Sales     []Sale `gorm:"foreignKey:CustomerId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
-	CreatedAt int64  `gorm:"autoCreateTime" json:"-"`
-	UpdatedAt int64  `gorm:"autoUpdateTime:milli" json:"-"`
+	ID        uint      `gorm:"primaryKey:autoIncrement" json:"id"`
+	Name      string    `json:"name" validate:"required,min=3"`
+	Address   string    `json:"address" validate:"required,min=3"`
+	Phone     string    `json:"phone" validate:"required,min=3"`
+	Sales     []Sale    `gorm:"foreignKey:CustomerId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	Payments  []Payment `gorm:"foreignKey:CustomerId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	CreatedAt int64     `gorm:"autoCreateTime" json:"-"`
+	UpdatedAt int64     `gorm:"autoUpdateTime:milli" json:"-"`
 }
 
 type Supplier struct {
 	gorm.Model
-	ID        uint       `gorm:"primaryKey:autoIncrement" json:"id"`
-	Name      string     `json:"name" validate:"required,min=3"`
-	Address   string     `json:"address" validate:"required,min=3"`
-	Phone     string     `json:"phone" validate:"required,min=3"`
-	Purchases []Purchase `gorm:"foreignKey:SupplierId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
-	CreatedAt int64      `gorm:"autoCreateTime" json:"-"`
-	UpdatedAt int64      `gorm:"autoUpdateTime:milli" json:"-"`
+	ID        uint              `gorm:"primaryKey:autoIncrement" json:"id"`
+	Name      string            `json:"name" validate:"required,min=3"`
+	Address   string            `json:"address" validate:"required,min=3"`
+	Phone     string            `json:"phone" validate:"required,min=3"`
+	Purchases []Purchase        `gorm:"foreignKey:SupplierId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	Payments  []SupplierPayment `gorm:"foreignKey:SupplierId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	CreatedAt int64             `gorm:"autoCreateTime" json:"-"`
+	UpdatedAt int64             `gorm:"autoUpdateTime:milli" json:"-"`
 }
 
 type Purchase struct {
@@ -167,10 +298,18 @@ type Purchase struct {
 	ID              string           `gorm:"primaryKey" json:"id"`
 	SupplierId      uint             `json:"supplierId"`
 	Supplier        *Supplier        `json:"supplier"`
+	LocationId      uint             `json:"locationId"` // where returned goods are taken from
 	PurchaseDetails []PurchaseDetail `gorm:"foreignKey:PurchaseId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"purchaseDetails"`
+	PurchaseReturns []PurchaseReturn `gorm:"foreignKey:PurchaseId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"purchaseReturns,omitempty"`
+	GoodsReceipts   []GoodsReceipt   `gorm:"foreignKey:PurchaseId" json:"goodsReceipts,omitempty"`
 	Discount        int64            `json:"discount"`
 	Total           int64            `json:"total"`
+	TaxRate         int64            `json:"taxRate"` // basis points, 750 = 7.5%
+	Tax             int64            `json:"tax"`
 	GrandTotal      int64            `json:"grandTotal"`
+	ReturnedTotal   int64            `json:"returnedTotal"`
+	MatchStatus     string           `gorm:"type:varchar(20);index" json:"matchStatus"` // MATCHED or MISMATCHED against its goods receipts
+	MatchNote       string           `json:"matchNote,omitempty"`                       // received goods the invoice does not bill
 	Remark          string           `json:"remark"`
 	PurchaseDate    string           `json:"purchaseDate"`
 	CreatedAt       int64            `gorm:"autoCreateTime" json:"-"`
@@ -183,25 +322,159 @@ type PurchaseDetail struct {
 	ProductId   string `gorm:"type:varchar(20)" json:"productId"`
 	ProductName string `json:"productName"`
 	Qty         int    `json:"qty"`
+	ReturnedQty int    `json:"returnedQty"`
 	Price       int64  `json:"price"`
 	UnitName    string `json:"unitName"`
-	Total       int64  `json:"total"`
+	Discount    int64  `json:"discount"`
+	Total       int64  `json:"total"` // Qty x Price - Discount
 	PurchaseId  string `json:"purchaseId"`
+	// what the matched goods receipts say about the product in this unit
+	ReceivedQty   int   `json:"receivedQty"`
+	ReceivedPrice int64 `json:"receivedPrice"` // the order price
+	QtyMismatch   bool  `json:"qtyMismatch"`
+	PriceMismatch bool  `json:"priceMismatch"`
 }
 
+// A Purchase is the supplier's invoice. It moves no stock, it is matched
+// against the goods receipts it bills.
+const (
+	PurchaseMatched    = "MATCHED"
+	PurchaseMismatched = "MISMATCHED"
+)
+
+// A purchase order goes DRAFT -> SENT -> PARTIALLY_RECEIVED -> RECEIVED as
+// goods receipts come in, or is CLOSED before that with nothing more to come.
+const (
+	PurchaseOrderDraft             = "DRAFT"
+	PurchaseOrderSent              = "SENT"
+	PurchaseOrderPartiallyReceived = "PARTIALLY_RECEIVED"
+	PurchaseOrderReceived          = "RECEIVED"
+	PurchaseOrderClosed            = "CLOSED"
+)
+
+// PurchaseOrder is what is to be bought from a supplier. It does not touch
+// stock; a draft is made by hand or from the reorder suggestions.
+type PurchaseOrder struct {
+	gorm.Model
+	ID                 string              `gorm:"primaryKey" json:"id"`
+	SupplierId         uint                `json:"supplierId" validate:"required"`
+	Supplier           *Supplier           `json:"supplier,omitempty"`
+	LocationId         uint                `json:"locationId"` // where the goods are to be received
+	Status             string              `gorm:"type:varchar(20);default:DRAFT;index" json:"status"`
+	PurchaseOrderLines []PurchaseOrderLine `gorm:"foreignKey:PurchaseOrderId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"lines" validate:"required,min=1,dive"`
+	GoodsReceipts      []GoodsReceipt      `gorm:"foreignKey:PurchaseOrderId" json:"goodsReceipts,omitempty"`
+	Total              int64               `json:"total"`
+	Remark             string              `json:"remark"`
+	OrderDate          string              `json:"orderDate"`
+	CreatedAt          int64               `gorm:"autoCreateTime" json:"-"`
+	UpdatedAt          int64               `gorm:"autoUpdateTime:milli" json:"-"`
+}
+
+type PurchaseOrderLine struct {
+	gorm.Model
+	ID              uint   `gorm:"primaryKey:autoIncrement" json:"id"`
+	PurchaseOrderId string `json:"purchaseOrderId"`
+	ProductId       string `gorm:"type:varchar(20)" json:"productId" validate:"required"`
+	ProductName     string `json:"productName"`
+	Qty             int    `json:"qty" validate:"required,min=1"`
+	ReceivedQty     int    `json:"receivedQty"`
+	UnitName        string `json:"unitName"` // empty is the base unit
+	Price           int64  `json:"price" validate:"min=0"`
+	Total           int64  `json:"total"` // Qty x Price
+}
+
+// GoodsReceipt, a GRN, books goods that arrived for a PurchaseOrder into the
+// stock of the order's location. It is the only purchase document that moves
+// stock in.
+type GoodsReceipt struct {
+	gorm.Model
+	ID                string             `gorm:"primaryKey" json:"id"`
+	PurchaseOrderId   string             `gorm:"index" json:"purchaseOrderId"`
+	SupplierId        uint               `json:"supplierId"`
+	LocationId        uint               `json:"locationId"`
+	PurchaseId        *string            `gorm:"index" json:"purchaseId"` // the invoice that bills it, nil until invoiced
+	GoodsReceiptLines []GoodsReceiptLine `gorm:"foreignKey:GoodsReceiptId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"lines"`
+	Remark            string             `json:"remark"`
+	ReceiptDate       string             `json:"receiptDate"`
+	CreatedAt         int64              `gorm:"autoCreateTime" json:"-"`
+	UpdatedAt         int64              `gorm:"autoUpdateTime:milli" json:"-"`
+}
+
+type GoodsReceiptLine struct {
+	gorm.Model
+	ID                  uint   `gorm:"primaryKey:autoIncrement" json:"id"`
+	GoodsReceiptId      string `json:"goodsReceiptId"`
+	PurchaseOrderLineId uint   `json:"purchaseOrderLineId"`
+	ProductId           string `gorm:"type:varchar(20)" json:"productId"`
+	Qty                 int    `json:"qty"`
+	UnitName            string `json:"unitName"` // the unit the stock was booked in
+	Price               int64  `json:"price"`    // the order price
+}
+
+// PurchaseReturn sends goods of an earlier Purchase back to its supplier.
+type PurchaseReturn struct {
+	gorm.Model
+	ID                    uint                   `gorm:"primaryKey:autoIncrement" json:"id"`
+	PurchaseId            string                 `json:"purchaseId"`
+	SupplierId            uint                   `json:"supplierId"`
+	PurchaseReturnDetails []PurchaseReturnDetail `gorm:"foreignKey:PurchaseReturnId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"purchaseReturnDetails"`
+	Total                 int64                  `json:"total"`
+	Remark                string                 `json:"remark"`
+	ReturnDate            string                 `json:"returnDate"`
+	CreatedAt             int64                  `gorm:"autoCreateTime" json:"-"`
+	UpdatedAt             int64                  `gorm:"autoUpdateTime:milli" json:"-"`
+}
+
+type PurchaseReturnDetail struct {
+	gorm.Model
+	ID               uint   `gorm:"primaryKey:autoIncrement" json:"id"`
+	PurchaseReturnId uint   `json:"purchaseReturnId"`
+	PurchaseDetailId uint   `json:"purchaseDetailId"`
+	ProductId        string `gorm:"type:varchar(20)" json:"productId"`
+	Qty              int    `json:"qty"`
+	UnitName         string `json:"unitName"`
+	Price            int64  `json:"price"`
+	Total            int64  `json:"total"`
+}
+
+type SaleStatus string
+
+const (
+	SaleActive            SaleStatus = "ACTIVE"
+	SalePartiallyReturned SaleStatus = "PARTIALLY_RETURNED"
+	SaleReturned          SaleStatus = "RETURNED"
+	SaleVoided            SaleStatus = "VOIDED"
+)
+
+type PaymentType string
+
+const (
+	PaymentCash    PaymentType = "CASH"    // paid in full at the counter
+	PaymentCredit  PaymentType = "CREDIT"  // bought on account
+	PaymentPartial PaymentType = "PARTIAL" // part paid at the counter, rest on account
+)
+
 type Sale struct {
 	gorm.Model
-	ID          string       `gorm:"primaryKey" json:"id"`
-	CustomerId  uint         `json:"customerId"`
-	Customer    *Customer    `json:"customer"`
-	SaleDetails []SaleDetail `gorm:"foreignKey:SaleId;reference:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"saleDetails"`
-	Discount    int64        `json:"discount"`
-	Total       int64        `json:"total"`
-	GrandTotal  int64        `json:"grandTotal"`
-	Remark      string       `json:"remark"`
-	SaleDate    string       `json:"saleDate"`
-	CreatedAt   int64        `gorm:"autoCreateTime" json:"-"`
-	UpdatedAt   int64        `gorm:"autoUpdateTime:milli" json:"-"`
+	ID            string       `gorm:"primaryKey" json:"id"`
+	CustomerId    uint         `json:"customerId"`
+	Customer      *Customer    `json:"customer"`
+	LocationId    uint         `json:"locationId"` // where the goods are taken from
+	SaleDetails   []SaleDetail `gorm:"foreignKey:SaleId;reference:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"saleDetails"`
+	SaleReturns   []SaleReturn `gorm:"foreignKey:SaleId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"saleReturns,omitempty"`
+	Discount      int64        `json:"discount"`
+	Total         int64        `json:"total"`
+	TaxRate       int64        `json:"taxRate"` // basis points, 750 = 7.5%
+	Tax           int64        `json:"tax"`
+	GrandTotal    int64        `json:"grandTotal"`
+	ReturnedTotal int64        `json:"returnedTotal"`
+	PaymentType   PaymentType  `json:"paymentType" gorm:"type:varchar(20);default:CASH"`
+	PaidAmount    int64        `json:"paidAmount"` // copy of the sum of the payment allocations to this sale
+	Status        SaleStatus   `json:"status" gorm:"type:varchar(20);default:ACTIVE"`
+	Remark        string       `json:"remark"`
+	SaleDate      string       `json:"saleDate"`
+	CreatedAt     int64        `gorm:"autoCreateTime" json:"-"`
+	UpdatedAt     int64        `gorm:"autoUpdateTime:milli" json:"-"`
 }
 
 type SaleDetail struct {
@@ -211,20 +484,101 @@ type SaleDetail struct {
 	ProductName string `json:"productName"`
 	Qty         int    `json:"qty"`
 	DerivedQty  int    `json:"derivedQty"`
+	ReturnedQty int    `json:"returnedQty"` // in the line's own unit (Qty for base, DerivedQty for derived)
 	Uom         string `json:"uom"`
 	Price       int64  `json:"price"`
-	Total       int64  `json:"total"`
+	Discount    int64  `json:"discount"`
+	Total       int64  `json:"total"` // line qty x Price - Discount
 	SaleId      string `json:"saleId"`
+	Barcode     string `gorm:"type:varchar(64)" json:"barcode,omitempty"` // scanned code the line was made from, if any
+}
+
+// SaleReturn is a reversal document against a Sale. A void is stored as a
+// SaleReturn with IsVoid set that covers every quantity not yet returned.
+type SaleReturn struct {
+	gorm.Model
+	ID                uint               `gorm:"primaryKey:autoIncrement" json:"id"`
+	SaleId            string             `json:"saleId"`
+	IsVoid            bool               `json:"isVoid"`
+	SaleReturnDetails []SaleReturnDetail `gorm:"foreignKey:SaleReturnId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"saleReturnDetails"`
+	Total             int64              `json:"total"`
+	Remark            string             `json:"remark"`
+	ReturnDate        string             `json:"returnDate"`
+	CreatedAt         int64              `gorm:"autoCreateTime" json:"-"`
+	UpdatedAt         int64              `gorm:"autoUpdateTime:milli" json:"-"`
+}
+
+type SaleReturnDetail struct {
+	gorm.Model
+	ID           uint   `gorm:"primaryKey:autoIncrement" json:"id"`
+	SaleReturnId uint   `json:"saleReturnId"`
+	SaleDetailId uint   `json:"saleDetailId"`
+	ProductId    string `json:"productId"`
+	Qty          int    `json:"qty"`
+	Uom          string `json:"uom"`
+	Price        int64  `json:"price"`
+	Total        int64  `json:"total"`
+}
+
+// Payment is money received from a customer. It is applied to one or more of
+// the customer's sales through PaymentAllocations; whatever is not allocated
+// stays on the customer's account as credit.
+type Payment struct {
+	ID                 uint                `gorm:"primaryKey:autoIncrement" json:"id"`
+	CustomerId         uint                `json:"customerId"`
+	Amount             int64               `json:"amount"`
+	Method             string              `gorm:"type:varchar(20)" json:"method"` // CASH, BANK, MOBILE, CHEQUE
+	PaymentDate        string              `json:"paymentDate"`
+	ReferenceNo        string              `json:"referenceNo"`
+	Remark             string              `json:"remark"`
+	PaymentAllocations []PaymentAllocation `gorm:"foreignKey:PaymentId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"paymentAllocations"`
+	CreatedAt          int64               `gorm:"autoCreateTime" json:"-"`
+	UpdatedAt          int64               `gorm:"autoUpdateTime:milli" json:"-"`
 }
 
-type ErrorResponse struct {
-	Field string                                 `json:"field"`
-	Tag   string                                 `json:"tag"`
-	Value string                                 `json:"value,omitempty"`
-	Info  validator.ValidationErrorsTranslations `json:"info"`
+type PaymentAllocation struct {
+	ID        uint   `gorm:"primaryKey:autoIncrement" json:"id"`
+	PaymentId uint   `json:"paymentId"`
+	SaleId    string `gorm:"type:varchar(30);index" json:"saleId"`
+	Amount    int64  `json:"amount"`
 }
 
-func ValidateStruct[T any](payload T) []*ErrorResponse {
+// SupplierPayment is money paid to a supplier, applied to its purchase
+// invoices through SupplierPaymentAllocations. What a purchase still owes is
+// grand_total - returned_total - its allocations.
+type SupplierPayment struct {
+	ID                         uint                        `gorm:"primaryKey:autoIncrement" json:"id"`
+	SupplierId                 uint                        `json:"supplierId"`
+	Amount                     int64                       `json:"amount"`
+	Method                     string                      `gorm:"type:varchar(20)" json:"method"` // CASH, BANK, MOBILE, CHEQUE
+	PaymentDate                string                      `json:"paymentDate"`
+	ReferenceNo                string                      `json:"referenceNo"`
+	Remark                     string                      `json:"remark"`
+	SupplierPaymentAllocations []SupplierPaymentAllocation `gorm:"foreignKey:SupplierPaymentId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"allocations"`
+	CreatedAt                  int64                       `gorm:"autoCreateTime" json:"-"`
+	UpdatedAt                  int64                       `gorm:"autoUpdateTime:milli" json:"-"`
+}
+
+type SupplierPaymentAllocation struct {
+	ID                uint   `gorm:"primaryKey:autoIncrement" json:"id"`
+	SupplierPaymentId uint   `json:"supplierPaymentId"`
+	PurchaseId        string `gorm:"type:varchar(30);index" json:"purchaseId"`
+	Amount            int64  `json:"amount"`
+}
+
+// DocumentSequence holds the last number handed out per document type and
+// numbering period ("2026-10" for monthly reset, "2026" yearly, "" never).
+type DocumentSequence struct {
+	ID        uint   `gorm:"primaryKey:autoIncrement" json:"id"`
+	DocType   string `gorm:"type:varchar(20);uniqueIndex:idx_doc_type_period" json:"docType"`
+	Period    string `gorm:"type:varchar(10);uniqueIndex:idx_doc_type_period" json:"period"`
+	LastNo    int64  `json:"lastNo"`
+	UpdatedAt int64  `gorm:"autoUpdateTime:milli" json:"-"`
+}
+
+// ValidateStruct checks the validate tags of payload and returns every
+// failed rule as one apperr validation error, nil when it passes.
+func ValidateStruct[T any](payload T) error {
 
 	en := en.New()
 	uni := ut.New(en, en)
@@ -234,45 +588,24 @@ func ValidateStruct[T any](payload T) []*ErrorResponse {
 	validate := validator.New()
 	en_translations.RegisterDefaultTranslations(validate, trans)
 
-	var errors []*ErrorResponse
 	err := validate.Struct(payload)
+	if err == nil {
+		return nil
+	}
 
-	if err != nil {
-
-		errTran := err.(validator.ValidationErrors)
-		fmt.Println(errTran.Translate(trans))
-		info := errTran.Translate(trans)
-
-		for _, err := range err.(validator.ValidationErrors) {
-			var element ErrorResponse
-			element.Field = err.StructNamespace()
-			element.Tag = err.Tag()
-			element.Value = err.Param()
-			element.Info = info
-			errors = append(errors, &element)
-		}
+	var invalid validator.ValidationErrors
+	if !errors.As(err, &invalid) {
+		return apperr.Validation("%s", err.Error())
+	}
 
+	fields := make([]apperr.FieldError, 0, len(invalid))
+	for _, fe := range invalid {
+		fields = append(fields, apperr.FieldError{
+			Field:   fe.StructNamespace(),
+			Tag:     fe.Tag(),
+			Param:   fe.Param(),
+			Message: fe.Translate(trans),
+		})
 	}
-	return errors
-}
-
-func MigrateModels(db *gorm.DB) error {
-	err := db.AutoMigrate(
-		&Category{},
-		&Product{},
-		&UnitOfMeasure{},
-		&UnitConversion{},
-		&ProductPrice{},
-		&ProductStock{},
-		&User{},
-		&Customer{},
-		&Supplier{},
-		&Sale{},
-		&SaleDetail{},
-		&Purchase{},
-		&PurchaseDetail{},
-		&ItemTransaction{},
-		&User{},
-	)
-	return err
+	return apperr.InvalidFields(fields)
 }
@@ -0,0 +1,323 @@
+// Package query parses the paging, sorting and filtering parameters of the
+// list endpoints, ?page=&limit=&sort=field,-field&<filter>=, and applies
+// them to the gorm queries of the repositories.
+package query
+
+import (
+	"encoding/base64"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// Kind says how the value of a filter parameter is parsed.
+type Kind int
+
+const (
+	Text Kind = iota // matched as is
+	Code             // upper-cased first, like product and sale ids
+	Uint
+	Bool
+	Date     // yyyy-mm-dd against a timestamp column
+	DateText // yyyy-mm-dd against a text column holding yyyy-mm-dd dates
+)
+
+// Filter binds a query parameter to a column.
+type Filter struct {
+	Column string
+	Kind   Kind
+}
+
+// Resource is what one list endpoint allows: the fields it sorts on, the
+// parameters it filters on, and how it pages.
+type Resource struct {
+	// Sort maps the field names of ?sort= to columns.
+	Sort map[string]string
+	// DefaultSort is used when ?sort= is empty, e.g. "-saleDate".
+	DefaultSort string
+	// Filters maps query parameters to columns, e.g. "categoryId".
+	Filters map[string]Filter
+	// Dates is the column of the ?from=&to= range, none when Column is "".
+	Dates Filter
+	// Key is a unique column. It breaks ties of the sort so pages do not
+	// overlap, and is the column of the cursor.
+	Key string
+	// Cursor pages with ?cursor= on Key instead of ?page=, for tables that
+	// only grow, like the ledger. Such a resource sorts on Key only.
+	Cursor bool
+}
+
+// SortField is one column of the ORDER BY.
+type SortField struct {
+	Column string
+	Desc   bool
+}
+
+type condition struct {
+	sql string
+	arg interface{}
+}
+
+// Spec is a parsed list request.
+type Spec struct {
+	Page  int
+	Limit int
+	// After is the key of the last row of the previous page in cursor mode.
+	After uint64
+	Sort  []SortField
+
+	res   Resource
+	where []condition
+}
+
+// Page is the paging metadata sent along with a list, as "meta".
+type Page struct {
+	Page       int    `json:"page,omitempty" example:"1"`
+	Limit      int    `json:"limit" example:"20"`
+	Total      int64  `json:"total" example:"135"`
+	TotalPages int    `json:"totalPages,omitempty" example:"7"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// Parse reads a Spec from the query parameters of a request, c.Queries().
+// Unknown sort fields and malformed values are validation errors; parameters
+// the resource does not know are ignored.
+func Parse(params map[string]string, res Resource) (Spec, error) {
+	s := Spec{Page: 1, Limit: DefaultLimit, res: res}
+
+	if v := params["limit"]; v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > MaxLimit {
+			return Spec{}, apperr.Validation("limit must be a number between 1 and %d, got %q", MaxLimit, v)
+		}
+		s.Limit = n
+	}
+
+	if res.Cursor {
+		if v := params["cursor"]; v != "" {
+			after, err := decodeCursor(v)
+			if err != nil {
+				return Spec{}, apperr.Validation("invalid cursor %q", v)
+			}
+			s.After = after
+		}
+	} else if v := params["page"]; v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return Spec{}, apperr.Validation("page must be a positive number, got %q", v)
+		}
+		s.Page = n
+	}
+
+	fields := params["sort"]
+	if fields == "" {
+		fields = res.DefaultSort
+	}
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		desc := strings.HasPrefix(field, "-")
+		column, ok := res.Sort[strings.TrimPrefix(field, "-")]
+		if !ok || (res.Cursor && column != res.Key) {
+			return Spec{}, apperr.Validation("cannot sort by %q", strings.TrimPrefix(field, "-"))
+		}
+		s.Sort = append(s.Sort, SortField{Column: column, Desc: desc})
+	}
+
+	names := make([]string, 0, len(res.Filters))
+	for name := range res.Filters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		f := res.Filters[name]
+		v := params[name]
+		if v == "" {
+			continue
+		}
+		arg, err := parseValue(f.Kind, v)
+		if err != nil {
+			return Spec{}, apperr.Validation("invalid %s %q", name, v)
+		}
+		s.where = append(s.where, condition{sql: f.Column + " = ?", arg: arg})
+	}
+
+	if res.Dates.Column != "" {
+		if err := s.parseDates(params["from"], params["to"]); err != nil {
+			return Spec{}, err
+		}
+	}
+
+	return s, nil
+}
+
+func parseValue(kind Kind, v string) (interface{}, error) {
+	switch kind {
+	case Code:
+		return strings.ToUpper(v), nil
+	case Uint:
+		return strconv.ParseUint(v, 10, 64)
+	case Bool:
+		return strconv.ParseBool(v)
+	}
+	return v, nil
+}
+
+// parseDates adds the from and to dates, both included.
+func (s *Spec) parseDates(from, to string) error {
+	var fromDay, toDay time.Time
+	var err error
+	if from != "" {
+		if fromDay, err = time.Parse(time.DateOnly, from); err != nil {
+			return apperr.Validation("from must be a yyyy-mm-dd date, got %q", from)
+		}
+	}
+	if to != "" {
+		if toDay, err = time.Parse(time.DateOnly, to); err != nil {
+			return apperr.Validation("to must be a yyyy-mm-dd date, got %q", to)
+		}
+	}
+	if from != "" && to != "" && toDay.Before(fromDay) {
+		return apperr.Validation("from %s is after to %s", from, to)
+	}
+
+	column := s.res.Dates.Column
+	if from != "" {
+		s.where = append(s.where, condition{sql: column + " >= ?", arg: s.dateArg(fromDay)})
+	}
+	if to != "" {
+		s.where = append(s.where, condition{sql: column + " < ?", arg: s.dateArg(toDay.AddDate(0, 0, 1))})
+	}
+	return nil
+}
+
+func (s *Spec) dateArg(day time.Time) interface{} {
+	if s.res.Dates.Kind == DateText {
+		return day.Format(time.DateOnly)
+	}
+	return day
+}
+
+// Filter adds the filters of the spec to db.
+func (s Spec) Filter(db *gorm.DB) *gorm.DB {
+	for _, w := range s.where {
+		db = db.Where(w.sql, w.arg)
+	}
+	return db
+}
+
+// withDefaults lets a zero Spec, one not made by Parse, load the first page.
+func (s Spec) withDefaults() Spec {
+	if s.Page < 1 {
+		s.Page = 1
+	}
+	if s.Limit < 1 {
+		s.Limit = DefaultLimit
+	}
+	return s
+}
+
+// order sorts db by the spec, then by the key of the resource.
+func (s Spec) order(db *gorm.DB) *gorm.DB {
+	keyed := false
+	desc := false
+	for _, f := range s.Sort {
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: f.Column, Raw: true}, Desc: f.Desc})
+		keyed = keyed || f.Column == s.res.Key
+		desc = f.Desc
+	}
+	if !keyed && s.res.Key != "" {
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: s.res.Key, Raw: true}, Desc: desc})
+	}
+	return db
+}
+
+// descending tells the direction of a cursor resource.
+func (s Spec) descending() bool {
+	return len(s.Sort) > 0 && s.Sort[0].Desc
+}
+
+// Find loads the page of the spec from db, which holds the table, joins and
+// select of the list, into dest, and counts the rows of all pages. Preloads
+// are only run for the rows of the page.
+func Find[T any](db *gorm.DB, s Spec, dest *[]T, preloads ...string) (Page, error) {
+	s = s.withDefaults()
+	db = s.Filter(db).Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return Page{}, err
+	}
+
+	rows := s.order(db).Offset((s.Page - 1) * s.Limit).Limit(s.Limit)
+	for _, p := range preloads {
+		rows = rows.Preload(p)
+	}
+	if err := rows.Find(dest).Error; err != nil {
+		return Page{}, err
+	}
+
+	return Page{
+		Page:       s.Page,
+		Limit:      s.Limit,
+		Total:      total,
+		TotalPages: int((total + int64(s.Limit) - 1) / int64(s.Limit)),
+	}, nil
+}
+
+// FindAfter is Find for a cursor resource: it loads the rows after the
+// cursor and sets NextCursor to the key of the last one while there are
+// more. key returns the value of the Key column of a row.
+func FindAfter[T any](db *gorm.DB, s Spec, dest *[]T, key func(T) uint64) (Page, error) {
+	s = s.withDefaults()
+	db = s.Filter(db).Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return Page{}, err
+	}
+
+	rows := db
+	if s.After != 0 {
+		op := " > ?"
+		if s.descending() {
+			op = " < ?"
+		}
+		rows = rows.Where(s.res.Key+op, s.After)
+	}
+	// one more row than asked tells whether there is a next page
+	if err := s.order(rows).Limit(s.Limit + 1).Find(dest).Error; err != nil {
+		return Page{}, err
+	}
+
+	page := Page{Limit: s.Limit, Total: total}
+	if len(*dest) > s.Limit {
+		*dest = (*dest)[:s.Limit]
+		page.NextCursor = encodeCursor(key((*dest)[s.Limit-1]))
+	}
+	return page, nil
+}
+
+func encodeCursor(key uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(key, 10)))
+}
+
+func decodeCursor(cursor string) (uint64, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(string(b), 10, 64)
+}
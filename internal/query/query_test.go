@@ -0,0 +1,92 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/sankangkin/di-rest-api/internal/apperr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+var sales = Resource{
+	Sort:        map[string]string{"id": "id", "saleDate": "sale_date", "grandTotal": "grand_total"},
+	DefaultSort: "-saleDate",
+	Filters: map[string]Filter{
+		"customerId": {Column: "customer_id", Kind: Uint},
+		"status":     {Column: "status", Kind: Code},
+	},
+	Dates: Filter{Column: "sale_date", Kind: DateText},
+	Key:   "id",
+}
+
+var ledger = Resource{
+	Sort:        map[string]string{"id": "id"},
+	DefaultSort: "id",
+	Key:         "id",
+	Cursor:      true,
+}
+
+type sale struct {
+	ID string
+}
+
+func dryRun(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+	return db
+}
+
+func TestParse(t *testing.T) {
+	s, err := Parse(map[string]string{}, sales)
+	require.NoError(t, err)
+	assert.Equal(t, 1, s.Page)
+	assert.Equal(t, DefaultLimit, s.Limit)
+	assert.Equal(t, []SortField{{Column: "sale_date", Desc: true}}, s.Sort)
+
+	s, err = Parse(map[string]string{"page": "3", "limit": "50", "sort": "grandTotal,-id"}, sales)
+	require.NoError(t, err)
+	assert.Equal(t, 3, s.Page)
+	assert.Equal(t, 50, s.Limit)
+	assert.Equal(t, []SortField{{Column: "grand_total"}, {Column: "id", Desc: true}}, s.Sort)
+
+	for name, params := range map[string]map[string]string{
+		"unknown sort field": {"sort": "password"},
+		"limit too high":     {"limit": "1000"},
+		"page zero":          {"page": "0"},
+		"bad customerId":     {"customerId": "abc"},
+		"bad date":           {"from": "01/02/2024"},
+		"range reversed":     {"from": "2024-02-01", "to": "2024-01-01"},
+	} {
+		_, err := Parse(params, sales)
+		assert.ErrorIs(t, err, apperr.ErrValidation, name)
+	}
+}
+
+func TestFilterAndOrder(t *testing.T) {
+	s, err := Parse(map[string]string{
+		"customerId": "7", "status": "voided", "from": "2024-01-01", "to": "2024-01-31", "sort": "grandTotal",
+	}, sales)
+	require.NoError(t, err)
+
+	db := dryRun(t)
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return s.order(s.Filter(tx.Table("sales"))).Limit(s.Limit).Find(&[]sale{})
+	})
+	assert.Equal(t, `SELECT * FROM "sales" WHERE customer_id = 7 AND status = 'VOIDED' AND sale_date >= '2024-01-01' AND sale_date < '2024-02-01' ORDER BY grand_total,id LIMIT 20`, sql)
+}
+
+func TestCursor(t *testing.T) {
+	s, err := Parse(map[string]string{"cursor": encodeCursor(42), "sort": "-id", "limit": "5"}, ledger)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), s.After)
+	assert.True(t, s.descending())
+
+	_, err = Parse(map[string]string{"cursor": "not a cursor"}, ledger)
+	assert.ErrorIs(t, err, apperr.ErrValidation)
+
+	// a cursor resource only sorts on its key
+	_, err = Parse(map[string]string{"sort": "createdAt"}, ledger)
+	assert.ErrorIs(t, err, apperr.ErrValidation)
+}
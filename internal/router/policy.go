@@ -0,0 +1,52 @@
+package router
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/middleware"
+	"github.com/sankangkin/di-rest-api/internal/models"
+)
+
+var adminOnly = []models.Role{models.ADMIN}
+
+// Policy lists the routes that are not open to every signed-in user. Keep it
+// next to the routes in router.go when adding or moving one.
+var Policy = middleware.Policy{
+	// user management
+	{Method: fiber.MethodPost, Path: "/api/auth/register", Roles: adminOnly},
+
+	// master data: prices live on products and product prices
+	{Method: fiber.MethodPost, Path: "/api/products", Roles: adminOnly},
+	{Method: fiber.MethodPut, Path: "/api/products/:id", Roles: adminOnly},
+	{Method: fiber.MethodDelete, Path: "/api/products/:id", Roles: adminOnly},
+	{Method: fiber.MethodPost, Path: "/api/products/:id/barcodes", Roles: adminOnly},
+	{Method: fiber.MethodDelete, Path: "/api/products/:id/barcodes/:code", Roles: adminOnly},
+	{Method: fiber.MethodPost, Path: "/api/productprices", Roles: adminOnly},
+	{Method: fiber.MethodPut, Path: "/api/productprices/:id", Roles: adminOnly},
+	{Method: fiber.MethodDelete, Path: "/api/categories/:id", Roles: adminOnly},
+	{Method: fiber.MethodPost, Path: "/api/uoms", Roles: adminOnly},
+	{Method: fiber.MethodPut, Path: "/api/uoms/:id", Roles: adminOnly},
+	{Method: fiber.MethodDelete, Path: "/api/uoms/:id", Roles: adminOnly},
+	{Method: fiber.MethodPost, Path: "/api/unitconversions", Roles: adminOnly},
+	{Method: fiber.MethodPut, Path: "/api/unitconversions/:id", Roles: adminOnly},
+	{Method: fiber.MethodDelete, Path: "/api/unitconversions/:id", Roles: adminOnly},
+	{Method: fiber.MethodDelete, Path: "/api/customers/:id", Roles: adminOnly},
+	{Method: fiber.MethodDelete, Path: "/api/suppliers/:id", Roles: adminOnly},
+	{Method: fiber.MethodPost, Path: "/api/locations", Roles: adminOnly},
+	{Method: fiber.MethodPut, Path: "/api/locations/:id", Roles: adminOnly},
+	{Method: fiber.MethodDelete, Path: "/api/locations/:id", Roles: adminOnly},
+
+	// stock outside of sales and purchases
+	{Method: fiber.MethodPost, Path: "/api/productstocks", Roles: adminOnly},
+	{Method: fiber.MethodPut, Path: "/api/productstocks/:id", Roles: adminOnly},
+	{Method: fiber.MethodPost, Path: "/api/inventories/*", Roles: adminOnly},
+	{Method: fiber.MethodPost, Path: "/api/transactions/adjustment", Roles: adminOnly},
+	{Method: fiber.MethodPost, Path: "/api/transfers", Roles: adminOnly},
+	{Method: fiber.MethodPost, Path: "/api/stocktakes", Roles: adminOnly},
+	{Method: fiber.MethodPost, Path: "/api/stocktakes/:id/post", Roles: adminOnly},
+
+	// cancelling a sale outright
+	{Method: fiber.MethodPost, Path: "/api/sales/:id/void", Roles: adminOnly},
+
+	// giving up on what is still to come of a purchase order
+	{Method: fiber.MethodPost, Path: "/api/purchaseorders/:id/close", Roles: adminOnly},
+}
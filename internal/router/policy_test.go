@@ -0,0 +1,70 @@
+package router
+
+import (
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicy(t *testing.T) {
+	tests := []struct {
+		method, path string
+		user, admin  bool
+	}{
+		{fiber.MethodPost, "/api/auth/register", false, true},
+		{fiber.MethodPost, "/api/auth/login", true, true},
+
+		{fiber.MethodGet, "/api/products", true, true},
+		{fiber.MethodGet, "/api/products/P001", true, true},
+		{fiber.MethodPost, "/api/products", false, true},
+		{fiber.MethodPut, "/api/products/P001", false, true},
+		{fiber.MethodDelete, "/api/products/P001", false, true},
+		{fiber.MethodGet, "/api/products/by-barcode/8851234567892", true, true},
+		{fiber.MethodGet, "/api/products/P001/barcodes", true, true},
+		{fiber.MethodPost, "/api/products/P001/barcodes", false, true},
+		{fiber.MethodDelete, "/api/products/P001/barcodes/8851234567892", false, true},
+		{fiber.MethodGet, "/api/productprices", true, true},
+		{fiber.MethodPost, "/api/productprices", false, true},
+		{fiber.MethodPut, "/api/productprices/1", false, true},
+
+		{fiber.MethodPost, "/api/categories", true, true},
+		{fiber.MethodDelete, "/api/categories/1", false, true},
+		{fiber.MethodPost, "/api/uoms", false, true},
+		{fiber.MethodDelete, "/api/unitconversions/1", false, true},
+		{fiber.MethodPost, "/api/customers", true, true},
+		{fiber.MethodDelete, "/api/customers/1", false, true},
+		{fiber.MethodPost, "/api/customers/1/payments", true, true},
+		{fiber.MethodDelete, "/api/suppliers/1", false, true},
+		{fiber.MethodGet, "/api/locations/1/stocks", true, true},
+		{fiber.MethodPost, "/api/locations", false, true},
+		{fiber.MethodPut, "/api/locations/1", false, true},
+		{fiber.MethodDelete, "/api/locations/1", false, true},
+
+		{fiber.MethodPost, "/api/productstocks", false, true},
+		{fiber.MethodPost, "/api/inventories/increase", false, true},
+		{fiber.MethodPost, "/api/transactions/adjustment", false, true},
+		{fiber.MethodPost, "/api/transfers", false, true},
+		{fiber.MethodGet, "/api/transfers", true, true},
+		{fiber.MethodPost, "/api/stocktakes", false, true},
+		{fiber.MethodPost, "/api/stocktakes/STK-2026-10-000001/counts", true, true},
+		{fiber.MethodPost, "/api/stocktakes/STK-2026-10-000001/post", false, true},
+
+		{fiber.MethodPost, "/api/sales", true, true},
+		{fiber.MethodPost, "/api/sales/INV-2026-10-000001/returns", true, true},
+		{fiber.MethodPost, "/api/sales/INV-2026-10-000001/void", false, true},
+		{fiber.MethodPost, "/api/purchases", true, true},
+		{fiber.MethodGet, "/api/productstocks/low", true, true},
+		{fiber.MethodPost, "/api/reorders/purchaseorders", true, true},
+		{fiber.MethodPost, "/api/purchaseorders/ORD-2026-10-000001/send", true, true},
+		{fiber.MethodPost, "/api/purchaseorders/ORD-2026-10-000001/close", false, true},
+		{fiber.MethodPost, "/api/goodsreceipts", true, true},
+		{fiber.MethodGet, "/api/payables/aging", true, true},
+		{fiber.MethodGet, "/api/profits", true, true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.user, Policy.Allows(tt.method, tt.path, models.USER), "%s %s as user", tt.method, tt.path)
+		assert.Equal(t, tt.admin, Policy.Allows(tt.method, tt.path, models.ADMIN), "%s %s as admin", tt.method, tt.path)
+	}
+}
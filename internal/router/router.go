@@ -1,206 +1,264 @@
 package router
 
 import (
-	"log"
-
 	"github.com/gofiber/fiber/v2"
-	authDi "github.com/sankangkin/di-rest-api/internal/auth/di"
-	categoryDi "github.com/sankangkin/di-rest-api/internal/domain/category/di"
-	customerDi "github.com/sankangkin/di-rest-api/internal/domain/customer/di"
-	inventoryDi "github.com/sankangkin/di-rest-api/internal/domain/inventory/di"
-	transactionDi "github.com/sankangkin/di-rest-api/internal/domain/itemtransactions/di"
-	productDi "github.com/sankangkin/di-rest-api/internal/domain/product/di"
-	productpriceDi "github.com/sankangkin/di-rest-api/internal/domain/productprice/di"
-	productStockDi "github.com/sankangkin/di-rest-api/internal/domain/productstock/di"
-	purchaseDi "github.com/sankangkin/di-rest-api/internal/domain/purchase/di"
-	saleDi "github.com/sankangkin/di-rest-api/internal/domain/sale/di"
-	supplierDi "github.com/sankangkin/di-rest-api/internal/domain/supplier/di"
-	unitconversionDi "github.com/sankangkin/di-rest-api/internal/domain/unitconversion/di"
-	unitofmeasurementDi "github.com/sankangkin/di-rest-api/internal/domain/unitofmeasurement/di"
+	"github.com/sankangkin/di-rest-api/internal/auth"
+	"github.com/sankangkin/di-rest-api/internal/config"
+	"github.com/sankangkin/di-rest-api/internal/domain/barcode"
+	"github.com/sankangkin/di-rest-api/internal/domain/category"
+	"github.com/sankangkin/di-rest-api/internal/domain/customer"
+	"github.com/sankangkin/di-rest-api/internal/domain/goodsreceipt"
+	"github.com/sankangkin/di-rest-api/internal/domain/inventory"
+	"github.com/sankangkin/di-rest-api/internal/domain/itemtransactions"
+	"github.com/sankangkin/di-rest-api/internal/domain/location"
+	"github.com/sankangkin/di-rest-api/internal/domain/payable"
+	"github.com/sankangkin/di-rest-api/internal/domain/product"
+	"github.com/sankangkin/di-rest-api/internal/domain/productprice"
+	"github.com/sankangkin/di-rest-api/internal/domain/productstock"
+	"github.com/sankangkin/di-rest-api/internal/domain/profit"
+	"github.com/sankangkin/di-rest-api/internal/domain/purchase"
+	"github.com/sankangkin/di-rest-api/internal/domain/purchaseorder"
+	"github.com/sankangkin/di-rest-api/internal/domain/receivable"
+	"github.com/sankangkin/di-rest-api/internal/domain/reorder"
+	"github.com/sankangkin/di-rest-api/internal/domain/sale"
+	"github.com/sankangkin/di-rest-api/internal/domain/stocktake"
+	"github.com/sankangkin/di-rest-api/internal/domain/stocktransfer"
+	"github.com/sankangkin/di-rest-api/internal/domain/supplier"
+	"github.com/sankangkin/di-rest-api/internal/domain/unitconversion"
+	"github.com/sankangkin/di-rest-api/internal/domain/unitofmeasurement"
+	"github.com/sankangkin/di-rest-api/internal/domain/valuation"
 	"github.com/sankangkin/di-rest-api/internal/middleware"
 )
 
-func Initialize(app *fiber.App) {
+// Handlers holds the handler of every route group; wire fills it in.
+type Handlers struct {
+	Auth              *auth.AuthHandler
+	Category          *category.CategoryHandler
+	Product           *product.ProductHandler
+	Barcode           *barcode.BarcodeHandler
+	UnitConversion    *unitconversion.UnitConversionHandler
+	UnitOfMeasurement *unitofmeasurement.UnitOfMeasurementHandler
+	Location          *location.LocationHandler
+	ProductStock      *productstock.ProductStockHandler
+	Transfer          *stocktransfer.StockTransferHandler
+	StockTake         *stocktake.StockTakeHandler
+	Valuation         *valuation.ValuationHandler
+	ProductPrice      *productprice.ProductPriceHandler
+	Transaction       *itemtransactions.TransactionHandler
+	Customer          *customer.CustomerHandler
+	Receivable        *receivable.ReceivableHandler
+	Supplier          *supplier.SupplierHandler
+	Payable           *payable.PayableHandler
+	Inventory         *inventory.InventoryHandler
+	Sale              *sale.SaleHandler
+	Profit            *profit.ProfitHandler
+	Purchase          *purchase.PurchaseHandler
+	PurchaseOrder     *purchaseorder.PurchaseOrderHandler
+	GoodsReceipt      *goodsreceipt.GoodsReceiptHandler
+	Reorder           *reorder.ReorderHandler
+}
+
+func Initialize(app *fiber.App, cfg *config.Config, sessions middleware.SessionChecker, h *Handlers) {
 
 	api := app.Group("/api")
 	api.Get("/", func(c *fiber.Ctx) error {
 		return c.Status(200).SendString("---->  Hello from stt api using go fiber framework <-- ")
 	})
 
-	// authentication di
-	authService, err := authDi.InitAuth()
-	if err != nil {
-		log.Fatalf("Failed to initialize auth service: %v", err)
-	}
+	// every protected route checks the token and that its session is still open
+	protected := middleware.Protected(cfg, sessions)
 	// auth route
 	auth := api.Group("/auth")
-	auth.Post("/register", authService.SignUp)
-	auth.Post("/login", authService.SignIn)
-	auth.Post("/refresh", authService.Refresh)
-	auth.Post("/logout", authService.Logout)
-
-	// category di
-	catService, err := categoryDi.InitCategory()
-	if err != nil {
-		log.Fatalf("Failed to initialize category service: %v", err)
-	}
+	auth.Post("/register", protected, middleware.Authorize(Policy), h.Auth.SignUp)
+	auth.Post("/login", h.Auth.SignIn)
+	auth.Post("/refresh", h.Auth.Refresh)
+	auth.Post("/logout", h.Auth.Logout)
+
 	// category route
 	categories := api.Group("/categories")
-	// categories.Use(middleware.Protected())
-	categories.Post("/", catService.CreateCategory)
-	categories.Get("/", catService.GetAllCategorie)
-	categories.Get("/:id", catService.GetCategoryById)
-	categories.Put("/:id", catService.UpdateCatagory)
-	categories.Delete("/:id", catService.DeleteCategory)
-
-	// product di
-	productService, err := productDi.InitProductDI()
-	if err != nil {
-		log.Fatalf("Failed to initialize product service: %v", err)
-	}
+	categories.Use(protected, middleware.Authorize(Policy))
+	categories.Post("/", h.Category.CreateCategory)
+	categories.Get("/", h.Category.GetAllCategorie)
+	categories.Get("/:id", h.Category.GetCategoryById)
+	categories.Put("/:id", h.Category.UpdateCatagory)
+	categories.Delete("/:id", h.Category.DeleteCategory)
 
 	products := api.Group("/products")
-	products.Use(middleware.Protected())
+	products.Use(protected, middleware.Authorize(Policy))
 
-	products.Post("/", productService.CreateProduct)
-	products.Get("/", productService.GetAllProducts)
+	products.Post("/", h.Product.CreateProduct)
+	products.Get("/", h.Product.GetAllProducts)
+	products.Get("/search", h.Product.SearchProducts)
+	products.Get("/by-barcode/:code", h.Barcode.GetByBarcode)
+	products.Get("/:id/barcodes", h.Barcode.GetProductBarcodes)
+	products.Post("/:id/barcodes", h.Barcode.CreateBarcode)
+	products.Delete("/:id/barcodes/:code", h.Barcode.DeleteBarcode)
 
-	// products.Get("/stocks", productService.GetAllProductStocks)
-	// products.Get("/stocks/:id", productService.GetProductStocksById)
-	products.Get("/prices", productService.GetAllProductPrices)
-	products.Get("/prices/:id", productService.GetProductUnitPricesById)
+	// products.Get("/stocks", h.Product.GetAllProductStocks)
+	// products.Get("/stocks/:id", h.Product.GetProductStocksById)
+	products.Get("/prices", h.Product.GetAllProductPrices)
+	products.Get("/prices/:id", h.Product.GetProductUnitPricesById)
 
-	products.Put("/:id", productService.UpdateProduct)
-	products.Delete("/:id", productService.DeleteProduct)
-	products.Get("/:id", productService.GetProductById) // ❗️Keep this at the BOTTOM
+	products.Put("/:id", h.Product.UpdateProduct)
+	products.Delete("/:id", h.Product.DeleteProduct)
+	products.Get("/:id", h.Product.GetProductById) // ❗️Keep this at the BOTTOM
 
-	// unitconversion di
-	unitConversionService, err := unitconversionDi.InitUnitConversionDI()
-	if err != nil {
-		log.Fatalf("Failed to initialize unit conversion service: %v", err)
-	}
 	unitconversion := api.Group("/unitconversions")
-	unitconversion.Use(middleware.Protected())
-	unitconversion.Post("/", unitConversionService.CreateUnitConversion)
-	unitconversion.Get("/", unitConversionService.GetAllUnitConversions)
-	unitconversion.Get("/:id", unitConversionService.GetUnitConversionById)
-	unitconversion.Put("/:id", unitConversionService.UpdateUnitConversion)
-	unitconversion.Delete("/:id", unitConversionService.DeleteUnitConversion)
+	unitconversion.Use(protected, middleware.Authorize(Policy))
+	unitconversion.Post("/", h.UnitConversion.CreateUnitConversion)
+	unitconversion.Get("/", h.UnitConversion.GetAllUnitConversions)
+	unitconversion.Get("/:id", h.UnitConversion.GetUnitConversionById)
+	unitconversion.Put("/:id", h.UnitConversion.UpdateUnitConversion)
+	unitconversion.Delete("/:id", h.UnitConversion.DeleteUnitConversion)
 	// unit conversion route
 
-	//unit of measurement di
-	unitOfMeasurementService, err := unitofmeasurementDi.InitUnitOfMeasurementDI()
-	if err != nil {
-		log.Fatalf("Failed to initialize unit of measurement service: %v", err)
-	}
 	unitofmeasurement := api.Group("/uoms")
-	unitofmeasurement.Use(middleware.Protected())
-	unitofmeasurement.Post("/", unitOfMeasurementService.CreateUnitOfMeasurement)
-	unitofmeasurement.Get("/", unitOfMeasurementService.GetAllUnitOfMeasurement)
-	unitofmeasurement.Get("/:id", unitOfMeasurementService.GetUnitOfMeasurementById)
-	unitofmeasurement.Put("/:id", unitOfMeasurementService.UpdateUnitOfMeasurement)
-	unitofmeasurement.Delete("/:id", unitOfMeasurementService.DeleteUnitOfMeasurement)
+	unitofmeasurement.Use(protected, middleware.Authorize(Policy))
+	unitofmeasurement.Post("/", h.UnitOfMeasurement.CreateUnitOfMeasurement)
+	unitofmeasurement.Get("/", h.UnitOfMeasurement.GetAllUnitOfMeasurement)
+	unitofmeasurement.Get("/:id", h.UnitOfMeasurement.GetUnitOfMeasurementById)
+	unitofmeasurement.Put("/:id", h.UnitOfMeasurement.UpdateUnitOfMeasurement)
+	unitofmeasurement.Delete("/:id", h.UnitOfMeasurement.DeleteUnitOfMeasurement)
 	// unit of measurement route
 
-	// productstock di
-	productStockService, err := productStockDi.InitProductStockDI()
-	if err != nil {
-		log.Fatalf("Failed to initialize product stock service: %v", err)
-	}
+	// location route, the places stock is kept
+	locations := api.Group("/locations")
+	locations.Use(protected, middleware.Authorize(Policy))
+	locations.Post("/", h.Location.CreateLocation)
+	locations.Get("/", h.Location.GetAllLocations)
+	locations.Get("/:id", h.Location.GetLocationById)
+	locations.Get("/:id/stocks", h.Location.GetLocationStocks)
+	locations.Put("/:id", h.Location.UpdateLocation)
+	locations.Delete("/:id", h.Location.DeleteLocation)
+
 	productstocks := api.Group("/productstocks")
-	productstocks.Use(middleware.Protected())
-	productstocks.Post("/", productStockService.CreateProductStocks)
-	productstocks.Get("/", productStockService.GetAllProductStocks)
-	productstocks.Get("/:id", productStockService.GetProductStocksById)
-	productstocks.Put("/:id", productStockService.UpdateProductStocksById)
-
-	//productprice di
-	productPriceService, err := productpriceDi.InitProductPriceDI()
-	if err != nil {
-		log.Fatalf("Failed to initialize product price service: %v", err)
-	}
+	productstocks.Use(protected, middleware.Authorize(Policy))
+	productstocks.Post("/", h.ProductStock.CreateProductStocks)
+	productstocks.Get("/", h.ProductStock.GetAllProductStocks)
+	productstocks.Get("/low", h.ProductStock.GetLowStocks)
+	productstocks.Get("/:id", h.ProductStock.GetProductStocksById)
+	productstocks.Put("/:id", h.ProductStock.UpdateProductStocksById)
+
 	productprices := api.Group("/productprices")
-	productprices.Use(middleware.Protected())
-	productprices.Post("/", productPriceService.CreateProductPrice)
-	productprices.Get("/", productPriceService.GetAllProductPrices)
-	productprices.Get("/:id", productPriceService.GetProductPriceById)
-	productprices.Put("/:id", productPriceService.UpdateProductPrice)
-
-	// item transactions di
-	transactionService, err := transactionDi.InitTransactionDI()
-	if err != nil {
-		log.Fatalf("Failed to initialize transaction service: %v", err)
-	}
+	productprices.Use(protected, middleware.Authorize(Policy))
+	productprices.Post("/", h.ProductPrice.CreateProductPrice)
+	productprices.Get("/", h.ProductPrice.GetAllProductPrices)
+	productprices.Get("/:id", h.ProductPrice.GetProductPriceById)
+	productprices.Put("/:id", h.ProductPrice.UpdateProductPrice)
+
+	// stock transfer route, moves stock between locations
+	transfers := api.Group("/transfers")
+	transfers.Use(protected, middleware.Authorize(Policy))
+	transfers.Post("/", h.Transfer.CreateTransfer)
+	transfers.Get("/", h.Transfer.GetAllTransfers)
+	transfers.Get("/:id", h.Transfer.GetTransferById)
+
+	// stock take route, physical counts posted as adjustments
+	stocktakes := api.Group("/stocktakes")
+	stocktakes.Use(protected, middleware.Authorize(Policy))
+	stocktakes.Post("/", h.StockTake.OpenStockTake)
+	stocktakes.Get("/", h.StockTake.GetAllStockTakes)
+	stocktakes.Get("/:id", h.StockTake.GetStockTakeById)
+	stocktakes.Get("/:id/variances", h.StockTake.GetStockTakeVariances)
+	stocktakes.Post("/:id/counts", h.StockTake.EnterCounts)
+	stocktakes.Post("/:id/post", h.StockTake.PostStockTake)
+
+	// stock valuation route, quantity x moving-average cost as of a date
+	valuation := api.Group("/valuation")
+	valuation.Use(protected, middleware.Authorize(Policy))
+	valuation.Get("/", h.Valuation.GetValuation)
+
 	// item transactions route
 	transactions := api.Group("/transactions")
-	transactions.Use(middleware.Protected())
-	transactions.Get("/", transactionService.GetAll)
-	transactions.Get("/by-product/:productId", transactionService.GetTransactionsByProductId)
-	transactions.Get("/by-type/:tranType", transactionService.GetTransactionsByTransactionType)
-	transactions.Get("/by-product-type/:productId/:tranType", transactionService.GetByProductIdAndTranType)
-	transactions.Post("/adjustment", transactionService.CreateAdjustmentTransaction)
-
-	// customer di
-	customerService, err := customerDi.InitCustomer()
-	if err != nil {
-		log.Fatalf("Failed to initialize customer service: %v", err)
-	}
+	transactions.Use(protected, middleware.Authorize(Policy))
+	transactions.Get("/", h.Transaction.GetAll)
+	transactions.Get("/by-product/:productId", h.Transaction.GetTransactionsByProductId)
+	transactions.Get("/by-type/:tranType", h.Transaction.GetTransactionsByTransactionType)
+	transactions.Get("/by-product-type/:productId/:tranType", h.Transaction.GetByProductIdAndTranType)
+	transactions.Post("/adjustment", h.Transaction.CreateAdjustmentTransaction)
+
 	// customer route
 	customer := api.Group("/customers")
-	customer.Use(middleware.Protected())
-	customer.Post("/", customerService.CreateCustomer)
-	customer.Get("/", customerService.GetAllCustomers)
-	customer.Get("/:id", customerService.GetCustomerById)
-	customer.Put("/:id", customerService.UpdateCustomer)
-	customer.Delete("/:id", customerService.DeleteCustomer)
-
-	// supplier di
-	supplierService, err := supplierDi.InitSupplier()
-	if err != nil {
-		log.Fatalf("Failed to initialize supplier service: %v", err)
-	}
+	customer.Use(protected, middleware.Authorize(Policy))
+	customer.Post("/", h.Customer.CreateCustomer)
+	customer.Get("/", h.Customer.GetAllCustomers)
+	customer.Get("/:id", h.Customer.GetCustomerById)
+	customer.Put("/:id", h.Customer.UpdateCustomer)
+	customer.Delete("/:id", h.Customer.DeleteCustomer)
+
+	// receivable route, hangs off the customer
+	customer.Get("/:id/balance", h.Receivable.GetCustomerBalance)
+	customer.Get("/:id/statement", h.Receivable.GetCustomerStatement)
+	customer.Get("/:id/payments", h.Receivable.GetCustomerPayments)
+	customer.Post("/:id/payments", h.Receivable.CreateCustomerPayment)
+
 	// supplier route
 	supplier := api.Group("/suppliers")
-	supplier.Use(middleware.Protected())
-	supplier.Post("/", supplierService.CreateSupplier)
-	supplier.Get("/", supplierService.GetAllSuppliers)
-	supplier.Get("/:id", supplierService.GetSupplierById)
-	supplier.Put("/:id", supplierService.UpdateSupplier)
-	supplier.Delete("/:id", supplierService.DeleteSupplier)
-
-	// inventory di
-	inventoryService, err := inventoryDi.InitInventoryDI()
-	if err != nil {
-		log.Fatalf("Failed to initialize inventory service: %v", err)
-	}
+	supplier.Use(protected, middleware.Authorize(Policy))
+	supplier.Post("/", h.Supplier.CreateSupplier)
+	supplier.Get("/", h.Supplier.GetAllSuppliers)
+	supplier.Get("/:id", h.Supplier.GetSupplierById)
+	supplier.Get("/:id/balance", h.Supplier.GetSupplierBalance)
+	supplier.Put("/:id", h.Supplier.UpdateSupplier)
+	supplier.Delete("/:id", h.Supplier.DeleteSupplier)
+
+	// payable route, per supplier and across all suppliers
+	supplier.Get("/:id/aging", h.Payable.GetSupplierAging)
+	supplier.Get("/:id/statement", h.Payable.GetSupplierStatement)
+	supplier.Get("/:id/payments", h.Payable.GetSupplierPayments)
+	supplier.Post("/:id/payments", h.Payable.CreateSupplierPayment)
+	payables := api.Group("/payables")
+	payables.Use(protected, middleware.Authorize(Policy))
+	payables.Get("/aging", h.Payable.GetAgingOverview)
+
 	// inventory route
 	inventory := api.Group("/inventories")
-	inventory.Use(middleware.Protected())
-	inventory.Get("/", inventoryService.GetAllInventories)
-	inventory.Post("/increase", inventoryService.IncreaseInventory)
-	inventory.Post("/decrease", inventoryService.DecreaseInventory)
-
-	// sale di
-	saleService, err := saleDi.InitSaleDI()
-	if err != nil {
-		log.Fatalf("Failed to initialize sale service: %v", err)
-	}
+	inventory.Use(protected, middleware.Authorize(Policy))
+	inventory.Get("/", h.Inventory.GetAllInventories)
+	inventory.Post("/increase", h.Inventory.IncreaseInventory)
+	inventory.Post("/decrease", h.Inventory.DecreaseInventory)
+
 	// sale route
 	sale := api.Group("/sales")
-	sale.Use(middleware.Protected())
-	sale.Post("/", saleService.CreateSale)
-	sale.Get("/", saleService.GetAllSales)
-	sale.Get("/:id", saleService.GetById)
-
-	// purchase di
-	purchaseService, err := purchaseDi.InitPurchaseDI()
-	if err != nil {
-		log.Fatalf("Failed to initialize purchase service: %v", err)
-	}
+	sale.Use(protected, middleware.Authorize(Policy))
+	sale.Post("/", h.Sale.CreateSale)
+	sale.Get("/", h.Sale.GetAllSales)
+	sale.Get("/:id", h.Sale.GetById)
+	sale.Post("/:id/void", h.Sale.VoidSale)
+	sale.Post("/:id/returns", h.Sale.CreateSaleReturn)
+
+	// profit route, gross profit and margin of the sales
+	profits := api.Group("/profits")
+	profits.Use(protected, middleware.Authorize(Policy))
+	profits.Get("/", h.Profit.GetProfitReport)
+
 	// purchase route
 	purchase := api.Group("/purchases")
-	purchase.Use(middleware.Protected())
-	purchase.Post("/", purchaseService.CreatePurchase)
-	purchase.Get("/", purchaseService.GetAllPurchases)
-	purchase.Get("/:id", purchaseService.GetById)
+	purchase.Use(protected, middleware.Authorize(Policy))
+	purchase.Post("/", h.Purchase.CreatePurchase)
+	purchase.Get("/", h.Purchase.GetAllPurchases)
+	purchase.Get("/:id", h.Purchase.GetById)
+	purchase.Post("/:id/returns", h.Purchase.CreatePurchaseReturn)
+
+	// purchase order route
+	purchaseorders := api.Group("/purchaseorders")
+	purchaseorders.Use(protected, middleware.Authorize(Policy))
+	purchaseorders.Post("/", h.PurchaseOrder.CreatePurchaseOrder)
+	purchaseorders.Get("/", h.PurchaseOrder.GetAllPurchaseOrders)
+	purchaseorders.Get("/:id", h.PurchaseOrder.GetPurchaseOrderById)
+	purchaseorders.Post("/:id/send", h.PurchaseOrder.SendPurchaseOrder)
+	purchaseorders.Post("/:id/close", h.PurchaseOrder.ClosePurchaseOrder)
+
+	// goods receipt route, the only purchase documents that move stock
+	goodsreceipts := api.Group("/goodsreceipts")
+	goodsreceipts.Use(protected, middleware.Authorize(Policy))
+	goodsreceipts.Post("/", h.GoodsReceipt.CreateGoodsReceipt)
+	goodsreceipts.Get("/", h.GoodsReceipt.GetAllGoodsReceipts)
+	goodsreceipts.Get("/:id", h.GoodsReceipt.GetGoodsReceiptById)
+
+	// reorder route, suggestions from the sales velocity and their draft orders
+	reorders := api.Group("/reorders")
+	reorders.Use(protected, middleware.Authorize(Policy))
+	reorders.Get("/suggestions", h.Reorder.GetSuggestions)
+	reorders.Post("/purchaseorders", h.Reorder.CreateSuggestedOrder)
 }
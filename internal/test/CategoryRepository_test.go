@@ -7,6 +7,7 @@ import (
 
 	c "github.com/sankangkin/di-rest-api/internal/domain/category"
 	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/sankangkin/di-rest-api/internal/query"
 	"github.com/stretchr/testify/suite"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
@@ -87,10 +88,11 @@ func (suite *CategoryRepositoryTestSuite) TestGetAll() {
 		suite.NoError(err)
 	}
 
-	categories, err := suite.repo.GetAll()
+	categories, page, err := suite.repo.GetAll(query.Spec{})
 	suite.NoError(err)
 	suite.NotNil(categories)
 	suite.Equal(6, len(categories))
+	suite.Equal(int64(6), page.Total)
 
 	suite.T().Log(categories)
 	// Check if "Category 2" is present in the list
@@ -0,0 +1,301 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/sankangkin/di-rest-api/internal/config"
+	"github.com/sankangkin/di-rest-api/internal/database/migrate"
+	"github.com/sankangkin/di-rest-api/internal/domain/docnumber"
+	"github.com/sankangkin/di-rest-api/internal/domain/sale"
+	"github.com/sankangkin/di-rest-api/internal/domain/stockmovement"
+	"github.com/sankangkin/di-rest-api/internal/models"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	p "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type SaleRepositoryTestSuite struct {
+	suite.Suite
+	db        *gorm.DB
+	repo      sale.SaleRepositoryInterface
+	stock     stockmovement.StockMovementServiceInterface
+	container testcontainers.Container
+}
+
+func TestSaleRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, &SaleRepositoryTestSuite{})
+}
+
+func (suite *SaleRepositoryTestSuite) SetupSuite() {
+
+	suite.T().Log("---------SetupSuite()--------")
+	// TEST_DATABASE_DSN points the suite at an existing (throwaway) database,
+	// otherwise a PostgreSQL container is started; without either we skip,
+	// as row locking cannot be exercised against anything but a real server
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		ctx := context.Background()
+		req := testcontainers.ContainerRequest{
+			Image:        "postgres:13-alpine",
+			ExposedPorts: []string{"5432/tcp"},
+			Env: map[string]string{
+				"POSTGRES_USER":     "testuser",
+				"POSTGRES_PASSWORD": "testpassword",
+				"POSTGRES_DB":       "testdb",
+			},
+			WaitingFor: wait.ForListeningPort("5432/tcp"),
+		}
+		postgresContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+			ContainerRequest: req,
+			Started:          true,
+		})
+		if err != nil {
+			suite.T().Skipf("postgres is not available: %v", err)
+		}
+		suite.container = postgresContainer
+
+		host, err := postgresContainer.Host(ctx)
+		suite.Require().NoError(err)
+		port, err := postgresContainer.MappedPort(ctx, "5432")
+		suite.Require().NoError(err)
+
+		dsn = fmt.Sprintf("host=%s port=%s user=testuser password=testpassword dbname=testdb sslmode=disable", host, port.Port())
+	}
+
+	db, err := gorm.Open(p.Open(dsn), &gorm.Config{})
+	suite.Require().NoError(err)
+
+	// the schema the api runs on, from the embedded migrations
+	m, err := migrate.New(db)
+	suite.Require().NoError(err)
+	_, err = m.Up(0)
+	suite.Require().NoError(err)
+
+	docNo, err := docnumber.NewDocNumberService(&config.Config{})
+	suite.Require().NoError(err)
+
+	suite.db = db
+	suite.stock = stockmovement.NewStockMovementService()
+	suite.repo = sale.NewSaleRepository(db, suite.stock, docNo)
+	suite.NotNil(suite.repo)
+}
+
+func (suite *SaleRepositoryTestSuite) TearDownSuite() {
+	suite.T().Log("----------TearDownSuite()----------")
+
+	if suite.container != nil {
+		err := suite.container.Terminate(context.Background())
+		suite.NoError(err)
+	}
+}
+
+// seedProduct creates a product sold in PCS (base) and FEET (derived, 10 per
+// PCS) with the given opening stock.
+func (suite *SaleRepositoryTestSuite) seedProduct(productId string, baseQty int, derivedQty int) {
+	db := suite.db
+	suite.Require().NoError(db.FirstOrCreate(&models.Category{ID: 1, CategoryName: "PIPES"}).Error)
+	suite.Require().NoError(db.FirstOrCreate(&models.UnitOfMeasure{ID: 1, UnitName: "PCS"}).Error)
+	suite.Require().NoError(db.FirstOrCreate(&models.UnitOfMeasure{ID: 2, UnitName: "FEET"}).Error)
+	suite.Require().NoError(db.FirstOrCreate(&models.Customer{ID: 1, Name: "WALK-IN", Address: "N/A", Phone: "N/A"}).Error)
+
+	suite.Require().NoError(db.Create(&models.Product{
+		ID:              productId,
+		ProductName:     "PVC PIPE " + productId,
+		CategoryId:      1,
+		Uom:             "PCS",
+		DeriveUom:       "FEET",
+		UomId:           1,
+		DeriveUomId:     2,
+		BuyPrice:        100,
+		SellPriceLevel1: 120,
+		DeriveUnitPrice: 15,
+	}).Error)
+	suite.Require().NoError(db.Create(&models.UnitConversion{
+		ProductId:    productId,
+		BaseUnit:     "PCS",
+		DeriveUnit:   "FEET",
+		BaseUnitId:   1,
+		DeriveUnitId: 2,
+		Factor:       10,
+	}).Error)
+	suite.Require().NoError(db.Create(&models.ProductStock{
+		ProductId:    productId,
+		LocationId:   1, // MAIN, the default location the migrations seed
+		BaseUnitId:   1,
+		DeriveUnitId: 2,
+		BaseQty:      baseQty,
+		DerivedQty:   derivedQty,
+	}).Error)
+}
+
+// takeConcurrently fires n stock-outs of qty uom at once, each in its own
+// transaction, and returns how many succeeded. It goes to the stock engine
+// directly: a whole sale locks its invoice number sequence first, which
+// would line the sales up and hide a missing stock row lock.
+func (suite *SaleRepositoryTestSuite) takeConcurrently(productId string, n int, qty int, uom string) int {
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+	)
+	start := make(chan struct{})
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			err := suite.db.Transaction(func(tx *gorm.DB) error {
+				_, err := suite.stock.Apply(tx, stockmovement.Movement{
+					ProductId:   productId,
+					Uom:         uom,
+					Qty:         -qty,
+					TranType:    "CREDIT",
+					ReferenceNo: fmt.Sprintf("%s-%d", productId, i),
+				})
+				return err
+			})
+			if err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+	return succeeded
+}
+
+// sellConcurrently fires n sales of qty uom at once and returns how many succeeded.
+func (suite *SaleRepositoryTestSuite) sellConcurrently(productId string, n int, qty int, uom string) int {
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+	)
+	start := make(chan struct{})
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			detail := models.SaleDetail{
+				ProductId: productId,
+				Qty:       qty,
+				Uom:       uom,
+				Price:     120,
+				Total:     int64(qty) * 120,
+			}
+			if uom == "FEET" {
+				detail.Qty, detail.DerivedQty = 0, qty
+			}
+			_, err := suite.repo.Create(&models.Sale{
+				CustomerId:  1,
+				SaleDetails: []models.SaleDetail{detail},
+				Total:       int64(qty) * 120,
+				GrandTotal:  int64(qty) * 120,
+			})
+			if err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+	return succeeded
+}
+
+func (suite *SaleRepositoryTestSuite) stockOf(productId string) models.ProductStock {
+	var stock models.ProductStock
+	suite.Require().NoError(suite.db.First(&stock, "product_id = ?", productId).Error)
+	return stock
+}
+
+func (suite *SaleRepositoryTestSuite) TestConcurrentSalesNeverOversell() {
+	suite.seedProduct("P-LOCK-1", 5, 0)
+
+	succeeded := suite.takeConcurrently("P-LOCK-1", 20, 1, "PCS")
+
+	stock := suite.stockOf("P-LOCK-1")
+	suite.Equal(5, succeeded)
+	suite.Equal(0, stock.BaseQty)
+
+	var sold int64
+	suite.db.Model(&models.ItemTransaction{}).
+		Where("product_id = ? AND tran_type = ?", "P-LOCK-1", "CREDIT").
+		Select("COALESCE(SUM(out_qty), 0)").Scan(&sold)
+	suite.Equal(int64(5), sold)
+}
+
+func (suite *SaleRepositoryTestSuite) TestConcurrentDerivedUnitSalesNeverOversell() {
+	// 2 PCS + 5 loose FEET = 25 FEET on hand; 10 sales of 3 FEET fit 8 times
+	suite.seedProduct("P-LOCK-2", 2, 5)
+
+	succeeded := suite.takeConcurrently("P-LOCK-2", 10, 3, "FEET")
+
+	stock := suite.stockOf("P-LOCK-2")
+	suite.Equal(8, succeeded)
+	suite.GreaterOrEqual(stock.BaseQty, 0)
+	suite.GreaterOrEqual(stock.DerivedQty, 0)
+	suite.Equal(25-8*3, stock.BaseQty*10+stock.DerivedQty)
+}
+
+func (suite *SaleRepositoryTestSuite) TestConcurrentSaleCreatesNeverOversell() {
+	suite.seedProduct("P-LOCK-3", 4, 0)
+
+	succeeded := suite.sellConcurrently("P-LOCK-3", 10, 1, "PCS")
+
+	suite.Equal(4, succeeded)
+	suite.Equal(0, suite.stockOf("P-LOCK-3").BaseQty)
+
+	var ids []string
+	suite.db.Model(&models.SaleDetail{}).
+		Where("product_id = ?", "P-LOCK-3").Distinct().Pluck("sale_id", &ids)
+	suite.Len(ids, 4)
+}
+
+func (suite *SaleRepositoryTestSuite) TestSaleOfProductWithoutUnitConversion() {
+	// a product sold by the piece only has no unit conversion row
+	db := suite.db
+	suite.Require().NoError(db.FirstOrCreate(&models.Category{ID: 1, CategoryName: "PIPES"}).Error)
+	suite.Require().NoError(db.FirstOrCreate(&models.UnitOfMeasure{ID: 1, UnitName: "PCS"}).Error)
+	suite.Require().NoError(db.FirstOrCreate(&models.Customer{ID: 1, Name: "WALK-IN", Address: "N/A", Phone: "N/A"}).Error)
+	suite.Require().NoError(db.Create(&models.Product{
+		ID:              "P-BASE-1",
+		ProductName:     "ELBOW",
+		CategoryId:      1,
+		Uom:             "PCS",
+		UomId:           1,
+		BuyPrice:        10,
+		SellPriceLevel1: 15,
+	}).Error)
+	suite.Require().NoError(db.Create(&models.ProductStock{
+		ProductId:  "P-BASE-1",
+		LocationId: 1,
+		BaseUnitId: 1,
+		BaseQty:    3,
+	}).Error)
+
+	sale, err := suite.repo.Create(&models.Sale{
+		CustomerId:  1,
+		SaleDetails: []models.SaleDetail{{ProductId: "P-BASE-1", Qty: 2, Uom: "PCS", Price: 15, Total: 30}},
+		Total:       30,
+		GrandTotal:  30,
+	})
+	suite.Require().NoError(err)
+	suite.Equal(1, suite.stockOf("P-BASE-1").BaseQty)
+
+	_, err = suite.repo.CreateReturn(sale.ID, &models.SaleReturn{
+		SaleReturnDetails: []models.SaleReturnDetail{{SaleDetailId: sale.SaleDetails[0].ID, Qty: 1}},
+	})
+	suite.Require().NoError(err)
+	suite.Equal(2, suite.stockOf("P-BASE-1").BaseQty)
+}